@@ -3,11 +3,16 @@ package client
 import (
 	"context"
 	"log"
+	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	ticket "github.com/talk2sohail/train-ticket-api/internal/common/genproto/ticket"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/certs"
 )
 
 // TicketClient wraps the gRPC client and connection.
@@ -28,9 +33,11 @@ type TicketClient struct {
 }
 
 // NewTrainTicketClient creates a new TicketClient and connects to the given gRPC server address.
-func NewTrainTicketClient(serverAddr string) (*TicketClient, error) {
-	// In production, consider using secure connections.
-	conn, err := grpc.NewClient(serverAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+// Options such as WithBearerToken are passed on to the connection. The connection is
+// plaintext unless WithTLS or WithMutualTLS is given.
+func NewTrainTicketClient(serverAddr string, opts ...grpc.DialOption) (*TicketClient, error) {
+	opts = append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, opts...)
+	conn, err := grpc.NewClient(serverAddr, opts...)
 	if err != nil {
 		log.Fatalf("did not connect: %v", err)
 	}
@@ -39,6 +46,47 @@ func NewTrainTicketClient(serverAddr string) (*TicketClient, error) {
 	return &TicketClient{conn: conn, client: client}, nil
 }
 
+// WithTLS connects over TLS, trusting servers whose certificate is signed by a CA in
+// caFile, or by the system roots when caFile is empty.
+func WithTLS(caFile string) (grpc.DialOption, error) {
+	return WithMutualTLS(caFile, "", "")
+}
+
+// WithMutualTLS connects over TLS like WithTLS and presents the certificate in certFile,
+// with its key in keyFile, to servers requiring client certificates. The certificate is
+// reread when it is rotated on disk.
+func WithMutualTLS(caFile, certFile, keyFile string) (grpc.DialOption, error) {
+	r, err := certs.NewReloader(certFile, keyFile, caFile)
+	if err != nil {
+		return nil, err
+	}
+	return grpc.WithTransportCredentials(credentials.NewTLS(certs.ClientConfig(r))), nil
+}
+
+// WithBearerToken authenticates every call with a signed token from the server's issuer.
+func WithBearerToken(token string) grpc.DialOption {
+	return grpc.WithPerRPCCredentials(bearerToken(token))
+}
+
+// bearerToken sends a token in the "authorization" metadata of every call.
+type bearerToken string
+
+func (t bearerToken) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(t)}, nil
+}
+
+// RequireTransportSecurity is false so that the token can also be sent over a plaintext connection.
+func (t bearerToken) RequireTransportSecurity() bool {
+	return false
+}
+
+// WithIdempotencyKey returns a context whose calls carry an idempotency key. Retrying a
+// mutating call with the same key and request returns the first call's response rather
+// than, say, buying a second ticket.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, "idempotency-key", key)
+}
+
 // Close shuts down the gRPC connection.
 func (tc *TicketClient) Close() error {
 	return tc.conn.Close()
@@ -54,6 +102,69 @@ func (tc *TicketClient) PurchaseTicket(ctx context.Context, req *ticket.Purchase
 	return resp, nil
 }
 
+// PurchaseGroupTicket forwards the call to the gRPC service.
+func (tc *TicketClient) PurchaseGroupTicket(ctx context.Context, req *ticket.PurchaseGroupTicketRequest) (*ticket.PurchaseGroupTicketResponse, error) {
+	resp, err := tc.client.PurchaseGroupTicket(ctx, req)
+	if err != nil {
+		log.Printf("PurchaseGroupTicket error: %v", err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+// HoldSeat forwards the call to the gRPC service.
+func (tc *TicketClient) HoldSeat(ctx context.Context, req *ticket.HoldSeatRequest) (*ticket.HoldSeatResponse, error) {
+	resp, err := tc.client.HoldSeat(ctx, req)
+	if err != nil {
+		log.Printf("HoldSeat error: %v", err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ConfirmHold forwards the call to the gRPC service, paying price for the held seat.
+func (tc *TicketClient) ConfirmHold(ctx context.Context, holdID string, price *ticket.Money) (*ticket.ConfirmHoldResponse, error) {
+	req := &ticket.ConfirmHoldRequest{HoldId: holdID, Price: price}
+	resp, err := tc.client.ConfirmHold(ctx, req)
+	if err != nil {
+		log.Printf("ConfirmHold error for holdID %s: %v", holdID, err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+// JoinWaitlist forwards the call to the gRPC service.
+func (tc *TicketClient) JoinWaitlist(ctx context.Context, req *ticket.JoinWaitlistRequest) (*ticket.JoinWaitlistResponse, error) {
+	resp, err := tc.client.JoinWaitlist(ctx, req)
+	if err != nil {
+		log.Printf("JoinWaitlist error: %v", err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetWaitlistStatus forwards the call to the gRPC service.
+func (tc *TicketClient) GetWaitlistStatus(ctx context.Context, waitlistID string) (*ticket.GetWaitlistStatusResponse, error) {
+	req := &ticket.GetWaitlistStatusRequest{WaitlistId: waitlistID}
+	resp, err := tc.client.GetWaitlistStatus(ctx, req)
+	if err != nil {
+		log.Printf("GetWaitlistStatus error for waitlistID %s: %v", waitlistID, err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+// WatchAvailability forwards the call to the gRPC service and returns the update stream.
+// The stream ends when ctx is cancelled.
+func (tc *TicketClient) WatchAvailability(ctx context.Context, req *ticket.WatchAvailabilityRequest) (grpc.ServerStreamingClient[ticket.AvailabilityUpdate], error) {
+	stream, err := tc.client.WatchAvailability(ctx, req)
+	if err != nil {
+		log.Printf("WatchAvailability error for journey %s: %v", req.GetJourneyId(), err)
+		return nil, err
+	}
+	return stream, nil
+}
+
 // GetReceiptDetails forwards the call to the gRPC service.
 func (tc *TicketClient) GetReceiptDetails(ctx context.Context, ticketID string) (*ticket.GetReceiptDetailsResponse, error) {
 	req := &ticket.GetReceiptDetailsRequest{Identifier: &ticket.GetReceiptDetailsRequest_TicketId{TicketId: ticketID}}
@@ -65,9 +176,21 @@ func (tc *TicketClient) GetReceiptDetails(ctx context.Context, ticketID string)
 	return resp, nil
 }
 
+// GetReceiptDetailsByEmail forwards the call to the gRPC service, identifying the ticket by its passenger's email.
+func (tc *TicketClient) GetReceiptDetailsByEmail(ctx context.Context, email string) (*ticket.GetReceiptDetailsResponse, error) {
+	req := &ticket.GetReceiptDetailsRequest{Identifier: &ticket.GetReceiptDetailsRequest_Email{Email: email}}
+	resp, err := tc.client.GetReceiptDetails(ctx, req)
+	if err != nil {
+		log.Printf("GetReceiptDetails error for email %s: %v", email, err)
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetUsersBySection forwards the call to the gRPC service.
-func (tc *TicketClient) GetUsersBySection(ctx context.Context, section ticket.Seat_Section) (*ticket.GetUsersBySectionResponse, error) {
-	req := &ticket.GetUsersBySectionRequest{Section: section}
+// An empty journeyID queries the default journey.
+func (tc *TicketClient) GetUsersBySection(ctx context.Context, journeyID string, section ticket.Seat_Section) (*ticket.GetUsersBySectionResponse, error) {
+	req := &ticket.GetUsersBySectionRequest{JourneyId: journeyID, Section: section}
 	resp, err := tc.client.GetUsersBySection(ctx, req)
 	if err != nil {
 		log.Printf("GetUsersBySection error for section %s: %v", section.String(), err)
@@ -76,6 +199,18 @@ func (tc *TicketClient) GetUsersBySection(ctx context.Context, section ticket.Se
 	return resp, nil
 }
 
+// GetUsersByCoach forwards the call to the gRPC service for a coach of the journey's train layout.
+// An empty journeyID queries the default journey.
+func (tc *TicketClient) GetUsersByCoach(ctx context.Context, journeyID, coach string) (*ticket.GetUsersBySectionResponse, error) {
+	req := &ticket.GetUsersBySectionRequest{JourneyId: journeyID, Coach: coach}
+	resp, err := tc.client.GetUsersBySection(ctx, req)
+	if err != nil {
+		log.Printf("GetUsersBySection error for coach %s: %v", coach, err)
+		return nil, err
+	}
+	return resp, nil
+}
+
 // RemoveUser forwards the call to the gRPC service.
 func (tc *TicketClient) RemoveUser(ctx context.Context, email string) (*ticket.RemoveUserResponse, error) {
 	req := &ticket.RemoveUserRequest{Identifier: &ticket.RemoveUserRequest_Email{Email: email}}
@@ -87,6 +222,54 @@ func (tc *TicketClient) RemoveUser(ctx context.Context, email string) (*ticket.R
 	return resp, nil
 }
 
+// CancelTicket forwards the call to the gRPC service.
+func (tc *TicketClient) CancelTicket(ctx context.Context, ticketID string) (*ticket.CancelTicketResponse, error) {
+	req := &ticket.CancelTicketRequest{TicketId: ticketID}
+	resp, err := tc.client.CancelTicket(ctx, req)
+	if err != nil {
+		log.Printf("CancelTicket error for ticketID %s: %v", ticketID, err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetRefund forwards the call to the gRPC service.
+func (tc *TicketClient) GetRefund(ctx context.Context, refundID string) (*ticket.GetRefundResponse, error) {
+	req := &ticket.GetRefundRequest{RefundId: refundID}
+	resp, err := tc.client.GetRefund(ctx, req)
+	if err != nil {
+		log.Printf("GetRefund error for refundID %s: %v", refundID, err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ListTicketsForUser forwards the call to the gRPC service.
+// An empty pageToken requests the first page; a zero pageSize uses the server's default.
+func (tc *TicketClient) ListTicketsForUser(ctx context.Context, email string, pageSize int32, pageToken string) (*ticket.ListTicketsForUserResponse, error) {
+	req := &ticket.ListTicketsForUserRequest{Email: email, PageSize: pageSize, PageToken: pageToken}
+	resp, err := tc.client.ListTicketsForUser(ctx, req)
+	if err != nil {
+		log.Printf("ListTicketsForUser error for email %s: %v", email, err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ModifyUserSeatByEmail forwards the call to the gRPC service, identifying the ticket by its passenger's email.
+func (tc *TicketClient) ModifyUserSeatByEmail(ctx context.Context, email string, newSeat *ticket.Seat) (*ticket.ModifyUserSeatResponse, error) {
+	req := &ticket.ModifyUserSeatRequest{
+		Identifier: &ticket.ModifyUserSeatRequest_Email{Email: email},
+		NewSeat:    newSeat,
+	}
+	resp, err := tc.client.ModifyUserSeat(ctx, req)
+	if err != nil {
+		log.Printf("ModifyUserSeat error for email %s: %v", email, err)
+		return nil, err
+	}
+	return resp, nil
+}
+
 // ModifyUserSeat forwards the call to the gRPC service.
 func (tc *TicketClient) ModifyUserSeat(ctx context.Context, ticketID string, newSeat *ticket.Seat) (*ticket.ModifyUserSeatResponse, error) {
 	req := &ticket.ModifyUserSeatRequest{
@@ -100,3 +283,58 @@ func (tc *TicketClient) ModifyUserSeat(ctx context.Context, ticketID string, new
 	}
 	return resp, nil
 }
+
+// GetTicketHistory forwards the call to the gRPC service.
+func (tc *TicketClient) GetTicketHistory(ctx context.Context, ticketID string) (*ticket.GetTicketHistoryResponse, error) {
+	req := &ticket.GetTicketHistoryRequest{TicketId: ticketID}
+	resp, err := tc.client.GetTicketHistory(ctx, req)
+	if err != nil {
+		log.Printf("GetTicketHistory error for ticketID %s: %v", ticketID, err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetSeatOccupant forwards the call to the gRPC service.
+// An empty journeyID queries the default journey.
+func (tc *TicketClient) GetSeatOccupant(ctx context.Context, journeyID, seatNumber string, at time.Time) (*ticket.GetSeatOccupantResponse, error) {
+	req := &ticket.GetSeatOccupantRequest{JourneyId: journeyID, SeatNumber: seatNumber, At: timestamppb.New(at)}
+	resp, err := tc.client.GetSeatOccupant(ctx, req)
+	if err != nil {
+		log.Printf("GetSeatOccupant error for seat %s: %v", seatNumber, err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+// CreateJourney forwards the call to the gRPC service.
+func (tc *TicketClient) CreateJourney(ctx context.Context, req *ticket.CreateJourneyRequest) (*ticket.CreateJourneyResponse, error) {
+	resp, err := tc.client.CreateJourney(ctx, req)
+	if err != nil {
+		log.Printf("CreateJourney error: %v", err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ListJourneys forwards the call to the gRPC service.
+// An empty serviceDate lists journeys on every date.
+func (tc *TicketClient) ListJourneys(ctx context.Context, serviceDate string) (*ticket.ListJourneysResponse, error) {
+	req := &ticket.ListJourneysRequest{ServiceDate: serviceDate}
+	resp, err := tc.client.ListJourneys(ctx, req)
+	if err != nil {
+		log.Printf("ListJourneys error for date %s: %v", serviceDate, err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+// QuoteFare forwards the call to the gRPC service.
+func (tc *TicketClient) QuoteFare(ctx context.Context, req *ticket.QuoteFareRequest) (*ticket.QuoteFareResponse, error) {
+	resp, err := tc.client.QuoteFare(ctx, req)
+	if err != nil {
+		log.Printf("QuoteFare error: %v", err)
+		return nil, err
+	}
+	return resp, nil
+}
@@ -3,16 +3,33 @@ package main
 import (
 	"context"
 	"log"
+	"os"
 	"time"
 
 	"github.com/talk2sohail/train-ticket-api/client"
 	ticket "github.com/talk2sohail/train-ticket-api/internal/common/genproto/ticket"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/money"
+	"google.golang.org/grpc"
 )
 
 func main() {
 
 	addr := ":9001"
-	trainTicketClient, err := client.NewTrainTicketClient(addr)
+	var opts []grpc.DialOption
+	// A server serving TLS needs its CA, e.g. TICKET_CA_FILE=ca.pem, and one
+	// requiring client certificates also TICKET_CERT_FILE and TICKET_KEY_FILE.
+	if caFile := os.Getenv("TICKET_CA_FILE"); caFile != "" {
+		tlsOpt, err := client.WithMutualTLS(caFile, os.Getenv("TICKET_CERT_FILE"), os.Getenv("TICKET_KEY_FILE"))
+		if err != nil {
+			log.Fatalf("could not load certificates: %v", err)
+		}
+		opts = append(opts, tlsOpt)
+	}
+	// A server with auth enabled needs a bearer token, e.g. TICKET_TOKEN=<jwt>.
+	if token := os.Getenv("TICKET_TOKEN"); token != "" {
+		opts = append(opts, client.WithBearerToken(token))
+	}
+	trainTicketClient, err := client.NewTrainTicketClient(addr, opts...)
 	if err != nil {
 		log.Fatalf("could not connect to server: %v", err)
 	}
@@ -21,6 +38,20 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*200)
 	defer cancel()
 
+	// The server prices tickets; pay the fare it quotes.
+	quote, err := trainTicketClient.QuoteFare(ctx, &ticket.QuoteFareRequest{
+		FromLocation: "New York",
+		ToLocation:   "Los Angeles",
+	})
+	if err != nil {
+		log.Fatalf("could not quote the fare: %v", err)
+	}
+	fare, err := money.FromProto(quote.GetPrice())
+	if err != nil {
+		log.Fatalf("could not read the quoted price: %v", err)
+	}
+	log.Printf("Fare: %s", fare)
+
 	respone, err := trainTicketClient.PurchaseTicket(ctx, &ticket.PurchaseTicketRequest{
 		FromLocation: "New York",
 		ToLocation:   "Los Angeles",
@@ -29,7 +60,8 @@ func main() {
 			LastName:  "Doe",
 			Email:     "a@gamil.com",
 		},
-		PricePaid: 100.0,
+		Price:         quote.GetPrice(),
+		PaymentMethod: "tok_visa",
 	})
 
 	if err != nil {
@@ -49,20 +81,24 @@ func main() {
 	log.Printf("From: %s", receiptDetails.GetReceipt().GetFromLocation())
 	log.Printf("To: %s", receiptDetails.GetReceipt().GetToLocation())
 	log.Printf("User: %s %s (%s)", receiptDetails.GetReceipt().GetUser().GetFirstName(), receiptDetails.GetReceipt().GetUser().GetLastName(), receiptDetails.GetReceipt().GetUser().GetEmail())
-	log.Printf("Price Paid: %.2f", receiptDetails.GetReceipt().GetPricePaid())
+	paid, err := money.FromProto(receiptDetails.GetReceipt().GetPrice())
+	if err != nil {
+		log.Fatalf("could not read the price paid: %v", err)
+	}
+	log.Printf("Price Paid: %s", paid)
 	log.Printf("Purchase Time: %s", receiptDetails.GetReceipt().GetPurchaseDate().AsTime().Format(time.RFC3339))
 	log.Printf("Seat Number: %s", receiptDetails.GetReceipt().GetAllocatedSeat().GetSeatNumber())
 	log.Printf("Seat Section: %s", receiptDetails.GetReceipt().GetAllocatedSeat().GetSection().String())
 
 	// Get users by section
-	usersBySection, err := trainTicketClient.GetUsersBySection(ctx, ticket.Seat_SECTION_A)
+	usersBySection, err := trainTicketClient.GetUsersBySection(ctx, "", ticket.Seat_SECTION_A)
 	if err != nil {
 		log.Fatalf("could not get users by section: %v", err)
 	}
 	log.Printf("Users in Section A: %v", usersBySection.GetUsersInSection())
 
 	// Get users by section
-	usersBySection, err = trainTicketClient.GetUsersBySection(ctx, ticket.Seat_SECTION_B)
+	usersBySection, err = trainTicketClient.GetUsersBySection(ctx, "", ticket.Seat_SECTION_B)
 	if err != nil {
 		log.Fatalf("could not get users by section: %v", err)
 	}
@@ -78,7 +114,7 @@ func main() {
 		log.Fatalf("could not modify user seat: %v", err)
 	}
 	log.Printf("User seat modified successfully: %s", modifiedSeat.GetMessage())
-	log.Printf("New Seat Number: %s", modifiedSeat.GetUpdatedSeat().GetSeatNumber())
+	log.Printf("New Seat Number: %s", modifiedSeat.GetUpdatedReceipt().GetAllocatedSeat().GetSeatNumber())
 
 	// Remove user
 	email := receiptDetails.GetReceipt().GetUser().GetEmail()
@@ -89,4 +125,27 @@ func main() {
 
 	log.Printf("User removed successfully: %s", removedUser.GetMessage())
 
+	// The refund given is recorded and can be looked up again. Tickets bought
+	// from a server that takes no payment have none.
+	if removedUser.GetRefund() != nil {
+		refund, err := trainTicketClient.GetRefund(ctx, removedUser.GetRefund().GetRefundId())
+		if err != nil {
+			log.Fatalf("could not get refund: %v", err)
+		}
+		refunded, err := money.FromProto(refund.GetRefund().GetAmount())
+		if err != nil {
+			log.Fatalf("could not read the refund: %v", err)
+		}
+		log.Printf("Refunded: %s (%g%%, rule %q, %s)", refunded, refund.GetRefund().GetRefundPercent(), refund.GetRefund().GetRule(), refund.GetRefund().GetStatus())
+	}
+
+	// The ticket is cancelled, but its history is kept in the ledger.
+	history, err := trainTicketClient.GetTicketHistory(ctx, receiptDetails.GetReceipt().GetTicketId())
+	if err != nil {
+		log.Fatalf("could not get ticket history: %v", err)
+	}
+	for _, event := range history.GetEvents() {
+		log.Printf("Event #%d at %s: %T", event.GetSequence(), event.GetOccurredAt().AsTime().Format(time.RFC3339), event.GetEvent())
+	}
+
 }
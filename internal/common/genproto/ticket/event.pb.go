@@ -0,0 +1,683 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.31.0
+// source: event.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// A single entry in the append-only booking ledger.
+// The current state of every ticket is derived by replaying these events in order.
+type BookingEvent struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Sequence   uint64                 `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`                      // Position in the ledger, starting at 1
+	TicketId   string                 `protobuf:"bytes,2,opt,name=ticket_id,json=ticketId,proto3" json:"ticket_id,omitempty"`       // Ticket the event applies to; empty for journey and waitlist join events
+	OccurredAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"` // When the change happened
+	// Types that are valid to be assigned to Event:
+	//
+	//	*BookingEvent_TicketPurchased
+	//	*BookingEvent_SeatChanged
+	//	*BookingEvent_TicketCancelled
+	//	*BookingEvent_JourneyCreated
+	//	*BookingEvent_WaitlistJoined
+	//	*BookingEvent_WaitlistPromoted
+	//	*BookingEvent_RefundSettled
+	Event         isBookingEvent_Event `protobuf_oneof:"event"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BookingEvent) Reset() {
+	*x = BookingEvent{}
+	mi := &file_event_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BookingEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BookingEvent) ProtoMessage() {}
+
+func (x *BookingEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BookingEvent.ProtoReflect.Descriptor instead.
+func (*BookingEvent) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *BookingEvent) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *BookingEvent) GetTicketId() string {
+	if x != nil {
+		return x.TicketId
+	}
+	return ""
+}
+
+func (x *BookingEvent) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+func (x *BookingEvent) GetEvent() isBookingEvent_Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *BookingEvent) GetTicketPurchased() *TicketPurchased {
+	if x != nil {
+		if x, ok := x.Event.(*BookingEvent_TicketPurchased); ok {
+			return x.TicketPurchased
+		}
+	}
+	return nil
+}
+
+func (x *BookingEvent) GetSeatChanged() *SeatChanged {
+	if x != nil {
+		if x, ok := x.Event.(*BookingEvent_SeatChanged); ok {
+			return x.SeatChanged
+		}
+	}
+	return nil
+}
+
+func (x *BookingEvent) GetTicketCancelled() *TicketCancelled {
+	if x != nil {
+		if x, ok := x.Event.(*BookingEvent_TicketCancelled); ok {
+			return x.TicketCancelled
+		}
+	}
+	return nil
+}
+
+func (x *BookingEvent) GetJourneyCreated() *JourneyCreated {
+	if x != nil {
+		if x, ok := x.Event.(*BookingEvent_JourneyCreated); ok {
+			return x.JourneyCreated
+		}
+	}
+	return nil
+}
+
+func (x *BookingEvent) GetWaitlistJoined() *WaitlistJoined {
+	if x != nil {
+		if x, ok := x.Event.(*BookingEvent_WaitlistJoined); ok {
+			return x.WaitlistJoined
+		}
+	}
+	return nil
+}
+
+func (x *BookingEvent) GetWaitlistPromoted() *WaitlistPromoted {
+	if x != nil {
+		if x, ok := x.Event.(*BookingEvent_WaitlistPromoted); ok {
+			return x.WaitlistPromoted
+		}
+	}
+	return nil
+}
+
+func (x *BookingEvent) GetRefundSettled() *RefundSettled {
+	if x != nil {
+		if x, ok := x.Event.(*BookingEvent_RefundSettled); ok {
+			return x.RefundSettled
+		}
+	}
+	return nil
+}
+
+type isBookingEvent_Event interface {
+	isBookingEvent_Event()
+}
+
+type BookingEvent_TicketPurchased struct {
+	TicketPurchased *TicketPurchased `protobuf:"bytes,4,opt,name=ticket_purchased,json=ticketPurchased,proto3,oneof"`
+}
+
+type BookingEvent_SeatChanged struct {
+	SeatChanged *SeatChanged `protobuf:"bytes,5,opt,name=seat_changed,json=seatChanged,proto3,oneof"`
+}
+
+type BookingEvent_TicketCancelled struct {
+	TicketCancelled *TicketCancelled `protobuf:"bytes,6,opt,name=ticket_cancelled,json=ticketCancelled,proto3,oneof"`
+}
+
+type BookingEvent_JourneyCreated struct {
+	JourneyCreated *JourneyCreated `protobuf:"bytes,7,opt,name=journey_created,json=journeyCreated,proto3,oneof"`
+}
+
+type BookingEvent_WaitlistJoined struct {
+	WaitlistJoined *WaitlistJoined `protobuf:"bytes,8,opt,name=waitlist_joined,json=waitlistJoined,proto3,oneof"`
+}
+
+type BookingEvent_WaitlistPromoted struct {
+	WaitlistPromoted *WaitlistPromoted `protobuf:"bytes,9,opt,name=waitlist_promoted,json=waitlistPromoted,proto3,oneof"`
+}
+
+type BookingEvent_RefundSettled struct {
+	RefundSettled *RefundSettled `protobuf:"bytes,10,opt,name=refund_settled,json=refundSettled,proto3,oneof"`
+}
+
+func (*BookingEvent_TicketPurchased) isBookingEvent_Event() {}
+
+func (*BookingEvent_SeatChanged) isBookingEvent_Event() {}
+
+func (*BookingEvent_TicketCancelled) isBookingEvent_Event() {}
+
+func (*BookingEvent_JourneyCreated) isBookingEvent_Event() {}
+
+func (*BookingEvent_WaitlistJoined) isBookingEvent_Event() {}
+
+func (*BookingEvent_WaitlistPromoted) isBookingEvent_Event() {}
+
+func (*BookingEvent_RefundSettled) isBookingEvent_Event() {}
+
+// Recorded when a ticket is bought.
+type TicketPurchased struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Receipt       *Receipt               `protobuf:"bytes,1,opt,name=receipt,proto3" json:"receipt,omitempty"` // The receipt as issued
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TicketPurchased) Reset() {
+	*x = TicketPurchased{}
+	mi := &file_event_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TicketPurchased) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TicketPurchased) ProtoMessage() {}
+
+func (x *TicketPurchased) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TicketPurchased.ProtoReflect.Descriptor instead.
+func (*TicketPurchased) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TicketPurchased) GetReceipt() *Receipt {
+	if x != nil {
+		return x.Receipt
+	}
+	return nil
+}
+
+// Recorded when a ticket moves to another seat.
+type SeatChanged struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PreviousSeat  *Seat                  `protobuf:"bytes,1,opt,name=previous_seat,json=previousSeat,proto3" json:"previous_seat,omitempty"`
+	NewSeat       *Seat                  `protobuf:"bytes,2,opt,name=new_seat,json=newSeat,proto3" json:"new_seat,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SeatChanged) Reset() {
+	*x = SeatChanged{}
+	mi := &file_event_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SeatChanged) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeatChanged) ProtoMessage() {}
+
+func (x *SeatChanged) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeatChanged.ProtoReflect.Descriptor instead.
+func (*SeatChanged) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SeatChanged) GetPreviousSeat() *Seat {
+	if x != nil {
+		return x.PreviousSeat
+	}
+	return nil
+}
+
+func (x *SeatChanged) GetNewSeat() *Seat {
+	if x != nil {
+		return x.NewSeat
+	}
+	return nil
+}
+
+// Recorded when a ticket is cancelled and its seat released.
+type TicketCancelled struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReleasedSeat  *Seat                  `protobuf:"bytes,1,opt,name=released_seat,json=releasedSeat,proto3" json:"released_seat,omitempty"`
+	Refund        *Refund                `protobuf:"bytes,2,opt,name=refund,proto3" json:"refund,omitempty"` // Refund given under the cancellation policy; absent for tickets booked without payment
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TicketCancelled) Reset() {
+	*x = TicketCancelled{}
+	mi := &file_event_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TicketCancelled) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TicketCancelled) ProtoMessage() {}
+
+func (x *TicketCancelled) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TicketCancelled.ProtoReflect.Descriptor instead.
+func (*TicketCancelled) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *TicketCancelled) GetReleasedSeat() *Seat {
+	if x != nil {
+		return x.ReleasedSeat
+	}
+	return nil
+}
+
+func (x *TicketCancelled) GetRefund() *Refund {
+	if x != nil {
+		return x.Refund
+	}
+	return nil
+}
+
+// Recorded when the payment provider has been asked to return the amount of a
+// refund recorded as pending on a cancellation.
+type RefundSettled struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RefundId      string                 `protobuf:"bytes,1,opt,name=refund_id,json=refundId,proto3" json:"refund_id,omitempty"`
+	Status        Refund_Status          `protobuf:"varint,2,opt,name=status,proto3,enum=trainticketing.entities.Refund_Status" json:"status,omitempty"` // STATUS_COMPLETED or STATUS_FAILED
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefundSettled) Reset() {
+	*x = RefundSettled{}
+	mi := &file_event_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundSettled) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundSettled) ProtoMessage() {}
+
+func (x *RefundSettled) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundSettled.ProtoReflect.Descriptor instead.
+func (*RefundSettled) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RefundSettled) GetRefundId() string {
+	if x != nil {
+		return x.RefundId
+	}
+	return ""
+}
+
+func (x *RefundSettled) GetStatus() Refund_Status {
+	if x != nil {
+		return x.Status
+	}
+	return Refund_STATUS_UNKNOWN
+}
+
+// Recorded when a new journey is scheduled.
+type JourneyCreated struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Journey       *Journey               `protobuf:"bytes,1,opt,name=journey,proto3" json:"journey,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JourneyCreated) Reset() {
+	*x = JourneyCreated{}
+	mi := &file_event_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JourneyCreated) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JourneyCreated) ProtoMessage() {}
+
+func (x *JourneyCreated) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JourneyCreated.ProtoReflect.Descriptor instead.
+func (*JourneyCreated) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *JourneyCreated) GetJourney() *Journey {
+	if x != nil {
+		return x.Journey
+	}
+	return nil
+}
+
+// Recorded when a passenger joins the waitlist of a sold-out journey.
+type WaitlistJoined struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entry         *WaitlistEntry         `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"` // The entry as queued
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WaitlistJoined) Reset() {
+	*x = WaitlistJoined{}
+	mi := &file_event_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WaitlistJoined) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WaitlistJoined) ProtoMessage() {}
+
+func (x *WaitlistJoined) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WaitlistJoined.ProtoReflect.Descriptor instead.
+func (*WaitlistJoined) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *WaitlistJoined) GetEntry() *WaitlistEntry {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+// Recorded when a waiting passenger is given a freed seat.
+// The TicketPurchased event for the new ticket follows in the same batch.
+type WaitlistPromoted struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WaitlistId    string                 `protobuf:"bytes,1,opt,name=waitlist_id,json=waitlistId,proto3" json:"waitlist_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WaitlistPromoted) Reset() {
+	*x = WaitlistPromoted{}
+	mi := &file_event_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WaitlistPromoted) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WaitlistPromoted) ProtoMessage() {}
+
+func (x *WaitlistPromoted) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WaitlistPromoted.ProtoReflect.Descriptor instead.
+func (*WaitlistPromoted) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *WaitlistPromoted) GetWaitlistId() string {
+	if x != nil {
+		return x.WaitlistId
+	}
+	return ""
+}
+
+var File_event_proto protoreflect.FileDescriptor
+
+const file_event_proto_rawDesc = "" +
+	"\n" +
+	"\vevent.proto\x12\x17trainticketing.entities\x1a\n" +
+	"seat.proto\x1a\rreceipt.proto\x1a\rjourney.proto\x1a\x0ewaitlist.proto\x1a\frefund.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xd9\x05\n" +
+	"\fBookingEvent\x12\x1a\n" +
+	"\bsequence\x18\x01 \x01(\x04R\bsequence\x12\x1b\n" +
+	"\tticket_id\x18\x02 \x01(\tR\bticketId\x12;\n" +
+	"\voccurred_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\x12U\n" +
+	"\x10ticket_purchased\x18\x04 \x01(\v2(.trainticketing.entities.TicketPurchasedH\x00R\x0fticketPurchased\x12I\n" +
+	"\fseat_changed\x18\x05 \x01(\v2$.trainticketing.entities.SeatChangedH\x00R\vseatChanged\x12U\n" +
+	"\x10ticket_cancelled\x18\x06 \x01(\v2(.trainticketing.entities.TicketCancelledH\x00R\x0fticketCancelled\x12R\n" +
+	"\x0fjourney_created\x18\a \x01(\v2'.trainticketing.entities.JourneyCreatedH\x00R\x0ejourneyCreated\x12R\n" +
+	"\x0fwaitlist_joined\x18\b \x01(\v2'.trainticketing.entities.WaitlistJoinedH\x00R\x0ewaitlistJoined\x12X\n" +
+	"\x11waitlist_promoted\x18\t \x01(\v2).trainticketing.entities.WaitlistPromotedH\x00R\x10waitlistPromoted\x12O\n" +
+	"\x0erefund_settled\x18\n" +
+	" \x01(\v2&.trainticketing.entities.RefundSettledH\x00R\rrefundSettledB\a\n" +
+	"\x05event\"M\n" +
+	"\x0fTicketPurchased\x12:\n" +
+	"\areceipt\x18\x01 \x01(\v2 .trainticketing.entities.ReceiptR\areceipt\"\x8b\x01\n" +
+	"\vSeatChanged\x12B\n" +
+	"\rprevious_seat\x18\x01 \x01(\v2\x1d.trainticketing.entities.SeatR\fpreviousSeat\x128\n" +
+	"\bnew_seat\x18\x02 \x01(\v2\x1d.trainticketing.entities.SeatR\anewSeat\"\x8e\x01\n" +
+	"\x0fTicketCancelled\x12B\n" +
+	"\rreleased_seat\x18\x01 \x01(\v2\x1d.trainticketing.entities.SeatR\freleasedSeat\x127\n" +
+	"\x06refund\x18\x02 \x01(\v2\x1f.trainticketing.entities.RefundR\x06refund\"l\n" +
+	"\rRefundSettled\x12\x1b\n" +
+	"\trefund_id\x18\x01 \x01(\tR\brefundId\x12>\n" +
+	"\x06status\x18\x02 \x01(\x0e2&.trainticketing.entities.Refund.StatusR\x06status\"L\n" +
+	"\x0eJourneyCreated\x12:\n" +
+	"\ajourney\x18\x01 \x01(\v2 .trainticketing.entities.JourneyR\ajourney\"N\n" +
+	"\x0eWaitlistJoined\x12<\n" +
+	"\x05entry\x18\x01 \x01(\v2&.trainticketing.entities.WaitlistEntryR\x05entry\"3\n" +
+	"\x10WaitlistPromoted\x12\x1f\n" +
+	"\vwaitlist_id\x18\x01 \x01(\tR\n" +
+	"waitlistIdB/Z-github.com/talk2sohail/train-ticket-api/protob\x06proto3"
+
+var (
+	file_event_proto_rawDescOnce sync.Once
+	file_event_proto_rawDescData []byte
+)
+
+func file_event_proto_rawDescGZIP() []byte {
+	file_event_proto_rawDescOnce.Do(func() {
+		file_event_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_event_proto_rawDesc), len(file_event_proto_rawDesc)))
+	})
+	return file_event_proto_rawDescData
+}
+
+var file_event_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_event_proto_goTypes = []any{
+	(*BookingEvent)(nil),          // 0: trainticketing.entities.BookingEvent
+	(*TicketPurchased)(nil),       // 1: trainticketing.entities.TicketPurchased
+	(*SeatChanged)(nil),           // 2: trainticketing.entities.SeatChanged
+	(*TicketCancelled)(nil),       // 3: trainticketing.entities.TicketCancelled
+	(*RefundSettled)(nil),         // 4: trainticketing.entities.RefundSettled
+	(*JourneyCreated)(nil),        // 5: trainticketing.entities.JourneyCreated
+	(*WaitlistJoined)(nil),        // 6: trainticketing.entities.WaitlistJoined
+	(*WaitlistPromoted)(nil),      // 7: trainticketing.entities.WaitlistPromoted
+	(*timestamppb.Timestamp)(nil), // 8: google.protobuf.Timestamp
+	(*Receipt)(nil),               // 9: trainticketing.entities.Receipt
+	(*Seat)(nil),                  // 10: trainticketing.entities.Seat
+	(*Refund)(nil),                // 11: trainticketing.entities.Refund
+	(Refund_Status)(0),            // 12: trainticketing.entities.Refund.Status
+	(*Journey)(nil),               // 13: trainticketing.entities.Journey
+	(*WaitlistEntry)(nil),         // 14: trainticketing.entities.WaitlistEntry
+}
+var file_event_proto_depIdxs = []int32{
+	8,  // 0: trainticketing.entities.BookingEvent.occurred_at:type_name -> google.protobuf.Timestamp
+	1,  // 1: trainticketing.entities.BookingEvent.ticket_purchased:type_name -> trainticketing.entities.TicketPurchased
+	2,  // 2: trainticketing.entities.BookingEvent.seat_changed:type_name -> trainticketing.entities.SeatChanged
+	3,  // 3: trainticketing.entities.BookingEvent.ticket_cancelled:type_name -> trainticketing.entities.TicketCancelled
+	5,  // 4: trainticketing.entities.BookingEvent.journey_created:type_name -> trainticketing.entities.JourneyCreated
+	6,  // 5: trainticketing.entities.BookingEvent.waitlist_joined:type_name -> trainticketing.entities.WaitlistJoined
+	7,  // 6: trainticketing.entities.BookingEvent.waitlist_promoted:type_name -> trainticketing.entities.WaitlistPromoted
+	4,  // 7: trainticketing.entities.BookingEvent.refund_settled:type_name -> trainticketing.entities.RefundSettled
+	9,  // 8: trainticketing.entities.TicketPurchased.receipt:type_name -> trainticketing.entities.Receipt
+	10, // 9: trainticketing.entities.SeatChanged.previous_seat:type_name -> trainticketing.entities.Seat
+	10, // 10: trainticketing.entities.SeatChanged.new_seat:type_name -> trainticketing.entities.Seat
+	10, // 11: trainticketing.entities.TicketCancelled.released_seat:type_name -> trainticketing.entities.Seat
+	11, // 12: trainticketing.entities.TicketCancelled.refund:type_name -> trainticketing.entities.Refund
+	12, // 13: trainticketing.entities.RefundSettled.status:type_name -> trainticketing.entities.Refund.Status
+	13, // 14: trainticketing.entities.JourneyCreated.journey:type_name -> trainticketing.entities.Journey
+	14, // 15: trainticketing.entities.WaitlistJoined.entry:type_name -> trainticketing.entities.WaitlistEntry
+	16, // [16:16] is the sub-list for method output_type
+	16, // [16:16] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
+}
+
+func init() { file_event_proto_init() }
+func file_event_proto_init() {
+	if File_event_proto != nil {
+		return
+	}
+	file_seat_proto_init()
+	file_receipt_proto_init()
+	file_journey_proto_init()
+	file_waitlist_proto_init()
+	file_refund_proto_init()
+	file_event_proto_msgTypes[0].OneofWrappers = []any{
+		(*BookingEvent_TicketPurchased)(nil),
+		(*BookingEvent_SeatChanged)(nil),
+		(*BookingEvent_TicketCancelled)(nil),
+		(*BookingEvent_JourneyCreated)(nil),
+		(*BookingEvent_WaitlistJoined)(nil),
+		(*BookingEvent_WaitlistPromoted)(nil),
+		(*BookingEvent_RefundSettled)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_event_proto_rawDesc), len(file_event_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_event_proto_goTypes,
+		DependencyIndexes: file_event_proto_depIdxs,
+		MessageInfos:      file_event_proto_msgTypes,
+	}.Build()
+	File_event_proto = out.File
+	file_event_proto_goTypes = nil
+	file_event_proto_depIdxs = nil
+}
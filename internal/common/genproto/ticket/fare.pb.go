@@ -0,0 +1,347 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.31.0
+// source: fare.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Class of travel a ticket is sold in.
+type TravelClass int32
+
+const (
+	TravelClass_TRAVEL_CLASS_STANDARD TravelClass = 0 // Default
+	TravelClass_TRAVEL_CLASS_FIRST    TravelClass = 1
+)
+
+// Enum value maps for TravelClass.
+var (
+	TravelClass_name = map[int32]string{
+		0: "TRAVEL_CLASS_STANDARD",
+		1: "TRAVEL_CLASS_FIRST",
+	}
+	TravelClass_value = map[string]int32{
+		"TRAVEL_CLASS_STANDARD": 0,
+		"TRAVEL_CLASS_FIRST":    1,
+	}
+)
+
+func (x TravelClass) Enum() *TravelClass {
+	p := new(TravelClass)
+	*p = x
+	return p
+}
+
+func (x TravelClass) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TravelClass) Descriptor() protoreflect.EnumDescriptor {
+	return file_fare_proto_enumTypes[0].Descriptor()
+}
+
+func (TravelClass) Type() protoreflect.EnumType {
+	return &file_fare_proto_enumTypes[0]
+}
+
+func (x TravelClass) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TravelClass.Descriptor instead.
+func (TravelClass) EnumDescriptor() ([]byte, []int) {
+	return file_fare_proto_rawDescGZIP(), []int{0}
+}
+
+// Kind of passenger a fare is priced for.
+type PassengerType int32
+
+const (
+	PassengerType_PASSENGER_TYPE_ADULT  PassengerType = 0 // Default
+	PassengerType_PASSENGER_TYPE_CHILD  PassengerType = 1
+	PassengerType_PASSENGER_TYPE_SENIOR PassengerType = 2
+)
+
+// Enum value maps for PassengerType.
+var (
+	PassengerType_name = map[int32]string{
+		0: "PASSENGER_TYPE_ADULT",
+		1: "PASSENGER_TYPE_CHILD",
+		2: "PASSENGER_TYPE_SENIOR",
+	}
+	PassengerType_value = map[string]int32{
+		"PASSENGER_TYPE_ADULT":  0,
+		"PASSENGER_TYPE_CHILD":  1,
+		"PASSENGER_TYPE_SENIOR": 2,
+	}
+)
+
+func (x PassengerType) Enum() *PassengerType {
+	p := new(PassengerType)
+	*p = x
+	return p
+}
+
+func (x PassengerType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PassengerType) Descriptor() protoreflect.EnumDescriptor {
+	return file_fare_proto_enumTypes[1].Descriptor()
+}
+
+func (PassengerType) Type() protoreflect.EnumType {
+	return &file_fare_proto_enumTypes[1]
+}
+
+func (x PassengerType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PassengerType.Descriptor instead.
+func (PassengerType) EnumDescriptor() ([]byte, []int) {
+	return file_fare_proto_rawDescGZIP(), []int{1}
+}
+
+// Represents the price of a ticket as worked out by the server's fare table.
+type Fare struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Amount              float64                `protobuf:"fixed64,1,opt,name=amount,proto3" json:"amount,omitempty"` // Amount of price in major units, e.g., 20.00
+	TravelClass         TravelClass            `protobuf:"varint,2,opt,name=travel_class,json=travelClass,proto3,enum=trainticketing.entities.TravelClass" json:"travel_class,omitempty"`
+	PassengerType       PassengerType          `protobuf:"varint,3,opt,name=passenger_type,json=passengerType,proto3,enum=trainticketing.entities.PassengerType" json:"passenger_type,omitempty"`
+	BaseAmount          float64                `protobuf:"fixed64,4,opt,name=base_amount,json=baseAmount,proto3" json:"base_amount,omitempty"`                            // Standard adult fare between the passenger's stops
+	ClassMultiplier     float64                `protobuf:"fixed64,5,opt,name=class_multiplier,json=classMultiplier,proto3" json:"class_multiplier,omitempty"`             // Applied for the travel class
+	PassengerMultiplier float64                `protobuf:"fixed64,6,opt,name=passenger_multiplier,json=passengerMultiplier,proto3" json:"passenger_multiplier,omitempty"` // Applied for the passenger type, e.g., 0.5 for children
+	AdvanceMultiplier   float64                `protobuf:"fixed64,7,opt,name=advance_multiplier,json=advanceMultiplier,proto3" json:"advance_multiplier,omitempty"`       // Applied for booking ahead of the service date
+	DaysInAdvance       int32                  `protobuf:"varint,8,opt,name=days_in_advance,json=daysInAdvance,proto3" json:"days_in_advance,omitempty"`                  // Whole days from the booking date to the service date; 0 for undated journeys
+	DemandMultiplier    float64                `protobuf:"fixed64,9,opt,name=demand_multiplier,json=demandMultiplier,proto3" json:"demand_multiplier,omitempty"`          // Applied by the pricing strategy; 1 when none is configured or no tier applies
+	PricingStrategy     string                 `protobuf:"bytes,10,opt,name=pricing_strategy,json=pricingStrategy,proto3" json:"pricing_strategy,omitempty"`              // Pricing strategy in force when the fare was quoted, e.g., "occupancy"; empty when none is configured
+	PricingTier         string                 `protobuf:"bytes,11,opt,name=pricing_tier,json=pricingTier,proto3" json:"pricing_tier,omitempty"`                          // Tier of the strategy that set the demand multiplier, e.g., "50%"; empty when no tier applied
+	Coach               string                 `protobuf:"bytes,12,opt,name=coach,proto3" json:"coach,omitempty"`                                                         // Coach of the seat the fare was quoted for; empty when no seat was allocated yet
+	Price               *Money                 `protobuf:"bytes,13,opt,name=price,proto3" json:"price,omitempty"`                                                         // The base amount times every multiplier, rounded to the minor unit of the fare table's currency
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *Fare) Reset() {
+	*x = Fare{}
+	mi := &file_fare_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Fare) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Fare) ProtoMessage() {}
+
+func (x *Fare) ProtoReflect() protoreflect.Message {
+	mi := &file_fare_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Fare.ProtoReflect.Descriptor instead.
+func (*Fare) Descriptor() ([]byte, []int) {
+	return file_fare_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Fare) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Fare) GetTravelClass() TravelClass {
+	if x != nil {
+		return x.TravelClass
+	}
+	return TravelClass_TRAVEL_CLASS_STANDARD
+}
+
+func (x *Fare) GetPassengerType() PassengerType {
+	if x != nil {
+		return x.PassengerType
+	}
+	return PassengerType_PASSENGER_TYPE_ADULT
+}
+
+func (x *Fare) GetBaseAmount() float64 {
+	if x != nil {
+		return x.BaseAmount
+	}
+	return 0
+}
+
+func (x *Fare) GetClassMultiplier() float64 {
+	if x != nil {
+		return x.ClassMultiplier
+	}
+	return 0
+}
+
+func (x *Fare) GetPassengerMultiplier() float64 {
+	if x != nil {
+		return x.PassengerMultiplier
+	}
+	return 0
+}
+
+func (x *Fare) GetAdvanceMultiplier() float64 {
+	if x != nil {
+		return x.AdvanceMultiplier
+	}
+	return 0
+}
+
+func (x *Fare) GetDaysInAdvance() int32 {
+	if x != nil {
+		return x.DaysInAdvance
+	}
+	return 0
+}
+
+func (x *Fare) GetDemandMultiplier() float64 {
+	if x != nil {
+		return x.DemandMultiplier
+	}
+	return 0
+}
+
+func (x *Fare) GetPricingStrategy() string {
+	if x != nil {
+		return x.PricingStrategy
+	}
+	return ""
+}
+
+func (x *Fare) GetPricingTier() string {
+	if x != nil {
+		return x.PricingTier
+	}
+	return ""
+}
+
+func (x *Fare) GetCoach() string {
+	if x != nil {
+		return x.Coach
+	}
+	return ""
+}
+
+func (x *Fare) GetPrice() *Money {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+var File_fare_proto protoreflect.FileDescriptor
+
+const file_fare_proto_rawDesc = "" +
+	"\n" +
+	"\n" +
+	"fare.proto\x12\x17trainticketing.entities\x1a\vmoney.proto\"\xd3\x04\n" +
+	"\x04Fare\x12\x16\n" +
+	"\x06amount\x18\x01 \x01(\x01R\x06amount\x12G\n" +
+	"\ftravel_class\x18\x02 \x01(\x0e2$.trainticketing.entities.TravelClassR\vtravelClass\x12M\n" +
+	"\x0epassenger_type\x18\x03 \x01(\x0e2&.trainticketing.entities.PassengerTypeR\rpassengerType\x12\x1f\n" +
+	"\vbase_amount\x18\x04 \x01(\x01R\n" +
+	"baseAmount\x12)\n" +
+	"\x10class_multiplier\x18\x05 \x01(\x01R\x0fclassMultiplier\x121\n" +
+	"\x14passenger_multiplier\x18\x06 \x01(\x01R\x13passengerMultiplier\x12-\n" +
+	"\x12advance_multiplier\x18\a \x01(\x01R\x11advanceMultiplier\x12&\n" +
+	"\x0fdays_in_advance\x18\b \x01(\x05R\rdaysInAdvance\x12+\n" +
+	"\x11demand_multiplier\x18\t \x01(\x01R\x10demandMultiplier\x12)\n" +
+	"\x10pricing_strategy\x18\n" +
+	" \x01(\tR\x0fpricingStrategy\x12!\n" +
+	"\fpricing_tier\x18\v \x01(\tR\vpricingTier\x12\x14\n" +
+	"\x05coach\x18\f \x01(\tR\x05coach\x124\n" +
+	"\x05price\x18\r \x01(\v2\x1e.trainticketing.entities.MoneyR\x05price*@\n" +
+	"\vTravelClass\x12\x19\n" +
+	"\x15TRAVEL_CLASS_STANDARD\x10\x00\x12\x16\n" +
+	"\x12TRAVEL_CLASS_FIRST\x10\x01*^\n" +
+	"\rPassengerType\x12\x18\n" +
+	"\x14PASSENGER_TYPE_ADULT\x10\x00\x12\x18\n" +
+	"\x14PASSENGER_TYPE_CHILD\x10\x01\x12\x19\n" +
+	"\x15PASSENGER_TYPE_SENIOR\x10\x02B/Z-github.com/talk2sohail/train-ticket-api/protob\x06proto3"
+
+var (
+	file_fare_proto_rawDescOnce sync.Once
+	file_fare_proto_rawDescData []byte
+)
+
+func file_fare_proto_rawDescGZIP() []byte {
+	file_fare_proto_rawDescOnce.Do(func() {
+		file_fare_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_fare_proto_rawDesc), len(file_fare_proto_rawDesc)))
+	})
+	return file_fare_proto_rawDescData
+}
+
+var file_fare_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_fare_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_fare_proto_goTypes = []any{
+	(TravelClass)(0),   // 0: trainticketing.entities.TravelClass
+	(PassengerType)(0), // 1: trainticketing.entities.PassengerType
+	(*Fare)(nil),       // 2: trainticketing.entities.Fare
+	(*Money)(nil),      // 3: trainticketing.entities.Money
+}
+var file_fare_proto_depIdxs = []int32{
+	0, // 0: trainticketing.entities.Fare.travel_class:type_name -> trainticketing.entities.TravelClass
+	1, // 1: trainticketing.entities.Fare.passenger_type:type_name -> trainticketing.entities.PassengerType
+	3, // 2: trainticketing.entities.Fare.price:type_name -> trainticketing.entities.Money
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_fare_proto_init() }
+func file_fare_proto_init() {
+	if File_fare_proto != nil {
+		return
+	}
+	file_money_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_fare_proto_rawDesc), len(file_fare_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_fare_proto_goTypes,
+		DependencyIndexes: file_fare_proto_depIdxs,
+		EnumInfos:         file_fare_proto_enumTypes,
+		MessageInfos:      file_fare_proto_msgTypes,
+	}.Build()
+	File_fare_proto = out.File
+	file_fare_proto_goTypes = nil
+	file_fare_proto_depIdxs = nil
+}
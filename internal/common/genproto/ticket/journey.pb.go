@@ -0,0 +1,212 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.31.0
+// source: journey.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Represents a single dated departure of a train, with its own seat inventory.
+type Journey struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	JourneyId       string                 `protobuf:"bytes,1,opt,name=journey_id,json=journeyId,proto3" json:"journey_id,omitempty"`                      // Unique identifier for the journey
+	TrainNumber     string                 `protobuf:"bytes,2,opt,name=train_number,json=trainNumber,proto3" json:"train_number,omitempty"`                // Optional operator train number, e.g., "ES9014"
+	ServiceDate     string                 `protobuf:"bytes,3,opt,name=service_date,json=serviceDate,proto3" json:"service_date,omitempty"`                // Date of service in YYYY-MM-DD format
+	DepartureTime   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=departure_time,json=departureTime,proto3" json:"departure_time,omitempty"`          // Scheduled departure from the origin
+	Origin          string                 `protobuf:"bytes,5,opt,name=origin,proto3" json:"origin,omitempty"`                                             // e.g., "London"
+	Destination     string                 `protobuf:"bytes,6,opt,name=destination,proto3" json:"destination,omitempty"`                                   // e.g., "Paris"
+	SeatsPerSection int32                  `protobuf:"varint,7,opt,name=seats_per_section,json=seatsPerSection,proto3" json:"seats_per_section,omitempty"` // Seats in each section when the train has the standard A/B layout
+	Stops           []string               `protobuf:"bytes,8,rep,name=stops,proto3" json:"stops,omitempty"`                                               // Ordered stops from origin to destination; a seat is only held between a passenger's stops
+	Layout          *TrainLayout           `protobuf:"bytes,9,opt,name=layout,proto3" json:"layout,omitempty"`                                             // Seating plan of the train, fixed when the journey is created
+	TimeZone        string                 `protobuf:"bytes,10,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`                        // IANA time zone the service date is kept in, e.g., "Europe/London"; UTC when empty
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Journey) Reset() {
+	*x = Journey{}
+	mi := &file_journey_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Journey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Journey) ProtoMessage() {}
+
+func (x *Journey) ProtoReflect() protoreflect.Message {
+	mi := &file_journey_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Journey.ProtoReflect.Descriptor instead.
+func (*Journey) Descriptor() ([]byte, []int) {
+	return file_journey_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Journey) GetJourneyId() string {
+	if x != nil {
+		return x.JourneyId
+	}
+	return ""
+}
+
+func (x *Journey) GetTrainNumber() string {
+	if x != nil {
+		return x.TrainNumber
+	}
+	return ""
+}
+
+func (x *Journey) GetServiceDate() string {
+	if x != nil {
+		return x.ServiceDate
+	}
+	return ""
+}
+
+func (x *Journey) GetDepartureTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DepartureTime
+	}
+	return nil
+}
+
+func (x *Journey) GetOrigin() string {
+	if x != nil {
+		return x.Origin
+	}
+	return ""
+}
+
+func (x *Journey) GetDestination() string {
+	if x != nil {
+		return x.Destination
+	}
+	return ""
+}
+
+func (x *Journey) GetSeatsPerSection() int32 {
+	if x != nil {
+		return x.SeatsPerSection
+	}
+	return 0
+}
+
+func (x *Journey) GetStops() []string {
+	if x != nil {
+		return x.Stops
+	}
+	return nil
+}
+
+func (x *Journey) GetLayout() *TrainLayout {
+	if x != nil {
+		return x.Layout
+	}
+	return nil
+}
+
+func (x *Journey) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+var File_journey_proto protoreflect.FileDescriptor
+
+const file_journey_proto_rawDesc = "" +
+	"\n" +
+	"\rjourney.proto\x12\x17trainticketing.entities\x1a\flayout.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x88\x03\n" +
+	"\aJourney\x12\x1d\n" +
+	"\n" +
+	"journey_id\x18\x01 \x01(\tR\tjourneyId\x12!\n" +
+	"\ftrain_number\x18\x02 \x01(\tR\vtrainNumber\x12!\n" +
+	"\fservice_date\x18\x03 \x01(\tR\vserviceDate\x12A\n" +
+	"\x0edeparture_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\rdepartureTime\x12\x16\n" +
+	"\x06origin\x18\x05 \x01(\tR\x06origin\x12 \n" +
+	"\vdestination\x18\x06 \x01(\tR\vdestination\x12*\n" +
+	"\x11seats_per_section\x18\a \x01(\x05R\x0fseatsPerSection\x12\x14\n" +
+	"\x05stops\x18\b \x03(\tR\x05stops\x12<\n" +
+	"\x06layout\x18\t \x01(\v2$.trainticketing.entities.TrainLayoutR\x06layout\x12\x1b\n" +
+	"\ttime_zone\x18\n" +
+	" \x01(\tR\btimeZoneB/Z-github.com/talk2sohail/train-ticket-api/protob\x06proto3"
+
+var (
+	file_journey_proto_rawDescOnce sync.Once
+	file_journey_proto_rawDescData []byte
+)
+
+func file_journey_proto_rawDescGZIP() []byte {
+	file_journey_proto_rawDescOnce.Do(func() {
+		file_journey_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_journey_proto_rawDesc), len(file_journey_proto_rawDesc)))
+	})
+	return file_journey_proto_rawDescData
+}
+
+var file_journey_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_journey_proto_goTypes = []any{
+	(*Journey)(nil),               // 0: trainticketing.entities.Journey
+	(*timestamppb.Timestamp)(nil), // 1: google.protobuf.Timestamp
+	(*TrainLayout)(nil),           // 2: trainticketing.entities.TrainLayout
+}
+var file_journey_proto_depIdxs = []int32{
+	1, // 0: trainticketing.entities.Journey.departure_time:type_name -> google.protobuf.Timestamp
+	2, // 1: trainticketing.entities.Journey.layout:type_name -> trainticketing.entities.TrainLayout
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_journey_proto_init() }
+func file_journey_proto_init() {
+	if File_journey_proto != nil {
+		return
+	}
+	file_layout_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_journey_proto_rawDesc), len(file_journey_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_journey_proto_goTypes,
+		DependencyIndexes: file_journey_proto_depIdxs,
+		MessageInfos:      file_journey_proto_msgTypes,
+	}.Build()
+	File_journey_proto = out.File
+	file_journey_proto_goTypes = nil
+	file_journey_proto_depIdxs = nil
+}
@@ -0,0 +1,194 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.31.0
+// source: layout.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Represents the seating plan of a train.
+type TrainLayout struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`       // Name of the layout in the server config, e.g., "standard"
+	Coaches       []*Coach               `protobuf:"bytes,2,rep,name=coaches,proto3" json:"coaches,omitempty"` // Coaches in train order
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrainLayout) Reset() {
+	*x = TrainLayout{}
+	mi := &file_layout_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrainLayout) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrainLayout) ProtoMessage() {}
+
+func (x *TrainLayout) ProtoReflect() protoreflect.Message {
+	mi := &file_layout_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrainLayout.ProtoReflect.Descriptor instead.
+func (*TrainLayout) Descriptor() ([]byte, []int) {
+	return file_layout_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TrainLayout) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TrainLayout) GetCoaches() []*Coach {
+	if x != nil {
+		return x.Coaches
+	}
+	return nil
+}
+
+// Represents a coach and every seat in it.
+type Coach struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CoachId       string                 `protobuf:"bytes,1,opt,name=coach_id,json=coachId,proto3" json:"coach_id,omitempty"` // e.g., "A", "12"
+	Seats         []*Seat                `protobuf:"bytes,2,rep,name=seats,proto3" json:"seats,omitempty"`                    // Seats ordered by row, then column
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Coach) Reset() {
+	*x = Coach{}
+	mi := &file_layout_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Coach) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Coach) ProtoMessage() {}
+
+func (x *Coach) ProtoReflect() protoreflect.Message {
+	mi := &file_layout_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Coach.ProtoReflect.Descriptor instead.
+func (*Coach) Descriptor() ([]byte, []int) {
+	return file_layout_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Coach) GetCoachId() string {
+	if x != nil {
+		return x.CoachId
+	}
+	return ""
+}
+
+func (x *Coach) GetSeats() []*Seat {
+	if x != nil {
+		return x.Seats
+	}
+	return nil
+}
+
+var File_layout_proto protoreflect.FileDescriptor
+
+const file_layout_proto_rawDesc = "" +
+	"\n" +
+	"\flayout.proto\x12\x17trainticketing.entities\x1a\n" +
+	"seat.proto\"[\n" +
+	"\vTrainLayout\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x128\n" +
+	"\acoaches\x18\x02 \x03(\v2\x1e.trainticketing.entities.CoachR\acoaches\"W\n" +
+	"\x05Coach\x12\x19\n" +
+	"\bcoach_id\x18\x01 \x01(\tR\acoachId\x123\n" +
+	"\x05seats\x18\x02 \x03(\v2\x1d.trainticketing.entities.SeatR\x05seatsB/Z-github.com/talk2sohail/train-ticket-api/protob\x06proto3"
+
+var (
+	file_layout_proto_rawDescOnce sync.Once
+	file_layout_proto_rawDescData []byte
+)
+
+func file_layout_proto_rawDescGZIP() []byte {
+	file_layout_proto_rawDescOnce.Do(func() {
+		file_layout_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_layout_proto_rawDesc), len(file_layout_proto_rawDesc)))
+	})
+	return file_layout_proto_rawDescData
+}
+
+var file_layout_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_layout_proto_goTypes = []any{
+	(*TrainLayout)(nil), // 0: trainticketing.entities.TrainLayout
+	(*Coach)(nil),       // 1: trainticketing.entities.Coach
+	(*Seat)(nil),        // 2: trainticketing.entities.Seat
+}
+var file_layout_proto_depIdxs = []int32{
+	1, // 0: trainticketing.entities.TrainLayout.coaches:type_name -> trainticketing.entities.Coach
+	2, // 1: trainticketing.entities.Coach.seats:type_name -> trainticketing.entities.Seat
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_layout_proto_init() }
+func file_layout_proto_init() {
+	if File_layout_proto != nil {
+		return
+	}
+	file_seat_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_layout_proto_rawDesc), len(file_layout_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_layout_proto_goTypes,
+		DependencyIndexes: file_layout_proto_depIdxs,
+		MessageInfos:      file_layout_proto_msgTypes,
+	}.Build()
+	File_layout_proto = out.File
+	file_layout_proto_goTypes = nil
+	file_layout_proto_depIdxs = nil
+}
@@ -24,16 +24,21 @@ const (
 
 // Represents a train ticket receipt.
 type Receipt struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TicketId      string                 `protobuf:"bytes,1,opt,name=ticket_id,json=ticketId,proto3" json:"ticket_id,omitempty"`                // Unique identifier for the ticket
-	FromLocation  string                 `protobuf:"bytes,2,opt,name=from_location,json=fromLocation,proto3" json:"from_location,omitempty"`    // e.g., "London"
-	ToLocation    string                 `protobuf:"bytes,3,opt,name=to_location,json=toLocation,proto3" json:"to_location,omitempty"`          // e.g., "France"
-	User          *User                  `protobuf:"bytes,4,opt,name=user,proto3" json:"user,omitempty"`                                        // Reference to the User message
-	PricePaid     float64                `protobuf:"fixed64,5,opt,name=price_paid,json=pricePaid,proto3" json:"price_paid,omitempty"`           // Price in USD, e.g., 20.00
-	AllocatedSeat *Seat                  `protobuf:"bytes,6,opt,name=allocated_seat,json=allocatedSeat,proto3" json:"allocated_seat,omitempty"` // Reference to the Seat message
-	PurchaseDate  *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=purchase_date,json=purchaseDate,proto3" json:"purchase_date,omitempty"`    // Timestamp when the ticket was purchased
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	TicketId         string                 `protobuf:"bytes,1,opt,name=ticket_id,json=ticketId,proto3" json:"ticket_id,omitempty"`                         // Unique identifier for the ticket
+	FromLocation     string                 `protobuf:"bytes,2,opt,name=from_location,json=fromLocation,proto3" json:"from_location,omitempty"`             // e.g., "London"
+	ToLocation       string                 `protobuf:"bytes,3,opt,name=to_location,json=toLocation,proto3" json:"to_location,omitempty"`                   // e.g., "France"
+	User             *User                  `protobuf:"bytes,4,opt,name=user,proto3" json:"user,omitempty"`                                                 // Reference to the User message
+	PricePaid        float64                `protobuf:"fixed64,5,opt,name=price_paid,json=pricePaid,proto3" json:"price_paid,omitempty"`                    // Deprecated: use price. The amount of price in major units, e.g., 20.00
+	AllocatedSeat    *Seat                  `protobuf:"bytes,6,opt,name=allocated_seat,json=allocatedSeat,proto3" json:"allocated_seat,omitempty"`          // Reference to the Seat message
+	PurchaseDate     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=purchase_date,json=purchaseDate,proto3" json:"purchase_date,omitempty"`             // Timestamp when the ticket was purchased
+	JourneyId        string                 `protobuf:"bytes,8,opt,name=journey_id,json=journeyId,proto3" json:"journey_id,omitempty"`                      // Journey the ticket is valid for
+	BookingReference string                 `protobuf:"bytes,9,opt,name=booking_reference,json=bookingReference,proto3" json:"booking_reference,omitempty"` // Shared by the tickets of a group booking; empty for single tickets
+	Fare             *Fare                  `protobuf:"bytes,10,opt,name=fare,proto3" json:"fare,omitempty"`                                                // How the price was worked out; absent when the server has no fare table
+	Price            *Money                 `protobuf:"bytes,11,opt,name=price,proto3" json:"price,omitempty"`                                              // Price paid, in the currency it was paid in
+	Payment          *Payment               `protobuf:"bytes,12,opt,name=payment,proto3" json:"payment,omitempty"`                                          // Payment taken for the ticket; absent when the server takes no payment
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *Receipt) Reset() {
@@ -115,13 +120,49 @@ func (x *Receipt) GetPurchaseDate() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Receipt) GetJourneyId() string {
+	if x != nil {
+		return x.JourneyId
+	}
+	return ""
+}
+
+func (x *Receipt) GetBookingReference() string {
+	if x != nil {
+		return x.BookingReference
+	}
+	return ""
+}
+
+func (x *Receipt) GetFare() *Fare {
+	if x != nil {
+		return x.Fare
+	}
+	return nil
+}
+
+func (x *Receipt) GetPrice() *Money {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *Receipt) GetPayment() *Payment {
+	if x != nil {
+		return x.Payment
+	}
+	return nil
+}
+
 var File_receipt_proto protoreflect.FileDescriptor
 
 const file_receipt_proto_rawDesc = "" +
 	"\n" +
 	"\rreceipt.proto\x12\x17trainticketing.entities\x1a\n" +
 	"user.proto\x1a\n" +
-	"seat.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xc5\x02\n" +
+	"seat.proto\x1a\n" +
+	"fare.proto\x1a\vmoney.proto\x1a\rpayment.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb6\x04\n" +
 	"\aReceipt\x12\x1b\n" +
 	"\tticket_id\x18\x01 \x01(\tR\bticketId\x12#\n" +
 	"\rfrom_location\x18\x02 \x01(\tR\ffromLocation\x12\x1f\n" +
@@ -131,7 +172,14 @@ const file_receipt_proto_rawDesc = "" +
 	"\n" +
 	"price_paid\x18\x05 \x01(\x01R\tpricePaid\x12D\n" +
 	"\x0eallocated_seat\x18\x06 \x01(\v2\x1d.trainticketing.entities.SeatR\rallocatedSeat\x12?\n" +
-	"\rpurchase_date\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\fpurchaseDateB/Z-github.com/talk2sohail/train-ticket-api/protob\x06proto3"
+	"\rpurchase_date\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\fpurchaseDate\x12\x1d\n" +
+	"\n" +
+	"journey_id\x18\b \x01(\tR\tjourneyId\x12+\n" +
+	"\x11booking_reference\x18\t \x01(\tR\x10bookingReference\x121\n" +
+	"\x04fare\x18\n" +
+	" \x01(\v2\x1d.trainticketing.entities.FareR\x04fare\x124\n" +
+	"\x05price\x18\v \x01(\v2\x1e.trainticketing.entities.MoneyR\x05price\x12:\n" +
+	"\apayment\x18\f \x01(\v2 .trainticketing.entities.PaymentR\apaymentB/Z-github.com/talk2sohail/train-ticket-api/protob\x06proto3"
 
 var (
 	file_receipt_proto_rawDescOnce sync.Once
@@ -151,16 +199,22 @@ var file_receipt_proto_goTypes = []any{
 	(*User)(nil),                  // 1: trainticketing.entities.User
 	(*Seat)(nil),                  // 2: trainticketing.entities.Seat
 	(*timestamppb.Timestamp)(nil), // 3: google.protobuf.Timestamp
+	(*Fare)(nil),                  // 4: trainticketing.entities.Fare
+	(*Money)(nil),                 // 5: trainticketing.entities.Money
+	(*Payment)(nil),               // 6: trainticketing.entities.Payment
 }
 var file_receipt_proto_depIdxs = []int32{
 	1, // 0: trainticketing.entities.Receipt.user:type_name -> trainticketing.entities.User
 	2, // 1: trainticketing.entities.Receipt.allocated_seat:type_name -> trainticketing.entities.Seat
 	3, // 2: trainticketing.entities.Receipt.purchase_date:type_name -> google.protobuf.Timestamp
-	3, // [3:3] is the sub-list for method output_type
-	3, // [3:3] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	4, // 3: trainticketing.entities.Receipt.fare:type_name -> trainticketing.entities.Fare
+	5, // 4: trainticketing.entities.Receipt.price:type_name -> trainticketing.entities.Money
+	6, // 5: trainticketing.entities.Receipt.payment:type_name -> trainticketing.entities.Payment
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_receipt_proto_init() }
@@ -170,6 +224,9 @@ func file_receipt_proto_init() {
 	}
 	file_user_proto_init()
 	file_seat_proto_init()
+	file_fare_proto_init()
+	file_money_proto_init()
+	file_payment_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
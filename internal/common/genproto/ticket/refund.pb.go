@@ -0,0 +1,268 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.31.0
+// source: refund.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Refund_Status int32
+
+const (
+	Refund_STATUS_UNKNOWN   Refund_Status = 0
+	Refund_STATUS_PENDING   Refund_Status = 1 // Recorded with the cancellation; the payment has not been refunded yet
+	Refund_STATUS_COMPLETED Refund_Status = 2 // The amount was returned to the payment, or there was nothing to return
+	Refund_STATUS_FAILED    Refund_Status = 3 // The payment provider did not refund the amount; it is left to be settled by hand
+)
+
+// Enum value maps for Refund_Status.
+var (
+	Refund_Status_name = map[int32]string{
+		0: "STATUS_UNKNOWN",
+		1: "STATUS_PENDING",
+		2: "STATUS_COMPLETED",
+		3: "STATUS_FAILED",
+	}
+	Refund_Status_value = map[string]int32{
+		"STATUS_UNKNOWN":   0,
+		"STATUS_PENDING":   1,
+		"STATUS_COMPLETED": 2,
+		"STATUS_FAILED":    3,
+	}
+)
+
+func (x Refund_Status) Enum() *Refund_Status {
+	p := new(Refund_Status)
+	*p = x
+	return p
+}
+
+func (x Refund_Status) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Refund_Status) Descriptor() protoreflect.EnumDescriptor {
+	return file_refund_proto_enumTypes[0].Descriptor()
+}
+
+func (Refund_Status) Type() protoreflect.EnumType {
+	return &file_refund_proto_enumTypes[0]
+}
+
+func (x Refund_Status) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Refund_Status.Descriptor instead.
+func (Refund_Status) EnumDescriptor() ([]byte, []int) {
+	return file_refund_proto_rawDescGZIP(), []int{0, 0}
+}
+
+// Represents the refund given for a cancelled ticket.
+type Refund struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RefundId      string                 `protobuf:"bytes,1,opt,name=refund_id,json=refundId,proto3" json:"refund_id,omitempty"`                         // Unique identifier for the refund
+	TicketId      string                 `protobuf:"bytes,2,opt,name=ticket_id,json=ticketId,proto3" json:"ticket_id,omitempty"`                         // Ticket that was cancelled
+	User          *User                  `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`                                                 // Passenger the ticket was issued to
+	Amount        *Money                 `protobuf:"bytes,4,opt,name=amount,proto3" json:"amount,omitempty"`                                             // Amount refunded, in the currency the ticket was paid in
+	RefundPercent float64                `protobuf:"fixed64,5,opt,name=refund_percent,json=refundPercent,proto3" json:"refund_percent,omitempty"`        // Share of the payment that was refunded, e.g., 50 or 12.5
+	Rule          string                 `protobuf:"bytes,6,opt,name=rule,proto3" json:"rule,omitempty"`                                                 // Cancellation rule that set the refund, e.g., ">=24h" or "departed"
+	PaymentId     string                 `protobuf:"bytes,7,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`                      // Payment the amount was returned to
+	RefundedAt    *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=refunded_at,json=refundedAt,proto3" json:"refunded_at,omitempty"`                   // When the ticket was cancelled
+	Status        Refund_Status          `protobuf:"varint,9,opt,name=status,proto3,enum=trainticketing.entities.Refund_Status" json:"status,omitempty"` // Whether the amount has been returned to the payment
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Refund) Reset() {
+	*x = Refund{}
+	mi := &file_refund_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Refund) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Refund) ProtoMessage() {}
+
+func (x *Refund) ProtoReflect() protoreflect.Message {
+	mi := &file_refund_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Refund.ProtoReflect.Descriptor instead.
+func (*Refund) Descriptor() ([]byte, []int) {
+	return file_refund_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Refund) GetRefundId() string {
+	if x != nil {
+		return x.RefundId
+	}
+	return ""
+}
+
+func (x *Refund) GetTicketId() string {
+	if x != nil {
+		return x.TicketId
+	}
+	return ""
+}
+
+func (x *Refund) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *Refund) GetAmount() *Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *Refund) GetRefundPercent() float64 {
+	if x != nil {
+		return x.RefundPercent
+	}
+	return 0
+}
+
+func (x *Refund) GetRule() string {
+	if x != nil {
+		return x.Rule
+	}
+	return ""
+}
+
+func (x *Refund) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+func (x *Refund) GetRefundedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RefundedAt
+	}
+	return nil
+}
+
+func (x *Refund) GetStatus() Refund_Status {
+	if x != nil {
+		return x.Status
+	}
+	return Refund_STATUS_UNKNOWN
+}
+
+var File_refund_proto protoreflect.FileDescriptor
+
+const file_refund_proto_rawDesc = "" +
+	"\n" +
+	"\frefund.proto\x12\x17trainticketing.entities\x1a\n" +
+	"user.proto\x1a\vmoney.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xdf\x03\n" +
+	"\x06Refund\x12\x1b\n" +
+	"\trefund_id\x18\x01 \x01(\tR\brefundId\x12\x1b\n" +
+	"\tticket_id\x18\x02 \x01(\tR\bticketId\x121\n" +
+	"\x04user\x18\x03 \x01(\v2\x1d.trainticketing.entities.UserR\x04user\x126\n" +
+	"\x06amount\x18\x04 \x01(\v2\x1e.trainticketing.entities.MoneyR\x06amount\x12%\n" +
+	"\x0erefund_percent\x18\x05 \x01(\x01R\rrefundPercent\x12\x12\n" +
+	"\x04rule\x18\x06 \x01(\tR\x04rule\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\a \x01(\tR\tpaymentId\x12;\n" +
+	"\vrefunded_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"refundedAt\x12>\n" +
+	"\x06status\x18\t \x01(\x0e2&.trainticketing.entities.Refund.StatusR\x06status\"Y\n" +
+	"\x06Status\x12\x12\n" +
+	"\x0eSTATUS_UNKNOWN\x10\x00\x12\x12\n" +
+	"\x0eSTATUS_PENDING\x10\x01\x12\x14\n" +
+	"\x10STATUS_COMPLETED\x10\x02\x12\x11\n" +
+	"\rSTATUS_FAILED\x10\x03B/Z-github.com/talk2sohail/train-ticket-api/protob\x06proto3"
+
+var (
+	file_refund_proto_rawDescOnce sync.Once
+	file_refund_proto_rawDescData []byte
+)
+
+func file_refund_proto_rawDescGZIP() []byte {
+	file_refund_proto_rawDescOnce.Do(func() {
+		file_refund_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_refund_proto_rawDesc), len(file_refund_proto_rawDesc)))
+	})
+	return file_refund_proto_rawDescData
+}
+
+var file_refund_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_refund_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_refund_proto_goTypes = []any{
+	(Refund_Status)(0),            // 0: trainticketing.entities.Refund.Status
+	(*Refund)(nil),                // 1: trainticketing.entities.Refund
+	(*User)(nil),                  // 2: trainticketing.entities.User
+	(*Money)(nil),                 // 3: trainticketing.entities.Money
+	(*timestamppb.Timestamp)(nil), // 4: google.protobuf.Timestamp
+}
+var file_refund_proto_depIdxs = []int32{
+	2, // 0: trainticketing.entities.Refund.user:type_name -> trainticketing.entities.User
+	3, // 1: trainticketing.entities.Refund.amount:type_name -> trainticketing.entities.Money
+	4, // 2: trainticketing.entities.Refund.refunded_at:type_name -> google.protobuf.Timestamp
+	0, // 3: trainticketing.entities.Refund.status:type_name -> trainticketing.entities.Refund.Status
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_refund_proto_init() }
+func file_refund_proto_init() {
+	if File_refund_proto != nil {
+		return
+	}
+	file_user_proto_init()
+	file_money_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_refund_proto_rawDesc), len(file_refund_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_refund_proto_goTypes,
+		DependencyIndexes: file_refund_proto_depIdxs,
+		EnumInfos:         file_refund_proto_enumTypes,
+		MessageInfos:      file_refund_proto_msgTypes,
+	}.Build()
+	File_refund_proto = out.File
+	file_refund_proto_goTypes = nil
+	file_refund_proto_depIdxs = nil
+}
@@ -70,11 +70,113 @@ func (Seat_Section) EnumDescriptor() ([]byte, []int) {
 	return file_seat_proto_rawDescGZIP(), []int{0, 0}
 }
 
+type SeatAttributes_Facing int32
+
+const (
+	SeatAttributes_FACING_UNKNOWN  SeatAttributes_Facing = 0 // Not recorded in the layout
+	SeatAttributes_FACING_FORWARD  SeatAttributes_Facing = 1 // Faces the direction of travel
+	SeatAttributes_FACING_BACKWARD SeatAttributes_Facing = 2
+)
+
+// Enum value maps for SeatAttributes_Facing.
+var (
+	SeatAttributes_Facing_name = map[int32]string{
+		0: "FACING_UNKNOWN",
+		1: "FACING_FORWARD",
+		2: "FACING_BACKWARD",
+	}
+	SeatAttributes_Facing_value = map[string]int32{
+		"FACING_UNKNOWN":  0,
+		"FACING_FORWARD":  1,
+		"FACING_BACKWARD": 2,
+	}
+)
+
+func (x SeatAttributes_Facing) Enum() *SeatAttributes_Facing {
+	p := new(SeatAttributes_Facing)
+	*p = x
+	return p
+}
+
+func (x SeatAttributes_Facing) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SeatAttributes_Facing) Descriptor() protoreflect.EnumDescriptor {
+	return file_seat_proto_enumTypes[1].Descriptor()
+}
+
+func (SeatAttributes_Facing) Type() protoreflect.EnumType {
+	return &file_seat_proto_enumTypes[1]
+}
+
+func (x SeatAttributes_Facing) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SeatAttributes_Facing.Descriptor instead.
+func (SeatAttributes_Facing) EnumDescriptor() ([]byte, []int) {
+	return file_seat_proto_rawDescGZIP(), []int{1, 0}
+}
+
+type SeatPreferences_Position int32
+
+const (
+	SeatPreferences_POSITION_ANY    SeatPreferences_Position = 0
+	SeatPreferences_POSITION_WINDOW SeatPreferences_Position = 1
+	SeatPreferences_POSITION_AISLE  SeatPreferences_Position = 2
+)
+
+// Enum value maps for SeatPreferences_Position.
+var (
+	SeatPreferences_Position_name = map[int32]string{
+		0: "POSITION_ANY",
+		1: "POSITION_WINDOW",
+		2: "POSITION_AISLE",
+	}
+	SeatPreferences_Position_value = map[string]int32{
+		"POSITION_ANY":    0,
+		"POSITION_WINDOW": 1,
+		"POSITION_AISLE":  2,
+	}
+)
+
+func (x SeatPreferences_Position) Enum() *SeatPreferences_Position {
+	p := new(SeatPreferences_Position)
+	*p = x
+	return p
+}
+
+func (x SeatPreferences_Position) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SeatPreferences_Position) Descriptor() protoreflect.EnumDescriptor {
+	return file_seat_proto_enumTypes[2].Descriptor()
+}
+
+func (SeatPreferences_Position) Type() protoreflect.EnumType {
+	return &file_seat_proto_enumTypes[2]
+}
+
+func (x SeatPreferences_Position) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SeatPreferences_Position.Descriptor instead.
+func (SeatPreferences_Position) EnumDescriptor() ([]byte, []int) {
+	return file_seat_proto_rawDescGZIP(), []int{2, 0}
+}
+
 // Represents a seat on the train.
 type Seat struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Section       Seat_Section           `protobuf:"varint,1,opt,name=section,proto3,enum=trainticketing.entities.Seat_Section" json:"section,omitempty"`
-	SeatNumber    string                 `protobuf:"bytes,2,opt,name=seat_number,json=seatNumber,proto3" json:"seat_number,omitempty"` // e.g., "1A", "1B", "2C"
+	Section       Seat_Section           `protobuf:"varint,1,opt,name=section,proto3,enum=trainticketing.entities.Seat_Section" json:"section,omitempty"` // Legacy section; only set for coaches "A" and "B"
+	SeatNumber    string                 `protobuf:"bytes,2,opt,name=seat_number,json=seatNumber,proto3" json:"seat_number,omitempty"`                    // e.g., "A1", "C12D"; unique within a train
+	Coach         string                 `protobuf:"bytes,3,opt,name=coach,proto3" json:"coach,omitempty"`                                                // Coach the seat is in, e.g., "C"
+	Row           int32                  `protobuf:"varint,4,opt,name=row,proto3" json:"row,omitempty"`                                                   // Row within the coach, starting at 1
+	Column        string                 `protobuf:"bytes,5,opt,name=column,proto3" json:"column,omitempty"`                                              // Column letter within the row, e.g., "D"; empty for single-column coaches
+	Attributes    *SeatAttributes        `protobuf:"bytes,6,opt,name=attributes,proto3" json:"attributes,omitempty"`                                      // Features of the seat
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -123,20 +225,259 @@ func (x *Seat) GetSeatNumber() string {
 	return ""
 }
 
+func (x *Seat) GetCoach() string {
+	if x != nil {
+		return x.Coach
+	}
+	return ""
+}
+
+func (x *Seat) GetRow() int32 {
+	if x != nil {
+		return x.Row
+	}
+	return 0
+}
+
+func (x *Seat) GetColumn() string {
+	if x != nil {
+		return x.Column
+	}
+	return ""
+}
+
+func (x *Seat) GetAttributes() *SeatAttributes {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+// Features a passenger may look for in a seat.
+type SeatAttributes struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Window        bool                   `protobuf:"varint,1,opt,name=window,proto3" json:"window,omitempty"`
+	Aisle         bool                   `protobuf:"varint,2,opt,name=aisle,proto3" json:"aisle,omitempty"`
+	Table         bool                   `protobuf:"varint,3,opt,name=table,proto3" json:"table,omitempty"`
+	PowerSocket   bool                   `protobuf:"varint,4,opt,name=power_socket,json=powerSocket,proto3" json:"power_socket,omitempty"`
+	Accessible    bool                   `protobuf:"varint,5,opt,name=accessible,proto3" json:"accessible,omitempty"` // Wheelchair space or priority seat
+	QuietZone     bool                   `protobuf:"varint,6,opt,name=quiet_zone,json=quietZone,proto3" json:"quiet_zone,omitempty"`
+	Facing        SeatAttributes_Facing  `protobuf:"varint,7,opt,name=facing,proto3,enum=trainticketing.entities.SeatAttributes_Facing" json:"facing,omitempty"`
+	NearDoor      bool                   `protobuf:"varint,8,opt,name=near_door,json=nearDoor,proto3" json:"near_door,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SeatAttributes) Reset() {
+	*x = SeatAttributes{}
+	mi := &file_seat_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SeatAttributes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeatAttributes) ProtoMessage() {}
+
+func (x *SeatAttributes) ProtoReflect() protoreflect.Message {
+	mi := &file_seat_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeatAttributes.ProtoReflect.Descriptor instead.
+func (*SeatAttributes) Descriptor() ([]byte, []int) {
+	return file_seat_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SeatAttributes) GetWindow() bool {
+	if x != nil {
+		return x.Window
+	}
+	return false
+}
+
+func (x *SeatAttributes) GetAisle() bool {
+	if x != nil {
+		return x.Aisle
+	}
+	return false
+}
+
+func (x *SeatAttributes) GetTable() bool {
+	if x != nil {
+		return x.Table
+	}
+	return false
+}
+
+func (x *SeatAttributes) GetPowerSocket() bool {
+	if x != nil {
+		return x.PowerSocket
+	}
+	return false
+}
+
+func (x *SeatAttributes) GetAccessible() bool {
+	if x != nil {
+		return x.Accessible
+	}
+	return false
+}
+
+func (x *SeatAttributes) GetQuietZone() bool {
+	if x != nil {
+		return x.QuietZone
+	}
+	return false
+}
+
+func (x *SeatAttributes) GetFacing() SeatAttributes_Facing {
+	if x != nil {
+		return x.Facing
+	}
+	return SeatAttributes_FACING_UNKNOWN
+}
+
+func (x *SeatAttributes) GetNearDoor() bool {
+	if x != nil {
+		return x.NearDoor
+	}
+	return false
+}
+
+// Seat features a passenger would like. Unset fields express no preference.
+type SeatPreferences struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Coach         string                   `protobuf:"bytes,1,opt,name=coach,proto3" json:"coach,omitempty"` // Preferred coach, e.g., "B" for section B
+	Position      SeatPreferences_Position `protobuf:"varint,2,opt,name=position,proto3,enum=trainticketing.entities.SeatPreferences_Position" json:"position,omitempty"`
+	Facing        SeatAttributes_Facing    `protobuf:"varint,3,opt,name=facing,proto3,enum=trainticketing.entities.SeatAttributes_Facing" json:"facing,omitempty"`
+	NearDoor      bool                     `protobuf:"varint,4,opt,name=near_door,json=nearDoor,proto3" json:"near_door,omitempty"`
+	SeatNumber    string                   `protobuf:"bytes,5,opt,name=seat_number,json=seatNumber,proto3" json:"seat_number,omitempty"` // A specific seat, e.g., "A3"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SeatPreferences) Reset() {
+	*x = SeatPreferences{}
+	mi := &file_seat_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SeatPreferences) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeatPreferences) ProtoMessage() {}
+
+func (x *SeatPreferences) ProtoReflect() protoreflect.Message {
+	mi := &file_seat_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeatPreferences.ProtoReflect.Descriptor instead.
+func (*SeatPreferences) Descriptor() ([]byte, []int) {
+	return file_seat_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SeatPreferences) GetCoach() string {
+	if x != nil {
+		return x.Coach
+	}
+	return ""
+}
+
+func (x *SeatPreferences) GetPosition() SeatPreferences_Position {
+	if x != nil {
+		return x.Position
+	}
+	return SeatPreferences_POSITION_ANY
+}
+
+func (x *SeatPreferences) GetFacing() SeatAttributes_Facing {
+	if x != nil {
+		return x.Facing
+	}
+	return SeatAttributes_FACING_UNKNOWN
+}
+
+func (x *SeatPreferences) GetNearDoor() bool {
+	if x != nil {
+		return x.NearDoor
+	}
+	return false
+}
+
+func (x *SeatPreferences) GetSeatNumber() string {
+	if x != nil {
+		return x.SeatNumber
+	}
+	return ""
+}
+
 var File_seat_proto protoreflect.FileDescriptor
 
 const file_seat_proto_rawDesc = "" +
 	"\n" +
 	"\n" +
-	"seat.proto\x12\x17trainticketing.entities\"\xa6\x01\n" +
+	"seat.proto\x12\x17trainticketing.entities\"\xaf\x02\n" +
 	"\x04Seat\x12?\n" +
 	"\asection\x18\x01 \x01(\x0e2%.trainticketing.entities.Seat.SectionR\asection\x12\x1f\n" +
 	"\vseat_number\x18\x02 \x01(\tR\n" +
-	"seatNumber\"<\n" +
+	"seatNumber\x12\x14\n" +
+	"\x05coach\x18\x03 \x01(\tR\x05coach\x12\x10\n" +
+	"\x03row\x18\x04 \x01(\x05R\x03row\x12\x16\n" +
+	"\x06column\x18\x05 \x01(\tR\x06column\x12G\n" +
+	"\n" +
+	"attributes\x18\x06 \x01(\v2'.trainticketing.entities.SeatAttributesR\n" +
+	"attributes\"<\n" +
 	"\aSection\x12\x13\n" +
 	"\x0fSECTION_UNKNOWN\x10\x00\x12\r\n" +
 	"\tSECTION_A\x10\x01\x12\r\n" +
-	"\tSECTION_B\x10\x02B/Z-github.com/talk2sohail/train-ticket-api/protob\x06proto3"
+	"\tSECTION_B\x10\x02\"\xe2\x02\n" +
+	"\x0eSeatAttributes\x12\x16\n" +
+	"\x06window\x18\x01 \x01(\bR\x06window\x12\x14\n" +
+	"\x05aisle\x18\x02 \x01(\bR\x05aisle\x12\x14\n" +
+	"\x05table\x18\x03 \x01(\bR\x05table\x12!\n" +
+	"\fpower_socket\x18\x04 \x01(\bR\vpowerSocket\x12\x1e\n" +
+	"\n" +
+	"accessible\x18\x05 \x01(\bR\n" +
+	"accessible\x12\x1d\n" +
+	"\n" +
+	"quiet_zone\x18\x06 \x01(\bR\tquietZone\x12F\n" +
+	"\x06facing\x18\a \x01(\x0e2..trainticketing.entities.SeatAttributes.FacingR\x06facing\x12\x1b\n" +
+	"\tnear_door\x18\b \x01(\bR\bnearDoor\"E\n" +
+	"\x06Facing\x12\x12\n" +
+	"\x0eFACING_UNKNOWN\x10\x00\x12\x12\n" +
+	"\x0eFACING_FORWARD\x10\x01\x12\x13\n" +
+	"\x0fFACING_BACKWARD\x10\x02\"\xc3\x02\n" +
+	"\x0fSeatPreferences\x12\x14\n" +
+	"\x05coach\x18\x01 \x01(\tR\x05coach\x12M\n" +
+	"\bposition\x18\x02 \x01(\x0e21.trainticketing.entities.SeatPreferences.PositionR\bposition\x12F\n" +
+	"\x06facing\x18\x03 \x01(\x0e2..trainticketing.entities.SeatAttributes.FacingR\x06facing\x12\x1b\n" +
+	"\tnear_door\x18\x04 \x01(\bR\bnearDoor\x12\x1f\n" +
+	"\vseat_number\x18\x05 \x01(\tR\n" +
+	"seatNumber\"E\n" +
+	"\bPosition\x12\x10\n" +
+	"\fPOSITION_ANY\x10\x00\x12\x13\n" +
+	"\x0fPOSITION_WINDOW\x10\x01\x12\x12\n" +
+	"\x0ePOSITION_AISLE\x10\x02B/Z-github.com/talk2sohail/train-ticket-api/protob\x06proto3"
 
 var (
 	file_seat_proto_rawDescOnce sync.Once
@@ -150,19 +491,27 @@ func file_seat_proto_rawDescGZIP() []byte {
 	return file_seat_proto_rawDescData
 }
 
-var file_seat_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_seat_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_seat_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_seat_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
 var file_seat_proto_goTypes = []any{
-	(Seat_Section)(0), // 0: trainticketing.entities.Seat.Section
-	(*Seat)(nil),      // 1: trainticketing.entities.Seat
+	(Seat_Section)(0),             // 0: trainticketing.entities.Seat.Section
+	(SeatAttributes_Facing)(0),    // 1: trainticketing.entities.SeatAttributes.Facing
+	(SeatPreferences_Position)(0), // 2: trainticketing.entities.SeatPreferences.Position
+	(*Seat)(nil),                  // 3: trainticketing.entities.Seat
+	(*SeatAttributes)(nil),        // 4: trainticketing.entities.SeatAttributes
+	(*SeatPreferences)(nil),       // 5: trainticketing.entities.SeatPreferences
 }
 var file_seat_proto_depIdxs = []int32{
 	0, // 0: trainticketing.entities.Seat.section:type_name -> trainticketing.entities.Seat.Section
-	1, // [1:1] is the sub-list for method output_type
-	1, // [1:1] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	4, // 1: trainticketing.entities.Seat.attributes:type_name -> trainticketing.entities.SeatAttributes
+	1, // 2: trainticketing.entities.SeatAttributes.facing:type_name -> trainticketing.entities.SeatAttributes.Facing
+	2, // 3: trainticketing.entities.SeatPreferences.position:type_name -> trainticketing.entities.SeatPreferences.Position
+	1, // 4: trainticketing.entities.SeatPreferences.facing:type_name -> trainticketing.entities.SeatAttributes.Facing
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_seat_proto_init() }
@@ -175,8 +524,8 @@ func file_seat_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_seat_proto_rawDesc), len(file_seat_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   1,
+			NumEnums:      3,
+			NumMessages:   3,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
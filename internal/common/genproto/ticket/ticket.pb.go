@@ -9,6 +9,7 @@ package proto
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -21,13 +22,68 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type AvailabilityUpdate_Kind int32
+
+const (
+	AvailabilityUpdate_KIND_UNKNOWN  AvailabilityUpdate_Kind = 0
+	AvailabilityUpdate_KIND_SNAPSHOT AvailabilityUpdate_Kind = 1 // Lists every seat of the journey; replaces anything received before
+	AvailabilityUpdate_KIND_DELTA    AvailabilityUpdate_Kind = 2 // Lists only the seats whose state changed
+)
+
+// Enum value maps for AvailabilityUpdate_Kind.
+var (
+	AvailabilityUpdate_Kind_name = map[int32]string{
+		0: "KIND_UNKNOWN",
+		1: "KIND_SNAPSHOT",
+		2: "KIND_DELTA",
+	}
+	AvailabilityUpdate_Kind_value = map[string]int32{
+		"KIND_UNKNOWN":  0,
+		"KIND_SNAPSHOT": 1,
+		"KIND_DELTA":    2,
+	}
+)
+
+func (x AvailabilityUpdate_Kind) Enum() *AvailabilityUpdate_Kind {
+	p := new(AvailabilityUpdate_Kind)
+	*p = x
+	return p
+}
+
+func (x AvailabilityUpdate_Kind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AvailabilityUpdate_Kind) Descriptor() protoreflect.EnumDescriptor {
+	return file_ticket_proto_enumTypes[0].Descriptor()
+}
+
+func (AvailabilityUpdate_Kind) Type() protoreflect.EnumType {
+	return &file_ticket_proto_enumTypes[0]
+}
+
+func (x AvailabilityUpdate_Kind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AvailabilityUpdate_Kind.Descriptor instead.
+func (AvailabilityUpdate_Kind) EnumDescriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{13, 0}
+}
+
 // Request message for purchasing a ticket.
 type PurchaseTicketRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	FromLocation  string                 `protobuf:"bytes,1,opt,name=from_location,json=fromLocation,proto3" json:"from_location,omitempty"` // e.g., "London"
-	ToLocation    string                 `protobuf:"bytes,2,opt,name=to_location,json=toLocation,proto3" json:"to_location,omitempty"`       // e.g., "France"
+	FromLocation  string                 `protobuf:"bytes,1,opt,name=from_location,json=fromLocation,proto3" json:"from_location,omitempty"` // e.g., "London"; must be a stop on the journey's route
+	ToLocation    string                 `protobuf:"bytes,2,opt,name=to_location,json=toLocation,proto3" json:"to_location,omitempty"`       // e.g., "France"; must be a later stop on the journey's route
 	User          *User                  `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`                                     // Reference to the User message
-	PricePaid     float64                `protobuf:"fixed64,4,opt,name=price_paid,json=pricePaid,proto3" json:"price_paid,omitempty"`        // Price in USD, e.g., 20.00
+	PricePaid     float64                `protobuf:"fixed64,4,opt,name=price_paid,json=pricePaid,proto3" json:"price_paid,omitempty"`        // Deprecated: use price. Price in USD, e.g., 20.00; only read when price is unset
+	JourneyId     string                 `protobuf:"bytes,5,opt,name=journey_id,json=journeyId,proto3" json:"journey_id,omitempty"`          // Journey to book; the default journey when empty
+	Preferences   *SeatPreferences       `protobuf:"bytes,6,opt,name=preferences,proto3" json:"preferences,omitempty"`                       // Optional seat preferences, met where possible
+	TravelClass   TravelClass            `protobuf:"varint,7,opt,name=travel_class,json=travelClass,proto3,enum=trainticketing.entities.TravelClass" json:"travel_class,omitempty"`
+	PassengerType PassengerType          `protobuf:"varint,8,opt,name=passenger_type,json=passengerType,proto3,enum=trainticketing.entities.PassengerType" json:"passenger_type,omitempty"`
+	Price         *Money                 `protobuf:"bytes,9,opt,name=price,proto3" json:"price,omitempty"`                                       // Price paid, in any currency the server has an exchange rate for
+	PaymentMethod string                 `protobuf:"bytes,10,opt,name=payment_method,json=paymentMethod,proto3" json:"payment_method,omitempty"` // Token of the card or account charged, when the server takes payment
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -90,14 +146,58 @@ func (x *PurchaseTicketRequest) GetPricePaid() float64 {
 	return 0
 }
 
+func (x *PurchaseTicketRequest) GetJourneyId() string {
+	if x != nil {
+		return x.JourneyId
+	}
+	return ""
+}
+
+func (x *PurchaseTicketRequest) GetPreferences() *SeatPreferences {
+	if x != nil {
+		return x.Preferences
+	}
+	return nil
+}
+
+func (x *PurchaseTicketRequest) GetTravelClass() TravelClass {
+	if x != nil {
+		return x.TravelClass
+	}
+	return TravelClass_TRAVEL_CLASS_STANDARD
+}
+
+func (x *PurchaseTicketRequest) GetPassengerType() PassengerType {
+	if x != nil {
+		return x.PassengerType
+	}
+	return PassengerType_PASSENGER_TYPE_ADULT
+}
+
+func (x *PurchaseTicketRequest) GetPrice() *Money {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *PurchaseTicketRequest) GetPaymentMethod() string {
+	if x != nil {
+		return x.PaymentMethod
+	}
+	return ""
+}
+
 // Response message for purchasing a ticket.
 type PurchaseTicketResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"` // Indicates if the purchase was successful
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`  // A descriptive message (e.g., error details)
-	Receipt       *Receipt               `protobuf:"bytes,3,opt,name=receipt,proto3" json:"receipt,omitempty"`  // The generated receipt if successful
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Success          bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`                                          // Indicates if the purchase was successful
+	Message          string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`                                           // A descriptive message (e.g., error details)
+	Receipt          *Receipt               `protobuf:"bytes,3,opt,name=receipt,proto3" json:"receipt,omitempty"`                                           // The generated receipt if successful
+	PreferencesMet   []string               `protobuf:"bytes,4,rep,name=preferences_met,json=preferencesMet,proto3" json:"preferences_met,omitempty"`       // Requested preferences the allocated seat meets, e.g., "position"
+	PreferencesUnmet []string               `protobuf:"bytes,5,rep,name=preferences_unmet,json=preferencesUnmet,proto3" json:"preferences_unmet,omitempty"` // Requested preferences no free seat could meet alongside the others
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *PurchaseTicketResponse) Reset() {
@@ -151,32 +251,51 @@ func (x *PurchaseTicketResponse) GetReceipt() *Receipt {
 	return nil
 }
 
-// Request message for getting receipt details.
-type GetReceiptDetailsRequest struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Types that are valid to be assigned to Identifier:
-	//
-	//	*GetReceiptDetailsRequest_Email
-	//	*GetReceiptDetailsRequest_TicketId
-	Identifier    isGetReceiptDetailsRequest_Identifier `protobuf_oneof:"identifier"`
+func (x *PurchaseTicketResponse) GetPreferencesMet() []string {
+	if x != nil {
+		return x.PreferencesMet
+	}
+	return nil
+}
+
+func (x *PurchaseTicketResponse) GetPreferencesUnmet() []string {
+	if x != nil {
+		return x.PreferencesUnmet
+	}
+	return nil
+}
+
+// Request message for purchasing tickets for a group of passengers.
+type PurchaseGroupTicketRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromLocation  string                 `protobuf:"bytes,1,opt,name=from_location,json=fromLocation,proto3" json:"from_location,omitempty"`                                                // Boarding stop shared by the whole party
+	ToLocation    string                 `protobuf:"bytes,2,opt,name=to_location,json=toLocation,proto3" json:"to_location,omitempty"`                                                      // Alighting stop shared by the whole party
+	Passengers    []*User                `protobuf:"bytes,3,rep,name=passengers,proto3" json:"passengers,omitempty"`                                                                        // One ticket is issued per passenger
+	PricePaid     float64                `protobuf:"fixed64,4,opt,name=price_paid,json=pricePaid,proto3" json:"price_paid,omitempty"`                                                       // Deprecated: use price. Price per passenger in USD, e.g., 20.00; only read when price is unset
+	JourneyId     string                 `protobuf:"bytes,5,opt,name=journey_id,json=journeyId,proto3" json:"journey_id,omitempty"`                                                         // Journey to book; the default journey when empty
+	AllowSplit    bool                   `protobuf:"varint,6,opt,name=allow_split,json=allowSplit,proto3" json:"allow_split,omitempty"`                                                     // Spread the party over several coaches when no single coach can seat it
+	TravelClass   TravelClass            `protobuf:"varint,7,opt,name=travel_class,json=travelClass,proto3,enum=trainticketing.entities.TravelClass" json:"travel_class,omitempty"`         // Shared by the whole party
+	PassengerType PassengerType          `protobuf:"varint,8,opt,name=passenger_type,json=passengerType,proto3,enum=trainticketing.entities.PassengerType" json:"passenger_type,omitempty"` // Shared by the whole party; book other passenger types separately
+	Price         *Money                 `protobuf:"bytes,9,opt,name=price,proto3" json:"price,omitempty"`                                                                                  // Price per passenger, in any currency the server has an exchange rate for
+	PaymentMethod string                 `protobuf:"bytes,10,opt,name=payment_method,json=paymentMethod,proto3" json:"payment_method,omitempty"`                                            // Token of the card or account charged for the whole party, when the server takes payment
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetReceiptDetailsRequest) Reset() {
-	*x = GetReceiptDetailsRequest{}
+func (x *PurchaseGroupTicketRequest) Reset() {
+	*x = PurchaseGroupTicketRequest{}
 	mi := &file_ticket_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetReceiptDetailsRequest) String() string {
+func (x *PurchaseGroupTicketRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetReceiptDetailsRequest) ProtoMessage() {}
+func (*PurchaseGroupTicketRequest) ProtoMessage() {}
 
-func (x *GetReceiptDetailsRequest) ProtoReflect() protoreflect.Message {
+func (x *PurchaseGroupTicketRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_ticket_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -188,76 +307,106 @@ func (x *GetReceiptDetailsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetReceiptDetailsRequest.ProtoReflect.Descriptor instead.
-func (*GetReceiptDetailsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use PurchaseGroupTicketRequest.ProtoReflect.Descriptor instead.
+func (*PurchaseGroupTicketRequest) Descriptor() ([]byte, []int) {
 	return file_ticket_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *GetReceiptDetailsRequest) GetIdentifier() isGetReceiptDetailsRequest_Identifier {
+func (x *PurchaseGroupTicketRequest) GetFromLocation() string {
 	if x != nil {
-		return x.Identifier
+		return x.FromLocation
 	}
-	return nil
+	return ""
 }
 
-func (x *GetReceiptDetailsRequest) GetEmail() string {
+func (x *PurchaseGroupTicketRequest) GetToLocation() string {
 	if x != nil {
-		if x, ok := x.Identifier.(*GetReceiptDetailsRequest_Email); ok {
-			return x.Email
-		}
+		return x.ToLocation
 	}
 	return ""
 }
 
-func (x *GetReceiptDetailsRequest) GetTicketId() string {
+func (x *PurchaseGroupTicketRequest) GetPassengers() []*User {
 	if x != nil {
-		if x, ok := x.Identifier.(*GetReceiptDetailsRequest_TicketId); ok {
-			return x.TicketId
-		}
+		return x.Passengers
+	}
+	return nil
+}
+
+func (x *PurchaseGroupTicketRequest) GetPricePaid() float64 {
+	if x != nil {
+		return x.PricePaid
+	}
+	return 0
+}
+
+func (x *PurchaseGroupTicketRequest) GetJourneyId() string {
+	if x != nil {
+		return x.JourneyId
 	}
 	return ""
 }
 
-type isGetReceiptDetailsRequest_Identifier interface {
-	isGetReceiptDetailsRequest_Identifier()
+func (x *PurchaseGroupTicketRequest) GetAllowSplit() bool {
+	if x != nil {
+		return x.AllowSplit
+	}
+	return false
 }
 
-type GetReceiptDetailsRequest_Email struct {
-	Email string `protobuf:"bytes,1,opt,name=email,proto3,oneof"` // User's email
+func (x *PurchaseGroupTicketRequest) GetTravelClass() TravelClass {
+	if x != nil {
+		return x.TravelClass
+	}
+	return TravelClass_TRAVEL_CLASS_STANDARD
 }
 
-type GetReceiptDetailsRequest_TicketId struct {
-	TicketId string `protobuf:"bytes,2,opt,name=ticket_id,json=ticketId,proto3,oneof"` // Specific ticket ID
+func (x *PurchaseGroupTicketRequest) GetPassengerType() PassengerType {
+	if x != nil {
+		return x.PassengerType
+	}
+	return PassengerType_PASSENGER_TYPE_ADULT
 }
 
-func (*GetReceiptDetailsRequest_Email) isGetReceiptDetailsRequest_Identifier() {}
+func (x *PurchaseGroupTicketRequest) GetPrice() *Money {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
 
-func (*GetReceiptDetailsRequest_TicketId) isGetReceiptDetailsRequest_Identifier() {}
+func (x *PurchaseGroupTicketRequest) GetPaymentMethod() string {
+	if x != nil {
+		return x.PaymentMethod
+	}
+	return ""
+}
 
-// Response message for getting receipt details.
-type GetReceiptDetailsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Receipt       *Receipt               `protobuf:"bytes,3,opt,name=receipt,proto3" json:"receipt,omitempty"` // The requested receipt
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// Response message for purchasing tickets for a group of passengers.
+type PurchaseGroupTicketResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Success          bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message          string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	BookingReference string                 `protobuf:"bytes,3,opt,name=booking_reference,json=bookingReference,proto3" json:"booking_reference,omitempty"` // Shared by every receipt of the group
+	Receipts         []*Receipt             `protobuf:"bytes,4,rep,name=receipts,proto3" json:"receipts,omitempty"`                                         // One receipt per passenger, in request order
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
-func (x *GetReceiptDetailsResponse) Reset() {
-	*x = GetReceiptDetailsResponse{}
+func (x *PurchaseGroupTicketResponse) Reset() {
+	*x = PurchaseGroupTicketResponse{}
 	mi := &file_ticket_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetReceiptDetailsResponse) String() string {
+func (x *PurchaseGroupTicketResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetReceiptDetailsResponse) ProtoMessage() {}
+func (*PurchaseGroupTicketResponse) ProtoMessage() {}
 
-func (x *GetReceiptDetailsResponse) ProtoReflect() protoreflect.Message {
+func (x *PurchaseGroupTicketResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_ticket_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -269,55 +418,67 @@ func (x *GetReceiptDetailsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetReceiptDetailsResponse.ProtoReflect.Descriptor instead.
-func (*GetReceiptDetailsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use PurchaseGroupTicketResponse.ProtoReflect.Descriptor instead.
+func (*PurchaseGroupTicketResponse) Descriptor() ([]byte, []int) {
 	return file_ticket_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *GetReceiptDetailsResponse) GetSuccess() bool {
+func (x *PurchaseGroupTicketResponse) GetSuccess() bool {
 	if x != nil {
 		return x.Success
 	}
 	return false
 }
 
-func (x *GetReceiptDetailsResponse) GetMessage() string {
+func (x *PurchaseGroupTicketResponse) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
-func (x *GetReceiptDetailsResponse) GetReceipt() *Receipt {
+func (x *PurchaseGroupTicketResponse) GetBookingReference() string {
 	if x != nil {
-		return x.Receipt
+		return x.BookingReference
+	}
+	return ""
+}
+
+func (x *PurchaseGroupTicketResponse) GetReceipts() []*Receipt {
+	if x != nil {
+		return x.Receipts
 	}
 	return nil
 }
 
-// Represents a user and their allocated seat.
-type UserSeat struct {
+// Request message for holding a seat.
+type HoldSeatRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"` // Reference to the User message
-	Seat          *Seat                  `protobuf:"bytes,2,opt,name=seat,proto3" json:"seat,omitempty"` // Reference to the Seat message
+	FromLocation  string                 `protobuf:"bytes,1,opt,name=from_location,json=fromLocation,proto3" json:"from_location,omitempty"` // Must be a stop on the journey's route
+	ToLocation    string                 `protobuf:"bytes,2,opt,name=to_location,json=toLocation,proto3" json:"to_location,omitempty"`       // Must be a later stop on the journey's route
+	User          *User                  `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`                                     // Passenger the ticket will be issued to
+	JourneyId     string                 `protobuf:"bytes,4,opt,name=journey_id,json=journeyId,proto3" json:"journey_id,omitempty"`          // Journey to book; the default journey when empty
+	Preferences   *SeatPreferences       `protobuf:"bytes,5,opt,name=preferences,proto3" json:"preferences,omitempty"`                       // Optional seat preferences, met where possible
+	TravelClass   TravelClass            `protobuf:"varint,6,opt,name=travel_class,json=travelClass,proto3,enum=trainticketing.entities.TravelClass" json:"travel_class,omitempty"`
+	PassengerType PassengerType          `protobuf:"varint,7,opt,name=passenger_type,json=passengerType,proto3,enum=trainticketing.entities.PassengerType" json:"passenger_type,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UserSeat) Reset() {
-	*x = UserSeat{}
+func (x *HoldSeatRequest) Reset() {
+	*x = HoldSeatRequest{}
 	mi := &file_ticket_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UserSeat) String() string {
+func (x *HoldSeatRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UserSeat) ProtoMessage() {}
+func (*HoldSeatRequest) ProtoMessage() {}
 
-func (x *UserSeat) ProtoReflect() protoreflect.Message {
+func (x *HoldSeatRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_ticket_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -329,47 +490,89 @@ func (x *UserSeat) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UserSeat.ProtoReflect.Descriptor instead.
-func (*UserSeat) Descriptor() ([]byte, []int) {
+// Deprecated: Use HoldSeatRequest.ProtoReflect.Descriptor instead.
+func (*HoldSeatRequest) Descriptor() ([]byte, []int) {
 	return file_ticket_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *UserSeat) GetUser() *User {
+func (x *HoldSeatRequest) GetFromLocation() string {
+	if x != nil {
+		return x.FromLocation
+	}
+	return ""
+}
+
+func (x *HoldSeatRequest) GetToLocation() string {
+	if x != nil {
+		return x.ToLocation
+	}
+	return ""
+}
+
+func (x *HoldSeatRequest) GetUser() *User {
 	if x != nil {
 		return x.User
 	}
 	return nil
 }
 
-func (x *UserSeat) GetSeat() *Seat {
+func (x *HoldSeatRequest) GetJourneyId() string {
 	if x != nil {
-		return x.Seat
+		return x.JourneyId
+	}
+	return ""
+}
+
+func (x *HoldSeatRequest) GetPreferences() *SeatPreferences {
+	if x != nil {
+		return x.Preferences
 	}
 	return nil
 }
 
-// Request message for getting users by section.
-type GetUsersBySectionRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Section       Seat_Section           `protobuf:"varint,1,opt,name=section,proto3,enum=trainticketing.entities.Seat_Section" json:"section,omitempty"` // The section to query (A or B)
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *HoldSeatRequest) GetTravelClass() TravelClass {
+	if x != nil {
+		return x.TravelClass
+	}
+	return TravelClass_TRAVEL_CLASS_STANDARD
 }
 
-func (x *GetUsersBySectionRequest) Reset() {
-	*x = GetUsersBySectionRequest{}
+func (x *HoldSeatRequest) GetPassengerType() PassengerType {
+	if x != nil {
+		return x.PassengerType
+	}
+	return PassengerType_PASSENGER_TYPE_ADULT
+}
+
+// Response message for holding a seat.
+type HoldSeatResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Success          bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message          string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	HoldId           string                 `protobuf:"bytes,3,opt,name=hold_id,json=holdId,proto3" json:"hold_id,omitempty"`                               // Pass to ConfirmHold to complete the purchase
+	Seat             *Seat                  `protobuf:"bytes,4,opt,name=seat,proto3" json:"seat,omitempty"`                                                 // The held seat
+	ExpiresAt        *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`                      // The seat is released if the hold is not confirmed by then
+	PreferencesMet   []string               `protobuf:"bytes,6,rep,name=preferences_met,json=preferencesMet,proto3" json:"preferences_met,omitempty"`       // Requested preferences the held seat meets
+	PreferencesUnmet []string               `protobuf:"bytes,7,rep,name=preferences_unmet,json=preferencesUnmet,proto3" json:"preferences_unmet,omitempty"` // Requested preferences no free seat could meet alongside the others
+	Fare             *Fare                  `protobuf:"bytes,8,opt,name=fare,proto3" json:"fare,omitempty"`                                                 // Price to pay on confirmation, fixed when the seat is held
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *HoldSeatResponse) Reset() {
+	*x = HoldSeatResponse{}
 	mi := &file_ticket_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetUsersBySectionRequest) String() string {
+func (x *HoldSeatResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetUsersBySectionRequest) ProtoMessage() {}
+func (*HoldSeatResponse) ProtoMessage() {}
 
-func (x *GetUsersBySectionRequest) ProtoReflect() protoreflect.Message {
+func (x *HoldSeatResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_ticket_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -381,106 +584,1907 @@ func (x *GetUsersBySectionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetUsersBySectionRequest.ProtoReflect.Descriptor instead.
-func (*GetUsersBySectionRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use HoldSeatResponse.ProtoReflect.Descriptor instead.
+func (*HoldSeatResponse) Descriptor() ([]byte, []int) {
 	return file_ticket_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *GetUsersBySectionRequest) GetSection() Seat_Section {
+func (x *HoldSeatResponse) GetSuccess() bool {
 	if x != nil {
-		return x.Section
+		return x.Success
 	}
-	return Seat_SECTION_UNKNOWN
-}
-
-// Response message for getting users by section.
-type GetUsersBySectionResponse struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	Success        bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message        string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	UsersInSection []*UserSeat            `protobuf:"bytes,3,rep,name=users_in_section,json=usersInSection,proto3" json:"users_in_section,omitempty"` // List of users and their seats
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	return false
 }
 
-func (x *GetUsersBySectionResponse) Reset() {
-	*x = GetUsersBySectionResponse{}
-	mi := &file_ticket_proto_msgTypes[6]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *HoldSeatResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
 }
 
-func (x *GetUsersBySectionResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *HoldSeatResponse) GetHoldId() string {
+	if x != nil {
+		return x.HoldId
+	}
+	return ""
 }
 
-func (*GetUsersBySectionResponse) ProtoMessage() {}
-
-func (x *GetUsersBySectionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_ticket_proto_msgTypes[6]
+func (x *HoldSeatResponse) GetSeat() *Seat {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Seat
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use GetUsersBySectionResponse.ProtoReflect.Descriptor instead.
-func (*GetUsersBySectionResponse) Descriptor() ([]byte, []int) {
-	return file_ticket_proto_rawDescGZIP(), []int{6}
+func (x *HoldSeatResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
 }
 
-func (x *GetUsersBySectionResponse) GetSuccess() bool {
+func (x *HoldSeatResponse) GetPreferencesMet() []string {
 	if x != nil {
-		return x.Success
+		return x.PreferencesMet
 	}
-	return false
+	return nil
 }
 
-func (x *GetUsersBySectionResponse) GetMessage() string {
+func (x *HoldSeatResponse) GetPreferencesUnmet() []string {
 	if x != nil {
-		return x.Message
+		return x.PreferencesUnmet
 	}
-	return ""
+	return nil
 }
 
-func (x *GetUsersBySectionResponse) GetUsersInSection() []*UserSeat {
+func (x *HoldSeatResponse) GetFare() *Fare {
 	if x != nil {
-		return x.UsersInSection
+		return x.Fare
 	}
 	return nil
 }
 
-// Request message for removing a user.
-type RemoveUserRequest struct {
+// Request message for confirming a seat hold.
+type ConfirmHoldRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	HoldId        string                 `protobuf:"bytes,1,opt,name=hold_id,json=holdId,proto3" json:"hold_id,omitempty"`
+	PricePaid     float64                `protobuf:"fixed64,2,opt,name=price_paid,json=pricePaid,proto3" json:"price_paid,omitempty"`           // Deprecated: use price. Price in USD, e.g., 20.00; only read when price is unset
+	Price         *Money                 `protobuf:"bytes,3,opt,name=price,proto3" json:"price,omitempty"`                                      // Must match the fare of the hold, in any currency the server has an exchange rate for
+	PaymentMethod string                 `protobuf:"bytes,4,opt,name=payment_method,json=paymentMethod,proto3" json:"payment_method,omitempty"` // Token of the card or account charged, when the server takes payment
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmHoldRequest) Reset() {
+	*x = ConfirmHoldRequest{}
+	mi := &file_ticket_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmHoldRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmHoldRequest) ProtoMessage() {}
+
+func (x *ConfirmHoldRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmHoldRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmHoldRequest) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ConfirmHoldRequest) GetHoldId() string {
+	if x != nil {
+		return x.HoldId
+	}
+	return ""
+}
+
+func (x *ConfirmHoldRequest) GetPricePaid() float64 {
+	if x != nil {
+		return x.PricePaid
+	}
+	return 0
+}
+
+func (x *ConfirmHoldRequest) GetPrice() *Money {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *ConfirmHoldRequest) GetPaymentMethod() string {
+	if x != nil {
+		return x.PaymentMethod
+	}
+	return ""
+}
+
+// Response message for confirming a seat hold.
+type ConfirmHoldResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Receipt       *Receipt               `protobuf:"bytes,3,opt,name=receipt,proto3" json:"receipt,omitempty"` // The ticket issued for the held seat
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmHoldResponse) Reset() {
+	*x = ConfirmHoldResponse{}
+	mi := &file_ticket_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmHoldResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmHoldResponse) ProtoMessage() {}
+
+func (x *ConfirmHoldResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmHoldResponse.ProtoReflect.Descriptor instead.
+func (*ConfirmHoldResponse) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ConfirmHoldResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ConfirmHoldResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ConfirmHoldResponse) GetReceipt() *Receipt {
+	if x != nil {
+		return x.Receipt
+	}
+	return nil
+}
+
+// Request message for joining the waitlist.
+type JoinWaitlistRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromLocation  string                 `protobuf:"bytes,1,opt,name=from_location,json=fromLocation,proto3" json:"from_location,omitempty"` // Must be a stop on the journey's route
+	ToLocation    string                 `protobuf:"bytes,2,opt,name=to_location,json=toLocation,proto3" json:"to_location,omitempty"`       // Must be a later stop on the journey's route
+	User          *User                  `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
+	PricePaid     float64                `protobuf:"fixed64,4,opt,name=price_paid,json=pricePaid,proto3" json:"price_paid,omitempty"` // Deprecated: use price. Price in USD charged when a seat is given; only read when price is unset
+	JourneyId     string                 `protobuf:"bytes,5,opt,name=journey_id,json=journeyId,proto3" json:"journey_id,omitempty"`   // Journey to wait for; the default journey when empty
+	TravelClass   TravelClass            `protobuf:"varint,6,opt,name=travel_class,json=travelClass,proto3,enum=trainticketing.entities.TravelClass" json:"travel_class,omitempty"`
+	PassengerType PassengerType          `protobuf:"varint,7,opt,name=passenger_type,json=passengerType,proto3,enum=trainticketing.entities.PassengerType" json:"passenger_type,omitempty"`
+	Price         *Money                 `protobuf:"bytes,8,opt,name=price,proto3" json:"price,omitempty"`                                      // Price charged when a seat is given, in any currency the server has an exchange rate for
+	PaymentMethod string                 `protobuf:"bytes,9,opt,name=payment_method,json=paymentMethod,proto3" json:"payment_method,omitempty"` // Token of the card or account charged when a seat is given, when the server takes payment
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JoinWaitlistRequest) Reset() {
+	*x = JoinWaitlistRequest{}
+	mi := &file_ticket_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JoinWaitlistRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinWaitlistRequest) ProtoMessage() {}
+
+func (x *JoinWaitlistRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinWaitlistRequest.ProtoReflect.Descriptor instead.
+func (*JoinWaitlistRequest) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *JoinWaitlistRequest) GetFromLocation() string {
+	if x != nil {
+		return x.FromLocation
+	}
+	return ""
+}
+
+func (x *JoinWaitlistRequest) GetToLocation() string {
+	if x != nil {
+		return x.ToLocation
+	}
+	return ""
+}
+
+func (x *JoinWaitlistRequest) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *JoinWaitlistRequest) GetPricePaid() float64 {
+	if x != nil {
+		return x.PricePaid
+	}
+	return 0
+}
+
+func (x *JoinWaitlistRequest) GetJourneyId() string {
+	if x != nil {
+		return x.JourneyId
+	}
+	return ""
+}
+
+func (x *JoinWaitlistRequest) GetTravelClass() TravelClass {
+	if x != nil {
+		return x.TravelClass
+	}
+	return TravelClass_TRAVEL_CLASS_STANDARD
+}
+
+func (x *JoinWaitlistRequest) GetPassengerType() PassengerType {
+	if x != nil {
+		return x.PassengerType
+	}
+	return PassengerType_PASSENGER_TYPE_ADULT
+}
+
+func (x *JoinWaitlistRequest) GetPrice() *Money {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *JoinWaitlistRequest) GetPaymentMethod() string {
+	if x != nil {
+		return x.PaymentMethod
+	}
+	return ""
+}
+
+// Response message for joining the waitlist.
+type JoinWaitlistResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Entry         *WaitlistEntry         `protobuf:"bytes,3,opt,name=entry,proto3" json:"entry,omitempty"`
+	Position      int32                  `protobuf:"varint,4,opt,name=position,proto3" json:"position,omitempty"` // 1 for the next passenger to be served
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JoinWaitlistResponse) Reset() {
+	*x = JoinWaitlistResponse{}
+	mi := &file_ticket_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JoinWaitlistResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinWaitlistResponse) ProtoMessage() {}
+
+func (x *JoinWaitlistResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinWaitlistResponse.ProtoReflect.Descriptor instead.
+func (*JoinWaitlistResponse) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *JoinWaitlistResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *JoinWaitlistResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *JoinWaitlistResponse) GetEntry() *WaitlistEntry {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+func (x *JoinWaitlistResponse) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+// Request message for checking a waitlist entry.
+type GetWaitlistStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WaitlistId    string                 `protobuf:"bytes,1,opt,name=waitlist_id,json=waitlistId,proto3" json:"waitlist_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWaitlistStatusRequest) Reset() {
+	*x = GetWaitlistStatusRequest{}
+	mi := &file_ticket_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWaitlistStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWaitlistStatusRequest) ProtoMessage() {}
+
+func (x *GetWaitlistStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWaitlistStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetWaitlistStatusRequest) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetWaitlistStatusRequest) GetWaitlistId() string {
+	if x != nil {
+		return x.WaitlistId
+	}
+	return ""
+}
+
+// Response message for checking a waitlist entry.
+type GetWaitlistStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Entry         *WaitlistEntry         `protobuf:"bytes,3,opt,name=entry,proto3" json:"entry,omitempty"`
+	Position      int32                  `protobuf:"varint,4,opt,name=position,proto3" json:"position,omitempty"` // Current place in the queue; 0 once promoted
+	Receipt       *Receipt               `protobuf:"bytes,5,opt,name=receipt,proto3" json:"receipt,omitempty"`    // The ticket given on promotion, while it is active
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWaitlistStatusResponse) Reset() {
+	*x = GetWaitlistStatusResponse{}
+	mi := &file_ticket_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWaitlistStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWaitlistStatusResponse) ProtoMessage() {}
+
+func (x *GetWaitlistStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWaitlistStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetWaitlistStatusResponse) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetWaitlistStatusResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *GetWaitlistStatusResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *GetWaitlistStatusResponse) GetEntry() *WaitlistEntry {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+func (x *GetWaitlistStatusResponse) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *GetWaitlistStatusResponse) GetReceipt() *Receipt {
+	if x != nil {
+		return x.Receipt
+	}
+	return nil
+}
+
+// Request message for watching seat availability.
+type WatchAvailabilityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JourneyId     string                 `protobuf:"bytes,1,opt,name=journey_id,json=journeyId,proto3" json:"journey_id,omitempty"`          // Journey to watch; the default journey when empty
+	FromLocation  string                 `protobuf:"bytes,2,opt,name=from_location,json=fromLocation,proto3" json:"from_location,omitempty"` // With to_location, watch availability over part of the route; the whole route when both are empty
+	ToLocation    string                 `protobuf:"bytes,3,opt,name=to_location,json=toLocation,proto3" json:"to_location,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchAvailabilityRequest) Reset() {
+	*x = WatchAvailabilityRequest{}
+	mi := &file_ticket_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchAvailabilityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchAvailabilityRequest) ProtoMessage() {}
+
+func (x *WatchAvailabilityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchAvailabilityRequest.ProtoReflect.Descriptor instead.
+func (*WatchAvailabilityRequest) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *WatchAvailabilityRequest) GetJourneyId() string {
+	if x != nil {
+		return x.JourneyId
+	}
+	return ""
+}
+
+func (x *WatchAvailabilityRequest) GetFromLocation() string {
+	if x != nil {
+		return x.FromLocation
+	}
+	return ""
+}
+
+func (x *WatchAvailabilityRequest) GetToLocation() string {
+	if x != nil {
+		return x.ToLocation
+	}
+	return ""
+}
+
+// A change in seat availability. Seats are identified by seat number only; no passenger data is sent.
+type AvailabilityUpdate struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Kind          AvailabilityUpdate_Kind `protobuf:"varint,1,opt,name=kind,proto3,enum=trainticketing.service.AvailabilityUpdate_Kind" json:"kind,omitempty"`
+	JourneyId     string                  `protobuf:"bytes,2,opt,name=journey_id,json=journeyId,proto3" json:"journey_id,omitempty"`
+	FreeSeats     []string                `protobuf:"bytes,3,rep,name=free_seats,json=freeSeats,proto3" json:"free_seats,omitempty"`
+	OccupiedSeats []string                `protobuf:"bytes,4,rep,name=occupied_seats,json=occupiedSeats,proto3" json:"occupied_seats,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AvailabilityUpdate) Reset() {
+	*x = AvailabilityUpdate{}
+	mi := &file_ticket_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AvailabilityUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AvailabilityUpdate) ProtoMessage() {}
+
+func (x *AvailabilityUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AvailabilityUpdate.ProtoReflect.Descriptor instead.
+func (*AvailabilityUpdate) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *AvailabilityUpdate) GetKind() AvailabilityUpdate_Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return AvailabilityUpdate_KIND_UNKNOWN
+}
+
+func (x *AvailabilityUpdate) GetJourneyId() string {
+	if x != nil {
+		return x.JourneyId
+	}
+	return ""
+}
+
+func (x *AvailabilityUpdate) GetFreeSeats() []string {
+	if x != nil {
+		return x.FreeSeats
+	}
+	return nil
+}
+
+func (x *AvailabilityUpdate) GetOccupiedSeats() []string {
+	if x != nil {
+		return x.OccupiedSeats
+	}
+	return nil
+}
+
+// Request message for getting receipt details.
+type GetReceiptDetailsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Identifier:
+	//
+	//	*GetReceiptDetailsRequest_Email
+	//	*GetReceiptDetailsRequest_TicketId
+	Identifier    isGetReceiptDetailsRequest_Identifier `protobuf_oneof:"identifier"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReceiptDetailsRequest) Reset() {
+	*x = GetReceiptDetailsRequest{}
+	mi := &file_ticket_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReceiptDetailsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReceiptDetailsRequest) ProtoMessage() {}
+
+func (x *GetReceiptDetailsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReceiptDetailsRequest.ProtoReflect.Descriptor instead.
+func (*GetReceiptDetailsRequest) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetReceiptDetailsRequest) GetIdentifier() isGetReceiptDetailsRequest_Identifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *GetReceiptDetailsRequest) GetEmail() string {
+	if x != nil {
+		if x, ok := x.Identifier.(*GetReceiptDetailsRequest_Email); ok {
+			return x.Email
+		}
+	}
+	return ""
+}
+
+func (x *GetReceiptDetailsRequest) GetTicketId() string {
+	if x != nil {
+		if x, ok := x.Identifier.(*GetReceiptDetailsRequest_TicketId); ok {
+			return x.TicketId
+		}
+	}
+	return ""
+}
+
+type isGetReceiptDetailsRequest_Identifier interface {
+	isGetReceiptDetailsRequest_Identifier()
+}
+
+type GetReceiptDetailsRequest_Email struct {
+	Email string `protobuf:"bytes,1,opt,name=email,proto3,oneof"` // User's email
+}
+
+type GetReceiptDetailsRequest_TicketId struct {
+	TicketId string `protobuf:"bytes,2,opt,name=ticket_id,json=ticketId,proto3,oneof"` // Specific ticket ID
+}
+
+func (*GetReceiptDetailsRequest_Email) isGetReceiptDetailsRequest_Identifier() {}
+
+func (*GetReceiptDetailsRequest_TicketId) isGetReceiptDetailsRequest_Identifier() {}
+
+// Response message for getting receipt details.
+type GetReceiptDetailsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Receipt       *Receipt               `protobuf:"bytes,3,opt,name=receipt,proto3" json:"receipt,omitempty"` // The requested receipt
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReceiptDetailsResponse) Reset() {
+	*x = GetReceiptDetailsResponse{}
+	mi := &file_ticket_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReceiptDetailsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReceiptDetailsResponse) ProtoMessage() {}
+
+func (x *GetReceiptDetailsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReceiptDetailsResponse.ProtoReflect.Descriptor instead.
+func (*GetReceiptDetailsResponse) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetReceiptDetailsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *GetReceiptDetailsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *GetReceiptDetailsResponse) GetReceipt() *Receipt {
+	if x != nil {
+		return x.Receipt
+	}
+	return nil
+}
+
+// Represents a user and their allocated seat.
+type UserSeat struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"` // Reference to the User message
+	Seat          *Seat                  `protobuf:"bytes,2,opt,name=seat,proto3" json:"seat,omitempty"` // Reference to the Seat message
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserSeat) Reset() {
+	*x = UserSeat{}
+	mi := &file_ticket_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserSeat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserSeat) ProtoMessage() {}
+
+func (x *UserSeat) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserSeat.ProtoReflect.Descriptor instead.
+func (*UserSeat) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *UserSeat) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *UserSeat) GetSeat() *Seat {
+	if x != nil {
+		return x.Seat
+	}
+	return nil
+}
+
+// Request message for getting users by section.
+type GetUsersBySectionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Section       Seat_Section           `protobuf:"varint,1,opt,name=section,proto3,enum=trainticketing.entities.Seat_Section" json:"section,omitempty"` // The section to query (A or B); ignored when coach is set
+	JourneyId     string                 `protobuf:"bytes,2,opt,name=journey_id,json=journeyId,proto3" json:"journey_id,omitempty"`                       // Journey to query; the default journey when empty
+	Coach         string                 `protobuf:"bytes,3,opt,name=coach,proto3" json:"coach,omitempty"`                                                // The coach to query, e.g., "C"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUsersBySectionRequest) Reset() {
+	*x = GetUsersBySectionRequest{}
+	mi := &file_ticket_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUsersBySectionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUsersBySectionRequest) ProtoMessage() {}
+
+func (x *GetUsersBySectionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUsersBySectionRequest.ProtoReflect.Descriptor instead.
+func (*GetUsersBySectionRequest) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetUsersBySectionRequest) GetSection() Seat_Section {
+	if x != nil {
+		return x.Section
+	}
+	return Seat_SECTION_UNKNOWN
+}
+
+func (x *GetUsersBySectionRequest) GetJourneyId() string {
+	if x != nil {
+		return x.JourneyId
+	}
+	return ""
+}
+
+func (x *GetUsersBySectionRequest) GetCoach() string {
+	if x != nil {
+		return x.Coach
+	}
+	return ""
+}
+
+// Response message for getting users by section.
+type GetUsersBySectionResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Success        bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message        string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	UsersInSection []*UserSeat            `protobuf:"bytes,3,rep,name=users_in_section,json=usersInSection,proto3" json:"users_in_section,omitempty"` // List of users and their seats
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetUsersBySectionResponse) Reset() {
+	*x = GetUsersBySectionResponse{}
+	mi := &file_ticket_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUsersBySectionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUsersBySectionResponse) ProtoMessage() {}
+
+func (x *GetUsersBySectionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUsersBySectionResponse.ProtoReflect.Descriptor instead.
+func (*GetUsersBySectionResponse) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetUsersBySectionResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *GetUsersBySectionResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *GetUsersBySectionResponse) GetUsersInSection() []*UserSeat {
+	if x != nil {
+		return x.UsersInSection
+	}
+	return nil
+}
+
+// Request message for removing a user.
+type RemoveUserRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Identifier:
+	//
+	//	*RemoveUserRequest_Email
+	//	*RemoveUserRequest_TicketId
+	Identifier    isRemoveUserRequest_Identifier `protobuf_oneof:"identifier"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveUserRequest) Reset() {
+	*x = RemoveUserRequest{}
+	mi := &file_ticket_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveUserRequest) ProtoMessage() {}
+
+func (x *RemoveUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveUserRequest.ProtoReflect.Descriptor instead.
+func (*RemoveUserRequest) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RemoveUserRequest) GetIdentifier() isRemoveUserRequest_Identifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *RemoveUserRequest) GetEmail() string {
+	if x != nil {
+		if x, ok := x.Identifier.(*RemoveUserRequest_Email); ok {
+			return x.Email
+		}
+	}
+	return ""
+}
+
+func (x *RemoveUserRequest) GetTicketId() string {
+	if x != nil {
+		if x, ok := x.Identifier.(*RemoveUserRequest_TicketId); ok {
+			return x.TicketId
+		}
+	}
+	return ""
+}
+
+type isRemoveUserRequest_Identifier interface {
+	isRemoveUserRequest_Identifier()
+}
+
+type RemoveUserRequest_Email struct {
+	Email string `protobuf:"bytes,1,opt,name=email,proto3,oneof"` // User's email
+}
+
+type RemoveUserRequest_TicketId struct {
+	TicketId string `protobuf:"bytes,2,opt,name=ticket_id,json=ticketId,proto3,oneof"` // Specific ticket ID
+}
+
+func (*RemoveUserRequest_Email) isRemoveUserRequest_Identifier() {}
+
+func (*RemoveUserRequest_TicketId) isRemoveUserRequest_Identifier() {}
+
+// Response message for removing a user.
+type RemoveUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Refund        *Refund                `protobuf:"bytes,3,opt,name=refund,proto3" json:"refund,omitempty"` // Refund given under the cancellation policy; absent when the ticket was booked without payment
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveUserResponse) Reset() {
+	*x = RemoveUserResponse{}
+	mi := &file_ticket_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveUserResponse) ProtoMessage() {}
+
+func (x *RemoveUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveUserResponse.ProtoReflect.Descriptor instead.
+func (*RemoveUserResponse) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RemoveUserResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RemoveUserResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *RemoveUserResponse) GetRefund() *Refund {
+	if x != nil {
+		return x.Refund
+	}
+	return nil
+}
+
+// Request message for cancelling a ticket.
+type CancelTicketRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TicketId      string                 `protobuf:"bytes,1,opt,name=ticket_id,json=ticketId,proto3" json:"ticket_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelTicketRequest) Reset() {
+	*x = CancelTicketRequest{}
+	mi := &file_ticket_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelTicketRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelTicketRequest) ProtoMessage() {}
+
+func (x *CancelTicketRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelTicketRequest.ProtoReflect.Descriptor instead.
+func (*CancelTicketRequest) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *CancelTicketRequest) GetTicketId() string {
+	if x != nil {
+		return x.TicketId
+	}
+	return ""
+}
+
+// Response message for cancelling a ticket.
+type CancelTicketResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Receipt       *Receipt               `protobuf:"bytes,3,opt,name=receipt,proto3" json:"receipt,omitempty"` // The cancelled ticket
+	Refund        *Refund                `protobuf:"bytes,4,opt,name=refund,proto3" json:"refund,omitempty"`   // Refund given under the cancellation policy; absent when the ticket was booked without payment
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelTicketResponse) Reset() {
+	*x = CancelTicketResponse{}
+	mi := &file_ticket_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelTicketResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelTicketResponse) ProtoMessage() {}
+
+func (x *CancelTicketResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelTicketResponse.ProtoReflect.Descriptor instead.
+func (*CancelTicketResponse) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *CancelTicketResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CancelTicketResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *CancelTicketResponse) GetReceipt() *Receipt {
+	if x != nil {
+		return x.Receipt
+	}
+	return nil
+}
+
+func (x *CancelTicketResponse) GetRefund() *Refund {
+	if x != nil {
+		return x.Refund
+	}
+	return nil
+}
+
+// Request message for listing a passenger's tickets.
+type ListTicketsForUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`   // Maximum tickets to return; the server's default when 0
+	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // next_page_token of the previous page; empty for the first page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTicketsForUserRequest) Reset() {
+	*x = ListTicketsForUserRequest{}
+	mi := &file_ticket_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTicketsForUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTicketsForUserRequest) ProtoMessage() {}
+
+func (x *ListTicketsForUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTicketsForUserRequest.ProtoReflect.Descriptor instead.
+func (*ListTicketsForUserRequest) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListTicketsForUserRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *ListTicketsForUserRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListTicketsForUserRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// Response message for listing a passenger's tickets.
+type ListTicketsForUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Tickets       []*Receipt             `protobuf:"bytes,3,rep,name=tickets,proto3" json:"tickets,omitempty"`                                    // Tickets ordered by purchase date
+	NextPageToken string                 `protobuf:"bytes,4,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // Token for the next page; empty on the last page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTicketsForUserResponse) Reset() {
+	*x = ListTicketsForUserResponse{}
+	mi := &file_ticket_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTicketsForUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTicketsForUserResponse) ProtoMessage() {}
+
+func (x *ListTicketsForUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTicketsForUserResponse.ProtoReflect.Descriptor instead.
+func (*ListTicketsForUserResponse) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListTicketsForUserResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ListTicketsForUserResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ListTicketsForUserResponse) GetTickets() []*Receipt {
+	if x != nil {
+		return x.Tickets
+	}
+	return nil
+}
+
+func (x *ListTicketsForUserResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// Request message for modifying a user's seat.
+type ModifyUserSeatRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Types that are valid to be assigned to Identifier:
 	//
-	//	*RemoveUserRequest_Email
-	//	*RemoveUserRequest_TicketId
-	Identifier    isRemoveUserRequest_Identifier `protobuf_oneof:"identifier"`
+	//	*ModifyUserSeatRequest_Email
+	//	*ModifyUserSeatRequest_TicketId
+	Identifier    isModifyUserSeatRequest_Identifier `protobuf_oneof:"identifier"`
+	NewSeat       *Seat                              `protobuf:"bytes,3,opt,name=new_seat,json=newSeat,proto3" json:"new_seat,omitempty"` // The new seat to allocate
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModifyUserSeatRequest) Reset() {
+	*x = ModifyUserSeatRequest{}
+	mi := &file_ticket_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModifyUserSeatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModifyUserSeatRequest) ProtoMessage() {}
+
+func (x *ModifyUserSeatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModifyUserSeatRequest.ProtoReflect.Descriptor instead.
+func (*ModifyUserSeatRequest) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ModifyUserSeatRequest) GetIdentifier() isModifyUserSeatRequest_Identifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *ModifyUserSeatRequest) GetEmail() string {
+	if x != nil {
+		if x, ok := x.Identifier.(*ModifyUserSeatRequest_Email); ok {
+			return x.Email
+		}
+	}
+	return ""
+}
+
+func (x *ModifyUserSeatRequest) GetTicketId() string {
+	if x != nil {
+		if x, ok := x.Identifier.(*ModifyUserSeatRequest_TicketId); ok {
+			return x.TicketId
+		}
+	}
+	return ""
+}
+
+func (x *ModifyUserSeatRequest) GetNewSeat() *Seat {
+	if x != nil {
+		return x.NewSeat
+	}
+	return nil
+}
+
+type isModifyUserSeatRequest_Identifier interface {
+	isModifyUserSeatRequest_Identifier()
+}
+
+type ModifyUserSeatRequest_Email struct {
+	Email string `protobuf:"bytes,1,opt,name=email,proto3,oneof"` // User's email
+}
+
+type ModifyUserSeatRequest_TicketId struct {
+	TicketId string `protobuf:"bytes,2,opt,name=ticket_id,json=ticketId,proto3,oneof"` // Specific ticket ID
+}
+
+func (*ModifyUserSeatRequest_Email) isModifyUserSeatRequest_Identifier() {}
+
+func (*ModifyUserSeatRequest_TicketId) isModifyUserSeatRequest_Identifier() {}
+
+// Response message for modifying a user's seat.
+type ModifyUserSeatResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Success        bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message        string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	UpdatedReceipt *Receipt               `protobuf:"bytes,3,opt,name=updated_receipt,json=updatedReceipt,proto3" json:"updated_receipt,omitempty"` // The newly allocated seat if successful
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ModifyUserSeatResponse) Reset() {
+	*x = ModifyUserSeatResponse{}
+	mi := &file_ticket_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModifyUserSeatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModifyUserSeatResponse) ProtoMessage() {}
+
+func (x *ModifyUserSeatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModifyUserSeatResponse.ProtoReflect.Descriptor instead.
+func (*ModifyUserSeatResponse) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ModifyUserSeatResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ModifyUserSeatResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ModifyUserSeatResponse) GetUpdatedReceipt() *Receipt {
+	if x != nil {
+		return x.UpdatedReceipt
+	}
+	return nil
+}
+
+// Request message for getting the event history of a ticket.
+type GetTicketHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TicketId      string                 `protobuf:"bytes,1,opt,name=ticket_id,json=ticketId,proto3" json:"ticket_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTicketHistoryRequest) Reset() {
+	*x = GetTicketHistoryRequest{}
+	mi := &file_ticket_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTicketHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTicketHistoryRequest) ProtoMessage() {}
+
+func (x *GetTicketHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTicketHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetTicketHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetTicketHistoryRequest) GetTicketId() string {
+	if x != nil {
+		return x.TicketId
+	}
+	return ""
+}
+
+// Response message for getting the event history of a ticket.
+type GetTicketHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Events        []*BookingEvent        `protobuf:"bytes,3,rep,name=events,proto3" json:"events,omitempty"` // Events in the order they happened
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTicketHistoryResponse) Reset() {
+	*x = GetTicketHistoryResponse{}
+	mi := &file_ticket_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTicketHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTicketHistoryResponse) ProtoMessage() {}
+
+func (x *GetTicketHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTicketHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetTicketHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetTicketHistoryResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *GetTicketHistoryResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *GetTicketHistoryResponse) GetEvents() []*BookingEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+// Request message for finding who sat in a seat at a given time.
+type GetSeatOccupantRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SeatNumber    string                 `protobuf:"bytes,1,opt,name=seat_number,json=seatNumber,proto3" json:"seat_number,omitempty"` // e.g., "B2"
+	At            *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=at,proto3" json:"at,omitempty"`                                   // Point in time to query; defaults to now
+	JourneyId     string                 `protobuf:"bytes,3,opt,name=journey_id,json=journeyId,proto3" json:"journey_id,omitempty"`    // Journey the seat belongs to; the default journey when empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSeatOccupantRequest) Reset() {
+	*x = GetSeatOccupantRequest{}
+	mi := &file_ticket_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSeatOccupantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSeatOccupantRequest) ProtoMessage() {}
+
+func (x *GetSeatOccupantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSeatOccupantRequest.ProtoReflect.Descriptor instead.
+func (*GetSeatOccupantRequest) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GetSeatOccupantRequest) GetSeatNumber() string {
+	if x != nil {
+		return x.SeatNumber
+	}
+	return ""
+}
+
+func (x *GetSeatOccupantRequest) GetAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.At
+	}
+	return nil
+}
+
+func (x *GetSeatOccupantRequest) GetJourneyId() string {
+	if x != nil {
+		return x.JourneyId
+	}
+	return ""
+}
+
+// Response message for finding who sat in a seat at a given time.
+type GetSeatOccupantResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Receipt       *Receipt               `protobuf:"bytes,3,opt,name=receipt,proto3" json:"receipt,omitempty"` // The occupying ticket as it was at that time
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSeatOccupantResponse) Reset() {
+	*x = GetSeatOccupantResponse{}
+	mi := &file_ticket_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSeatOccupantResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSeatOccupantResponse) ProtoMessage() {}
+
+func (x *GetSeatOccupantResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSeatOccupantResponse.ProtoReflect.Descriptor instead.
+func (*GetSeatOccupantResponse) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetSeatOccupantResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *GetSeatOccupantResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *GetSeatOccupantResponse) GetReceipt() *Receipt {
+	if x != nil {
+		return x.Receipt
+	}
+	return nil
+}
+
+// Request message for scheduling a journey.
+type CreateJourneyRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	TrainNumber     string                 `protobuf:"bytes,1,opt,name=train_number,json=trainNumber,proto3" json:"train_number,omitempty"` // Optional operator train number
+	ServiceDate     string                 `protobuf:"bytes,2,opt,name=service_date,json=serviceDate,proto3" json:"service_date,omitempty"` // Date of service in YYYY-MM-DD format
+	DepartureTime   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=departure_time,json=departureTime,proto3" json:"departure_time,omitempty"`
+	Origin          string                 `protobuf:"bytes,4,opt,name=origin,proto3" json:"origin,omitempty"`
+	Destination     string                 `protobuf:"bytes,5,opt,name=destination,proto3" json:"destination,omitempty"`
+	SeatsPerSection int32                  `protobuf:"varint,6,opt,name=seats_per_section,json=seatsPerSection,proto3" json:"seats_per_section,omitempty"` // Builds a standard A/B train of this size; cannot be combined with layout
+	Stops           []string               `protobuf:"bytes,7,rep,name=stops,proto3" json:"stops,omitempty"`                                               // Ordered stops including origin and destination; defaults to [origin, destination]
+	Layout          string                 `protobuf:"bytes,8,opt,name=layout,proto3" json:"layout,omitempty"`                                             // Name of a configured train layout; defaults to the server's default layout
+	TimeZone        string                 `protobuf:"bytes,9,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`                         // IANA time zone the service date is kept in, e.g., "Europe/London"; defaults to UTC
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CreateJourneyRequest) Reset() {
+	*x = CreateJourneyRequest{}
+	mi := &file_ticket_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateJourneyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateJourneyRequest) ProtoMessage() {}
+
+func (x *CreateJourneyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateJourneyRequest.ProtoReflect.Descriptor instead.
+func (*CreateJourneyRequest) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *CreateJourneyRequest) GetTrainNumber() string {
+	if x != nil {
+		return x.TrainNumber
+	}
+	return ""
+}
+
+func (x *CreateJourneyRequest) GetServiceDate() string {
+	if x != nil {
+		return x.ServiceDate
+	}
+	return ""
+}
+
+func (x *CreateJourneyRequest) GetDepartureTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DepartureTime
+	}
+	return nil
+}
+
+func (x *CreateJourneyRequest) GetOrigin() string {
+	if x != nil {
+		return x.Origin
+	}
+	return ""
+}
+
+func (x *CreateJourneyRequest) GetDestination() string {
+	if x != nil {
+		return x.Destination
+	}
+	return ""
+}
+
+func (x *CreateJourneyRequest) GetSeatsPerSection() int32 {
+	if x != nil {
+		return x.SeatsPerSection
+	}
+	return 0
+}
+
+func (x *CreateJourneyRequest) GetStops() []string {
+	if x != nil {
+		return x.Stops
+	}
+	return nil
+}
+
+func (x *CreateJourneyRequest) GetLayout() string {
+	if x != nil {
+		return x.Layout
+	}
+	return ""
+}
+
+func (x *CreateJourneyRequest) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+// Response message for scheduling a journey.
+type CreateJourneyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Journey       *Journey               `protobuf:"bytes,3,opt,name=journey,proto3" json:"journey,omitempty"` // The created journey
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateJourneyResponse) Reset() {
+	*x = CreateJourneyResponse{}
+	mi := &file_ticket_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateJourneyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateJourneyResponse) ProtoMessage() {}
+
+func (x *CreateJourneyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateJourneyResponse.ProtoReflect.Descriptor instead.
+func (*CreateJourneyResponse) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *CreateJourneyResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CreateJourneyResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *CreateJourneyResponse) GetJourney() *Journey {
+	if x != nil {
+		return x.Journey
+	}
+	return nil
+}
+
+// Request message for listing journeys.
+type ListJourneysRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServiceDate   string                 `protobuf:"bytes,1,opt,name=service_date,json=serviceDate,proto3" json:"service_date,omitempty"` // Optional YYYY-MM-DD filter
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RemoveUserRequest) Reset() {
-	*x = RemoveUserRequest{}
-	mi := &file_ticket_proto_msgTypes[7]
+func (x *ListJourneysRequest) Reset() {
+	*x = ListJourneysRequest{}
+	mi := &file_ticket_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RemoveUserRequest) String() string {
+func (x *ListJourneysRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RemoveUserRequest) ProtoMessage() {}
+func (*ListJourneysRequest) ProtoMessage() {}
 
-func (x *RemoveUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ticket_proto_msgTypes[7]
+func (x *ListJourneysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -491,76 +2495,108 @@ func (x *RemoveUserRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RemoveUserRequest.ProtoReflect.Descriptor instead.
-func (*RemoveUserRequest) Descriptor() ([]byte, []int) {
-	return file_ticket_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use ListJourneysRequest.ProtoReflect.Descriptor instead.
+func (*ListJourneysRequest) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{33}
 }
 
-func (x *RemoveUserRequest) GetIdentifier() isRemoveUserRequest_Identifier {
+func (x *ListJourneysRequest) GetServiceDate() string {
 	if x != nil {
-		return x.Identifier
+		return x.ServiceDate
 	}
-	return nil
+	return ""
 }
 
-func (x *RemoveUserRequest) GetEmail() string {
-	if x != nil {
-		if x, ok := x.Identifier.(*RemoveUserRequest_Email); ok {
-			return x.Email
-		}
-	}
-	return ""
+// Response message for listing journeys.
+type ListJourneysResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Journeys      []*Journey             `protobuf:"bytes,3,rep,name=journeys,proto3" json:"journeys,omitempty"` // Journeys ordered by departure time
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RemoveUserRequest) GetTicketId() string {
+func (x *ListJourneysResponse) Reset() {
+	*x = ListJourneysResponse{}
+	mi := &file_ticket_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListJourneysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListJourneysResponse) ProtoMessage() {}
+
+func (x *ListJourneysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[34]
 	if x != nil {
-		if x, ok := x.Identifier.(*RemoveUserRequest_TicketId); ok {
-			return x.TicketId
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-type isRemoveUserRequest_Identifier interface {
-	isRemoveUserRequest_Identifier()
+// Deprecated: Use ListJourneysResponse.ProtoReflect.Descriptor instead.
+func (*ListJourneysResponse) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{34}
 }
 
-type RemoveUserRequest_Email struct {
-	Email string `protobuf:"bytes,1,opt,name=email,proto3,oneof"` // User's email
+func (x *ListJourneysResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
 }
 
-type RemoveUserRequest_TicketId struct {
-	TicketId string `protobuf:"bytes,2,opt,name=ticket_id,json=ticketId,proto3,oneof"` // Specific ticket ID
+func (x *ListJourneysResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
 }
 
-func (*RemoveUserRequest_Email) isRemoveUserRequest_Identifier() {}
-
-func (*RemoveUserRequest_TicketId) isRemoveUserRequest_Identifier() {}
+func (x *ListJourneysResponse) GetJourneys() []*Journey {
+	if x != nil {
+		return x.Journeys
+	}
+	return nil
+}
 
-// Response message for removing a user.
-type RemoveUserResponse struct {
+// Request message for pricing a trip.
+type QuoteFareRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	FromLocation  string                 `protobuf:"bytes,1,opt,name=from_location,json=fromLocation,proto3" json:"from_location,omitempty"` // Must be a stop on the journey's route
+	ToLocation    string                 `protobuf:"bytes,2,opt,name=to_location,json=toLocation,proto3" json:"to_location,omitempty"`       // Must be a later stop on the journey's route
+	JourneyId     string                 `protobuf:"bytes,3,opt,name=journey_id,json=journeyId,proto3" json:"journey_id,omitempty"`          // Journey to price; the default journey when empty
+	TravelClass   TravelClass            `protobuf:"varint,4,opt,name=travel_class,json=travelClass,proto3,enum=trainticketing.entities.TravelClass" json:"travel_class,omitempty"`
+	PassengerType PassengerType          `protobuf:"varint,5,opt,name=passenger_type,json=passengerType,proto3,enum=trainticketing.entities.PassengerType" json:"passenger_type,omitempty"`
+	Preferences   *SeatPreferences       `protobuf:"bytes,6,opt,name=preferences,proto3" json:"preferences,omitempty"`                       // Price the seat a purchase with these preferences would get
+	CurrencyCode  string                 `protobuf:"bytes,7,opt,name=currency_code,json=currencyCode,proto3" json:"currency_code,omitempty"` // ISO 4217 code of the currency to quote in; the fare table's when empty
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RemoveUserResponse) Reset() {
-	*x = RemoveUserResponse{}
-	mi := &file_ticket_proto_msgTypes[8]
+func (x *QuoteFareRequest) Reset() {
+	*x = QuoteFareRequest{}
+	mi := &file_ticket_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RemoveUserResponse) String() string {
+func (x *QuoteFareRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RemoveUserResponse) ProtoMessage() {}
+func (*QuoteFareRequest) ProtoMessage() {}
 
-func (x *RemoveUserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_ticket_proto_msgTypes[8]
+func (x *QuoteFareRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -571,53 +2607,86 @@ func (x *RemoveUserResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RemoveUserResponse.ProtoReflect.Descriptor instead.
-func (*RemoveUserResponse) Descriptor() ([]byte, []int) {
-	return file_ticket_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use QuoteFareRequest.ProtoReflect.Descriptor instead.
+func (*QuoteFareRequest) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{35}
 }
 
-func (x *RemoveUserResponse) GetSuccess() bool {
+func (x *QuoteFareRequest) GetFromLocation() string {
 	if x != nil {
-		return x.Success
+		return x.FromLocation
 	}
-	return false
+	return ""
 }
 
-func (x *RemoveUserResponse) GetMessage() string {
+func (x *QuoteFareRequest) GetToLocation() string {
 	if x != nil {
-		return x.Message
+		return x.ToLocation
 	}
 	return ""
 }
 
-// Request message for modifying a user's seat.
-type ModifyUserSeatRequest struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Types that are valid to be assigned to Identifier:
-	//
-	//	*ModifyUserSeatRequest_Email
-	//	*ModifyUserSeatRequest_TicketId
-	Identifier    isModifyUserSeatRequest_Identifier `protobuf_oneof:"identifier"`
-	NewSeat       *Seat                              `protobuf:"bytes,3,opt,name=new_seat,json=newSeat,proto3" json:"new_seat,omitempty"` // The new seat to allocate
+func (x *QuoteFareRequest) GetJourneyId() string {
+	if x != nil {
+		return x.JourneyId
+	}
+	return ""
+}
+
+func (x *QuoteFareRequest) GetTravelClass() TravelClass {
+	if x != nil {
+		return x.TravelClass
+	}
+	return TravelClass_TRAVEL_CLASS_STANDARD
+}
+
+func (x *QuoteFareRequest) GetPassengerType() PassengerType {
+	if x != nil {
+		return x.PassengerType
+	}
+	return PassengerType_PASSENGER_TYPE_ADULT
+}
+
+func (x *QuoteFareRequest) GetPreferences() *SeatPreferences {
+	if x != nil {
+		return x.Preferences
+	}
+	return nil
+}
+
+func (x *QuoteFareRequest) GetCurrencyCode() string {
+	if x != nil {
+		return x.CurrencyCode
+	}
+	return ""
+}
+
+// Response message for pricing a trip.
+type QuoteFareResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Fare          *Fare                  `protobuf:"bytes,3,opt,name=fare,proto3" json:"fare,omitempty"`   // How the price was worked out, in the fare table's currency
+	Price         *Money                 `protobuf:"bytes,4,opt,name=price,proto3" json:"price,omitempty"` // The fare in the currency asked for; pass it as price to book at this price
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ModifyUserSeatRequest) Reset() {
-	*x = ModifyUserSeatRequest{}
-	mi := &file_ticket_proto_msgTypes[9]
+func (x *QuoteFareResponse) Reset() {
+	*x = QuoteFareResponse{}
+	mi := &file_ticket_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ModifyUserSeatRequest) String() string {
+func (x *QuoteFareResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ModifyUserSeatRequest) ProtoMessage() {}
+func (*QuoteFareResponse) ProtoMessage() {}
 
-func (x *ModifyUserSeatRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ticket_proto_msgTypes[9]
+func (x *QuoteFareResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -628,84 +2697,109 @@ func (x *ModifyUserSeatRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ModifyUserSeatRequest.ProtoReflect.Descriptor instead.
-func (*ModifyUserSeatRequest) Descriptor() ([]byte, []int) {
-	return file_ticket_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use QuoteFareResponse.ProtoReflect.Descriptor instead.
+func (*QuoteFareResponse) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{36}
 }
 
-func (x *ModifyUserSeatRequest) GetIdentifier() isModifyUserSeatRequest_Identifier {
+func (x *QuoteFareResponse) GetSuccess() bool {
 	if x != nil {
-		return x.Identifier
+		return x.Success
 	}
-	return nil
+	return false
 }
 
-func (x *ModifyUserSeatRequest) GetEmail() string {
+func (x *QuoteFareResponse) GetMessage() string {
 	if x != nil {
-		if x, ok := x.Identifier.(*ModifyUserSeatRequest_Email); ok {
-			return x.Email
-		}
+		return x.Message
 	}
 	return ""
 }
 
-func (x *ModifyUserSeatRequest) GetTicketId() string {
+func (x *QuoteFareResponse) GetFare() *Fare {
 	if x != nil {
-		if x, ok := x.Identifier.(*ModifyUserSeatRequest_TicketId); ok {
-			return x.TicketId
-		}
+		return x.Fare
 	}
-	return ""
+	return nil
 }
 
-func (x *ModifyUserSeatRequest) GetNewSeat() *Seat {
+func (x *QuoteFareResponse) GetPrice() *Money {
 	if x != nil {
-		return x.NewSeat
+		return x.Price
 	}
 	return nil
 }
 
-type isModifyUserSeatRequest_Identifier interface {
-	isModifyUserSeatRequest_Identifier()
+// Request message for retrieving a refund.
+type GetRefundRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RefundId      string                 `protobuf:"bytes,1,opt,name=refund_id,json=refundId,proto3" json:"refund_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-type ModifyUserSeatRequest_Email struct {
-	Email string `protobuf:"bytes,1,opt,name=email,proto3,oneof"` // User's email
+func (x *GetRefundRequest) Reset() {
+	*x = GetRefundRequest{}
+	mi := &file_ticket_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-type ModifyUserSeatRequest_TicketId struct {
-	TicketId string `protobuf:"bytes,2,opt,name=ticket_id,json=ticketId,proto3,oneof"` // Specific ticket ID
+func (x *GetRefundRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ModifyUserSeatRequest_Email) isModifyUserSeatRequest_Identifier() {}
+func (*GetRefundRequest) ProtoMessage() {}
 
-func (*ModifyUserSeatRequest_TicketId) isModifyUserSeatRequest_Identifier() {}
+func (x *GetRefundRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
 
-// Response message for modifying a user's seat.
-type ModifyUserSeatResponse struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	Success        bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message        string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	UpdatedReceipt *Receipt               `protobuf:"bytes,3,opt,name=updated_receipt,json=updatedReceipt,proto3" json:"updated_receipt,omitempty"` // The newly allocated seat if successful
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+// Deprecated: Use GetRefundRequest.ProtoReflect.Descriptor instead.
+func (*GetRefundRequest) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{37}
 }
 
-func (x *ModifyUserSeatResponse) Reset() {
-	*x = ModifyUserSeatResponse{}
-	mi := &file_ticket_proto_msgTypes[10]
+func (x *GetRefundRequest) GetRefundId() string {
+	if x != nil {
+		return x.RefundId
+	}
+	return ""
+}
+
+// Response message for retrieving a refund.
+type GetRefundResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Refund        *Refund                `protobuf:"bytes,3,opt,name=refund,proto3" json:"refund,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRefundResponse) Reset() {
+	*x = GetRefundResponse{}
+	mi := &file_ticket_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ModifyUserSeatResponse) String() string {
+func (x *GetRefundResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ModifyUserSeatResponse) ProtoMessage() {}
+func (*GetRefundResponse) ProtoMessage() {}
 
-func (x *ModifyUserSeatResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_ticket_proto_msgTypes[10]
+func (x *GetRefundResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ticket_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -716,28 +2810,28 @@ func (x *ModifyUserSeatResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ModifyUserSeatResponse.ProtoReflect.Descriptor instead.
-func (*ModifyUserSeatResponse) Descriptor() ([]byte, []int) {
-	return file_ticket_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use GetRefundResponse.ProtoReflect.Descriptor instead.
+func (*GetRefundResponse) Descriptor() ([]byte, []int) {
+	return file_ticket_proto_rawDescGZIP(), []int{38}
 }
 
-func (x *ModifyUserSeatResponse) GetSuccess() bool {
+func (x *GetRefundResponse) GetSuccess() bool {
 	if x != nil {
 		return x.Success
 	}
 	return false
 }
 
-func (x *ModifyUserSeatResponse) GetMessage() string {
+func (x *GetRefundResponse) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
-func (x *ModifyUserSeatResponse) GetUpdatedReceipt() *Receipt {
+func (x *GetRefundResponse) GetRefund() *Refund {
 	if x != nil {
-		return x.UpdatedReceipt
+		return x.Refund
 	}
 	return nil
 }
@@ -748,18 +2842,127 @@ const file_ticket_proto_rawDesc = "" +
 	"\n" +
 	"\fticket.proto\x12\x16trainticketing.service\x1a\n" +
 	"user.proto\x1a\n" +
-	"seat.proto\x1a\rreceipt.proto\"\xaf\x01\n" +
+	"seat.proto\x1a\rreceipt.proto\x1a\vevent.proto\x1a\rjourney.proto\x1a\x0ewaitlist.proto\x1a\n" +
+	"fare.proto\x1a\vmoney.proto\x1a\frefund.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x8f\x04\n" +
 	"\x15PurchaseTicketRequest\x12#\n" +
 	"\rfrom_location\x18\x01 \x01(\tR\ffromLocation\x12\x1f\n" +
 	"\vto_location\x18\x02 \x01(\tR\n" +
 	"toLocation\x121\n" +
 	"\x04user\x18\x03 \x01(\v2\x1d.trainticketing.entities.UserR\x04user\x12\x1d\n" +
 	"\n" +
-	"price_paid\x18\x04 \x01(\x01R\tpricePaid\"\x88\x01\n" +
+	"price_paid\x18\x04 \x01(\x01R\tpricePaid\x12\x1d\n" +
+	"\n" +
+	"journey_id\x18\x05 \x01(\tR\tjourneyId\x12J\n" +
+	"\vpreferences\x18\x06 \x01(\v2(.trainticketing.entities.SeatPreferencesR\vpreferences\x12G\n" +
+	"\ftravel_class\x18\a \x01(\x0e2$.trainticketing.entities.TravelClassR\vtravelClass\x12M\n" +
+	"\x0epassenger_type\x18\b \x01(\x0e2&.trainticketing.entities.PassengerTypeR\rpassengerType\x124\n" +
+	"\x05price\x18\t \x01(\v2\x1e.trainticketing.entities.MoneyR\x05price\x12%\n" +
+	"\x0epayment_method\x18\n" +
+	" \x01(\tR\rpaymentMethod\"\xde\x01\n" +
 	"\x16PurchaseTicketResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12:\n" +
-	"\areceipt\x18\x03 \x01(\v2 .trainticketing.entities.ReceiptR\areceipt\"_\n" +
+	"\areceipt\x18\x03 \x01(\v2 .trainticketing.entities.ReceiptR\areceipt\x12'\n" +
+	"\x0fpreferences_met\x18\x04 \x03(\tR\x0epreferencesMet\x12+\n" +
+	"\x11preferences_unmet\x18\x05 \x03(\tR\x10preferencesUnmet\"\xf5\x03\n" +
+	"\x1aPurchaseGroupTicketRequest\x12#\n" +
+	"\rfrom_location\x18\x01 \x01(\tR\ffromLocation\x12\x1f\n" +
+	"\vto_location\x18\x02 \x01(\tR\n" +
+	"toLocation\x12=\n" +
+	"\n" +
+	"passengers\x18\x03 \x03(\v2\x1d.trainticketing.entities.UserR\n" +
+	"passengers\x12\x1d\n" +
+	"\n" +
+	"price_paid\x18\x04 \x01(\x01R\tpricePaid\x12\x1d\n" +
+	"\n" +
+	"journey_id\x18\x05 \x01(\tR\tjourneyId\x12\x1f\n" +
+	"\vallow_split\x18\x06 \x01(\bR\n" +
+	"allowSplit\x12G\n" +
+	"\ftravel_class\x18\a \x01(\x0e2$.trainticketing.entities.TravelClassR\vtravelClass\x12M\n" +
+	"\x0epassenger_type\x18\b \x01(\x0e2&.trainticketing.entities.PassengerTypeR\rpassengerType\x124\n" +
+	"\x05price\x18\t \x01(\v2\x1e.trainticketing.entities.MoneyR\x05price\x12%\n" +
+	"\x0epayment_method\x18\n" +
+	" \x01(\tR\rpaymentMethod\"\xbc\x01\n" +
+	"\x1bPurchaseGroupTicketResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12+\n" +
+	"\x11booking_reference\x18\x03 \x01(\tR\x10bookingReference\x12<\n" +
+	"\breceipts\x18\x04 \x03(\v2 .trainticketing.entities.ReceiptR\breceipts\"\x8d\x03\n" +
+	"\x0fHoldSeatRequest\x12#\n" +
+	"\rfrom_location\x18\x01 \x01(\tR\ffromLocation\x12\x1f\n" +
+	"\vto_location\x18\x02 \x01(\tR\n" +
+	"toLocation\x121\n" +
+	"\x04user\x18\x03 \x01(\v2\x1d.trainticketing.entities.UserR\x04user\x12\x1d\n" +
+	"\n" +
+	"journey_id\x18\x04 \x01(\tR\tjourneyId\x12J\n" +
+	"\vpreferences\x18\x05 \x01(\v2(.trainticketing.entities.SeatPreferencesR\vpreferences\x12G\n" +
+	"\ftravel_class\x18\x06 \x01(\x0e2$.trainticketing.entities.TravelClassR\vtravelClass\x12M\n" +
+	"\x0epassenger_type\x18\a \x01(\x0e2&.trainticketing.entities.PassengerTypeR\rpassengerType\"\xd6\x02\n" +
+	"\x10HoldSeatResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x17\n" +
+	"\ahold_id\x18\x03 \x01(\tR\x06holdId\x121\n" +
+	"\x04seat\x18\x04 \x01(\v2\x1d.trainticketing.entities.SeatR\x04seat\x129\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12'\n" +
+	"\x0fpreferences_met\x18\x06 \x03(\tR\x0epreferencesMet\x12+\n" +
+	"\x11preferences_unmet\x18\a \x03(\tR\x10preferencesUnmet\x121\n" +
+	"\x04fare\x18\b \x01(\v2\x1d.trainticketing.entities.FareR\x04fare\"\xa9\x01\n" +
+	"\x12ConfirmHoldRequest\x12\x17\n" +
+	"\ahold_id\x18\x01 \x01(\tR\x06holdId\x12\x1d\n" +
+	"\n" +
+	"price_paid\x18\x02 \x01(\x01R\tpricePaid\x124\n" +
+	"\x05price\x18\x03 \x01(\v2\x1e.trainticketing.entities.MoneyR\x05price\x12%\n" +
+	"\x0epayment_method\x18\x04 \x01(\tR\rpaymentMethod\"\x85\x01\n" +
+	"\x13ConfirmHoldResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12:\n" +
+	"\areceipt\x18\x03 \x01(\v2 .trainticketing.entities.ReceiptR\areceipt\"\xc1\x03\n" +
+	"\x13JoinWaitlistRequest\x12#\n" +
+	"\rfrom_location\x18\x01 \x01(\tR\ffromLocation\x12\x1f\n" +
+	"\vto_location\x18\x02 \x01(\tR\n" +
+	"toLocation\x121\n" +
+	"\x04user\x18\x03 \x01(\v2\x1d.trainticketing.entities.UserR\x04user\x12\x1d\n" +
+	"\n" +
+	"price_paid\x18\x04 \x01(\x01R\tpricePaid\x12\x1d\n" +
+	"\n" +
+	"journey_id\x18\x05 \x01(\tR\tjourneyId\x12G\n" +
+	"\ftravel_class\x18\x06 \x01(\x0e2$.trainticketing.entities.TravelClassR\vtravelClass\x12M\n" +
+	"\x0epassenger_type\x18\a \x01(\x0e2&.trainticketing.entities.PassengerTypeR\rpassengerType\x124\n" +
+	"\x05price\x18\b \x01(\v2\x1e.trainticketing.entities.MoneyR\x05price\x12%\n" +
+	"\x0epayment_method\x18\t \x01(\tR\rpaymentMethod\"\xa4\x01\n" +
+	"\x14JoinWaitlistResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12<\n" +
+	"\x05entry\x18\x03 \x01(\v2&.trainticketing.entities.WaitlistEntryR\x05entry\x12\x1a\n" +
+	"\bposition\x18\x04 \x01(\x05R\bposition\";\n" +
+	"\x18GetWaitlistStatusRequest\x12\x1f\n" +
+	"\vwaitlist_id\x18\x01 \x01(\tR\n" +
+	"waitlistId\"\xe5\x01\n" +
+	"\x19GetWaitlistStatusResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12<\n" +
+	"\x05entry\x18\x03 \x01(\v2&.trainticketing.entities.WaitlistEntryR\x05entry\x12\x1a\n" +
+	"\bposition\x18\x04 \x01(\x05R\bposition\x12:\n" +
+	"\areceipt\x18\x05 \x01(\v2 .trainticketing.entities.ReceiptR\areceipt\"\x7f\n" +
+	"\x18WatchAvailabilityRequest\x12\x1d\n" +
+	"\n" +
+	"journey_id\x18\x01 \x01(\tR\tjourneyId\x12#\n" +
+	"\rfrom_location\x18\x02 \x01(\tR\ffromLocation\x12\x1f\n" +
+	"\vto_location\x18\x03 \x01(\tR\n" +
+	"toLocation\"\xfb\x01\n" +
+	"\x12AvailabilityUpdate\x12C\n" +
+	"\x04kind\x18\x01 \x01(\x0e2/.trainticketing.service.AvailabilityUpdate.KindR\x04kind\x12\x1d\n" +
+	"\n" +
+	"journey_id\x18\x02 \x01(\tR\tjourneyId\x12\x1d\n" +
+	"\n" +
+	"free_seats\x18\x03 \x03(\tR\tfreeSeats\x12%\n" +
+	"\x0eoccupied_seats\x18\x04 \x03(\tR\roccupiedSeats\";\n" +
+	"\x04Kind\x12\x10\n" +
+	"\fKIND_UNKNOWN\x10\x00\x12\x11\n" +
+	"\rKIND_SNAPSHOT\x10\x01\x12\x0e\n" +
+	"\n" +
+	"KIND_DELTA\x10\x02\"_\n" +
 	"\x18GetReceiptDetailsRequest\x12\x16\n" +
 	"\x05email\x18\x01 \x01(\tH\x00R\x05email\x12\x1d\n" +
 	"\tticket_id\x18\x02 \x01(\tH\x00R\bticketIdB\f\n" +
@@ -771,9 +2974,12 @@ const file_ticket_proto_rawDesc = "" +
 	"\areceipt\x18\x03 \x01(\v2 .trainticketing.entities.ReceiptR\areceipt\"p\n" +
 	"\bUserSeat\x121\n" +
 	"\x04user\x18\x01 \x01(\v2\x1d.trainticketing.entities.UserR\x04user\x121\n" +
-	"\x04seat\x18\x02 \x01(\v2\x1d.trainticketing.entities.SeatR\x04seat\"[\n" +
+	"\x04seat\x18\x02 \x01(\v2\x1d.trainticketing.entities.SeatR\x04seat\"\x90\x01\n" +
 	"\x18GetUsersBySectionRequest\x12?\n" +
-	"\asection\x18\x01 \x01(\x0e2%.trainticketing.entities.Seat.SectionR\asection\"\x9b\x01\n" +
+	"\asection\x18\x01 \x01(\x0e2%.trainticketing.entities.Seat.SectionR\asection\x12\x1d\n" +
+	"\n" +
+	"journey_id\x18\x02 \x01(\tR\tjourneyId\x12\x14\n" +
+	"\x05coach\x18\x03 \x01(\tR\x05coach\"\x9b\x01\n" +
 	"\x19GetUsersBySectionResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12J\n" +
@@ -782,10 +2988,28 @@ const file_ticket_proto_rawDesc = "" +
 	"\x05email\x18\x01 \x01(\tH\x00R\x05email\x12\x1d\n" +
 	"\tticket_id\x18\x02 \x01(\tH\x00R\bticketIdB\f\n" +
 	"\n" +
-	"identifier\"H\n" +
+	"identifier\"\x81\x01\n" +
 	"\x12RemoveUserResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"\x96\x01\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x127\n" +
+	"\x06refund\x18\x03 \x01(\v2\x1f.trainticketing.entities.RefundR\x06refund\"2\n" +
+	"\x13CancelTicketRequest\x12\x1b\n" +
+	"\tticket_id\x18\x01 \x01(\tR\bticketId\"\xbf\x01\n" +
+	"\x14CancelTicketResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12:\n" +
+	"\areceipt\x18\x03 \x01(\v2 .trainticketing.entities.ReceiptR\areceipt\x127\n" +
+	"\x06refund\x18\x04 \x01(\v2\x1f.trainticketing.entities.RefundR\x06refund\"m\n" +
+	"\x19ListTicketsForUserRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"\xb4\x01\n" +
+	"\x1aListTicketsForUserResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12:\n" +
+	"\atickets\x18\x03 \x03(\v2 .trainticketing.entities.ReceiptR\atickets\x12&\n" +
+	"\x0fnext_page_token\x18\x04 \x01(\tR\rnextPageToken\"\x96\x01\n" +
 	"\x15ModifyUserSeatRequest\x12\x16\n" +
 	"\x05email\x18\x01 \x01(\tH\x00R\x05email\x12\x1d\n" +
 	"\tticket_id\x18\x02 \x01(\tH\x00R\bticketId\x128\n" +
@@ -795,14 +3019,85 @@ const file_ticket_proto_rawDesc = "" +
 	"\x16ModifyUserSeatResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12I\n" +
-	"\x0fupdated_receipt\x18\x03 \x01(\v2 .trainticketing.entities.ReceiptR\x0eupdatedReceipt2\xd2\x04\n" +
+	"\x0fupdated_receipt\x18\x03 \x01(\v2 .trainticketing.entities.ReceiptR\x0eupdatedReceipt\"6\n" +
+	"\x17GetTicketHistoryRequest\x12\x1b\n" +
+	"\tticket_id\x18\x01 \x01(\tR\bticketId\"\x8d\x01\n" +
+	"\x18GetTicketHistoryResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12=\n" +
+	"\x06events\x18\x03 \x03(\v2%.trainticketing.entities.BookingEventR\x06events\"\x84\x01\n" +
+	"\x16GetSeatOccupantRequest\x12\x1f\n" +
+	"\vseat_number\x18\x01 \x01(\tR\n" +
+	"seatNumber\x12*\n" +
+	"\x02at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x02at\x12\x1d\n" +
+	"\n" +
+	"journey_id\x18\x03 \x01(\tR\tjourneyId\"\x89\x01\n" +
+	"\x17GetSeatOccupantResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12:\n" +
+	"\areceipt\x18\x03 \x01(\v2 .trainticketing.entities.ReceiptR\areceipt\"\xd0\x02\n" +
+	"\x14CreateJourneyRequest\x12!\n" +
+	"\ftrain_number\x18\x01 \x01(\tR\vtrainNumber\x12!\n" +
+	"\fservice_date\x18\x02 \x01(\tR\vserviceDate\x12A\n" +
+	"\x0edeparture_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\rdepartureTime\x12\x16\n" +
+	"\x06origin\x18\x04 \x01(\tR\x06origin\x12 \n" +
+	"\vdestination\x18\x05 \x01(\tR\vdestination\x12*\n" +
+	"\x11seats_per_section\x18\x06 \x01(\x05R\x0fseatsPerSection\x12\x14\n" +
+	"\x05stops\x18\a \x03(\tR\x05stops\x12\x16\n" +
+	"\x06layout\x18\b \x01(\tR\x06layout\x12\x1b\n" +
+	"\ttime_zone\x18\t \x01(\tR\btimeZone\"\x87\x01\n" +
+	"\x15CreateJourneyResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12:\n" +
+	"\ajourney\x18\x03 \x01(\v2 .trainticketing.entities.JourneyR\ajourney\"8\n" +
+	"\x13ListJourneysRequest\x12!\n" +
+	"\fservice_date\x18\x01 \x01(\tR\vserviceDate\"\x88\x01\n" +
+	"\x14ListJourneysResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12<\n" +
+	"\bjourneys\x18\x03 \x03(\v2 .trainticketing.entities.JourneyR\bjourneys\"\x80\x03\n" +
+	"\x10QuoteFareRequest\x12#\n" +
+	"\rfrom_location\x18\x01 \x01(\tR\ffromLocation\x12\x1f\n" +
+	"\vto_location\x18\x02 \x01(\tR\n" +
+	"toLocation\x12\x1d\n" +
+	"\n" +
+	"journey_id\x18\x03 \x01(\tR\tjourneyId\x12G\n" +
+	"\ftravel_class\x18\x04 \x01(\x0e2$.trainticketing.entities.TravelClassR\vtravelClass\x12M\n" +
+	"\x0epassenger_type\x18\x05 \x01(\x0e2&.trainticketing.entities.PassengerTypeR\rpassengerType\x12J\n" +
+	"\vpreferences\x18\x06 \x01(\v2(.trainticketing.entities.SeatPreferencesR\vpreferences\x12#\n" +
+	"\rcurrency_code\x18\a \x01(\tR\fcurrencyCode\"\xb0\x01\n" +
+	"\x11QuoteFareResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x121\n" +
+	"\x04fare\x18\x03 \x01(\v2\x1d.trainticketing.entities.FareR\x04fare\x124\n" +
+	"\x05price\x18\x04 \x01(\v2\x1e.trainticketing.entities.MoneyR\x05price\"/\n" +
+	"\x10GetRefundRequest\x12\x1b\n" +
+	"\trefund_id\x18\x01 \x01(\tR\brefundId\"\x80\x01\n" +
+	"\x11GetRefundResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x127\n" +
+	"\x06refund\x18\x03 \x01(\v2\x1f.trainticketing.entities.RefundR\x06refund2\xe3\x10\n" +
 	"\x15TrainTicketingService\x12o\n" +
-	"\x0ePurchaseTicket\x12-.trainticketing.service.PurchaseTicketRequest\x1a..trainticketing.service.PurchaseTicketResponse\x12x\n" +
+	"\x0ePurchaseTicket\x12-.trainticketing.service.PurchaseTicketRequest\x1a..trainticketing.service.PurchaseTicketResponse\x12~\n" +
+	"\x13PurchaseGroupTicket\x122.trainticketing.service.PurchaseGroupTicketRequest\x1a3.trainticketing.service.PurchaseGroupTicketResponse\x12]\n" +
+	"\bHoldSeat\x12'.trainticketing.service.HoldSeatRequest\x1a(.trainticketing.service.HoldSeatResponse\x12f\n" +
+	"\vConfirmHold\x12*.trainticketing.service.ConfirmHoldRequest\x1a+.trainticketing.service.ConfirmHoldResponse\x12i\n" +
+	"\fJoinWaitlist\x12+.trainticketing.service.JoinWaitlistRequest\x1a,.trainticketing.service.JoinWaitlistResponse\x12x\n" +
+	"\x11GetWaitlistStatus\x120.trainticketing.service.GetWaitlistStatusRequest\x1a1.trainticketing.service.GetWaitlistStatusResponse\x12s\n" +
+	"\x11WatchAvailability\x120.trainticketing.service.WatchAvailabilityRequest\x1a*.trainticketing.service.AvailabilityUpdate0\x01\x12x\n" +
 	"\x11GetReceiptDetails\x120.trainticketing.service.GetReceiptDetailsRequest\x1a1.trainticketing.service.GetReceiptDetailsResponse\x12x\n" +
 	"\x11GetUsersBySection\x120.trainticketing.service.GetUsersBySectionRequest\x1a1.trainticketing.service.GetUsersBySectionResponse\x12c\n" +
 	"\n" +
-	"RemoveUser\x12).trainticketing.service.RemoveUserRequest\x1a*.trainticketing.service.RemoveUserResponse\x12o\n" +
-	"\x0eModifyUserSeat\x12-.trainticketing.service.ModifyUserSeatRequest\x1a..trainticketing.service.ModifyUserSeatResponseB/Z-github.com/talk2sohail/train-ticket-api/protob\x06proto3"
+	"RemoveUser\x12).trainticketing.service.RemoveUserRequest\x1a*.trainticketing.service.RemoveUserResponse\x12i\n" +
+	"\fCancelTicket\x12+.trainticketing.service.CancelTicketRequest\x1a,.trainticketing.service.CancelTicketResponse\x12{\n" +
+	"\x12ListTicketsForUser\x121.trainticketing.service.ListTicketsForUserRequest\x1a2.trainticketing.service.ListTicketsForUserResponse\x12o\n" +
+	"\x0eModifyUserSeat\x12-.trainticketing.service.ModifyUserSeatRequest\x1a..trainticketing.service.ModifyUserSeatResponse\x12u\n" +
+	"\x10GetTicketHistory\x12/.trainticketing.service.GetTicketHistoryRequest\x1a0.trainticketing.service.GetTicketHistoryResponse\x12r\n" +
+	"\x0fGetSeatOccupant\x12..trainticketing.service.GetSeatOccupantRequest\x1a/.trainticketing.service.GetSeatOccupantResponse\x12l\n" +
+	"\rCreateJourney\x12,.trainticketing.service.CreateJourneyRequest\x1a-.trainticketing.service.CreateJourneyResponse\x12i\n" +
+	"\fListJourneys\x12+.trainticketing.service.ListJourneysRequest\x1a,.trainticketing.service.ListJourneysResponse\x12`\n" +
+	"\tQuoteFare\x12(.trainticketing.service.QuoteFareRequest\x1a).trainticketing.service.QuoteFareResponse\x12`\n" +
+	"\tGetRefund\x12(.trainticketing.service.GetRefundRequest\x1a).trainticketing.service.GetRefundResponseB/Z-github.com/talk2sohail/train-ticket-api/protob\x06proto3"
 
 var (
 	file_ticket_proto_rawDescOnce sync.Once
@@ -816,49 +3111,159 @@ func file_ticket_proto_rawDescGZIP() []byte {
 	return file_ticket_proto_rawDescData
 }
 
-var file_ticket_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_ticket_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_ticket_proto_msgTypes = make([]protoimpl.MessageInfo, 39)
 var file_ticket_proto_goTypes = []any{
-	(*PurchaseTicketRequest)(nil),     // 0: trainticketing.service.PurchaseTicketRequest
-	(*PurchaseTicketResponse)(nil),    // 1: trainticketing.service.PurchaseTicketResponse
-	(*GetReceiptDetailsRequest)(nil),  // 2: trainticketing.service.GetReceiptDetailsRequest
-	(*GetReceiptDetailsResponse)(nil), // 3: trainticketing.service.GetReceiptDetailsResponse
-	(*UserSeat)(nil),                  // 4: trainticketing.service.UserSeat
-	(*GetUsersBySectionRequest)(nil),  // 5: trainticketing.service.GetUsersBySectionRequest
-	(*GetUsersBySectionResponse)(nil), // 6: trainticketing.service.GetUsersBySectionResponse
-	(*RemoveUserRequest)(nil),         // 7: trainticketing.service.RemoveUserRequest
-	(*RemoveUserResponse)(nil),        // 8: trainticketing.service.RemoveUserResponse
-	(*ModifyUserSeatRequest)(nil),     // 9: trainticketing.service.ModifyUserSeatRequest
-	(*ModifyUserSeatResponse)(nil),    // 10: trainticketing.service.ModifyUserSeatResponse
-	(*User)(nil),                      // 11: trainticketing.entities.User
-	(*Receipt)(nil),                   // 12: trainticketing.entities.Receipt
-	(*Seat)(nil),                      // 13: trainticketing.entities.Seat
-	(Seat_Section)(0),                 // 14: trainticketing.entities.Seat.Section
+	(AvailabilityUpdate_Kind)(0),        // 0: trainticketing.service.AvailabilityUpdate.Kind
+	(*PurchaseTicketRequest)(nil),       // 1: trainticketing.service.PurchaseTicketRequest
+	(*PurchaseTicketResponse)(nil),      // 2: trainticketing.service.PurchaseTicketResponse
+	(*PurchaseGroupTicketRequest)(nil),  // 3: trainticketing.service.PurchaseGroupTicketRequest
+	(*PurchaseGroupTicketResponse)(nil), // 4: trainticketing.service.PurchaseGroupTicketResponse
+	(*HoldSeatRequest)(nil),             // 5: trainticketing.service.HoldSeatRequest
+	(*HoldSeatResponse)(nil),            // 6: trainticketing.service.HoldSeatResponse
+	(*ConfirmHoldRequest)(nil),          // 7: trainticketing.service.ConfirmHoldRequest
+	(*ConfirmHoldResponse)(nil),         // 8: trainticketing.service.ConfirmHoldResponse
+	(*JoinWaitlistRequest)(nil),         // 9: trainticketing.service.JoinWaitlistRequest
+	(*JoinWaitlistResponse)(nil),        // 10: trainticketing.service.JoinWaitlistResponse
+	(*GetWaitlistStatusRequest)(nil),    // 11: trainticketing.service.GetWaitlistStatusRequest
+	(*GetWaitlistStatusResponse)(nil),   // 12: trainticketing.service.GetWaitlistStatusResponse
+	(*WatchAvailabilityRequest)(nil),    // 13: trainticketing.service.WatchAvailabilityRequest
+	(*AvailabilityUpdate)(nil),          // 14: trainticketing.service.AvailabilityUpdate
+	(*GetReceiptDetailsRequest)(nil),    // 15: trainticketing.service.GetReceiptDetailsRequest
+	(*GetReceiptDetailsResponse)(nil),   // 16: trainticketing.service.GetReceiptDetailsResponse
+	(*UserSeat)(nil),                    // 17: trainticketing.service.UserSeat
+	(*GetUsersBySectionRequest)(nil),    // 18: trainticketing.service.GetUsersBySectionRequest
+	(*GetUsersBySectionResponse)(nil),   // 19: trainticketing.service.GetUsersBySectionResponse
+	(*RemoveUserRequest)(nil),           // 20: trainticketing.service.RemoveUserRequest
+	(*RemoveUserResponse)(nil),          // 21: trainticketing.service.RemoveUserResponse
+	(*CancelTicketRequest)(nil),         // 22: trainticketing.service.CancelTicketRequest
+	(*CancelTicketResponse)(nil),        // 23: trainticketing.service.CancelTicketResponse
+	(*ListTicketsForUserRequest)(nil),   // 24: trainticketing.service.ListTicketsForUserRequest
+	(*ListTicketsForUserResponse)(nil),  // 25: trainticketing.service.ListTicketsForUserResponse
+	(*ModifyUserSeatRequest)(nil),       // 26: trainticketing.service.ModifyUserSeatRequest
+	(*ModifyUserSeatResponse)(nil),      // 27: trainticketing.service.ModifyUserSeatResponse
+	(*GetTicketHistoryRequest)(nil),     // 28: trainticketing.service.GetTicketHistoryRequest
+	(*GetTicketHistoryResponse)(nil),    // 29: trainticketing.service.GetTicketHistoryResponse
+	(*GetSeatOccupantRequest)(nil),      // 30: trainticketing.service.GetSeatOccupantRequest
+	(*GetSeatOccupantResponse)(nil),     // 31: trainticketing.service.GetSeatOccupantResponse
+	(*CreateJourneyRequest)(nil),        // 32: trainticketing.service.CreateJourneyRequest
+	(*CreateJourneyResponse)(nil),       // 33: trainticketing.service.CreateJourneyResponse
+	(*ListJourneysRequest)(nil),         // 34: trainticketing.service.ListJourneysRequest
+	(*ListJourneysResponse)(nil),        // 35: trainticketing.service.ListJourneysResponse
+	(*QuoteFareRequest)(nil),            // 36: trainticketing.service.QuoteFareRequest
+	(*QuoteFareResponse)(nil),           // 37: trainticketing.service.QuoteFareResponse
+	(*GetRefundRequest)(nil),            // 38: trainticketing.service.GetRefundRequest
+	(*GetRefundResponse)(nil),           // 39: trainticketing.service.GetRefundResponse
+	(*User)(nil),                        // 40: trainticketing.entities.User
+	(*SeatPreferences)(nil),             // 41: trainticketing.entities.SeatPreferences
+	(TravelClass)(0),                    // 42: trainticketing.entities.TravelClass
+	(PassengerType)(0),                  // 43: trainticketing.entities.PassengerType
+	(*Money)(nil),                       // 44: trainticketing.entities.Money
+	(*Receipt)(nil),                     // 45: trainticketing.entities.Receipt
+	(*Seat)(nil),                        // 46: trainticketing.entities.Seat
+	(*timestamppb.Timestamp)(nil),       // 47: google.protobuf.Timestamp
+	(*Fare)(nil),                        // 48: trainticketing.entities.Fare
+	(*WaitlistEntry)(nil),               // 49: trainticketing.entities.WaitlistEntry
+	(Seat_Section)(0),                   // 50: trainticketing.entities.Seat.Section
+	(*Refund)(nil),                      // 51: trainticketing.entities.Refund
+	(*BookingEvent)(nil),                // 52: trainticketing.entities.BookingEvent
+	(*Journey)(nil),                     // 53: trainticketing.entities.Journey
 }
 var file_ticket_proto_depIdxs = []int32{
-	11, // 0: trainticketing.service.PurchaseTicketRequest.user:type_name -> trainticketing.entities.User
-	12, // 1: trainticketing.service.PurchaseTicketResponse.receipt:type_name -> trainticketing.entities.Receipt
-	12, // 2: trainticketing.service.GetReceiptDetailsResponse.receipt:type_name -> trainticketing.entities.Receipt
-	11, // 3: trainticketing.service.UserSeat.user:type_name -> trainticketing.entities.User
-	13, // 4: trainticketing.service.UserSeat.seat:type_name -> trainticketing.entities.Seat
-	14, // 5: trainticketing.service.GetUsersBySectionRequest.section:type_name -> trainticketing.entities.Seat.Section
-	4,  // 6: trainticketing.service.GetUsersBySectionResponse.users_in_section:type_name -> trainticketing.service.UserSeat
-	13, // 7: trainticketing.service.ModifyUserSeatRequest.new_seat:type_name -> trainticketing.entities.Seat
-	12, // 8: trainticketing.service.ModifyUserSeatResponse.updated_receipt:type_name -> trainticketing.entities.Receipt
-	0,  // 9: trainticketing.service.TrainTicketingService.PurchaseTicket:input_type -> trainticketing.service.PurchaseTicketRequest
-	2,  // 10: trainticketing.service.TrainTicketingService.GetReceiptDetails:input_type -> trainticketing.service.GetReceiptDetailsRequest
-	5,  // 11: trainticketing.service.TrainTicketingService.GetUsersBySection:input_type -> trainticketing.service.GetUsersBySectionRequest
-	7,  // 12: trainticketing.service.TrainTicketingService.RemoveUser:input_type -> trainticketing.service.RemoveUserRequest
-	9,  // 13: trainticketing.service.TrainTicketingService.ModifyUserSeat:input_type -> trainticketing.service.ModifyUserSeatRequest
-	1,  // 14: trainticketing.service.TrainTicketingService.PurchaseTicket:output_type -> trainticketing.service.PurchaseTicketResponse
-	3,  // 15: trainticketing.service.TrainTicketingService.GetReceiptDetails:output_type -> trainticketing.service.GetReceiptDetailsResponse
-	6,  // 16: trainticketing.service.TrainTicketingService.GetUsersBySection:output_type -> trainticketing.service.GetUsersBySectionResponse
-	8,  // 17: trainticketing.service.TrainTicketingService.RemoveUser:output_type -> trainticketing.service.RemoveUserResponse
-	10, // 18: trainticketing.service.TrainTicketingService.ModifyUserSeat:output_type -> trainticketing.service.ModifyUserSeatResponse
-	14, // [14:19] is the sub-list for method output_type
-	9,  // [9:14] is the sub-list for method input_type
-	9,  // [9:9] is the sub-list for extension type_name
-	9,  // [9:9] is the sub-list for extension extendee
-	0,  // [0:9] is the sub-list for field type_name
+	40, // 0: trainticketing.service.PurchaseTicketRequest.user:type_name -> trainticketing.entities.User
+	41, // 1: trainticketing.service.PurchaseTicketRequest.preferences:type_name -> trainticketing.entities.SeatPreferences
+	42, // 2: trainticketing.service.PurchaseTicketRequest.travel_class:type_name -> trainticketing.entities.TravelClass
+	43, // 3: trainticketing.service.PurchaseTicketRequest.passenger_type:type_name -> trainticketing.entities.PassengerType
+	44, // 4: trainticketing.service.PurchaseTicketRequest.price:type_name -> trainticketing.entities.Money
+	45, // 5: trainticketing.service.PurchaseTicketResponse.receipt:type_name -> trainticketing.entities.Receipt
+	40, // 6: trainticketing.service.PurchaseGroupTicketRequest.passengers:type_name -> trainticketing.entities.User
+	42, // 7: trainticketing.service.PurchaseGroupTicketRequest.travel_class:type_name -> trainticketing.entities.TravelClass
+	43, // 8: trainticketing.service.PurchaseGroupTicketRequest.passenger_type:type_name -> trainticketing.entities.PassengerType
+	44, // 9: trainticketing.service.PurchaseGroupTicketRequest.price:type_name -> trainticketing.entities.Money
+	45, // 10: trainticketing.service.PurchaseGroupTicketResponse.receipts:type_name -> trainticketing.entities.Receipt
+	40, // 11: trainticketing.service.HoldSeatRequest.user:type_name -> trainticketing.entities.User
+	41, // 12: trainticketing.service.HoldSeatRequest.preferences:type_name -> trainticketing.entities.SeatPreferences
+	42, // 13: trainticketing.service.HoldSeatRequest.travel_class:type_name -> trainticketing.entities.TravelClass
+	43, // 14: trainticketing.service.HoldSeatRequest.passenger_type:type_name -> trainticketing.entities.PassengerType
+	46, // 15: trainticketing.service.HoldSeatResponse.seat:type_name -> trainticketing.entities.Seat
+	47, // 16: trainticketing.service.HoldSeatResponse.expires_at:type_name -> google.protobuf.Timestamp
+	48, // 17: trainticketing.service.HoldSeatResponse.fare:type_name -> trainticketing.entities.Fare
+	44, // 18: trainticketing.service.ConfirmHoldRequest.price:type_name -> trainticketing.entities.Money
+	45, // 19: trainticketing.service.ConfirmHoldResponse.receipt:type_name -> trainticketing.entities.Receipt
+	40, // 20: trainticketing.service.JoinWaitlistRequest.user:type_name -> trainticketing.entities.User
+	42, // 21: trainticketing.service.JoinWaitlistRequest.travel_class:type_name -> trainticketing.entities.TravelClass
+	43, // 22: trainticketing.service.JoinWaitlistRequest.passenger_type:type_name -> trainticketing.entities.PassengerType
+	44, // 23: trainticketing.service.JoinWaitlistRequest.price:type_name -> trainticketing.entities.Money
+	49, // 24: trainticketing.service.JoinWaitlistResponse.entry:type_name -> trainticketing.entities.WaitlistEntry
+	49, // 25: trainticketing.service.GetWaitlistStatusResponse.entry:type_name -> trainticketing.entities.WaitlistEntry
+	45, // 26: trainticketing.service.GetWaitlistStatusResponse.receipt:type_name -> trainticketing.entities.Receipt
+	0,  // 27: trainticketing.service.AvailabilityUpdate.kind:type_name -> trainticketing.service.AvailabilityUpdate.Kind
+	45, // 28: trainticketing.service.GetReceiptDetailsResponse.receipt:type_name -> trainticketing.entities.Receipt
+	40, // 29: trainticketing.service.UserSeat.user:type_name -> trainticketing.entities.User
+	46, // 30: trainticketing.service.UserSeat.seat:type_name -> trainticketing.entities.Seat
+	50, // 31: trainticketing.service.GetUsersBySectionRequest.section:type_name -> trainticketing.entities.Seat.Section
+	17, // 32: trainticketing.service.GetUsersBySectionResponse.users_in_section:type_name -> trainticketing.service.UserSeat
+	51, // 33: trainticketing.service.RemoveUserResponse.refund:type_name -> trainticketing.entities.Refund
+	45, // 34: trainticketing.service.CancelTicketResponse.receipt:type_name -> trainticketing.entities.Receipt
+	51, // 35: trainticketing.service.CancelTicketResponse.refund:type_name -> trainticketing.entities.Refund
+	45, // 36: trainticketing.service.ListTicketsForUserResponse.tickets:type_name -> trainticketing.entities.Receipt
+	46, // 37: trainticketing.service.ModifyUserSeatRequest.new_seat:type_name -> trainticketing.entities.Seat
+	45, // 38: trainticketing.service.ModifyUserSeatResponse.updated_receipt:type_name -> trainticketing.entities.Receipt
+	52, // 39: trainticketing.service.GetTicketHistoryResponse.events:type_name -> trainticketing.entities.BookingEvent
+	47, // 40: trainticketing.service.GetSeatOccupantRequest.at:type_name -> google.protobuf.Timestamp
+	45, // 41: trainticketing.service.GetSeatOccupantResponse.receipt:type_name -> trainticketing.entities.Receipt
+	47, // 42: trainticketing.service.CreateJourneyRequest.departure_time:type_name -> google.protobuf.Timestamp
+	53, // 43: trainticketing.service.CreateJourneyResponse.journey:type_name -> trainticketing.entities.Journey
+	53, // 44: trainticketing.service.ListJourneysResponse.journeys:type_name -> trainticketing.entities.Journey
+	42, // 45: trainticketing.service.QuoteFareRequest.travel_class:type_name -> trainticketing.entities.TravelClass
+	43, // 46: trainticketing.service.QuoteFareRequest.passenger_type:type_name -> trainticketing.entities.PassengerType
+	41, // 47: trainticketing.service.QuoteFareRequest.preferences:type_name -> trainticketing.entities.SeatPreferences
+	48, // 48: trainticketing.service.QuoteFareResponse.fare:type_name -> trainticketing.entities.Fare
+	44, // 49: trainticketing.service.QuoteFareResponse.price:type_name -> trainticketing.entities.Money
+	51, // 50: trainticketing.service.GetRefundResponse.refund:type_name -> trainticketing.entities.Refund
+	1,  // 51: trainticketing.service.TrainTicketingService.PurchaseTicket:input_type -> trainticketing.service.PurchaseTicketRequest
+	3,  // 52: trainticketing.service.TrainTicketingService.PurchaseGroupTicket:input_type -> trainticketing.service.PurchaseGroupTicketRequest
+	5,  // 53: trainticketing.service.TrainTicketingService.HoldSeat:input_type -> trainticketing.service.HoldSeatRequest
+	7,  // 54: trainticketing.service.TrainTicketingService.ConfirmHold:input_type -> trainticketing.service.ConfirmHoldRequest
+	9,  // 55: trainticketing.service.TrainTicketingService.JoinWaitlist:input_type -> trainticketing.service.JoinWaitlistRequest
+	11, // 56: trainticketing.service.TrainTicketingService.GetWaitlistStatus:input_type -> trainticketing.service.GetWaitlistStatusRequest
+	13, // 57: trainticketing.service.TrainTicketingService.WatchAvailability:input_type -> trainticketing.service.WatchAvailabilityRequest
+	15, // 58: trainticketing.service.TrainTicketingService.GetReceiptDetails:input_type -> trainticketing.service.GetReceiptDetailsRequest
+	18, // 59: trainticketing.service.TrainTicketingService.GetUsersBySection:input_type -> trainticketing.service.GetUsersBySectionRequest
+	20, // 60: trainticketing.service.TrainTicketingService.RemoveUser:input_type -> trainticketing.service.RemoveUserRequest
+	22, // 61: trainticketing.service.TrainTicketingService.CancelTicket:input_type -> trainticketing.service.CancelTicketRequest
+	24, // 62: trainticketing.service.TrainTicketingService.ListTicketsForUser:input_type -> trainticketing.service.ListTicketsForUserRequest
+	26, // 63: trainticketing.service.TrainTicketingService.ModifyUserSeat:input_type -> trainticketing.service.ModifyUserSeatRequest
+	28, // 64: trainticketing.service.TrainTicketingService.GetTicketHistory:input_type -> trainticketing.service.GetTicketHistoryRequest
+	30, // 65: trainticketing.service.TrainTicketingService.GetSeatOccupant:input_type -> trainticketing.service.GetSeatOccupantRequest
+	32, // 66: trainticketing.service.TrainTicketingService.CreateJourney:input_type -> trainticketing.service.CreateJourneyRequest
+	34, // 67: trainticketing.service.TrainTicketingService.ListJourneys:input_type -> trainticketing.service.ListJourneysRequest
+	36, // 68: trainticketing.service.TrainTicketingService.QuoteFare:input_type -> trainticketing.service.QuoteFareRequest
+	38, // 69: trainticketing.service.TrainTicketingService.GetRefund:input_type -> trainticketing.service.GetRefundRequest
+	2,  // 70: trainticketing.service.TrainTicketingService.PurchaseTicket:output_type -> trainticketing.service.PurchaseTicketResponse
+	4,  // 71: trainticketing.service.TrainTicketingService.PurchaseGroupTicket:output_type -> trainticketing.service.PurchaseGroupTicketResponse
+	6,  // 72: trainticketing.service.TrainTicketingService.HoldSeat:output_type -> trainticketing.service.HoldSeatResponse
+	8,  // 73: trainticketing.service.TrainTicketingService.ConfirmHold:output_type -> trainticketing.service.ConfirmHoldResponse
+	10, // 74: trainticketing.service.TrainTicketingService.JoinWaitlist:output_type -> trainticketing.service.JoinWaitlistResponse
+	12, // 75: trainticketing.service.TrainTicketingService.GetWaitlistStatus:output_type -> trainticketing.service.GetWaitlistStatusResponse
+	14, // 76: trainticketing.service.TrainTicketingService.WatchAvailability:output_type -> trainticketing.service.AvailabilityUpdate
+	16, // 77: trainticketing.service.TrainTicketingService.GetReceiptDetails:output_type -> trainticketing.service.GetReceiptDetailsResponse
+	19, // 78: trainticketing.service.TrainTicketingService.GetUsersBySection:output_type -> trainticketing.service.GetUsersBySectionResponse
+	21, // 79: trainticketing.service.TrainTicketingService.RemoveUser:output_type -> trainticketing.service.RemoveUserResponse
+	23, // 80: trainticketing.service.TrainTicketingService.CancelTicket:output_type -> trainticketing.service.CancelTicketResponse
+	25, // 81: trainticketing.service.TrainTicketingService.ListTicketsForUser:output_type -> trainticketing.service.ListTicketsForUserResponse
+	27, // 82: trainticketing.service.TrainTicketingService.ModifyUserSeat:output_type -> trainticketing.service.ModifyUserSeatResponse
+	29, // 83: trainticketing.service.TrainTicketingService.GetTicketHistory:output_type -> trainticketing.service.GetTicketHistoryResponse
+	31, // 84: trainticketing.service.TrainTicketingService.GetSeatOccupant:output_type -> trainticketing.service.GetSeatOccupantResponse
+	33, // 85: trainticketing.service.TrainTicketingService.CreateJourney:output_type -> trainticketing.service.CreateJourneyResponse
+	35, // 86: trainticketing.service.TrainTicketingService.ListJourneys:output_type -> trainticketing.service.ListJourneysResponse
+	37, // 87: trainticketing.service.TrainTicketingService.QuoteFare:output_type -> trainticketing.service.QuoteFareResponse
+	39, // 88: trainticketing.service.TrainTicketingService.GetRefund:output_type -> trainticketing.service.GetRefundResponse
+	70, // [70:89] is the sub-list for method output_type
+	51, // [51:70] is the sub-list for method input_type
+	51, // [51:51] is the sub-list for extension type_name
+	51, // [51:51] is the sub-list for extension extendee
+	0,  // [0:51] is the sub-list for field type_name
 }
 
 func init() { file_ticket_proto_init() }
@@ -869,15 +3274,21 @@ func file_ticket_proto_init() {
 	file_user_proto_init()
 	file_seat_proto_init()
 	file_receipt_proto_init()
-	file_ticket_proto_msgTypes[2].OneofWrappers = []any{
+	file_event_proto_init()
+	file_journey_proto_init()
+	file_waitlist_proto_init()
+	file_fare_proto_init()
+	file_money_proto_init()
+	file_refund_proto_init()
+	file_ticket_proto_msgTypes[14].OneofWrappers = []any{
 		(*GetReceiptDetailsRequest_Email)(nil),
 		(*GetReceiptDetailsRequest_TicketId)(nil),
 	}
-	file_ticket_proto_msgTypes[7].OneofWrappers = []any{
+	file_ticket_proto_msgTypes[19].OneofWrappers = []any{
 		(*RemoveUserRequest_Email)(nil),
 		(*RemoveUserRequest_TicketId)(nil),
 	}
-	file_ticket_proto_msgTypes[9].OneofWrappers = []any{
+	file_ticket_proto_msgTypes[25].OneofWrappers = []any{
 		(*ModifyUserSeatRequest_Email)(nil),
 		(*ModifyUserSeatRequest_TicketId)(nil),
 	}
@@ -886,13 +3297,14 @@ func file_ticket_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_ticket_proto_rawDesc), len(file_ticket_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   11,
+			NumEnums:      1,
+			NumMessages:   39,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_ticket_proto_goTypes,
 		DependencyIndexes: file_ticket_proto_depIdxs,
+		EnumInfos:         file_ticket_proto_enumTypes,
 		MessageInfos:      file_ticket_proto_msgTypes,
 	}.Build()
 	File_ticket_proto = out.File
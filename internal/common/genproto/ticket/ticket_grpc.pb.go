@@ -19,11 +19,25 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	TrainTicketingService_PurchaseTicket_FullMethodName    = "/trainticketing.service.TrainTicketingService/PurchaseTicket"
-	TrainTicketingService_GetReceiptDetails_FullMethodName = "/trainticketing.service.TrainTicketingService/GetReceiptDetails"
-	TrainTicketingService_GetUsersBySection_FullMethodName = "/trainticketing.service.TrainTicketingService/GetUsersBySection"
-	TrainTicketingService_RemoveUser_FullMethodName        = "/trainticketing.service.TrainTicketingService/RemoveUser"
-	TrainTicketingService_ModifyUserSeat_FullMethodName    = "/trainticketing.service.TrainTicketingService/ModifyUserSeat"
+	TrainTicketingService_PurchaseTicket_FullMethodName      = "/trainticketing.service.TrainTicketingService/PurchaseTicket"
+	TrainTicketingService_PurchaseGroupTicket_FullMethodName = "/trainticketing.service.TrainTicketingService/PurchaseGroupTicket"
+	TrainTicketingService_HoldSeat_FullMethodName            = "/trainticketing.service.TrainTicketingService/HoldSeat"
+	TrainTicketingService_ConfirmHold_FullMethodName         = "/trainticketing.service.TrainTicketingService/ConfirmHold"
+	TrainTicketingService_JoinWaitlist_FullMethodName        = "/trainticketing.service.TrainTicketingService/JoinWaitlist"
+	TrainTicketingService_GetWaitlistStatus_FullMethodName   = "/trainticketing.service.TrainTicketingService/GetWaitlistStatus"
+	TrainTicketingService_WatchAvailability_FullMethodName   = "/trainticketing.service.TrainTicketingService/WatchAvailability"
+	TrainTicketingService_GetReceiptDetails_FullMethodName   = "/trainticketing.service.TrainTicketingService/GetReceiptDetails"
+	TrainTicketingService_GetUsersBySection_FullMethodName   = "/trainticketing.service.TrainTicketingService/GetUsersBySection"
+	TrainTicketingService_RemoveUser_FullMethodName          = "/trainticketing.service.TrainTicketingService/RemoveUser"
+	TrainTicketingService_CancelTicket_FullMethodName        = "/trainticketing.service.TrainTicketingService/CancelTicket"
+	TrainTicketingService_ListTicketsForUser_FullMethodName  = "/trainticketing.service.TrainTicketingService/ListTicketsForUser"
+	TrainTicketingService_ModifyUserSeat_FullMethodName      = "/trainticketing.service.TrainTicketingService/ModifyUserSeat"
+	TrainTicketingService_GetTicketHistory_FullMethodName    = "/trainticketing.service.TrainTicketingService/GetTicketHistory"
+	TrainTicketingService_GetSeatOccupant_FullMethodName     = "/trainticketing.service.TrainTicketingService/GetSeatOccupant"
+	TrainTicketingService_CreateJourney_FullMethodName       = "/trainticketing.service.TrainTicketingService/CreateJourney"
+	TrainTicketingService_ListJourneys_FullMethodName        = "/trainticketing.service.TrainTicketingService/ListJourneys"
+	TrainTicketingService_QuoteFare_FullMethodName           = "/trainticketing.service.TrainTicketingService/QuoteFare"
+	TrainTicketingService_GetRefund_FullMethodName           = "/trainticketing.service.TrainTicketingService/GetRefund"
 )
 
 // TrainTicketingServiceClient is the client API for TrainTicketingService service.
@@ -34,14 +48,42 @@ const (
 type TrainTicketingServiceClient interface {
 	// Submits a purchase for a train ticket.
 	PurchaseTicket(ctx context.Context, in *PurchaseTicketRequest, opts ...grpc.CallOption) (*PurchaseTicketResponse, error)
+	// Books seats for a party of passengers together, all or nothing.
+	PurchaseGroupTicket(ctx context.Context, in *PurchaseGroupTicketRequest, opts ...grpc.CallOption) (*PurchaseGroupTicketResponse, error)
+	// Reserves a seat for a limited time while the purchase is completed.
+	HoldSeat(ctx context.Context, in *HoldSeatRequest, opts ...grpc.CallOption) (*HoldSeatResponse, error)
+	// Turns an unexpired hold into a ticket for the held seat.
+	ConfirmHold(ctx context.Context, in *ConfirmHoldRequest, opts ...grpc.CallOption) (*ConfirmHoldResponse, error)
+	// Queues a passenger for a seat on a sold-out journey.
+	JoinWaitlist(ctx context.Context, in *JoinWaitlistRequest, opts ...grpc.CallOption) (*JoinWaitlistResponse, error)
+	// Reports a waitlist entry's position, or the ticket it was given.
+	GetWaitlistStatus(ctx context.Context, in *GetWaitlistStatusRequest, opts ...grpc.CallOption) (*GetWaitlistStatusResponse, error)
+	// Streams a journey's free and occupied seats: a snapshot first, then a delta whenever occupancy changes.
+	WatchAvailability(ctx context.Context, in *WatchAvailabilityRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AvailabilityUpdate], error)
 	// Retrieves the details of a specific receipt for a user.
 	GetReceiptDetails(ctx context.Context, in *GetReceiptDetailsRequest, opts ...grpc.CallOption) (*GetReceiptDetailsResponse, error)
 	// Views all users and their allocated seats for a given train section.
 	GetUsersBySection(ctx context.Context, in *GetUsersBySectionRequest, opts ...grpc.CallOption) (*GetUsersBySectionResponse, error)
-	// Removes a user and their ticket from the train.
+	// Removes a user and their ticket from the train. An email only identifies a passenger holding a single ticket.
 	RemoveUser(ctx context.Context, in *RemoveUserRequest, opts ...grpc.CallOption) (*RemoveUserResponse, error)
+	// Cancels one specific ticket, leaving the passenger's other tickets untouched.
+	CancelTicket(ctx context.Context, in *CancelTicketRequest, opts ...grpc.CallOption) (*CancelTicketResponse, error)
+	// Lists a passenger's active tickets, oldest purchase first, a page at a time.
+	ListTicketsForUser(ctx context.Context, in *ListTicketsForUserRequest, opts ...grpc.CallOption) (*ListTicketsForUserResponse, error)
 	// Modifies the seat allocation for an existing user.
 	ModifyUserSeat(ctx context.Context, in *ModifyUserSeatRequest, opts ...grpc.CallOption) (*ModifyUserSeatResponse, error)
+	// Returns every booking event recorded for a ticket, oldest first.
+	GetTicketHistory(ctx context.Context, in *GetTicketHistoryRequest, opts ...grpc.CallOption) (*GetTicketHistoryResponse, error)
+	// Returns the ticket that occupied a seat at a given point in time.
+	GetSeatOccupant(ctx context.Context, in *GetSeatOccupantRequest, opts ...grpc.CallOption) (*GetSeatOccupantResponse, error)
+	// Schedules a new journey with its own seat inventory. Admin only.
+	CreateJourney(ctx context.Context, in *CreateJourneyRequest, opts ...grpc.CallOption) (*CreateJourneyResponse, error)
+	// Lists scheduled journeys, optionally for a single service date.
+	ListJourneys(ctx context.Context, in *ListJourneysRequest, opts ...grpc.CallOption) (*ListJourneysResponse, error)
+	// Prices a trip without booking it.
+	QuoteFare(ctx context.Context, in *QuoteFareRequest, opts ...grpc.CallOption) (*QuoteFareResponse, error)
+	// Retrieves the refund given when a ticket was cancelled.
+	GetRefund(ctx context.Context, in *GetRefundRequest, opts ...grpc.CallOption) (*GetRefundResponse, error)
 }
 
 type trainTicketingServiceClient struct {
@@ -62,6 +104,75 @@ func (c *trainTicketingServiceClient) PurchaseTicket(ctx context.Context, in *Pu
 	return out, nil
 }
 
+func (c *trainTicketingServiceClient) PurchaseGroupTicket(ctx context.Context, in *PurchaseGroupTicketRequest, opts ...grpc.CallOption) (*PurchaseGroupTicketResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PurchaseGroupTicketResponse)
+	err := c.cc.Invoke(ctx, TrainTicketingService_PurchaseGroupTicket_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trainTicketingServiceClient) HoldSeat(ctx context.Context, in *HoldSeatRequest, opts ...grpc.CallOption) (*HoldSeatResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HoldSeatResponse)
+	err := c.cc.Invoke(ctx, TrainTicketingService_HoldSeat_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trainTicketingServiceClient) ConfirmHold(ctx context.Context, in *ConfirmHoldRequest, opts ...grpc.CallOption) (*ConfirmHoldResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfirmHoldResponse)
+	err := c.cc.Invoke(ctx, TrainTicketingService_ConfirmHold_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trainTicketingServiceClient) JoinWaitlist(ctx context.Context, in *JoinWaitlistRequest, opts ...grpc.CallOption) (*JoinWaitlistResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(JoinWaitlistResponse)
+	err := c.cc.Invoke(ctx, TrainTicketingService_JoinWaitlist_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trainTicketingServiceClient) GetWaitlistStatus(ctx context.Context, in *GetWaitlistStatusRequest, opts ...grpc.CallOption) (*GetWaitlistStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetWaitlistStatusResponse)
+	err := c.cc.Invoke(ctx, TrainTicketingService_GetWaitlistStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trainTicketingServiceClient) WatchAvailability(ctx context.Context, in *WatchAvailabilityRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AvailabilityUpdate], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TrainTicketingService_ServiceDesc.Streams[0], TrainTicketingService_WatchAvailability_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchAvailabilityRequest, AvailabilityUpdate]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TrainTicketingService_WatchAvailabilityClient = grpc.ServerStreamingClient[AvailabilityUpdate]
+
 func (c *trainTicketingServiceClient) GetReceiptDetails(ctx context.Context, in *GetReceiptDetailsRequest, opts ...grpc.CallOption) (*GetReceiptDetailsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetReceiptDetailsResponse)
@@ -92,6 +203,26 @@ func (c *trainTicketingServiceClient) RemoveUser(ctx context.Context, in *Remove
 	return out, nil
 }
 
+func (c *trainTicketingServiceClient) CancelTicket(ctx context.Context, in *CancelTicketRequest, opts ...grpc.CallOption) (*CancelTicketResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelTicketResponse)
+	err := c.cc.Invoke(ctx, TrainTicketingService_CancelTicket_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trainTicketingServiceClient) ListTicketsForUser(ctx context.Context, in *ListTicketsForUserRequest, opts ...grpc.CallOption) (*ListTicketsForUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTicketsForUserResponse)
+	err := c.cc.Invoke(ctx, TrainTicketingService_ListTicketsForUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *trainTicketingServiceClient) ModifyUserSeat(ctx context.Context, in *ModifyUserSeatRequest, opts ...grpc.CallOption) (*ModifyUserSeatResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ModifyUserSeatResponse)
@@ -102,6 +233,66 @@ func (c *trainTicketingServiceClient) ModifyUserSeat(ctx context.Context, in *Mo
 	return out, nil
 }
 
+func (c *trainTicketingServiceClient) GetTicketHistory(ctx context.Context, in *GetTicketHistoryRequest, opts ...grpc.CallOption) (*GetTicketHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTicketHistoryResponse)
+	err := c.cc.Invoke(ctx, TrainTicketingService_GetTicketHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trainTicketingServiceClient) GetSeatOccupant(ctx context.Context, in *GetSeatOccupantRequest, opts ...grpc.CallOption) (*GetSeatOccupantResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSeatOccupantResponse)
+	err := c.cc.Invoke(ctx, TrainTicketingService_GetSeatOccupant_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trainTicketingServiceClient) CreateJourney(ctx context.Context, in *CreateJourneyRequest, opts ...grpc.CallOption) (*CreateJourneyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateJourneyResponse)
+	err := c.cc.Invoke(ctx, TrainTicketingService_CreateJourney_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trainTicketingServiceClient) ListJourneys(ctx context.Context, in *ListJourneysRequest, opts ...grpc.CallOption) (*ListJourneysResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListJourneysResponse)
+	err := c.cc.Invoke(ctx, TrainTicketingService_ListJourneys_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trainTicketingServiceClient) QuoteFare(ctx context.Context, in *QuoteFareRequest, opts ...grpc.CallOption) (*QuoteFareResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QuoteFareResponse)
+	err := c.cc.Invoke(ctx, TrainTicketingService_QuoteFare_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trainTicketingServiceClient) GetRefund(ctx context.Context, in *GetRefundRequest, opts ...grpc.CallOption) (*GetRefundResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRefundResponse)
+	err := c.cc.Invoke(ctx, TrainTicketingService_GetRefund_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TrainTicketingServiceServer is the server API for TrainTicketingService service.
 // All implementations must embed UnimplementedTrainTicketingServiceServer
 // for forward compatibility.
@@ -110,14 +301,42 @@ func (c *trainTicketingServiceClient) ModifyUserSeat(ctx context.Context, in *Mo
 type TrainTicketingServiceServer interface {
 	// Submits a purchase for a train ticket.
 	PurchaseTicket(context.Context, *PurchaseTicketRequest) (*PurchaseTicketResponse, error)
+	// Books seats for a party of passengers together, all or nothing.
+	PurchaseGroupTicket(context.Context, *PurchaseGroupTicketRequest) (*PurchaseGroupTicketResponse, error)
+	// Reserves a seat for a limited time while the purchase is completed.
+	HoldSeat(context.Context, *HoldSeatRequest) (*HoldSeatResponse, error)
+	// Turns an unexpired hold into a ticket for the held seat.
+	ConfirmHold(context.Context, *ConfirmHoldRequest) (*ConfirmHoldResponse, error)
+	// Queues a passenger for a seat on a sold-out journey.
+	JoinWaitlist(context.Context, *JoinWaitlistRequest) (*JoinWaitlistResponse, error)
+	// Reports a waitlist entry's position, or the ticket it was given.
+	GetWaitlistStatus(context.Context, *GetWaitlistStatusRequest) (*GetWaitlistStatusResponse, error)
+	// Streams a journey's free and occupied seats: a snapshot first, then a delta whenever occupancy changes.
+	WatchAvailability(*WatchAvailabilityRequest, grpc.ServerStreamingServer[AvailabilityUpdate]) error
 	// Retrieves the details of a specific receipt for a user.
 	GetReceiptDetails(context.Context, *GetReceiptDetailsRequest) (*GetReceiptDetailsResponse, error)
 	// Views all users and their allocated seats for a given train section.
 	GetUsersBySection(context.Context, *GetUsersBySectionRequest) (*GetUsersBySectionResponse, error)
-	// Removes a user and their ticket from the train.
+	// Removes a user and their ticket from the train. An email only identifies a passenger holding a single ticket.
 	RemoveUser(context.Context, *RemoveUserRequest) (*RemoveUserResponse, error)
+	// Cancels one specific ticket, leaving the passenger's other tickets untouched.
+	CancelTicket(context.Context, *CancelTicketRequest) (*CancelTicketResponse, error)
+	// Lists a passenger's active tickets, oldest purchase first, a page at a time.
+	ListTicketsForUser(context.Context, *ListTicketsForUserRequest) (*ListTicketsForUserResponse, error)
 	// Modifies the seat allocation for an existing user.
 	ModifyUserSeat(context.Context, *ModifyUserSeatRequest) (*ModifyUserSeatResponse, error)
+	// Returns every booking event recorded for a ticket, oldest first.
+	GetTicketHistory(context.Context, *GetTicketHistoryRequest) (*GetTicketHistoryResponse, error)
+	// Returns the ticket that occupied a seat at a given point in time.
+	GetSeatOccupant(context.Context, *GetSeatOccupantRequest) (*GetSeatOccupantResponse, error)
+	// Schedules a new journey with its own seat inventory. Admin only.
+	CreateJourney(context.Context, *CreateJourneyRequest) (*CreateJourneyResponse, error)
+	// Lists scheduled journeys, optionally for a single service date.
+	ListJourneys(context.Context, *ListJourneysRequest) (*ListJourneysResponse, error)
+	// Prices a trip without booking it.
+	QuoteFare(context.Context, *QuoteFareRequest) (*QuoteFareResponse, error)
+	// Retrieves the refund given when a ticket was cancelled.
+	GetRefund(context.Context, *GetRefundRequest) (*GetRefundResponse, error)
 	mustEmbedUnimplementedTrainTicketingServiceServer()
 }
 
@@ -131,6 +350,24 @@ type UnimplementedTrainTicketingServiceServer struct{}
 func (UnimplementedTrainTicketingServiceServer) PurchaseTicket(context.Context, *PurchaseTicketRequest) (*PurchaseTicketResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PurchaseTicket not implemented")
 }
+func (UnimplementedTrainTicketingServiceServer) PurchaseGroupTicket(context.Context, *PurchaseGroupTicketRequest) (*PurchaseGroupTicketResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurchaseGroupTicket not implemented")
+}
+func (UnimplementedTrainTicketingServiceServer) HoldSeat(context.Context, *HoldSeatRequest) (*HoldSeatResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HoldSeat not implemented")
+}
+func (UnimplementedTrainTicketingServiceServer) ConfirmHold(context.Context, *ConfirmHoldRequest) (*ConfirmHoldResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmHold not implemented")
+}
+func (UnimplementedTrainTicketingServiceServer) JoinWaitlist(context.Context, *JoinWaitlistRequest) (*JoinWaitlistResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method JoinWaitlist not implemented")
+}
+func (UnimplementedTrainTicketingServiceServer) GetWaitlistStatus(context.Context, *GetWaitlistStatusRequest) (*GetWaitlistStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWaitlistStatus not implemented")
+}
+func (UnimplementedTrainTicketingServiceServer) WatchAvailability(*WatchAvailabilityRequest, grpc.ServerStreamingServer[AvailabilityUpdate]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchAvailability not implemented")
+}
 func (UnimplementedTrainTicketingServiceServer) GetReceiptDetails(context.Context, *GetReceiptDetailsRequest) (*GetReceiptDetailsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetReceiptDetails not implemented")
 }
@@ -140,9 +377,33 @@ func (UnimplementedTrainTicketingServiceServer) GetUsersBySection(context.Contex
 func (UnimplementedTrainTicketingServiceServer) RemoveUser(context.Context, *RemoveUserRequest) (*RemoveUserResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RemoveUser not implemented")
 }
+func (UnimplementedTrainTicketingServiceServer) CancelTicket(context.Context, *CancelTicketRequest) (*CancelTicketResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelTicket not implemented")
+}
+func (UnimplementedTrainTicketingServiceServer) ListTicketsForUser(context.Context, *ListTicketsForUserRequest) (*ListTicketsForUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTicketsForUser not implemented")
+}
 func (UnimplementedTrainTicketingServiceServer) ModifyUserSeat(context.Context, *ModifyUserSeatRequest) (*ModifyUserSeatResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ModifyUserSeat not implemented")
 }
+func (UnimplementedTrainTicketingServiceServer) GetTicketHistory(context.Context, *GetTicketHistoryRequest) (*GetTicketHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTicketHistory not implemented")
+}
+func (UnimplementedTrainTicketingServiceServer) GetSeatOccupant(context.Context, *GetSeatOccupantRequest) (*GetSeatOccupantResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSeatOccupant not implemented")
+}
+func (UnimplementedTrainTicketingServiceServer) CreateJourney(context.Context, *CreateJourneyRequest) (*CreateJourneyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateJourney not implemented")
+}
+func (UnimplementedTrainTicketingServiceServer) ListJourneys(context.Context, *ListJourneysRequest) (*ListJourneysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListJourneys not implemented")
+}
+func (UnimplementedTrainTicketingServiceServer) QuoteFare(context.Context, *QuoteFareRequest) (*QuoteFareResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QuoteFare not implemented")
+}
+func (UnimplementedTrainTicketingServiceServer) GetRefund(context.Context, *GetRefundRequest) (*GetRefundResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRefund not implemented")
+}
 func (UnimplementedTrainTicketingServiceServer) mustEmbedUnimplementedTrainTicketingServiceServer() {}
 func (UnimplementedTrainTicketingServiceServer) testEmbeddedByValue()                               {}
 
@@ -182,6 +443,107 @@ func _TrainTicketingService_PurchaseTicket_Handler(srv interface{}, ctx context.
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TrainTicketingService_PurchaseGroupTicket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurchaseGroupTicketRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrainTicketingServiceServer).PurchaseGroupTicket(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TrainTicketingService_PurchaseGroupTicket_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrainTicketingServiceServer).PurchaseGroupTicket(ctx, req.(*PurchaseGroupTicketRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrainTicketingService_HoldSeat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HoldSeatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrainTicketingServiceServer).HoldSeat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TrainTicketingService_HoldSeat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrainTicketingServiceServer).HoldSeat(ctx, req.(*HoldSeatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrainTicketingService_ConfirmHold_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmHoldRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrainTicketingServiceServer).ConfirmHold(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TrainTicketingService_ConfirmHold_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrainTicketingServiceServer).ConfirmHold(ctx, req.(*ConfirmHoldRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrainTicketingService_JoinWaitlist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JoinWaitlistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrainTicketingServiceServer).JoinWaitlist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TrainTicketingService_JoinWaitlist_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrainTicketingServiceServer).JoinWaitlist(ctx, req.(*JoinWaitlistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrainTicketingService_GetWaitlistStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWaitlistStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrainTicketingServiceServer).GetWaitlistStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TrainTicketingService_GetWaitlistStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrainTicketingServiceServer).GetWaitlistStatus(ctx, req.(*GetWaitlistStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrainTicketingService_WatchAvailability_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchAvailabilityRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TrainTicketingServiceServer).WatchAvailability(m, &grpc.GenericServerStream[WatchAvailabilityRequest, AvailabilityUpdate]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TrainTicketingService_WatchAvailabilityServer = grpc.ServerStreamingServer[AvailabilityUpdate]
+
 func _TrainTicketingService_GetReceiptDetails_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetReceiptDetailsRequest)
 	if err := dec(in); err != nil {
@@ -236,6 +598,42 @@ func _TrainTicketingService_RemoveUser_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TrainTicketingService_CancelTicket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelTicketRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrainTicketingServiceServer).CancelTicket(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TrainTicketingService_CancelTicket_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrainTicketingServiceServer).CancelTicket(ctx, req.(*CancelTicketRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrainTicketingService_ListTicketsForUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTicketsForUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrainTicketingServiceServer).ListTicketsForUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TrainTicketingService_ListTicketsForUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrainTicketingServiceServer).ListTicketsForUser(ctx, req.(*ListTicketsForUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _TrainTicketingService_ModifyUserSeat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ModifyUserSeatRequest)
 	if err := dec(in); err != nil {
@@ -254,6 +652,114 @@ func _TrainTicketingService_ModifyUserSeat_Handler(srv interface{}, ctx context.
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TrainTicketingService_GetTicketHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTicketHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrainTicketingServiceServer).GetTicketHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TrainTicketingService_GetTicketHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrainTicketingServiceServer).GetTicketHistory(ctx, req.(*GetTicketHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrainTicketingService_GetSeatOccupant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSeatOccupantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrainTicketingServiceServer).GetSeatOccupant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TrainTicketingService_GetSeatOccupant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrainTicketingServiceServer).GetSeatOccupant(ctx, req.(*GetSeatOccupantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrainTicketingService_CreateJourney_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateJourneyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrainTicketingServiceServer).CreateJourney(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TrainTicketingService_CreateJourney_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrainTicketingServiceServer).CreateJourney(ctx, req.(*CreateJourneyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrainTicketingService_ListJourneys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListJourneysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrainTicketingServiceServer).ListJourneys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TrainTicketingService_ListJourneys_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrainTicketingServiceServer).ListJourneys(ctx, req.(*ListJourneysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrainTicketingService_QuoteFare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QuoteFareRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrainTicketingServiceServer).QuoteFare(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TrainTicketingService_QuoteFare_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrainTicketingServiceServer).QuoteFare(ctx, req.(*QuoteFareRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrainTicketingService_GetRefund_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRefundRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrainTicketingServiceServer).GetRefund(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TrainTicketingService_GetRefund_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrainTicketingServiceServer).GetRefund(ctx, req.(*GetRefundRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TrainTicketingService_ServiceDesc is the grpc.ServiceDesc for TrainTicketingService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -265,6 +771,26 @@ var TrainTicketingService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "PurchaseTicket",
 			Handler:    _TrainTicketingService_PurchaseTicket_Handler,
 		},
+		{
+			MethodName: "PurchaseGroupTicket",
+			Handler:    _TrainTicketingService_PurchaseGroupTicket_Handler,
+		},
+		{
+			MethodName: "HoldSeat",
+			Handler:    _TrainTicketingService_HoldSeat_Handler,
+		},
+		{
+			MethodName: "ConfirmHold",
+			Handler:    _TrainTicketingService_ConfirmHold_Handler,
+		},
+		{
+			MethodName: "JoinWaitlist",
+			Handler:    _TrainTicketingService_JoinWaitlist_Handler,
+		},
+		{
+			MethodName: "GetWaitlistStatus",
+			Handler:    _TrainTicketingService_GetWaitlistStatus_Handler,
+		},
 		{
 			MethodName: "GetReceiptDetails",
 			Handler:    _TrainTicketingService_GetReceiptDetails_Handler,
@@ -277,11 +803,49 @@ var TrainTicketingService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RemoveUser",
 			Handler:    _TrainTicketingService_RemoveUser_Handler,
 		},
+		{
+			MethodName: "CancelTicket",
+			Handler:    _TrainTicketingService_CancelTicket_Handler,
+		},
+		{
+			MethodName: "ListTicketsForUser",
+			Handler:    _TrainTicketingService_ListTicketsForUser_Handler,
+		},
 		{
 			MethodName: "ModifyUserSeat",
 			Handler:    _TrainTicketingService_ModifyUserSeat_Handler,
 		},
+		{
+			MethodName: "GetTicketHistory",
+			Handler:    _TrainTicketingService_GetTicketHistory_Handler,
+		},
+		{
+			MethodName: "GetSeatOccupant",
+			Handler:    _TrainTicketingService_GetSeatOccupant_Handler,
+		},
+		{
+			MethodName: "CreateJourney",
+			Handler:    _TrainTicketingService_CreateJourney_Handler,
+		},
+		{
+			MethodName: "ListJourneys",
+			Handler:    _TrainTicketingService_ListJourneys_Handler,
+		},
+		{
+			MethodName: "QuoteFare",
+			Handler:    _TrainTicketingService_QuoteFare_Handler,
+		},
+		{
+			MethodName: "GetRefund",
+			Handler:    _TrainTicketingService_GetRefund_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchAvailability",
+			Handler:       _TrainTicketingService_WatchAvailability_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "ticket.proto",
 }
@@ -0,0 +1,309 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.31.0
+// source: waitlist.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type WaitlistEntry_Status int32
+
+const (
+	WaitlistEntry_STATUS_UNKNOWN  WaitlistEntry_Status = 0
+	WaitlistEntry_STATUS_WAITING  WaitlistEntry_Status = 1 // Still queued for a seat
+	WaitlistEntry_STATUS_PROMOTED WaitlistEntry_Status = 2 // Given a freed seat; see ticket_id
+)
+
+// Enum value maps for WaitlistEntry_Status.
+var (
+	WaitlistEntry_Status_name = map[int32]string{
+		0: "STATUS_UNKNOWN",
+		1: "STATUS_WAITING",
+		2: "STATUS_PROMOTED",
+	}
+	WaitlistEntry_Status_value = map[string]int32{
+		"STATUS_UNKNOWN":  0,
+		"STATUS_WAITING":  1,
+		"STATUS_PROMOTED": 2,
+	}
+)
+
+func (x WaitlistEntry_Status) Enum() *WaitlistEntry_Status {
+	p := new(WaitlistEntry_Status)
+	*p = x
+	return p
+}
+
+func (x WaitlistEntry_Status) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WaitlistEntry_Status) Descriptor() protoreflect.EnumDescriptor {
+	return file_waitlist_proto_enumTypes[0].Descriptor()
+}
+
+func (WaitlistEntry_Status) Type() protoreflect.EnumType {
+	return &file_waitlist_proto_enumTypes[0]
+}
+
+func (x WaitlistEntry_Status) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WaitlistEntry_Status.Descriptor instead.
+func (WaitlistEntry_Status) EnumDescriptor() ([]byte, []int) {
+	return file_waitlist_proto_rawDescGZIP(), []int{0, 0}
+}
+
+// Represents a passenger waiting for a seat on a sold-out journey.
+type WaitlistEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WaitlistId    string                 `protobuf:"bytes,1,opt,name=waitlist_id,json=waitlistId,proto3" json:"waitlist_id,omitempty"` // Unique identifier for the entry
+	JourneyId     string                 `protobuf:"bytes,2,opt,name=journey_id,json=journeyId,proto3" json:"journey_id,omitempty"`    // Journey the passenger is waiting for
+	FromLocation  string                 `protobuf:"bytes,3,opt,name=from_location,json=fromLocation,proto3" json:"from_location,omitempty"`
+	ToLocation    string                 `protobuf:"bytes,4,opt,name=to_location,json=toLocation,proto3" json:"to_location,omitempty"`
+	User          *User                  `protobuf:"bytes,5,opt,name=user,proto3" json:"user,omitempty"`
+	PricePaid     float64                `protobuf:"fixed64,6,opt,name=price_paid,json=pricePaid,proto3" json:"price_paid,omitempty"` // Deprecated: use price. The amount of price in major units, e.g., 20.00
+	JoinedAt      *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=joined_at,json=joinedAt,proto3" json:"joined_at,omitempty"`      // Entries are served in joining order
+	Status        WaitlistEntry_Status   `protobuf:"varint,8,opt,name=status,proto3,enum=trainticketing.entities.WaitlistEntry_Status" json:"status,omitempty"`
+	TicketId      string                 `protobuf:"bytes,9,opt,name=ticket_id,json=ticketId,proto3" json:"ticket_id,omitempty"` // Ticket issued on promotion
+	PromotedAt    *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=promoted_at,json=promotedAt,proto3" json:"promoted_at,omitempty"`
+	Fare          *Fare                  `protobuf:"bytes,11,opt,name=fare,proto3" json:"fare,omitempty"`                                        // How price was worked out when the passenger joined
+	Price         *Money                 `protobuf:"bytes,12,opt,name=price,proto3" json:"price,omitempty"`                                      // Price charged when a seat is given; unset on entries stored before it was recorded
+	PaymentMethod string                 `protobuf:"bytes,13,opt,name=payment_method,json=paymentMethod,proto3" json:"payment_method,omitempty"` // Token of the card or account charged when a seat is given
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WaitlistEntry) Reset() {
+	*x = WaitlistEntry{}
+	mi := &file_waitlist_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WaitlistEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WaitlistEntry) ProtoMessage() {}
+
+func (x *WaitlistEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_waitlist_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WaitlistEntry.ProtoReflect.Descriptor instead.
+func (*WaitlistEntry) Descriptor() ([]byte, []int) {
+	return file_waitlist_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *WaitlistEntry) GetWaitlistId() string {
+	if x != nil {
+		return x.WaitlistId
+	}
+	return ""
+}
+
+func (x *WaitlistEntry) GetJourneyId() string {
+	if x != nil {
+		return x.JourneyId
+	}
+	return ""
+}
+
+func (x *WaitlistEntry) GetFromLocation() string {
+	if x != nil {
+		return x.FromLocation
+	}
+	return ""
+}
+
+func (x *WaitlistEntry) GetToLocation() string {
+	if x != nil {
+		return x.ToLocation
+	}
+	return ""
+}
+
+func (x *WaitlistEntry) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *WaitlistEntry) GetPricePaid() float64 {
+	if x != nil {
+		return x.PricePaid
+	}
+	return 0
+}
+
+func (x *WaitlistEntry) GetJoinedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.JoinedAt
+	}
+	return nil
+}
+
+func (x *WaitlistEntry) GetStatus() WaitlistEntry_Status {
+	if x != nil {
+		return x.Status
+	}
+	return WaitlistEntry_STATUS_UNKNOWN
+}
+
+func (x *WaitlistEntry) GetTicketId() string {
+	if x != nil {
+		return x.TicketId
+	}
+	return ""
+}
+
+func (x *WaitlistEntry) GetPromotedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PromotedAt
+	}
+	return nil
+}
+
+func (x *WaitlistEntry) GetFare() *Fare {
+	if x != nil {
+		return x.Fare
+	}
+	return nil
+}
+
+func (x *WaitlistEntry) GetPrice() *Money {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *WaitlistEntry) GetPaymentMethod() string {
+	if x != nil {
+		return x.PaymentMethod
+	}
+	return ""
+}
+
+var File_waitlist_proto protoreflect.FileDescriptor
+
+const file_waitlist_proto_rawDesc = "" +
+	"\n" +
+	"\x0ewaitlist.proto\x12\x17trainticketing.entities\x1a\n" +
+	"user.proto\x1a\n" +
+	"fare.proto\x1a\vmoney.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x98\x05\n" +
+	"\rWaitlistEntry\x12\x1f\n" +
+	"\vwaitlist_id\x18\x01 \x01(\tR\n" +
+	"waitlistId\x12\x1d\n" +
+	"\n" +
+	"journey_id\x18\x02 \x01(\tR\tjourneyId\x12#\n" +
+	"\rfrom_location\x18\x03 \x01(\tR\ffromLocation\x12\x1f\n" +
+	"\vto_location\x18\x04 \x01(\tR\n" +
+	"toLocation\x121\n" +
+	"\x04user\x18\x05 \x01(\v2\x1d.trainticketing.entities.UserR\x04user\x12\x1d\n" +
+	"\n" +
+	"price_paid\x18\x06 \x01(\x01R\tpricePaid\x127\n" +
+	"\tjoined_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\bjoinedAt\x12E\n" +
+	"\x06status\x18\b \x01(\x0e2-.trainticketing.entities.WaitlistEntry.StatusR\x06status\x12\x1b\n" +
+	"\tticket_id\x18\t \x01(\tR\bticketId\x12;\n" +
+	"\vpromoted_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"promotedAt\x121\n" +
+	"\x04fare\x18\v \x01(\v2\x1d.trainticketing.entities.FareR\x04fare\x124\n" +
+	"\x05price\x18\f \x01(\v2\x1e.trainticketing.entities.MoneyR\x05price\x12%\n" +
+	"\x0epayment_method\x18\r \x01(\tR\rpaymentMethod\"E\n" +
+	"\x06Status\x12\x12\n" +
+	"\x0eSTATUS_UNKNOWN\x10\x00\x12\x12\n" +
+	"\x0eSTATUS_WAITING\x10\x01\x12\x13\n" +
+	"\x0fSTATUS_PROMOTED\x10\x02B/Z-github.com/talk2sohail/train-ticket-api/protob\x06proto3"
+
+var (
+	file_waitlist_proto_rawDescOnce sync.Once
+	file_waitlist_proto_rawDescData []byte
+)
+
+func file_waitlist_proto_rawDescGZIP() []byte {
+	file_waitlist_proto_rawDescOnce.Do(func() {
+		file_waitlist_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_waitlist_proto_rawDesc), len(file_waitlist_proto_rawDesc)))
+	})
+	return file_waitlist_proto_rawDescData
+}
+
+var file_waitlist_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_waitlist_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_waitlist_proto_goTypes = []any{
+	(WaitlistEntry_Status)(0),     // 0: trainticketing.entities.WaitlistEntry.Status
+	(*WaitlistEntry)(nil),         // 1: trainticketing.entities.WaitlistEntry
+	(*User)(nil),                  // 2: trainticketing.entities.User
+	(*timestamppb.Timestamp)(nil), // 3: google.protobuf.Timestamp
+	(*Fare)(nil),                  // 4: trainticketing.entities.Fare
+	(*Money)(nil),                 // 5: trainticketing.entities.Money
+}
+var file_waitlist_proto_depIdxs = []int32{
+	2, // 0: trainticketing.entities.WaitlistEntry.user:type_name -> trainticketing.entities.User
+	3, // 1: trainticketing.entities.WaitlistEntry.joined_at:type_name -> google.protobuf.Timestamp
+	0, // 2: trainticketing.entities.WaitlistEntry.status:type_name -> trainticketing.entities.WaitlistEntry.Status
+	3, // 3: trainticketing.entities.WaitlistEntry.promoted_at:type_name -> google.protobuf.Timestamp
+	4, // 4: trainticketing.entities.WaitlistEntry.fare:type_name -> trainticketing.entities.Fare
+	5, // 5: trainticketing.entities.WaitlistEntry.price:type_name -> trainticketing.entities.Money
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_waitlist_proto_init() }
+func file_waitlist_proto_init() {
+	if File_waitlist_proto != nil {
+		return
+	}
+	file_user_proto_init()
+	file_fare_proto_init()
+	file_money_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_waitlist_proto_rawDesc), len(file_waitlist_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_waitlist_proto_goTypes,
+		DependencyIndexes: file_waitlist_proto_depIdxs,
+		EnumInfos:         file_waitlist_proto_enumTypes,
+		MessageInfos:      file_waitlist_proto_msgTypes,
+	}.Build()
+	File_waitlist_proto = out.File
+	file_waitlist_proto_goTypes = nil
+	file_waitlist_proto_depIdxs = nil
+}
@@ -3,37 +3,208 @@ package util
 import (
 	"fmt"
 	"log"
+	"time"
 
 	ticket "github.com/talk2sohail/train-ticket-api/internal/common/genproto/ticket"
 )
 
+// FieldError reports a request field that failed validation.
+type FieldError struct {
+	Field       string // Path of the field in the request, such as "new_seat.seat_number".
+	Description string
+}
+
+func (e *FieldError) Error() string {
+	return e.Description
+}
+
+func fieldError(field, description string) error {
+	return &FieldError{Field: field, Description: description}
+}
+
 func ValidatePurchseRequestObject(r *ticket.PurchaseTicketRequest) error {
 	if r.GetFromLocation() == "" {
 		log.Printf("FromLocation is required")
-		return fmt.Errorf("FromLocation is required")
+		return fieldError("from_location", "FromLocation is required")
 	}
 	if r.GetToLocation() == "" {
 		log.Printf("ToLocation is required")
-		return fmt.Errorf("ToLocation is required")
+		return fieldError("to_location", "ToLocation is required")
 	}
 	if r.GetUser() == nil {
 		log.Printf("User is required")
-		return fmt.Errorf("User is required")
+		return fieldError("user", "User is required")
+	}
+	if err := validatePricePaid(r.GetPrice(), r.GetPricePaid()); err != nil {
+		return err
 	}
-	if r.GetPricePaid() <= 0 {
+	if err := ValidateFareOptions(r.GetTravelClass(), r.GetPassengerType()); err != nil {
+		return err
+	}
+	return ValidateSeatPreferences(r.GetPreferences())
+}
+
+// validatePricePaid checks that a request gives its price either as price or as the legacy
+// price_paid, but not both. The money package checks the price itself.
+func validatePricePaid(price *ticket.Money, pricePaid float64) error {
+	if price != nil {
+		if pricePaid != 0 {
+			log.Printf("Price and PricePaid cannot both be set")
+			return fieldError("price_paid", "Price and PricePaid cannot both be set")
+		}
+	} else if pricePaid <= 0 {
 		log.Printf("PricePaid must be greater than zero")
-		return fmt.Errorf("PricePaid must be greater than zero")
+		return fieldError("price_paid", "PricePaid must be greater than zero")
+	}
+	return nil
+}
+
+func ValidateHoldSeatRequestObject(r *ticket.HoldSeatRequest) error {
+	if r.GetFromLocation() == "" {
+		log.Printf("FromLocation is required")
+		return fieldError("from_location", "FromLocation is required")
+	}
+	if r.GetToLocation() == "" {
+		log.Printf("ToLocation is required")
+		return fieldError("to_location", "ToLocation is required")
+	}
+	if r.GetUser() == nil {
+		log.Printf("User is required")
+		return fieldError("user", "User is required")
+	}
+	if err := ValidateFareOptions(r.GetTravelClass(), r.GetPassengerType()); err != nil {
+		return err
+	}
+	return ValidateSeatPreferences(r.GetPreferences())
+}
+
+func ValidateConfirmHoldRequestObject(r *ticket.ConfirmHoldRequest) error {
+	if r.GetHoldId() == "" {
+		log.Printf("HoldId is required")
+		return fieldError("hold_id", "HoldId is required")
+	}
+	return validatePricePaid(r.GetPrice(), r.GetPricePaid())
+}
+
+func ValidateJoinWaitlistRequestObject(r *ticket.JoinWaitlistRequest) error {
+	if r.GetFromLocation() == "" {
+		log.Printf("FromLocation is required")
+		return fieldError("from_location", "FromLocation is required")
+	}
+	if r.GetToLocation() == "" {
+		log.Printf("ToLocation is required")
+		return fieldError("to_location", "ToLocation is required")
+	}
+	if r.GetUser() == nil {
+		log.Printf("User is required")
+		return fieldError("user", "User is required")
+	}
+	if err := validatePricePaid(r.GetPrice(), r.GetPricePaid()); err != nil {
+		return err
+	}
+	return ValidateFareOptions(r.GetTravelClass(), r.GetPassengerType())
+}
+
+func ValidateListTicketsForUserRequestObject(r *ticket.ListTicketsForUserRequest) error {
+	if r.GetEmail() == "" {
+		log.Printf("Email is required")
+		return fieldError("email", "Email is required")
+	}
+	if r.GetPageSize() < 0 {
+		log.Printf("PageSize cannot be negative")
+		return fieldError("page_size", "PageSize cannot be negative")
+	}
+	return nil
+}
+
+func ValidateWatchAvailabilityRequestObject(r *ticket.WatchAvailabilityRequest) error {
+	if (r.GetFromLocation() == "") != (r.GetToLocation() == "") {
+		log.Printf("FromLocation and ToLocation must be given together")
+		return fieldError("from_location", "FromLocation and ToLocation must be given together")
+	}
+	return nil
+}
+
+// MaxGroupSize caps the number of passengers in a single group booking.
+const MaxGroupSize = 50
+
+func ValidatePurchaseGroupRequestObject(r *ticket.PurchaseGroupTicketRequest) error {
+	if r.GetFromLocation() == "" {
+		log.Printf("FromLocation is required")
+		return fieldError("from_location", "FromLocation is required")
+	}
+	if r.GetToLocation() == "" {
+		log.Printf("ToLocation is required")
+		return fieldError("to_location", "ToLocation is required")
+	}
+	if len(r.GetPassengers()) == 0 {
+		log.Printf("Passengers are required")
+		return fieldError("passengers", "Passengers are required")
+	}
+	if len(r.GetPassengers()) > MaxGroupSize {
+		log.Printf("Group of %d passengers exceeds the maximum of %d", len(r.GetPassengers()), MaxGroupSize)
+		return fieldError("passengers", fmt.Sprintf("a group can have at most %d passengers", MaxGroupSize))
+	}
+	for i, passenger := range r.GetPassengers() {
+		if passenger.GetEmail() == "" {
+			log.Printf("Passenger %d has no email", i)
+			return fieldError(fmt.Sprintf("passengers[%d].email", i), fmt.Sprintf("Passenger %d: email is required", i))
+		}
+	}
+	if err := validatePricePaid(r.GetPrice(), r.GetPricePaid()); err != nil {
+		return err
+	}
+	return ValidateFareOptions(r.GetTravelClass(), r.GetPassengerType())
+}
+
+func ValidateQuoteFareRequestObject(r *ticket.QuoteFareRequest) error {
+	if r.GetFromLocation() == "" {
+		log.Printf("FromLocation is required")
+		return fieldError("from_location", "FromLocation is required")
+	}
+	if r.GetToLocation() == "" {
+		log.Printf("ToLocation is required")
+		return fieldError("to_location", "ToLocation is required")
+	}
+	return ValidateFareOptions(r.GetTravelClass(), r.GetPassengerType())
+}
+
+// ValidateFareOptions checks the travel class and passenger type a trip is priced for.
+func ValidateFareOptions(class ticket.TravelClass, passenger ticket.PassengerType) error {
+	if _, ok := ticket.TravelClass_name[int32(class)]; !ok {
+		log.Printf("TravelClass %d is invalid", class)
+		return fieldError("travel_class", "TravelClass is invalid")
+	}
+	if _, ok := ticket.PassengerType_name[int32(passenger)]; !ok {
+		log.Printf("PassengerType %d is invalid", passenger)
+		return fieldError("passenger_type", "PassengerType is invalid")
+	}
+	return nil
+}
+
+func ValidateSeatPreferences(p *ticket.SeatPreferences) error {
+	if _, ok := ticket.SeatPreferences_Position_name[int32(p.GetPosition())]; !ok {
+		log.Printf("Preferences.Position %d is invalid", p.GetPosition())
+		return fieldError("preferences.position", "Preferences.Position is invalid")
+	}
+	if _, ok := ticket.SeatAttributes_Facing_name[int32(p.GetFacing())]; !ok {
+		log.Printf("Preferences.Facing %d is invalid", p.GetFacing())
+		return fieldError("preferences.facing", "Preferences.Facing is invalid")
 	}
 	return nil
 }
 
 func ValidateSection(r *ticket.GetUsersBySectionRequest) error {
+	// A coach from the train layout takes precedence over the legacy section.
+	if r.GetCoach() != "" {
+		return nil
+	}
 	if r.GetSection().String() == "" {
-		return fmt.Errorf("Section is required")
+		return fieldError("section", "Section is required")
 	}
 
 	if r.GetSection() == ticket.Seat_SECTION_UNKNOWN {
-		return fmt.Errorf("Section is invalid")
+		return fieldError("section", "Section is invalid")
 	}
 	return nil
 }
@@ -43,13 +214,92 @@ func ValidateModifyUserSeatRequestObject(req *ticket.ModifyUserSeatRequest) erro
 		log.Printf("Invalid ModifyUserSeat request: request is nil")
 		return fmt.Errorf("request cannot be nil")
 	}
-	if req.GetTicketId() == "" {
-		log.Printf("Invalid ModifyUserSeat request: ticketId is required")
-		return fmt.Errorf("ticketId is required")
+	if req.GetTicketId() == "" && req.GetEmail() == "" {
+		log.Printf("Invalid ModifyUserSeat request: ticketId or email is required")
+		return fieldError("identifier", "ticketId or email is required")
 	}
 	if req.GetNewSeat() == nil {
 		log.Printf("Invalid ModifyUserSeat request: new seat is required")
-		return fmt.Errorf("new seat is required")
+		return fieldError("new_seat", "new seat is required")
+	}
+	if req.GetNewSeat().GetSeatNumber() == "" {
+		log.Printf("Invalid ModifyUserSeat request: new seat number is required")
+		return fieldError("new_seat.seat_number", "new seat number is required")
+	}
+	return nil
+}
+
+// ServiceDateLayout is the format of journey service dates, e.g. "2025-05-01".
+const ServiceDateLayout = "2006-01-02"
+
+func ValidateCreateJourneyRequestObject(r *ticket.CreateJourneyRequest) error {
+	if r.GetOrigin() == "" {
+		log.Printf("Origin is required")
+		return fieldError("origin", "Origin is required")
+	}
+	if r.GetDestination() == "" {
+		log.Printf("Destination is required")
+		return fieldError("destination", "Destination is required")
+	}
+	if r.GetOrigin() == r.GetDestination() {
+		log.Printf("Origin and Destination must differ")
+		return fieldError("destination", "Origin and Destination must differ")
+	}
+	if err := ValidateServiceDate(r.GetServiceDate()); err != nil {
+		return err
+	}
+	if r.GetDepartureTime() == nil {
+		log.Printf("DepartureTime is required")
+		return fieldError("departure_time", "DepartureTime is required")
+	}
+	if _, err := time.LoadLocation(r.GetTimeZone()); err != nil || r.GetTimeZone() == "Local" {
+		log.Printf("TimeZone %s is unknown", r.GetTimeZone())
+		return fieldError("time_zone", "TimeZone must be an IANA time zone")
+	}
+	if r.GetSeatsPerSection() < 0 {
+		log.Printf("SeatsPerSection must not be negative")
+		return fieldError("seats_per_section", "SeatsPerSection must not be negative")
+	}
+	if r.GetSeatsPerSection() > 0 && r.GetLayout() != "" {
+		log.Printf("SeatsPerSection cannot be combined with Layout %s", r.GetLayout())
+		return fieldError("layout", "SeatsPerSection cannot be combined with Layout")
+	}
+	return ValidateStops(r.GetOrigin(), r.GetDestination(), r.GetStops())
+}
+
+// ValidateStops checks that a route, when given, runs from origin to destination
+// and does not call at the same stop twice.
+func ValidateStops(origin, destination string, stops []string) error {
+	if len(stops) == 0 {
+		return nil
+	}
+	if stops[0] != origin || stops[len(stops)-1] != destination {
+		log.Printf("Stops must start at %s and end at %s", origin, destination)
+		return fieldError("stops", "Stops must start at Origin and end at Destination")
+	}
+	seen := make(map[string]bool, len(stops))
+	for _, stop := range stops {
+		if stop == "" {
+			log.Printf("Stops must not be empty")
+			return fieldError("stops", "Stops must not be empty")
+		}
+		if seen[stop] {
+			log.Printf("Stop %s appears more than once", stop)
+			return fieldError("stops", fmt.Sprintf("Stop %s appears more than once", stop))
+		}
+		seen[stop] = true
+	}
+	return nil
+}
+
+func ValidateServiceDate(date string) error {
+	if date == "" {
+		log.Printf("ServiceDate is required")
+		return fieldError("service_date", "ServiceDate is required")
+	}
+	if _, err := time.Parse(ServiceDateLayout, date); err != nil {
+		log.Printf("ServiceDate %q is not in YYYY-MM-DD format", date)
+		return fieldError("service_date", "ServiceDate must be in YYYY-MM-DD format")
 	}
 	return nil
 }
@@ -0,0 +1,64 @@
+// Package auth authenticates gRPC callers with signed bearer tokens and decides
+// which RPCs each caller may make. Tokens are JWTs signed with HMAC or Ed25519
+// keys configured on the server; their claims name the caller's email and role.
+package auth
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Role is what a caller is allowed to do.
+type Role string
+
+const (
+	// RolePassenger may only book for, inspect and change their own tickets.
+	RolePassenger Role = "passenger"
+	// RoleStaff may also view manifests and seat occupants and remove any passenger.
+	RoleStaff Role = "staff"
+	// RoleAdmin may do anything, including scheduling journeys.
+	RoleAdmin Role = "admin"
+)
+
+// Valid reports whether r is a known role.
+func (r Role) Valid() bool {
+	switch r {
+	case RolePassenger, RoleStaff, RoleAdmin:
+		return true
+	}
+	return false
+}
+
+// Claims are the claims of a bearer token.
+type Claims struct {
+	Email string `json:"email"` // The caller's email; the passenger whose tickets they may touch.
+	Role  Role   `json:"role"`
+	jwt.RegisteredClaims
+}
+
+// Validate checks the claims the server relies on; registered claims such as
+// the expiry are checked by the parser.
+func (c *Claims) Validate() error {
+	if !c.Role.Valid() {
+		return fmt.Errorf("unknown role %q", c.Role)
+	}
+	if c.Role == RolePassenger && c.Email == "" {
+		return fmt.Errorf("passenger token has no email")
+	}
+	return nil
+}
+
+type claimsKey struct{}
+
+// NewContext returns a context carrying the caller's claims.
+func NewContext(ctx context.Context, claims *Claims) context.Context {
+	return context.WithValue(ctx, claimsKey{}, claims)
+}
+
+// FromContext returns the claims of the authenticated caller, if any.
+func FromContext(ctx context.Context) (*Claims, bool) {
+	claims, ok := ctx.Value(claimsKey{}).(*Claims)
+	return claims, ok
+}
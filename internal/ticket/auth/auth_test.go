@@ -0,0 +1,426 @@
+package auth_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	ticket "github.com/talk2sohail/train-ticket-api/internal/common/genproto/ticket"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/auth"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/handler"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/payment"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var testSecret = []byte("test-secret")
+
+const (
+	owner    = "alice@example.com"
+	stranger = "mallory@example.com"
+)
+
+// sign issues an HS256 token for the test key.
+func sign(t *testing.T, claims auth.Claims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	token.Header["kid"] = "hs"
+	signed, err := token.SignedString(testSecret)
+	if err != nil {
+		t.Fatalf("unexpected error signing token: %v", err)
+	}
+	return signed
+}
+
+func claimsFor(role auth.Role, email string) auth.Claims {
+	return auth.Claims{
+		Email:            email,
+		Role:             role,
+		RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour))},
+	}
+}
+
+// fixture is a served ticket service holding a ticket, a hold, a waitlist entry and a refund of the owner.
+type fixture struct {
+	client     ticket.TrainTicketingServiceClient
+	ticketID   string
+	holdID     string
+	waitlistID string
+	refundID   string
+}
+
+func newFixture(t *testing.T, keys ...auth.Key) *fixture {
+	t.Helper()
+	ctx := context.Background()
+	s := service.NewTicketService(service.WithPayments(payment.NewFake())) // Only paid tickets are refunded.
+	f := &fixture{}
+
+	purchase, err := s.PurchaseTicket(ctx, &ticket.PurchaseTicketRequest{
+		FromLocation: "London", ToLocation: "Paris", User: &ticket.User{Email: owner}, PricePaid: 20,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error purchasing: %v", err)
+	}
+	f.ticketID = purchase.GetReceipt().GetTicketId()
+
+	cancelled, err := s.PurchaseTicket(ctx, &ticket.PurchaseTicketRequest{
+		FromLocation: "London", ToLocation: "Paris", User: &ticket.User{Email: owner}, PricePaid: 20,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error purchasing: %v", err)
+	}
+	cancellation, err := s.CancelTicket(ctx, cancelled.GetReceipt().GetTicketId())
+	if err != nil {
+		t.Fatalf("unexpected error cancelling: %v", err)
+	}
+	f.refundID = cancellation.GetRefund().GetRefundId()
+
+	hold, err := s.HoldSeat(ctx, &ticket.HoldSeatRequest{FromLocation: "London", ToLocation: "Paris", User: &ticket.User{Email: owner}})
+	if err != nil {
+		t.Fatalf("unexpected error holding: %v", err)
+	}
+	f.holdID = hold.GetHoldId()
+
+	// A one-seat-per-coach journey sold out to others, so that the owner can join its waitlist.
+	journey, err := s.CreateJourney(ctx, &ticket.CreateJourneyRequest{
+		ServiceDate: "2025-05-01", DepartureTime: timestamppb.New(time.Date(2025, 5, 1, 8, 0, 0, 0, time.UTC)),
+		Origin: "London", Destination: "Paris", SeatsPerSection: 1,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error creating journey: %v", err)
+	}
+	for _, email := range []string{"b@example.com", "c@example.com"} {
+		if _, err := s.PurchaseTicket(ctx, &ticket.PurchaseTicketRequest{
+			FromLocation: "London", ToLocation: "Paris", User: &ticket.User{Email: email}, PricePaid: 20, JourneyId: journey.GetJourney().GetJourneyId(),
+		}); err != nil {
+			t.Fatalf("unexpected error purchasing: %v", err)
+		}
+	}
+	entry, err := s.JoinWaitlist(ctx, &ticket.JoinWaitlistRequest{
+		FromLocation: "London", ToLocation: "Paris", User: &ticket.User{Email: owner}, PricePaid: 20, JourneyId: journey.GetJourney().GetJourneyId(),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error joining waitlist: %v", err)
+	}
+	f.waitlistID = entry.GetEntry().GetWaitlistId()
+
+	if len(keys) == 0 {
+		keys = []auth.Key{{ID: "hs", Algorithm: "HS256", Secret: testSecret}}
+	}
+	verifier, err := auth.NewVerifier("", "", keys...)
+	if err != nil {
+		t.Fatalf("unexpected error creating verifier: %v", err)
+	}
+	authorizer := auth.NewAuthorizer(verifier, s)
+	srv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(authorizer.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(authorizer.StreamServerInterceptor()),
+	)
+	handler.RegisterTicketServiceServer(srv, s)
+	lis := bufconn.Listen(1 << 20)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error dialing: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	f.client = ticket.NewTrainTicketingServiceClient(conn)
+	return f
+}
+
+func withToken(token string) context.Context {
+	ctx := context.Background()
+	if token == "" {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+}
+
+// calls makes each RPC on resources of the owner.
+var calls = map[string]func(ctx context.Context, f *fixture) error{
+	"PurchaseTicket": func(ctx context.Context, f *fixture) error {
+		_, err := f.client.PurchaseTicket(ctx, &ticket.PurchaseTicketRequest{FromLocation: "London", ToLocation: "Paris", User: &ticket.User{Email: owner}, PricePaid: 20})
+		return err
+	},
+	"PurchaseGroupTicket": func(ctx context.Context, f *fixture) error {
+		_, err := f.client.PurchaseGroupTicket(ctx, &ticket.PurchaseGroupTicketRequest{
+			FromLocation: "London", ToLocation: "Paris", PricePaid: 20,
+			Passengers: []*ticket.User{{Email: "friend@example.com"}, {Email: owner}},
+		})
+		return err
+	},
+	"HoldSeat": func(ctx context.Context, f *fixture) error {
+		_, err := f.client.HoldSeat(ctx, &ticket.HoldSeatRequest{FromLocation: "London", ToLocation: "Paris", User: &ticket.User{Email: owner}})
+		return err
+	},
+	"ConfirmHold": func(ctx context.Context, f *fixture) error {
+		_, err := f.client.ConfirmHold(ctx, &ticket.ConfirmHoldRequest{HoldId: f.holdID, PricePaid: 20})
+		return err
+	},
+	"JoinWaitlist": func(ctx context.Context, f *fixture) error {
+		_, err := f.client.JoinWaitlist(ctx, &ticket.JoinWaitlistRequest{FromLocation: "London", ToLocation: "Paris", User: &ticket.User{Email: owner}, PricePaid: 20})
+		return err
+	},
+	"GetWaitlistStatus": func(ctx context.Context, f *fixture) error {
+		_, err := f.client.GetWaitlistStatus(ctx, &ticket.GetWaitlistStatusRequest{WaitlistId: f.waitlistID})
+		return err
+	},
+	"WatchAvailability": func(ctx context.Context, f *fixture) error {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		stream, err := f.client.WatchAvailability(ctx, &ticket.WatchAvailabilityRequest{})
+		if err != nil {
+			return err
+		}
+		_, err = stream.Recv()
+		return err
+	},
+	"GetReceiptDetails": func(ctx context.Context, f *fixture) error {
+		_, err := f.client.GetReceiptDetails(ctx, &ticket.GetReceiptDetailsRequest{Identifier: &ticket.GetReceiptDetailsRequest_TicketId{TicketId: f.ticketID}})
+		return err
+	},
+	"GetUsersBySection": func(ctx context.Context, f *fixture) error {
+		_, err := f.client.GetUsersBySection(ctx, &ticket.GetUsersBySectionRequest{Coach: "A"})
+		return err
+	},
+	"RemoveUser": func(ctx context.Context, f *fixture) error {
+		_, err := f.client.RemoveUser(ctx, &ticket.RemoveUserRequest{Identifier: &ticket.RemoveUserRequest_Email{Email: owner}})
+		return err
+	},
+	"CancelTicket": func(ctx context.Context, f *fixture) error {
+		_, err := f.client.CancelTicket(ctx, &ticket.CancelTicketRequest{TicketId: f.ticketID})
+		return err
+	},
+	"GetRefund": func(ctx context.Context, f *fixture) error {
+		_, err := f.client.GetRefund(ctx, &ticket.GetRefundRequest{RefundId: f.refundID})
+		return err
+	},
+	"ListTicketsForUser": func(ctx context.Context, f *fixture) error {
+		_, err := f.client.ListTicketsForUser(ctx, &ticket.ListTicketsForUserRequest{Email: owner})
+		return err
+	},
+	"ModifyUserSeat": func(ctx context.Context, f *fixture) error {
+		_, err := f.client.ModifyUserSeat(ctx, &ticket.ModifyUserSeatRequest{
+			Identifier: &ticket.ModifyUserSeatRequest_TicketId{TicketId: f.ticketID},
+			NewSeat:    &ticket.Seat{SeatNumber: "B5"},
+		})
+		return err
+	},
+	"GetTicketHistory": func(ctx context.Context, f *fixture) error {
+		_, err := f.client.GetTicketHistory(ctx, &ticket.GetTicketHistoryRequest{TicketId: f.ticketID})
+		return err
+	},
+	"GetSeatOccupant": func(ctx context.Context, f *fixture) error {
+		_, err := f.client.GetSeatOccupant(ctx, &ticket.GetSeatOccupantRequest{SeatNumber: "A1"})
+		return err
+	},
+	"CreateJourney": func(ctx context.Context, f *fixture) error {
+		_, err := f.client.CreateJourney(ctx, &ticket.CreateJourneyRequest{
+			ServiceDate: "2025-05-02", DepartureTime: timestamppb.New(time.Date(2025, 5, 2, 8, 0, 0, 0, time.UTC)),
+			Origin: "London", Destination: "Paris", SeatsPerSection: 2,
+		})
+		return err
+	},
+	"ListJourneys": func(ctx context.Context, f *fixture) error {
+		_, err := f.client.ListJourneys(ctx, &ticket.ListJourneysRequest{})
+		return err
+	},
+	"QuoteFare": func(ctx context.Context, f *fixture) error {
+		_, err := f.client.QuoteFare(ctx, &ticket.QuoteFareRequest{FromLocation: "London", ToLocation: "Paris"})
+		return err
+	},
+}
+
+func TestUnit_AuthorizationMatrix(t *testing.T) {
+	callers := []struct {
+		name   string
+		claims auth.Claims
+	}{
+		{"owner", claimsFor(auth.RolePassenger, owner)},
+		{"other passenger", claimsFor(auth.RolePassenger, stranger)},
+		{"staff", claimsFor(auth.RoleStaff, "staff@example.com")},
+		{"admin", claimsFor(auth.RoleAdmin, "admin@example.com")},
+	}
+	// Whether each caller may make each call; the owner may touch everything of their own
+	// except manifests, seat occupants and journey scheduling.
+	allowed := map[string][4]bool{
+		"PurchaseTicket":      {true, false, true, true},
+		"PurchaseGroupTicket": {true, false, true, true},
+		"HoldSeat":            {true, false, true, true},
+		"ConfirmHold":         {true, false, true, true},
+		"JoinWaitlist":        {true, false, true, true},
+		"GetWaitlistStatus":   {true, false, true, true},
+		"WatchAvailability":   {true, true, true, true},
+		"GetReceiptDetails":   {true, false, true, true},
+		"GetUsersBySection":   {false, false, true, true},
+		"RemoveUser":          {true, false, true, true},
+		"CancelTicket":        {true, false, true, true},
+		"GetRefund":           {true, false, true, true},
+		"ListTicketsForUser":  {true, false, true, true},
+		"ModifyUserSeat":      {true, false, true, true},
+		"GetTicketHistory":    {true, false, true, true},
+		"GetSeatOccupant":     {false, false, true, true},
+		"CreateJourney":       {false, false, false, true},
+		"ListJourneys":        {true, true, true, true},
+		"QuoteFare":           {true, true, true, true},
+	}
+	methods := ticket.File_ticket_proto.Services().ByName("TrainTicketingService").Methods()
+	if methods.Len() != len(allowed) || len(calls) != len(allowed) {
+		t.Fatalf("expected a call and an expectation for each of the %d RPCs", methods.Len())
+	}
+
+	for i := 0; i < methods.Len(); i++ {
+		rpc := string(methods.Get(i).Name())
+		call, ok := calls[rpc]
+		if !ok {
+			t.Fatalf("no call for %s", rpc)
+		}
+		t.Run(rpc, func(t *testing.T) {
+			for j, caller := range callers {
+				t.Run(caller.name, func(t *testing.T) {
+					f := newFixture(t)
+					code := status.Code(call(withToken(sign(t, caller.claims)), f))
+					if want := allowed[rpc][j]; want && (code == codes.PermissionDenied || code == codes.Unauthenticated) {
+						t.Errorf("expected %s to be allowed, got %v", caller.name, code)
+					} else if !want && code != codes.PermissionDenied {
+						t.Errorf("expected %s to be denied, got %v", caller.name, code)
+					}
+				})
+			}
+			t.Run("anonymous", func(t *testing.T) {
+				if code := status.Code(call(context.Background(), newFixture(t))); code != codes.Unauthenticated {
+					t.Errorf("expected %v without a token, got %v", codes.Unauthenticated, code)
+				}
+			})
+		})
+	}
+}
+
+func TestUnit_TokenVerification(t *testing.T) {
+	f := newFixture(t)
+	listJourneys := func(token string) codes.Code {
+		_, err := f.client.ListJourneys(withToken(token), &ticket.ListJourneysRequest{})
+		return status.Code(err)
+	}
+
+	t.Run("Valid token", func(t *testing.T) {
+		if code := listJourneys(sign(t, claimsFor(auth.RolePassenger, owner))); code != codes.OK {
+			t.Errorf("expected %v, got %v", codes.OK, code)
+		}
+	})
+
+	t.Run("Expired token", func(t *testing.T) {
+		claims := claimsFor(auth.RolePassenger, owner)
+		claims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute))
+		if code := listJourneys(sign(t, claims)); code != codes.Unauthenticated {
+			t.Errorf("expected %v, got %v", codes.Unauthenticated, code)
+		}
+	})
+
+	t.Run("Token without expiry", func(t *testing.T) {
+		claims := claimsFor(auth.RolePassenger, owner)
+		claims.ExpiresAt = nil
+		if code := listJourneys(sign(t, claims)); code != codes.Unauthenticated {
+			t.Errorf("expected %v, got %v", codes.Unauthenticated, code)
+		}
+	})
+
+	t.Run("Unknown role", func(t *testing.T) {
+		if code := listJourneys(sign(t, claimsFor("superuser", owner))); code != codes.Unauthenticated {
+			t.Errorf("expected %v, got %v", codes.Unauthenticated, code)
+		}
+	})
+
+	t.Run("Wrong secret", func(t *testing.T) {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claimsFor(auth.RoleAdmin, ""))
+		token.Header["kid"] = "hs"
+		signed, _ := token.SignedString([]byte("guessed"))
+		if code := listJourneys(signed); code != codes.Unauthenticated {
+			t.Errorf("expected %v, got %v", codes.Unauthenticated, code)
+		}
+	})
+
+	t.Run("Unsigned token", func(t *testing.T) {
+		token := jwt.NewWithClaims(jwt.SigningMethodNone, claimsFor(auth.RoleAdmin, ""))
+		token.Header["kid"] = "hs"
+		signed, _ := token.SignedString(jwt.UnsafeAllowNoneSignatureType)
+		if code := listJourneys(signed); code != codes.Unauthenticated {
+			t.Errorf("expected %v, got %v", codes.Unauthenticated, code)
+		}
+	})
+
+	t.Run("Not a bearer token", func(t *testing.T) {
+		ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Basic YWxpY2U6c2VjcmV0")
+		if _, err := f.client.ListJourneys(ctx, &ticket.ListJourneysRequest{}); status.Code(err) != codes.Unauthenticated {
+			t.Errorf("expected %v, got %v", codes.Unauthenticated, err)
+		}
+	})
+}
+
+func TestUnit_Ed25519Tokens(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("unexpected error generating key: %v", err)
+	}
+	f := newFixture(t,
+		auth.Key{ID: "hs", Algorithm: "HS256", Secret: testSecret},
+		auth.Key{ID: "ed", Algorithm: "EdDSA", PublicKey: publicKey},
+	)
+	listJourneys := func(token string) codes.Code {
+		_, err := f.client.ListJourneys(withToken(token), &ticket.ListJourneysRequest{})
+		return status.Code(err)
+	}
+
+	t.Run("Signed with the private key", func(t *testing.T) {
+		token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, claimsFor(auth.RoleStaff, ""))
+		token.Header["kid"] = "ed"
+		signed, err := token.SignedString(privateKey)
+		if err != nil {
+			t.Fatalf("unexpected error signing: %v", err)
+		}
+		if code := listJourneys(signed); code != codes.OK {
+			t.Errorf("expected %v, got %v", codes.OK, code)
+		}
+	})
+
+	t.Run("Public key used as an HMAC secret", func(t *testing.T) {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claimsFor(auth.RoleAdmin, ""))
+		token.Header["kid"] = "ed"
+		signed, _ := token.SignedString([]byte(publicKey))
+		if code := listJourneys(signed); code != codes.Unauthenticated {
+			t.Errorf("expected %v, got %v", codes.Unauthenticated, code)
+		}
+	})
+}
+
+func TestUnit_NewVerifier(t *testing.T) {
+	for name, keys := range map[string][]auth.Key{
+		"no keys":             nil,
+		"unknown algorithm":   {{ID: "k", Algorithm: "RS256", Secret: testSecret}},
+		"HMAC without secret": {{ID: "k", Algorithm: "HS256"}},
+		"EdDSA without key":   {{ID: "k", Algorithm: "EdDSA"}},
+		"duplicate ID":        {{ID: "k", Algorithm: "HS256", Secret: testSecret}, {ID: "k", Algorithm: "HS512", Secret: testSecret}},
+		"missing ID":          {{Algorithm: "HS256", Secret: testSecret}, {ID: "k", Algorithm: "HS512", Secret: testSecret}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := auth.NewVerifier("", "", keys...); err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package auth
+
+import (
+	"context"
+	"log"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Authorizer authenticates the bearer token of every call and checks the
+// caller's role and, for passengers, ownership of what the request touches.
+type Authorizer struct {
+	verifier *Verifier
+	owners   Owners
+}
+
+// NewAuthorizer creates an Authorizer that verifies tokens with verifier and
+// resolves the owners of tickets, holds and waitlist entries with owners.
+func NewAuthorizer(verifier *Verifier, owners Owners) *Authorizer {
+	return &Authorizer{verifier: verifier, owners: owners}
+}
+
+// UnaryServerInterceptor authenticates and authorizes unary calls.
+func (a *Authorizer) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		claims, err := a.authenticate(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if err := a.Authorize(info.FullMethod, claims, req); err != nil {
+			return nil, err
+		}
+		return handler(NewContext(ctx, claims), req)
+	}
+}
+
+// StreamServerInterceptor authenticates streaming calls and authorizes each request message they receive.
+func (a *Authorizer) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		claims, err := a.authenticate(ss.Context())
+		if err != nil {
+			return err
+		}
+		// Refuse callers whose role may never make the call before reading a request.
+		if p, ok := policies[info.FullMethod]; !ok || !slices.Contains(p.roles, claims.Role) {
+			return denied(info.FullMethod, claims)
+		}
+		return handler(srv, &authorizedStream{ServerStream: ss, a: a, method: info.FullMethod, claims: claims})
+	}
+}
+
+// authorizedStream checks every request message received on a stream.
+type authorizedStream struct {
+	grpc.ServerStream
+	a      *Authorizer
+	method string
+	claims *Claims
+}
+
+func (s *authorizedStream) Context() context.Context {
+	return NewContext(s.ServerStream.Context(), s.claims)
+}
+
+func (s *authorizedStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return s.a.Authorize(s.method, s.claims, m)
+}
+
+// authenticate verifies the bearer token in the call's "authorization" metadata.
+func (a *Authorizer) authenticate(ctx context.Context) (*Claims, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "missing bearer token")
+	}
+	return a.Authenticate(values[0])
+}
+
+// Authenticate verifies the bearer token in an authorization value such as
+// "Bearer eyJ...", as sent in gRPC metadata or an HTTP Authorization header.
+// It fails with an Unauthenticated status.
+func (a *Authorizer) Authenticate(authorization string) (*Claims, error) {
+	if authorization == "" {
+		return nil, status.Error(codes.Unauthenticated, "missing bearer token")
+	}
+	token, ok := strings.CutPrefix(authorization, "Bearer ")
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "authorization must be a bearer token")
+	}
+	claims, err := a.verifier.Verify(token)
+	if err != nil {
+		log.Printf("[Auth] Rejected token: %v", err)
+		return nil, status.Error(codes.Unauthenticated, "invalid bearer token")
+	}
+	return claims, nil
+}
+
+// Authorize checks that the caller may make req on method, the full name of an
+// RPC such as "/trainticketing.service.TrainTicketingService/PurchaseTicket". It fails
+// with a PermissionDenied status.
+func (a *Authorizer) Authorize(method string, claims *Claims, req any) error {
+	if p, ok := policies[method]; !ok || !p.allows(a.owners, claims, req) {
+		return denied(method, claims)
+	}
+	return nil
+}
+
+func denied(method string, claims *Claims) error {
+	log.Printf("[Auth] Denied %s to %s %s", method, claims.Role, claims.Email)
+	return status.Errorf(codes.PermissionDenied, "%s may not make this %s call", claims.Role, method)
+}
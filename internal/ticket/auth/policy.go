@@ -0,0 +1,124 @@
+package auth
+
+import (
+	"slices"
+
+	ticket "github.com/talk2sohail/train-ticket-api/internal/common/genproto/ticket"
+)
+
+// Owners tells the authorizer which passenger the tickets, holds, waitlist
+// entries and refunds named in a request belong to. ok is false for an unknown
+// ID; such requests are let through so that the service can report the ID as
+// not found.
+type Owners interface {
+	TicketOwner(ticketID string) (email string, ok bool)
+	HoldOwner(holdID string) (email string, ok bool)
+	WaitlistOwner(waitlistID string) (email string, ok bool)
+	RefundOwner(refundID string) (email string, ok bool)
+}
+
+// policy decides who may call an RPC.
+type policy struct {
+	roles []Role // Roles allowed to call the RPC.
+	// owns reports whether a passenger may make the request; staff and admins
+	// are not subject to it. A nil owns lets any passenger through.
+	owns func(owners Owners, email string, req any) bool
+}
+
+var (
+	everyone  = []Role{RolePassenger, RoleStaff, RoleAdmin}
+	staff     = []Role{RoleStaff, RoleAdmin}
+	adminOnly = []Role{RoleAdmin}
+)
+
+// policies lists the policy of every RPC. An RPC missing from the table is refused.
+var policies = map[string]policy{
+	ticket.TrainTicketingService_PurchaseTicket_FullMethodName: {everyone, func(_ Owners, email string, req any) bool {
+		return sameEmail(email, req.(*ticket.PurchaseTicketRequest).GetUser().GetEmail())
+	}},
+	// A passenger may book for a party they travel with.
+	ticket.TrainTicketingService_PurchaseGroupTicket_FullMethodName: {everyone, func(_ Owners, email string, req any) bool {
+		return slices.ContainsFunc(req.(*ticket.PurchaseGroupTicketRequest).GetPassengers(), func(u *ticket.User) bool {
+			return sameEmail(email, u.GetEmail())
+		})
+	}},
+	ticket.TrainTicketingService_HoldSeat_FullMethodName: {everyone, func(_ Owners, email string, req any) bool {
+		return sameEmail(email, req.(*ticket.HoldSeatRequest).GetUser().GetEmail())
+	}},
+	ticket.TrainTicketingService_ConfirmHold_FullMethodName: {everyone, func(owners Owners, email string, req any) bool {
+		return owned(email)(owners.HoldOwner(req.(*ticket.ConfirmHoldRequest).GetHoldId()))
+	}},
+	ticket.TrainTicketingService_JoinWaitlist_FullMethodName: {everyone, func(_ Owners, email string, req any) bool {
+		return sameEmail(email, req.(*ticket.JoinWaitlistRequest).GetUser().GetEmail())
+	}},
+	ticket.TrainTicketingService_GetWaitlistStatus_FullMethodName: {everyone, func(owners Owners, email string, req any) bool {
+		return owned(email)(owners.WaitlistOwner(req.(*ticket.GetWaitlistStatusRequest).GetWaitlistId()))
+	}},
+	// The availability stream carries seat numbers only, no passenger data.
+	ticket.TrainTicketingService_WatchAvailability_FullMethodName: {everyone, nil},
+	ticket.TrainTicketingService_GetReceiptDetails_FullMethodName: {everyone, func(owners Owners, email string, req any) bool {
+		r := req.(*ticket.GetReceiptDetailsRequest)
+		return ownsIdentified(owners, email, r.GetTicketId(), r.GetEmail())
+	}},
+	ticket.TrainTicketingService_GetUsersBySection_FullMethodName: {staff, nil},
+	ticket.TrainTicketingService_RemoveUser_FullMethodName: {everyone, func(owners Owners, email string, req any) bool {
+		r := req.(*ticket.RemoveUserRequest)
+		return ownsIdentified(owners, email, r.GetTicketId(), r.GetEmail())
+	}},
+	ticket.TrainTicketingService_CancelTicket_FullMethodName: {everyone, func(owners Owners, email string, req any) bool {
+		return owned(email)(owners.TicketOwner(req.(*ticket.CancelTicketRequest).GetTicketId()))
+	}},
+	ticket.TrainTicketingService_GetRefund_FullMethodName: {everyone, func(owners Owners, email string, req any) bool {
+		return owned(email)(owners.RefundOwner(req.(*ticket.GetRefundRequest).GetRefundId()))
+	}},
+	ticket.TrainTicketingService_ListTicketsForUser_FullMethodName: {everyone, func(_ Owners, email string, req any) bool {
+		return sameEmail(email, req.(*ticket.ListTicketsForUserRequest).GetEmail())
+	}},
+	ticket.TrainTicketingService_ModifyUserSeat_FullMethodName: {everyone, func(owners Owners, email string, req any) bool {
+		r := req.(*ticket.ModifyUserSeatRequest)
+		return ownsIdentified(owners, email, r.GetTicketId(), r.GetEmail())
+	}},
+	ticket.TrainTicketingService_GetTicketHistory_FullMethodName: {everyone, func(owners Owners, email string, req any) bool {
+		return owned(email)(owners.TicketOwner(req.(*ticket.GetTicketHistoryRequest).GetTicketId()))
+	}},
+	ticket.TrainTicketingService_GetSeatOccupant_FullMethodName: {staff, nil},
+	ticket.TrainTicketingService_CreateJourney_FullMethodName:   {adminOnly, nil},
+	ticket.TrainTicketingService_ListJourneys_FullMethodName:    {everyone, nil},
+	ticket.TrainTicketingService_QuoteFare_FullMethodName:       {everyone, nil},
+}
+
+// allows reports whether the caller may make req on an RPC with this policy.
+func (p policy) allows(owners Owners, claims *Claims, req any) bool {
+	if !slices.Contains(p.roles, claims.Role) {
+		return false
+	}
+	if claims.Role != RolePassenger || p.owns == nil {
+		return true
+	}
+	return p.owns(owners, claims.Email, req)
+}
+
+// ownsIdentified reports whether a request naming a ticket by ID or by email touches the caller's own ticket.
+// A request naming neither is let through for the handler to reject.
+func ownsIdentified(owners Owners, email, ticketID, identifiedEmail string) bool {
+	switch {
+	case ticketID != "":
+		return owned(email)(owners.TicketOwner(ticketID))
+	case identifiedEmail != "":
+		return sameEmail(email, identifiedEmail)
+	default:
+		return true
+	}
+}
+
+// owned returns a check of an Owners lookup against the caller's email.
+func owned(email string) func(owner string, ok bool) bool {
+	return func(owner string, ok bool) bool {
+		return !ok || sameEmail(email, owner)
+	}
+}
+
+// sameEmail compares emails exactly, as tickets are indexed by the email they were bought under.
+func sameEmail(a, b string) bool {
+	return a != "" && a == b
+}
@@ -0,0 +1,126 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Key is a key tokens may be signed with.
+type Key struct {
+	ID        string            // Matched against the token's "kid" header; may be empty when it is the only key.
+	Algorithm string            // "HS256", "HS384", "HS512" or "EdDSA".
+	Secret    []byte            // Shared secret of an HMAC key.
+	PublicKey ed25519.PublicKey // Public key of an Ed25519 key.
+}
+
+func (k Key) validate() error {
+	switch k.Algorithm {
+	case jwt.SigningMethodHS256.Alg(), jwt.SigningMethodHS384.Alg(), jwt.SigningMethodHS512.Alg():
+		if len(k.Secret) == 0 {
+			return fmt.Errorf("key %q: %s needs a secret", k.ID, k.Algorithm)
+		}
+	case jwt.SigningMethodEdDSA.Alg():
+		if len(k.PublicKey) != ed25519.PublicKeySize {
+			return fmt.Errorf("key %q: EdDSA needs an Ed25519 public key", k.ID)
+		}
+	default:
+		return fmt.Errorf("key %q: unsupported algorithm %q", k.ID, k.Algorithm)
+	}
+	return nil
+}
+
+func (k Key) material() any {
+	if k.Algorithm == jwt.SigningMethodEdDSA.Alg() {
+		return k.PublicKey
+	}
+	return k.Secret
+}
+
+// Verifier checks bearer tokens against the configured keys.
+type Verifier struct {
+	keys   map[string]Key
+	parser *jwt.Parser
+}
+
+// NewVerifier creates a Verifier accepting tokens signed with any of keys. Tokens must
+// carry an expiry and, when issuer or audience are not empty, that issuer and audience.
+func NewVerifier(issuer, audience string, keys ...Key) (*Verifier, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("no signing keys configured")
+	}
+	v := &Verifier{keys: make(map[string]Key, len(keys))}
+	var methods []string
+	for _, key := range keys {
+		if err := key.validate(); err != nil {
+			return nil, err
+		}
+		if _, dup := v.keys[key.ID]; dup {
+			return nil, fmt.Errorf("key %q is configured twice", key.ID)
+		}
+		if key.ID == "" && len(keys) > 1 {
+			return nil, errors.New("every key needs an ID when several are configured")
+		}
+		v.keys[key.ID] = key
+		methods = append(methods, key.Algorithm)
+	}
+
+	opts := []jwt.ParserOption{jwt.WithValidMethods(methods), jwt.WithExpirationRequired()}
+	if issuer != "" {
+		opts = append(opts, jwt.WithIssuer(issuer))
+	}
+	if audience != "" {
+		opts = append(opts, jwt.WithAudience(audience))
+	}
+	v.parser = jwt.NewParser(opts...)
+	return v, nil
+}
+
+// Verify checks a token's signature and claims and returns the claims.
+func (v *Verifier) Verify(token string) (*Claims, error) {
+	claims := &Claims{}
+	if _, err := v.parser.ParseWithClaims(token, claims, v.keyFor); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// keyFor picks the key named by the token's "kid" header, refusing a key of
+// another algorithm so that, say, an Ed25519 public key is never used as an HMAC secret.
+func (v *Verifier) keyFor(token *jwt.Token) (any, error) {
+	kid, _ := token.Header["kid"].(string)
+	key, ok := v.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown key %q", kid)
+	}
+	if token.Method.Alg() != key.Algorithm {
+		return nil, fmt.Errorf("key %q does not sign with %s", kid, token.Method.Alg())
+	}
+	return key.material(), nil
+}
+
+// LoadEd25519PublicKey reads a PEM-encoded PKIX Ed25519 public key.
+func LoadEd25519PublicKey(path string) (ed25519.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%s: no PEM block", path)
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	publicKey, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("%s: not an Ed25519 public key", path)
+	}
+	return publicKey, nil
+}
@@ -0,0 +1,119 @@
+// Package cancellation decides how much of a ticket's price is refunded when it is
+// cancelled, from how long before departure the cancellation comes.
+package cancellation
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/money"
+)
+
+// Rules applied outside the configured ones, as recorded on refunds.
+const (
+	RuleDeparted = "departed" // Cancelled once the train has left; nothing is refunded.
+	RuleUndated  = "undated"  // The journey has no departure time to measure against; refunded in full.
+	RuleNone     = "none"     // Cancelled later than every configured rule allows; nothing is refunded.
+)
+
+// Config describes a cancellation policy as read from the server configuration.
+// Without rules every ticket cancelled before departure is refunded in full.
+type Config struct {
+	Rules []Rule `json:"rules"`
+}
+
+// Rule refunds RefundPercent of the price of tickets cancelled at least MinHoursBefore
+// departure. When several apply, the one with the most hours wins.
+type Rule struct {
+	Name           string      `json:"name"` // Recorded on refunds; the hours, e.g. ">=24h", when empty.
+	MinHoursBefore float64     `json:"min_hours_before"`
+	RefundPercent  json.Number `json:"refund_percent"` // Between 0 and 100, e.g. 50 or 12.5.
+}
+
+// Policy decides refunds. A nil *Policy refunds every ticket cancelled before
+// departure in full. Policies are not changed once built and are safe for concurrent use.
+type Policy struct {
+	rules []rule // Most time first.
+}
+
+// rule is a Rule with its threshold as a duration and its percentage as an exact share.
+type rule struct {
+	name   string
+	before time.Duration
+	share  *big.Rat
+}
+
+// Shares of the price refunded outside the configured rules. They are never changed.
+var (
+	all     = big.NewRat(1, 1)
+	nothing = new(big.Rat)
+)
+
+// Build checks a cancellation config and prepares it for deciding refunds.
+func Build(cfg Config) (*Policy, error) {
+	p := &Policy{}
+	seen := make(map[time.Duration]bool, len(cfg.Rules))
+	for i, r := range cfg.Rules {
+		if r.MinHoursBefore < 0 {
+			return nil, fmt.Errorf("rules[%d].min_hours_before must not be negative", i)
+		}
+		percent, ok := new(big.Rat).SetString(r.RefundPercent.String())
+		if !ok || percent.Sign() < 0 || percent.Cmp(big.NewRat(100, 1)) > 0 {
+			return nil, fmt.Errorf("rules[%d].refund_percent must be between 0 and 100", i)
+		}
+		before := time.Duration(r.MinHoursBefore * float64(time.Hour))
+		if seen[before] {
+			return nil, fmt.Errorf("rules[%d] repeats min_hours_before %g", i, r.MinHoursBefore)
+		}
+		seen[before] = true
+		if r.Name == "" {
+			r.Name = ">=" + strconv.FormatFloat(r.MinHoursBefore, 'f', -1, 64) + "h"
+		}
+		p.rules = append(p.rules, rule{name: r.Name, before: before, share: percent.Quo(percent, big.NewRat(100, 1))})
+	}
+	sort.Slice(p.rules, func(i, j int) bool { return p.rules[i].before > p.rules[j].before })
+	return p, nil
+}
+
+// Decision is the share of its price a cancelled ticket gets back, and the rule that set it.
+type Decision struct {
+	Share *big.Rat // Between 0 and 1, e.g. 1/2 for half the price. Not to be changed.
+	Rule  string   // Name of the configured rule, one of the Rule values, or empty without rules.
+}
+
+// Decide returns the refund of a ticket on a train departing at departure, cancelled
+// at cancelledAt. departure is zero for undated journeys.
+func (p *Policy) Decide(departure, cancelledAt time.Time) Decision {
+	if departure.IsZero() {
+		return Decision{Share: all, Rule: RuleUndated}
+	}
+	if !cancelledAt.Before(departure) {
+		return Decision{Share: nothing, Rule: RuleDeparted}
+	}
+	if p == nil || len(p.rules) == 0 {
+		return Decision{Share: all}
+	}
+	left := departure.Sub(cancelledAt)
+	for _, r := range p.rules {
+		if left >= r.before {
+			return Decision{Share: r.share, Rule: r.name}
+		}
+	}
+	return Decision{Share: nothing, Rule: RuleNone}
+}
+
+// Percent returns the share as the nearest percentage, e.g. 12.5, for refunds that record it as a double.
+func (d Decision) Percent() float64 {
+	percent, _ := new(big.Rat).Mul(d.Share, big.NewRat(100, 1)).Float64()
+	return percent
+}
+
+// Refund returns the part of paid the decision gives back, rounded to the nearest
+// minor unit, halves away from zero.
+func (d Decision) Refund(paid money.Money) money.Money {
+	return paid.Share(d.Share)
+}
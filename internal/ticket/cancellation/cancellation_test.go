@@ -0,0 +1,75 @@
+package cancellation
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/money"
+)
+
+func TestUnit_Decide(t *testing.T) {
+	departure := time.Date(2024, 5, 10, 8, 0, 0, 0, time.UTC)
+	policy, err := Build(Config{Rules: []Rule{
+		{MinHoursBefore: 2, RefundPercent: "25"},
+		{Name: "flexible", MinHoursBefore: 24, RefundPercent: "100"},
+		{MinHoursBefore: 6, RefundPercent: "50"},
+		{MinHoursBefore: 1, RefundPercent: "12.5"},
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error building the policy: %v", err)
+	}
+	paid := money.Money{Minor: 2050, Currency: money.USD}
+
+	tests := []struct {
+		name        string
+		policy      *Policy
+		departure   time.Time
+		cancelledAt time.Time
+		percent     float64
+		rule        string
+		refund      int64
+	}{
+		{"Well ahead", policy, departure, departure.Add(-48 * time.Hour), 100, "flexible", 2050},
+		{"On a rule's boundary", policy, departure, departure.Add(-24 * time.Hour), 100, "flexible", 2050},
+		{"Partial refund", policy, departure, departure.Add(-12 * time.Hour), 50, ">=6h", 1025},
+		{"Rounded to the cent", policy, departure, departure.Add(-3 * time.Hour), 25, ">=2h", 513},
+		{"Fractional percentage", policy, departure, departure.Add(-time.Hour), 12.5, ">=1h", 256},
+		{"Too late for every rule", policy, departure, departure.Add(-time.Minute), 0, RuleNone, 0},
+		{"Departed", policy, departure, departure, 0, RuleDeparted, 0},
+		{"Undated journey", policy, time.Time{}, departure, 100, RuleUndated, 2050},
+		{"No rules", nil, departure, departure.Add(-time.Minute), 100, "", 2050},
+		{"No rules after departure", nil, departure, departure.Add(time.Minute), 0, RuleDeparted, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := tt.policy.Decide(tt.departure, tt.cancelledAt)
+			if d.Percent() != tt.percent || d.Rule != tt.rule {
+				t.Errorf("expected %g%% under %q, got %+v", tt.percent, tt.rule, d)
+			}
+			if got := d.Refund(paid); got != (money.Money{Minor: tt.refund, Currency: money.USD}) {
+				t.Errorf("expected a refund of %d cents, got %v", tt.refund, got)
+			}
+		})
+	}
+}
+
+func TestUnit_Build(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules []Rule
+		want  string
+	}{
+		{"Negative hours", []Rule{{MinHoursBefore: -1, RefundPercent: "50"}}, "rules[0].min_hours_before"},
+		{"Refund over 100%", []Rule{{MinHoursBefore: 24, RefundPercent: "120"}}, "rules[0].refund_percent"},
+		{"Refund not a number", []Rule{{MinHoursBefore: 24, RefundPercent: "all"}}, "rules[0].refund_percent"},
+		{"Repeated hours", []Rule{{MinHoursBefore: 24, RefundPercent: "100"}, {MinHoursBefore: 24, RefundPercent: "50"}}, "rules[1]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Build(Config{Rules: tt.rules}); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected an error about %s, got %v", tt.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package certs_test
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/talk2sohail/train-ticket-api/client"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/certs"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/certs/certstest"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/handler"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// serverName is the name clients dial, and so the name server certificates must carry.
+const serverName = "bufnet"
+
+// serve starts a ticket server with the reloader's TLS config and returns a dialer for it.
+func serve(t *testing.T, r *certs.Reloader) grpc.DialOption {
+	t.Helper()
+	srv := grpc.NewServer(grpc.Creds(credentials.NewTLS(certs.ServerConfig(r))))
+	handler.RegisterTicketServiceServer(srv, service.NewTicketService())
+	lis := bufconn.Listen(1 << 20)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+	return grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) })
+}
+
+// call dials a fresh connection with opts and makes a call on it.
+func call(t *testing.T, opts ...grpc.DialOption) error {
+	t.Helper()
+	c, err := client.NewTrainTicketClient("passthrough:///"+serverName, opts...)
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	defer c.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err = c.ListJourneys(ctx, "")
+	return err
+}
+
+func tlsOption(t *testing.T, caFile string) grpc.DialOption {
+	t.Helper()
+	opt, err := client.WithTLS(caFile)
+	if err != nil {
+		t.Fatalf("unexpected error loading CA: %v", err)
+	}
+	return opt
+}
+
+func mutualTLSOption(t *testing.T, caFile, certFile, keyFile string) grpc.DialOption {
+	t.Helper()
+	opt, err := client.WithMutualTLS(caFile, certFile, keyFile)
+	if err != nil {
+		t.Fatalf("unexpected error loading client certificate: %v", err)
+	}
+	return opt
+}
+
+func newReloader(t *testing.T, certFile, keyFile, caFile string) *certs.Reloader {
+	t.Helper()
+	r, err := certs.NewReloader(certFile, keyFile, caFile)
+	if err != nil {
+		t.Fatalf("unexpected error loading certificates: %v", err)
+	}
+	return r
+}
+
+// touch moves a file's modification time forward so that a rewrite within the
+// file system's timestamp granularity is still seen as a change.
+func touch(t *testing.T, path string) {
+	t.Helper()
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatalf("touch %s: %v", path, err)
+	}
+}
+
+func copyFile(t *testing.T, from, to string) {
+	t.Helper()
+	data, err := os.ReadFile(from)
+	if err != nil {
+		t.Fatalf("read %s: %v", from, err)
+	}
+	if err := os.WriteFile(to, data, 0o600); err != nil {
+		t.Fatalf("write %s: %v", to, err)
+	}
+}
+
+func TestUnit_TLS(t *testing.T) {
+	ca := certstest.NewCA(t, "Ticket CA")
+	certFile, keyFile := ca.Issue(t, "server", serverName)
+	dialer := serve(t, newReloader(t, certFile, keyFile, ""))
+
+	t.Run("Trusted server", func(t *testing.T) {
+		if err := call(t, dialer, tlsOption(t, ca.CertFile)); err != nil {
+			t.Errorf("expected the call to succeed, got %v", err)
+		}
+	})
+
+	t.Run("Plaintext client", func(t *testing.T) {
+		if err := call(t, dialer); err == nil {
+			t.Errorf("expected a plaintext call to fail")
+		}
+	})
+
+	t.Run("Server signed by an unknown CA", func(t *testing.T) {
+		other := certstest.NewCA(t, "Other CA")
+		if err := call(t, dialer, tlsOption(t, other.CertFile)); err == nil {
+			t.Errorf("expected the call to fail")
+		}
+	})
+
+	t.Run("Wrong server name", func(t *testing.T) {
+		certFile, keyFile := ca.Issue(t, "elsewhere", "elsewhere.example.com")
+		dialer := serve(t, newReloader(t, certFile, keyFile, ""))
+		if err := call(t, dialer, tlsOption(t, ca.CertFile)); err == nil {
+			t.Errorf("expected the call to fail")
+		}
+	})
+}
+
+func TestUnit_MutualTLS(t *testing.T) {
+	ca := certstest.NewCA(t, "Ticket CA")
+	certFile, keyFile := ca.Issue(t, "server", serverName)
+	dialer := serve(t, newReloader(t, certFile, keyFile, ca.CertFile))
+
+	t.Run("Client with a certificate", func(t *testing.T) {
+		clientCert, clientKey := ca.Issue(t, "client")
+		if err := call(t, dialer, mutualTLSOption(t, ca.CertFile, clientCert, clientKey)); err != nil {
+			t.Errorf("expected the call to succeed, got %v", err)
+		}
+	})
+
+	t.Run("Client without a certificate", func(t *testing.T) {
+		if err := call(t, dialer, tlsOption(t, ca.CertFile)); err == nil {
+			t.Errorf("expected the call to fail")
+		}
+	})
+
+	t.Run("Client certificate signed by an unknown CA", func(t *testing.T) {
+		clientCert, clientKey := certstest.NewCA(t, "Other CA").Issue(t, "client")
+		if err := call(t, dialer, mutualTLSOption(t, ca.CertFile, clientCert, clientKey)); err == nil {
+			t.Errorf("expected the call to fail")
+		}
+	})
+}
+
+func TestUnit_CertificateReload(t *testing.T) {
+	t.Run("Rotated server certificate", func(t *testing.T) {
+		oldCA, newCA := certstest.NewCA(t, "Old CA"), certstest.NewCA(t, "New CA")
+		certFile, keyFile := oldCA.Issue(t, "server", serverName)
+		dialer := serve(t, newReloader(t, certFile, keyFile, ""))
+		if err := call(t, dialer, tlsOption(t, newCA.CertFile)); err == nil {
+			t.Fatalf("expected the call to fail before rotation")
+		}
+
+		newCA.IssueTo(t, certFile, keyFile, "server", serverName)
+		touch(t, certFile)
+		if err := call(t, dialer, tlsOption(t, newCA.CertFile)); err != nil {
+			t.Errorf("expected the rotated certificate to be served, got %v", err)
+		}
+		if err := call(t, dialer, tlsOption(t, oldCA.CertFile)); err == nil {
+			t.Errorf("expected the old certificate to be retired")
+		}
+	})
+
+	t.Run("Rotated client CA", func(t *testing.T) {
+		ca, clientCA := certstest.NewCA(t, "Ticket CA"), certstest.NewCA(t, "Client CA")
+		certFile, keyFile := ca.Issue(t, "server", serverName)
+		clientCAFile := filepath.Join(t.TempDir(), "clients.pem")
+		copyFile(t, ca.CertFile, clientCAFile)
+		dialer := serve(t, newReloader(t, certFile, keyFile, clientCAFile))
+		clientCert, clientKey := clientCA.Issue(t, "client")
+		if err := call(t, dialer, mutualTLSOption(t, ca.CertFile, clientCert, clientKey)); err == nil {
+			t.Fatalf("expected the call to fail before rotation")
+		}
+
+		copyFile(t, clientCA.CertFile, clientCAFile)
+		touch(t, clientCAFile)
+		if err := call(t, dialer, mutualTLSOption(t, ca.CertFile, clientCert, clientKey)); err != nil {
+			t.Errorf("expected the client CA to be reloaded, got %v", err)
+		}
+	})
+
+	t.Run("Incomplete rotation", func(t *testing.T) {
+		ca := certstest.NewCA(t, "Ticket CA")
+		certFile, keyFile := ca.Issue(t, "server", serverName)
+		dialer := serve(t, newReloader(t, certFile, keyFile, ""))
+
+		// A new key without its certificate does not match; the old pair stays in use.
+		_, otherKey := ca.Issue(t, "other", serverName)
+		copyFile(t, otherKey, keyFile)
+		touch(t, keyFile)
+		if err := call(t, dialer, tlsOption(t, ca.CertFile)); err != nil {
+			t.Errorf("expected the previous certificate to be served, got %v", err)
+		}
+	})
+}
+
+func TestUnit_NewReloader(t *testing.T) {
+	ca := certstest.NewCA(t, "Ticket CA")
+	certFile, keyFile := ca.Issue(t, "server", serverName)
+
+	for name, files := range map[string][3]string{
+		"certificate without key": {certFile, "", ""},
+		"missing certificate":     {certFile + ".missing", keyFile, ""},
+		"key as CA":               {certFile, keyFile, keyFile},
+		"mismatched pair":         {ca.CertFile, keyFile, ""},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := certs.NewReloader(files[0], files[1], files[2]); err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
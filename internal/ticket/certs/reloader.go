@@ -0,0 +1,93 @@
+// Package certs loads the TLS certificates of the ticket server and its
+// clients from PEM files and rereads them when they are rotated on disk.
+package certs
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/filewatch"
+)
+
+// Reloader serves a certificate and, optionally, a CA pool read from PEM files.
+// The files are checked before every handshake, so a rotated certificate is
+// picked up without a restart. A rotation caught half way, say with the key
+// written before the certificate, keeps the previous pair in use until it is complete.
+type Reloader struct {
+	certFile, caFile string // Empty when the reloader has no certificate or no CA pool.
+	files            *filewatch.Value[loaded]
+}
+
+// loaded is what one read of the files gave.
+type loaded struct {
+	cert *tls.Certificate
+	pool *x509.CertPool
+}
+
+// NewReloader reads the certificate in certFile with its private key in
+// keyFile and, when caFile is not empty, the CA certificates in caFile.
+// Either certificate part may be empty when only a CA pool is needed.
+func NewReloader(certFile, keyFile, caFile string) (*Reloader, error) {
+	if (certFile == "") != (keyFile == "") {
+		return nil, errors.New("a certificate needs both a certificate and a key file")
+	}
+	var files []string
+	for _, f := range []string{certFile, keyFile, caFile} {
+		if f != "" {
+			files = append(files, f)
+		}
+	}
+	value, err := filewatch.New("certificates", func() (loaded, error) {
+		return load(certFile, keyFile, caFile)
+	}, files...)
+	if err != nil {
+		return nil, err
+	}
+	return &Reloader{certFile: certFile, caFile: caFile, files: value}, nil
+}
+
+// load reads the certificate pair and the CA pool, skipping those not configured.
+func load(certFile, keyFile, caFile string) (loaded, error) {
+	var l loaded
+	if certFile != "" {
+		c, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return loaded{}, fmt.Errorf("load certificate %s: %w", certFile, err)
+		}
+		l.cert = &c
+	}
+	if caFile != "" {
+		pool, err := LoadCertPool(caFile)
+		if err != nil {
+			return loaded{}, err
+		}
+		l.pool = pool
+	}
+	return l, nil
+}
+
+// Certificate returns the current certificate.
+func (r *Reloader) Certificate() *tls.Certificate {
+	return r.files.Get().cert
+}
+
+// CertPool returns the current CA pool.
+func (r *Reloader) CertPool() *x509.CertPool {
+	return r.files.Get().pool
+}
+
+// LoadCertPool reads the PEM-encoded CA certificates in path.
+func LoadCertPool(path string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("%s: no PEM certificates", path)
+	}
+	return pool, nil
+}
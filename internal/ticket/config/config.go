@@ -0,0 +1,423 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	ticket "github.com/talk2sohail/train-ticket-api/internal/common/genproto/ticket"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/auth"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/cancellation"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/certs"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/fare"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/layout"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/money"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/payment"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/ratelimit"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	// StorageMemory keeps bookings in process memory only.
+	StorageMemory = "memory"
+	// StorageFile persists bookings to a write-ahead log and snapshots on local disk.
+	StorageFile = "file"
+
+	// DefaultDataDir is where the file backend stores bookings when no directory is configured.
+	DefaultDataDir = "data"
+	// DefaultSnapshotEvery is how many log records the file backend writes between snapshots.
+	DefaultSnapshotEvery = 1000
+
+	// PaymentFake takes payment with an in-process fake processor.
+	PaymentFake = "fake"
+
+	// DefaultHoldTTLSeconds is how long a seat hold lasts when no TTL is configured.
+	DefaultHoldTTLSeconds = 600
+	// DefaultHoldReapIntervalSeconds is how often expired holds are released.
+	DefaultHoldReapIntervalSeconds = 30
+
+	// DefaultIdempotencyWindowSeconds is how long responses to idempotency keys are replayed.
+	DefaultIdempotencyWindowSeconds = 24 * 60 * 60
+
+	// DefaultHTTPAddr is where the REST gateway listens when no address is configured.
+	DefaultHTTPAddr = ":8080"
+)
+
+// Config holds the settings used to start the ticket gRPC server.
+type Config struct {
+	Storage       StorageConfig            `json:"storage"`
+	Layouts       map[string]layout.Config `json:"layouts"`        // Train layouts journeys can be created with, keyed by name.
+	DefaultLayout string                   `json:"default_layout"` // Layout of the default journey; the standard A/B layout when empty.
+	Holds         HoldConfig               `json:"holds"`
+	HTTP          HTTPConfig               `json:"http"`
+	Auth          AuthConfig               `json:"auth"`
+	TLS           TLSConfig                `json:"tls"`
+	Idempotency   IdempotencyConfig        `json:"idempotency"`
+	RateLimits    RateLimitConfig          `json:"rate_limits"`
+	Quotas        QuotaConfig              `json:"quotas"`
+	Fares         FareConfig               `json:"fares"`
+	Currency      CurrencyConfig           `json:"currency"`
+	Payments      PaymentConfig            `json:"payments"`
+	Cancellation  cancellation.Config      `json:"cancellation"` // Refund rules; tickets cancelled before departure are refunded in full without rules.
+}
+
+// CancellationPolicy builds the configured cancellation policy.
+func (c Config) CancellationPolicy() (*cancellation.Policy, error) {
+	policy, err := cancellation.Build(c.Cancellation)
+	if err != nil {
+		return nil, fmt.Errorf("cancellation: %w", err)
+	}
+	return policy, nil
+}
+
+// PaymentConfig selects the processor purchased tickets are paid through.
+type PaymentConfig struct {
+	Provider string            `json:"provider"` // "fake"; tickets are booked without payment when empty.
+	Fake     FakePaymentConfig `json:"fake"`
+}
+
+// FakePaymentConfig scripts the fake processor.
+type FakePaymentConfig struct {
+	LatencyMillis int               `json:"latency_ms"` // How long each call to the processor takes.
+	Declines      map[string]string `json:"declines"`   // Decline codes keyed by the payment methods they decline, e.g. {"tok_declined": "card_declined"}.
+}
+
+// Processor returns the configured payment provider, or nil when tickets are booked without payment.
+func (p PaymentConfig) Processor() (payment.Provider, error) {
+	switch p.Provider {
+	case "":
+		return nil, nil
+	case PaymentFake:
+		if p.Fake.LatencyMillis < 0 {
+			return nil, fmt.Errorf("payments.fake.latency_ms must not be negative")
+		}
+		fake := payment.NewFake()
+		fake.SetLatency(time.Duration(p.Fake.LatencyMillis) * time.Millisecond)
+		for method, code := range p.Fake.Declines {
+			if code == "" {
+				return nil, fmt.Errorf("payments.fake.declines.%s must give a decline code", method)
+			}
+			fake.DeclineMethod(method, code)
+		}
+		return fake, nil
+	default:
+		return nil, fmt.Errorf("unknown payments.provider %q", p.Provider)
+	}
+}
+
+// CurrencyConfig sets the exchange rates prices paid in other currencies than the fare table's are converted at.
+type CurrencyConfig struct {
+	RatesFile string `json:"rates_file"` // JSON rates table; prices must be paid in the fare table's currency when empty.
+}
+
+// Rates reads the configured exchange rates, or returns nil when none are configured.
+func (c CurrencyConfig) Rates() (*money.Rates, error) {
+	if c.RatesFile == "" {
+		return nil, nil
+	}
+	cfg, err := money.LoadRates(c.RatesFile)
+	if err != nil {
+		return nil, fmt.Errorf("currency: %w", err)
+	}
+	rates, err := money.BuildRates(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("currency: %w", err)
+	}
+	return rates, nil
+}
+
+// FareConfig selects the fare table tickets are priced with.
+type FareConfig struct {
+	TableFile string `json:"table_file"` // JSON fare table, reread when it changes; the built-in table of fare.Default when empty.
+}
+
+// Source returns the function giving the fare table in force: the table file as last
+// read when one is configured, or the built-in table.
+func (f FareConfig) Source() (func() *fare.Table, error) {
+	if f.TableFile != "" {
+		r, err := fare.NewReloader(f.TableFile)
+		if err != nil {
+			return nil, fmt.Errorf("fares: %w", err)
+		}
+		return r.Table, nil
+	}
+	table, err := fare.Build(fare.Default())
+	if err != nil {
+		return nil, fmt.Errorf("fares: %w", err)
+	}
+	return func() *fare.Table { return table }, nil
+}
+
+// RateLimitConfig throttles the calls each client makes, counting against the
+// authenticated caller or, without authentication, the client's address.
+type RateLimitConfig struct {
+	Default *RateLimit           `json:"default"` // Limit of RPCs without their own; they are unlimited when absent.
+	RPCs    map[string]RateLimit `json:"rpcs"`    // Limits of individual RPCs, keyed by RPC name such as "GetUsersBySection".
+}
+
+// RateLimit is the rate at which a client may call an RPC.
+type RateLimit struct {
+	PerSecond float64 `json:"per_second"` // Sustained calls per second.
+	Burst     int     `json:"burst"`      // Calls that may be made at once after a quiet period.
+}
+
+// Enabled reports whether any RPC is rate limited.
+func (r RateLimitConfig) Enabled() bool {
+	return r.Default != nil || len(r.RPCs) > 0
+}
+
+// Limits returns the fallback and per-RPC limits in the form the limiter takes.
+func (r RateLimitConfig) Limits() (*ratelimit.Limit, map[string]ratelimit.Limit) {
+	var fallback *ratelimit.Limit
+	if r.Default != nil {
+		fallback = &ratelimit.Limit{PerSecond: r.Default.PerSecond, Burst: r.Default.Burst}
+	}
+	limits := make(map[string]ratelimit.Limit, len(r.RPCs))
+	for rpc, limit := range r.RPCs {
+		limits[rpc] = ratelimit.Limit{PerSecond: limit.PerSecond, Burst: limit.Burst}
+	}
+	return fallback, limits
+}
+
+func (r RateLimit) validate(name string) error {
+	if r.PerSecond <= 0 {
+		return fmt.Errorf("%s.per_second must be positive", name)
+	}
+	if r.Burst < 1 {
+		return fmt.Errorf("%s.burst must be at least 1", name)
+	}
+	return nil
+}
+
+// QuotaConfig caps what one passenger may hold.
+type QuotaConfig struct {
+	MaxActiveTickets int `json:"max_active_tickets"` // Active tickets and unconfirmed holds per email; unlimited when 0.
+}
+
+// IdempotencyConfig controls how retried mutating requests carrying an idempotency key are answered.
+type IdempotencyConfig struct {
+	WindowSeconds int `json:"window_seconds"` // How long the first response to a key is replayed.
+}
+
+// Window returns the replay window as a duration.
+func (i IdempotencyConfig) Window() time.Duration {
+	return time.Duration(i.WindowSeconds) * time.Second
+}
+
+// TLSConfig secures the gRPC server and the REST gateway with TLS. The files are
+// reread when they change, so rotated certificates are served without a restart.
+type TLSConfig struct {
+	CertFile     string `json:"cert_file"`      // PEM certificate of the server; TLS is off when empty.
+	KeyFile      string `json:"key_file"`       // PEM private key of the certificate.
+	ClientCAFile string `json:"client_ca_file"` // PEM CAs client certificates must be signed by; enables mutual TLS.
+}
+
+// Enabled reports whether the server is served over TLS.
+func (t TLSConfig) Enabled() bool {
+	return t.CertFile != ""
+}
+
+// AuthConfig controls bearer token authentication of gRPC calls and REST requests.
+type AuthConfig struct {
+	Keys     []AuthKeyConfig `json:"keys"`     // Keys tokens may be signed with; authentication is off when empty.
+	Issuer   string          `json:"issuer"`   // Required "iss" claim, if set.
+	Audience string          `json:"audience"` // Required "aud" claim, if set.
+}
+
+// Enabled reports whether callers must present a bearer token.
+func (a AuthConfig) Enabled() bool {
+	return len(a.Keys) > 0
+}
+
+// AuthKeyConfig is a key tokens may be signed with.
+type AuthKeyConfig struct {
+	ID            string `json:"id"`              // Matched against the token's "kid" header.
+	Algorithm     string `json:"algorithm"`       // "HS256", "HS384", "HS512" or "EdDSA".
+	SecretFile    string `json:"secret_file"`     // File holding the shared secret of an HMAC key.
+	PublicKeyFile string `json:"public_key_file"` // PEM file holding the Ed25519 public key of an EdDSA key.
+}
+
+// SigningKeys reads the configured keys.
+func (a AuthConfig) SigningKeys() ([]auth.Key, error) {
+	keys := make([]auth.Key, 0, len(a.Keys))
+	for i, k := range a.Keys {
+		key := auth.Key{ID: k.ID, Algorithm: k.Algorithm}
+		if k.SecretFile != "" {
+			secret, err := os.ReadFile(k.SecretFile)
+			if err != nil {
+				return nil, fmt.Errorf("auth.keys[%d]: %w", i, err)
+			}
+			key.Secret = bytes.TrimSpace(secret)
+		}
+		if k.PublicKeyFile != "" {
+			publicKey, err := auth.LoadEd25519PublicKey(k.PublicKeyFile)
+			if err != nil {
+				return nil, fmt.Errorf("auth.keys[%d]: %w", i, err)
+			}
+			key.PublicKey = publicKey
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// HTTPConfig controls the REST gateway served alongside gRPC.
+type HTTPConfig struct {
+	Addr string `json:"addr"` // Listen address of the REST gateway; empty disables it.
+}
+
+// HoldConfig controls how long seat holds last before they are released.
+type HoldConfig struct {
+	TTLSeconds          int `json:"ttl_seconds"`           // How long HoldSeat reserves a seat.
+	ReapIntervalSeconds int `json:"reap_interval_seconds"` // How often expired holds are released.
+}
+
+// TTL returns the hold TTL as a duration.
+func (h HoldConfig) TTL() time.Duration {
+	return time.Duration(h.TTLSeconds) * time.Second
+}
+
+// ReapInterval returns the interval between hold reaper runs as a duration.
+func (h HoldConfig) ReapInterval() time.Duration {
+	return time.Duration(h.ReapIntervalSeconds) * time.Second
+}
+
+// StorageConfig selects the booking storage backend.
+type StorageConfig struct {
+	Backend       string `json:"backend"`        // "memory" (default) or "file"
+	Dir           string `json:"dir"`            // Directory holding the snapshot and write-ahead log of the file backend.
+	SnapshotEvery int    `json:"snapshot_every"` // Log records written between compacted snapshots.
+}
+
+// Default returns the configuration used when no config file is given.
+func Default() Config {
+	return Config{
+		Storage: StorageConfig{
+			Backend:       StorageMemory,
+			Dir:           DefaultDataDir,
+			SnapshotEvery: DefaultSnapshotEvery,
+		},
+		Holds: HoldConfig{
+			TTLSeconds:          DefaultHoldTTLSeconds,
+			ReapIntervalSeconds: DefaultHoldReapIntervalSeconds,
+		},
+		HTTP: HTTPConfig{
+			Addr: DefaultHTTPAddr,
+		},
+		Idempotency: IdempotencyConfig{
+			WindowSeconds: DefaultIdempotencyWindowSeconds,
+		},
+	}
+}
+
+// Load reads a JSON config file. Fields missing from the file keep their default values.
+func Load(path string) (Config, error) {
+	cfg := Default()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cfg, fmt.Errorf("read config %s: %w", path, err)
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("parse config %s: %w", path, err)
+	}
+	return cfg, cfg.Validate()
+}
+
+// Validate reports configuration values the server cannot start with.
+func (c Config) Validate() error {
+	switch c.Storage.Backend {
+	case StorageMemory:
+	case StorageFile:
+		if c.Storage.Dir == "" {
+			return fmt.Errorf("storage.dir is required for the %q backend", StorageFile)
+		}
+		if c.Storage.SnapshotEvery < 0 {
+			return fmt.Errorf("storage.snapshot_every must not be negative")
+		}
+	default:
+		return fmt.Errorf("unknown storage.backend %q", c.Storage.Backend)
+	}
+	if c.Holds.TTLSeconds <= 0 {
+		return fmt.Errorf("holds.ttl_seconds must be positive")
+	}
+	if c.Holds.ReapIntervalSeconds <= 0 {
+		return fmt.Errorf("holds.reap_interval_seconds must be positive")
+	}
+	if c.Idempotency.WindowSeconds <= 0 {
+		return fmt.Errorf("idempotency.window_seconds must be positive")
+	}
+	if c.RateLimits.Default != nil {
+		if err := c.RateLimits.Default.validate("rate_limits.default"); err != nil {
+			return err
+		}
+	}
+	rpcs := ticket.File_ticket_proto.Services().ByName("TrainTicketingService").Methods()
+	for rpc, limit := range c.RateLimits.RPCs {
+		if rpcs.ByName(protoreflect.Name(rpc)) == nil {
+			return fmt.Errorf("rate_limits.rpcs.%s is not an RPC of the ticket service", rpc)
+		}
+		if err := limit.validate("rate_limits.rpcs." + rpc); err != nil {
+			return err
+		}
+	}
+	if c.Quotas.MaxActiveTickets < 0 {
+		return fmt.Errorf("quotas.max_active_tickets must not be negative")
+	}
+	if _, err := c.TrainLayouts(); err != nil {
+		return err
+	}
+	if _, err := c.Fares.Source(); err != nil {
+		return err
+	}
+	if _, err := c.Currency.Rates(); err != nil {
+		return err
+	}
+	if _, err := c.Payments.Processor(); err != nil {
+		return err
+	}
+	if _, err := c.CancellationPolicy(); err != nil {
+		return err
+	}
+	// The fake forgets its payments when the server stops, so tickets kept across a restart could not be refunded.
+	if c.Payments.Provider == PaymentFake && c.Storage.Backend != StorageMemory {
+		return fmt.Errorf("payments.provider %q requires the %q storage backend", PaymentFake, StorageMemory)
+	}
+	if c.TLS.Enabled() {
+		if _, err := certs.NewReloader(c.TLS.CertFile, c.TLS.KeyFile, c.TLS.ClientCAFile); err != nil {
+			return fmt.Errorf("tls: %w", err)
+		}
+	} else if c.TLS.KeyFile != "" || c.TLS.ClientCAFile != "" {
+		return fmt.Errorf("tls.cert_file is required when tls.key_file or tls.client_ca_file is set")
+	}
+	if c.Auth.Enabled() {
+		keys, err := c.Auth.SigningKeys()
+		if err != nil {
+			return err
+		}
+		if _, err := auth.NewVerifier(c.Auth.Issuer, c.Auth.Audience, keys...); err != nil {
+			return fmt.Errorf("auth: %w", err)
+		}
+	}
+	return nil
+}
+
+// TrainLayouts builds the configured layouts, keyed by name.
+func (c Config) TrainLayouts() (map[string]*ticket.TrainLayout, error) {
+	layouts := make(map[string]*ticket.TrainLayout, len(c.Layouts))
+	for name, cfg := range c.Layouts {
+		if name == layout.StandardName {
+			return nil, fmt.Errorf("layouts.%s is reserved for the built-in layout", name)
+		}
+		l, err := layout.Build(name, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("layouts.%s: %w", name, err)
+		}
+		layouts[name] = l
+	}
+	if _, ok := layouts[c.DefaultLayout]; c.DefaultLayout != "" && !ok {
+		return nil, fmt.Errorf("default_layout %q is not a configured layout", c.DefaultLayout)
+	}
+	return layouts, nil
+}
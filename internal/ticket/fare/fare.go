@@ -0,0 +1,285 @@
+// Package fare prices tickets from a fare table read from configuration.
+package fare
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"time"
+
+	ticket "github.com/talk2sohail/train-ticket-api/internal/common/genproto/ticket"
+	"github.com/talk2sohail/train-ticket-api/internal/common/util"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/money"
+)
+
+// Travel classes as named in a fare table.
+const (
+	ClassStandard = "standard"
+	ClassFirst    = "first"
+)
+
+// Passenger types as named in a fare table.
+const (
+	PassengerAdult  = "adult"
+	PassengerChild  = "child"
+	PassengerSenior = "senior"
+)
+
+// DefaultBaseFare is the standard adult fare of the built-in table, charged for any trip.
+const DefaultBaseFare json.Number = "20.00"
+
+var classes = map[string]ticket.TravelClass{
+	ClassStandard: ticket.TravelClass_TRAVEL_CLASS_STANDARD,
+	ClassFirst:    ticket.TravelClass_TRAVEL_CLASS_FIRST,
+}
+
+var passengers = map[string]ticket.PassengerType{
+	PassengerAdult:  ticket.PassengerType_PASSENGER_TYPE_ADULT,
+	PassengerChild:  ticket.PassengerType_PASSENGER_TYPE_CHILD,
+	PassengerSenior: ticket.PassengerType_PASSENGER_TYPE_SENIOR,
+}
+
+// Config describes a fare table as read from a fare table file. Fares and multipliers
+// are kept as written, so that prices are worked out exactly and only rounded once.
+type Config struct {
+	Currency   string                 `json:"currency"`   // ISO 4217 code of the fares; "USD" when empty.
+	BaseFare   json.Number            `json:"base_fare"`  // Standard adult fare between stops without a route fare, in major units.
+	Routes     []RouteConfig          `json:"routes"`     // Standard adult fares between particular stops.
+	Classes    map[string]json.Number `json:"classes"`    // Multiplier of each travel class, "standard" or "first"; 1 when missing.
+	Passengers map[string]json.Number `json:"passengers"` // Multiplier of each passenger type, "adult", "child" or "senior"; 1 when missing.
+	Advance    []AdvanceConfig        `json:"advance"`    // Multipliers for booking ahead of the service date.
+	Pricing    PricingConfig          `json:"pricing"`    // Adjusts fares to demand.
+}
+
+// RouteConfig is the standard adult fare between two stops, travelled in either direction.
+type RouteConfig struct {
+	From string      `json:"from"`
+	To   string      `json:"to"`
+	Fare json.Number `json:"fare"` // In major units, e.g. 80.50.
+}
+
+// AdvanceConfig applies a multiplier to trips booked at least MinDays before their service date.
+// When several apply, the one with the most days wins.
+type AdvanceConfig struct {
+	MinDays    int         `json:"min_days"`
+	Multiplier json.Number `json:"multiplier"` // e.g. 0.8 for 20% off
+}
+
+// Default returns the fare table used when no fare table file is configured: a flat
+// adult fare, half price for children and 30% off for seniors, and 50% more in first class.
+func Default() Config {
+	return Config{
+		BaseFare:   DefaultBaseFare,
+		Classes:    map[string]json.Number{ClassFirst: "1.5"},
+		Passengers: map[string]json.Number{PassengerChild: "0.5", PassengerSenior: "0.7"},
+	}
+}
+
+// one is the multiplier of what a fare table does not adjust. It is never changed.
+var one = big.NewRat(1, 1)
+
+// positive reads a number of a fare table exactly, failing unless it is positive. field
+// names the number in the error.
+func positive(n json.Number, field string) (*big.Rat, error) {
+	r, ok := new(big.Rat).SetString(n.String())
+	if !ok || r.Sign() <= 0 {
+		return nil, fmt.Errorf("%s must be a positive number", field)
+	}
+	return r, nil
+}
+
+// float returns r as the nearest double, for the fare fields that record multipliers as doubles.
+func float(r *big.Rat) float64 {
+	f, _ := r.Float64()
+	return f
+}
+
+// Load reads a JSON fare table file.
+func Load(path string) (Config, error) {
+	var cfg Config
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cfg, fmt.Errorf("read fare table %s: %w", path, err)
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("parse fare table %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// Table prices trips. It is not changed once built and is safe for concurrent use.
+type Table struct {
+	currency   string
+	base       *big.Rat
+	routes     map[[2]string]*big.Rat // Keyed by the stops in both orders.
+	classes    map[ticket.TravelClass]*big.Rat
+	passengers map[ticket.PassengerType]*big.Rat
+	advance    []advance // Most days first.
+	strategy   Strategy  // Adjusts fares to demand; nil when fares are not adjusted.
+}
+
+// advance is an AdvanceConfig read for quoting.
+type advance struct {
+	minDays    int
+	multiplier *big.Rat
+}
+
+// Build checks a fare table config and prepares it for quoting.
+func Build(cfg Config) (*Table, error) {
+	if cfg.Currency == "" {
+		cfg.Currency = money.USD
+	}
+	if _, ok := money.Exponent(cfg.Currency); !ok {
+		return nil, fmt.Errorf("currency %q is not supported", cfg.Currency)
+	}
+	base, err := positive(cfg.BaseFare, "base_fare")
+	if err != nil {
+		return nil, err
+	}
+	t := &Table{
+		currency:   cfg.Currency,
+		base:       base,
+		routes:     make(map[[2]string]*big.Rat, 2*len(cfg.Routes)),
+		classes:    make(map[ticket.TravelClass]*big.Rat, len(cfg.Classes)),
+		passengers: make(map[ticket.PassengerType]*big.Rat, len(cfg.Passengers)),
+	}
+	for i, route := range cfg.Routes {
+		if route.From == "" || route.To == "" || route.From == route.To {
+			return nil, fmt.Errorf("routes[%d] must name two different stops", i)
+		}
+		fare, err := positive(route.Fare, fmt.Sprintf("routes[%d].fare", i))
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := t.routes[[2]string{route.From, route.To}]; ok {
+			return nil, fmt.Errorf("routes[%d] repeats the fare between %s and %s", i, route.From, route.To)
+		}
+		t.routes[[2]string{route.From, route.To}] = fare
+		t.routes[[2]string{route.To, route.From}] = fare
+	}
+	for name, number := range cfg.Classes {
+		class, ok := classes[name]
+		if !ok {
+			return nil, fmt.Errorf("classes.%s is not a travel class", name)
+		}
+		multiplier, err := positive(number, "classes."+name)
+		if err != nil {
+			return nil, err
+		}
+		t.classes[class] = multiplier
+	}
+	for name, number := range cfg.Passengers {
+		passenger, ok := passengers[name]
+		if !ok {
+			return nil, fmt.Errorf("passengers.%s is not a passenger type", name)
+		}
+		multiplier, err := positive(number, "passengers."+name)
+		if err != nil {
+			return nil, err
+		}
+		t.passengers[passenger] = multiplier
+	}
+	seen := make(map[int]bool, len(cfg.Advance))
+	for i, a := range cfg.Advance {
+		if a.MinDays < 0 {
+			return nil, fmt.Errorf("advance[%d].min_days must not be negative", i)
+		}
+		multiplier, err := positive(a.Multiplier, fmt.Sprintf("advance[%d].multiplier", i))
+		if err != nil {
+			return nil, err
+		}
+		if seen[a.MinDays] {
+			return nil, fmt.Errorf("advance[%d] repeats min_days %d", i, a.MinDays)
+		}
+		seen[a.MinDays] = true
+		t.advance = append(t.advance, advance{minDays: a.MinDays, multiplier: multiplier})
+	}
+	sort.Slice(t.advance, func(i, j int) bool { return t.advance[i].minDays > t.advance[j].minDays })
+	strategy, err := buildStrategy(cfg.Pricing)
+	if err != nil {
+		return nil, err
+	}
+	t.strategy = strategy
+	return t, nil
+}
+
+// Trip is what a fare is quoted for.
+type Trip struct {
+	From, To    string
+	ServiceDate string         // YYYY-MM-DD; no advance multiplier applies when empty.
+	Location    *time.Location // Where the service date is kept, and so where the booking date is taken; UTC when nil.
+	Class       ticket.TravelClass
+	Passenger   ticket.PassengerType
+	Demand      Demand // What the pricing strategy adjusts the fare to.
+}
+
+// Quote prices a trip booked at bookedAt. The price is the base fare times every
+// multiplier, worked out exactly and rounded once to the nearest minor unit of the
+// table's currency, halves away from zero.
+func (t *Table) Quote(trip Trip, bookedAt time.Time) *ticket.Fare {
+	days := daysInAdvance(trip.ServiceDate, bookedAt, trip.Location)
+	base, class, passenger, advance, demand := t.base, one, one, one, one
+	if fare, ok := t.routes[[2]string{trip.From, trip.To}]; ok {
+		base = fare
+	}
+	if multiplier, ok := t.classes[trip.Class]; ok {
+		class = multiplier
+	}
+	if multiplier, ok := t.passengers[trip.Passenger]; ok {
+		passenger = multiplier
+	}
+	if trip.ServiceDate != "" {
+		for _, a := range t.advance {
+			if days >= a.minDays {
+				advance = a.multiplier
+				break
+			}
+		}
+	}
+	var strategy, tier string
+	if t.strategy != nil {
+		strategy = t.strategy.Name()
+		demand, tier = t.strategy.Multiplier(trip.Demand, bookedAt)
+	}
+
+	amount := new(big.Rat).Set(base)
+	for _, multiplier := range []*big.Rat{class, passenger, advance, demand} {
+		amount.Mul(amount, multiplier)
+	}
+	// The currency was checked when the table was built.
+	price, _ := money.FromRat(amount, t.currency)
+	return &ticket.Fare{
+		Amount:              price.Major(),
+		TravelClass:         trip.Class,
+		PassengerType:       trip.Passenger,
+		BaseAmount:          float(base),
+		ClassMultiplier:     float(class),
+		PassengerMultiplier: float(passenger),
+		AdvanceMultiplier:   float(advance),
+		DaysInAdvance:       int32(days),
+		DemandMultiplier:    float(demand),
+		PricingStrategy:     strategy,
+		PricingTier:         tier,
+		Coach:               trip.Demand.Coach,
+		Price:               price.Proto(),
+	}
+}
+
+// daysInAdvance counts the calendar days from the booking date to the service date, both
+// as seen in loc; bookings on or after the service date, and undated journeys, are 0 days ahead.
+func daysInAdvance(serviceDate string, bookedAt time.Time, loc *time.Location) int {
+	service, err := time.Parse(util.ServiceDateLayout, serviceDate)
+	if err != nil {
+		return 0
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+	// Both dates are compared at UTC midnight so that a daylight saving change in loc
+	// between them does not make a day 23 or 25 hours long.
+	year, month, day := bookedAt.In(loc).Date()
+	booked := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	return max(0, int(service.Sub(booked).Hours()/24))
+}
@@ -0,0 +1,184 @@
+package fare
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	ticket "github.com/talk2sohail/train-ticket-api/internal/common/genproto/ticket"
+)
+
+func TestUnit_Quote(t *testing.T) {
+	table, err := Build(Config{
+		BaseFare:   "20",
+		Routes:     []RouteConfig{{From: "London", To: "Paris", Fare: "80"}},
+		Classes:    map[string]json.Number{ClassFirst: "1.5"},
+		Passengers: map[string]json.Number{PassengerChild: "0.5", PassengerSenior: "0.7"},
+		Advance:    []AdvanceConfig{{MinDays: 7, Multiplier: "0.9"}, {MinDays: 30, Multiplier: "0.75"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error building the table: %v", err)
+	}
+	bookedAt := time.Date(2024, 5, 1, 22, 30, 0, 0, time.UTC)
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Fatalf("unexpected error loading Europe/Paris: %v", err)
+	}
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("unexpected error loading America/New_York: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		trip Trip
+		want int64 // In cents.
+		days int32
+	}{
+		{"Route fare", Trip{From: "London", To: "Paris"}, 8000, 0},
+		{"Route fare in reverse", Trip{From: "Paris", To: "London"}, 8000, 0},
+		{"Base fare off the listed routes", Trip{From: "London", To: "Lille"}, 2000, 0},
+		{"First class", Trip{From: "London", To: "Paris", Class: ticket.TravelClass_TRAVEL_CLASS_FIRST}, 12000, 0},
+		{"Child", Trip{From: "London", To: "Paris", Passenger: ticket.PassengerType_PASSENGER_TYPE_CHILD}, 4000, 0},
+		{"Senior in first class", Trip{From: "London", To: "Paris", Class: ticket.TravelClass_TRAVEL_CLASS_FIRST, Passenger: ticket.PassengerType_PASSENGER_TYPE_SENIOR}, 8400, 0},
+		{"Booked on the day", Trip{From: "London", To: "Paris", ServiceDate: "2024-05-01"}, 8000, 0},
+		{"Booked a week ahead", Trip{From: "London", To: "Paris", ServiceDate: "2024-05-08"}, 7200, 7},
+		{"Booked a month ahead", Trip{From: "London", To: "Paris", ServiceDate: "2024-06-15"}, 6000, 45},
+		{"Booked a week ahead where it is already the next day", Trip{From: "London", To: "Paris", ServiceDate: "2024-05-08", Location: paris}, 8000, 6},
+		{"Booked a week ahead where it is still the same day", Trip{From: "London", To: "Paris", ServiceDate: "2024-05-08", Location: newYork}, 7200, 7},
+		{"Booked a month ahead across a clock change", Trip{From: "London", To: "Paris", ServiceDate: "2024-11-15", Location: newYork}, 6000, 198},
+		{"Service date passed", Trip{From: "London", To: "Paris", ServiceDate: "2024-04-01"}, 8000, 0},
+		{"Rounded to the cent", Trip{From: "London", To: "Lille", ServiceDate: "2024-05-08", Passenger: ticket.PassengerType_PASSENGER_TYPE_SENIOR}, 1260, 7},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fare := table.Quote(tt.trip, bookedAt)
+			if fare.GetPrice().GetMinorUnits() != tt.want {
+				t.Errorf("expected %d cents, got %d (%v)", tt.want, fare.GetPrice().GetMinorUnits(), fare)
+			}
+			if fare.GetDaysInAdvance() != tt.days {
+				t.Errorf("expected %d days in advance, got %d", tt.days, fare.GetDaysInAdvance())
+			}
+			if fare.GetTravelClass() != tt.trip.Class || fare.GetPassengerType() != tt.trip.Passenger {
+				t.Errorf("expected the fare to record the class and passenger type, got %v", fare)
+			}
+		})
+	}
+}
+
+// TestUnit_QuoteRounding checks that a price is worked out exactly and rounded once,
+// where multiplying doubles would land either side of a half cent.
+func TestUnit_QuoteRounding(t *testing.T) {
+	table, err := Build(Config{
+		BaseFare:   "2.01",
+		Classes:    map[string]json.Number{ClassFirst: "1.5"},
+		Passengers: map[string]json.Number{PassengerChild: "0.5"},
+		Advance:    []AdvanceConfig{{MinDays: 1, Multiplier: "0.7"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error building the table: %v", err)
+	}
+	tests := []struct {
+		name string
+		trip Trip
+		want int64 // In cents.
+	}{
+		// 2.01 * 0.5 = 1.005 exactly, rounded up; as doubles it is just below 1.005.
+		{"Half a cent rounded up", Trip{Passenger: ticket.PassengerType_PASSENGER_TYPE_CHILD}, 101},
+		// 2.01 * 1.5 * 0.5 * 0.7 = 1.05525.
+		{"Several multipliers rounded once", Trip{ServiceDate: "2024-05-08", Class: ticket.TravelClass_TRAVEL_CLASS_FIRST, Passenger: ticket.PassengerType_PASSENGER_TYPE_CHILD}, 106},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := table.Quote(tt.trip, time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)).GetPrice().GetMinorUnits(); got != tt.want {
+				t.Errorf("expected %d cents, got %d", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestUnit_QuoteCurrency(t *testing.T) {
+	table, err := Build(Config{Currency: "JPY", BaseFare: "2500", Passengers: map[string]json.Number{PassengerSenior: "0.7"}})
+	if err != nil {
+		t.Fatalf("unexpected error building the table: %v", err)
+	}
+	fare := table.Quote(Trip{From: "Tokyo", To: "Osaka", Passenger: ticket.PassengerType_PASSENGER_TYPE_SENIOR}, time.Now())
+	if fare.GetPrice().GetMinorUnits() != 1750 || fare.GetPrice().GetCurrencyCode() != "JPY" || fare.GetAmount() != 1750 {
+		t.Errorf("expected JPY 1750, got %v", fare)
+	}
+
+	table, err = Build(Config{BaseFare: "20"})
+	if err != nil {
+		t.Fatalf("unexpected error building the table: %v", err)
+	}
+	if price := table.Quote(Trip{From: "London", To: "Paris"}, time.Now()).GetPrice(); price.GetMinorUnits() != 2000 || price.GetCurrencyCode() != "USD" {
+		t.Errorf("expected fares in USD by default, got %v", price)
+	}
+}
+
+func TestUnit_Default(t *testing.T) {
+	table, err := Build(Default())
+	if err != nil {
+		t.Fatalf("unexpected error building the default table: %v", err)
+	}
+	if price := table.Quote(Trip{From: "London", To: "Paris"}, time.Now()).GetPrice(); price.GetMinorUnits() != 2000 || price.GetCurrencyCode() != "USD" {
+		t.Errorf("expected the default adult fare to be %s USD, got %v", DefaultBaseFare, price)
+	}
+}
+
+func TestUnit_Build(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+		want string
+	}{
+		{"No base fare", Config{}, "base_fare"},
+		{"Unknown currency", Config{Currency: "XYZ", BaseFare: "20"}, "currency"},
+		{"Route without a stop", Config{BaseFare: "20", Routes: []RouteConfig{{From: "London", Fare: "10"}}}, "routes[0]"},
+		{"Route to the same stop", Config{BaseFare: "20", Routes: []RouteConfig{{From: "London", To: "London", Fare: "10"}}}, "routes[0]"},
+		{"Route without a fare", Config{BaseFare: "20", Routes: []RouteConfig{{From: "London", To: "Paris"}}}, "routes[0].fare"},
+		{"Repeated route", Config{BaseFare: "20", Routes: []RouteConfig{{From: "London", To: "Paris", Fare: "10"}, {From: "Paris", To: "London", Fare: "12"}}}, "routes[1]"},
+		{"Unknown class", Config{BaseFare: "20", Classes: map[string]json.Number{"business": "2"}}, "classes.business"},
+		{"Free class", Config{BaseFare: "20", Classes: map[string]json.Number{ClassFirst: "0"}}, "classes.first"},
+		{"Unknown passenger type", Config{BaseFare: "20", Passengers: map[string]json.Number{"student": "0.8"}}, "passengers.student"},
+		{"Negative advance", Config{BaseFare: "20", Advance: []AdvanceConfig{{MinDays: -1, Multiplier: "0.9"}}}, "advance[0].min_days"},
+		{"Advance without a multiplier", Config{BaseFare: "20", Advance: []AdvanceConfig{{MinDays: 7}}}, "advance[0].multiplier"},
+		{"Repeated advance", Config{BaseFare: "20", Advance: []AdvanceConfig{{MinDays: 7, Multiplier: "0.9"}, {MinDays: 7, Multiplier: "0.8"}}}, "advance[1]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Build(tt.cfg); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected an error about %s, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestUnit_Load(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fares.json")
+	data := `{"base_fare": 25, "routes": [{"from": "London", "to": "Paris", "fare": 60.10}], "passengers": {"child": 0.5}}`
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error loading the table: %v", err)
+	}
+	table, err := Build(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error building the table: %v", err)
+	}
+	if fare := table.Quote(Trip{From: "London", To: "Paris", Passenger: ticket.PassengerType_PASSENGER_TYPE_CHILD}, time.Now()); fare.GetPrice().GetMinorUnits() != 3005 {
+		t.Errorf("expected 30.05, got %v", fare.GetPrice())
+	}
+	if _, err := Build(Config{BaseFare: "twenty"}); err == nil || !strings.Contains(err.Error(), "base_fare") {
+		t.Errorf("expected an error about a base fare that is not a number, got %v", err)
+	}
+
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Errorf("expected an error loading a missing file")
+	}
+}
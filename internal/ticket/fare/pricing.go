@@ -0,0 +1,222 @@
+package fare
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Pricing strategies as named in a fare table.
+const (
+	StrategyOccupancy = "occupancy" // Step tiers by the percentage of seats sold.
+	StrategyDeparture = "departure" // Step tiers by the hours left before departure.
+	StrategySection   = "section"   // A multiplier per coach.
+)
+
+// Demand is what a pricing strategy knows about the trip being priced.
+type Demand struct {
+	SeatsSold  int       // Seats taken over the trip's stops, including held seats.
+	SeatsTotal int       // Seats on the train.
+	Departure  time.Time // Departure from the origin; zero for undated journeys.
+	Coach      string    // Coach of the seat being priced; empty when no seat was allocated yet.
+}
+
+// PercentSold is the percentage of the train's seats taken, 0 for a train without seats.
+func (d Demand) PercentSold() float64 {
+	if d.SeatsTotal <= 0 {
+		return 0
+	}
+	return 100 * float64(d.SeatsSold) / float64(d.SeatsTotal)
+}
+
+// Strategy adjusts fares to demand. Name is recorded on every fare it prices, along
+// with the tier that set its multiplier, so receipts can be audited.
+type Strategy interface {
+	Name() string
+	// Multiplier returns the multiplier of a trip booked at bookedAt and the tier it
+	// fell in; a trip in no tier has a multiplier of 1 and an empty tier. The caller
+	// must not change the multiplier.
+	Multiplier(d Demand, bookedAt time.Time) (*big.Rat, string)
+}
+
+// PricingConfig selects the pricing strategy of a fare table and configures each strategy.
+// Only the selected strategy is used, so switching strategies only takes a change of Strategy.
+type PricingConfig struct {
+	Strategy  string                 `json:"strategy"`  // One of the Strategy values; fares are not adjusted when empty.
+	Occupancy []OccupancyTier        `json:"occupancy"` // Tiers of the "occupancy" strategy.
+	Departure []DepartureTier        `json:"departure"` // Tiers of the "departure" strategy.
+	Sections  map[string]json.Number `json:"sections"`  // Multipliers of the "section" strategy, keyed by coach; 1 when missing.
+}
+
+// OccupancyTier applies a multiplier once at least MinPercentSold of the seats are sold.
+// When several apply, the one with the highest percentage wins.
+type OccupancyTier struct {
+	Name           string      `json:"name"` // Recorded on fares; the percentage, e.g. "50%", when empty.
+	MinPercentSold float64     `json:"min_percent_sold"`
+	Multiplier     json.Number `json:"multiplier"`
+}
+
+// DepartureTier applies a multiplier to trips booked at most WithinHours before departure.
+// When several apply, the one with the fewest hours wins. The multiplier only changes as a
+// booking crosses a tier's threshold, so a quoted fare can still be paid until then.
+type DepartureTier struct {
+	Name        string      `json:"name"` // Recorded on fares; the threshold, e.g. "<=24h", when empty.
+	WithinHours float64     `json:"within_hours"`
+	Multiplier  json.Number `json:"multiplier"`
+}
+
+// strategies builds each named strategy from its configuration.
+var strategies = map[string]func(PricingConfig) (Strategy, error){
+	StrategyOccupancy: newOccupancyStrategy,
+	StrategyDeparture: newDepartureStrategy,
+	StrategySection:   newSectionStrategy,
+}
+
+// buildStrategy checks the pricing config and builds its strategy, or returns nil when none is selected.
+func buildStrategy(cfg PricingConfig) (Strategy, error) {
+	if cfg.Strategy == "" {
+		return nil, nil
+	}
+	build, ok := strategies[cfg.Strategy]
+	if !ok {
+		return nil, fmt.Errorf("pricing.strategy %q is not a pricing strategy", cfg.Strategy)
+	}
+	return build(cfg)
+}
+
+type occupancyStrategy struct {
+	tiers []occupancyTier // Highest percentage first.
+}
+
+type occupancyTier struct {
+	name           string
+	minPercentSold float64
+	multiplier     *big.Rat
+}
+
+func newOccupancyStrategy(cfg PricingConfig) (Strategy, error) {
+	if len(cfg.Occupancy) == 0 {
+		return nil, fmt.Errorf("pricing.occupancy must list at least one tier")
+	}
+	s := &occupancyStrategy{}
+	seen := make(map[float64]bool, len(cfg.Occupancy))
+	for i, tier := range cfg.Occupancy {
+		if tier.MinPercentSold < 0 || tier.MinPercentSold > 100 {
+			return nil, fmt.Errorf("pricing.occupancy[%d].min_percent_sold must be between 0 and 100", i)
+		}
+		multiplier, err := positive(tier.Multiplier, fmt.Sprintf("pricing.occupancy[%d].multiplier", i))
+		if err != nil {
+			return nil, err
+		}
+		if seen[tier.MinPercentSold] {
+			return nil, fmt.Errorf("pricing.occupancy[%d] repeats min_percent_sold %g", i, tier.MinPercentSold)
+		}
+		seen[tier.MinPercentSold] = true
+		if tier.Name == "" {
+			tier.Name = strconv.FormatFloat(tier.MinPercentSold, 'f', -1, 64) + "%"
+		}
+		s.tiers = append(s.tiers, occupancyTier{name: tier.Name, minPercentSold: tier.MinPercentSold, multiplier: multiplier})
+	}
+	sort.Slice(s.tiers, func(i, j int) bool { return s.tiers[i].minPercentSold > s.tiers[j].minPercentSold })
+	return s, nil
+}
+
+func (s *occupancyStrategy) Name() string { return StrategyOccupancy }
+
+func (s *occupancyStrategy) Multiplier(d Demand, _ time.Time) (*big.Rat, string) {
+	sold := d.PercentSold()
+	for _, tier := range s.tiers {
+		if sold >= tier.minPercentSold {
+			return tier.multiplier, tier.name
+		}
+	}
+	return one, ""
+}
+
+type departureStrategy struct {
+	tiers []departureTier // Fewest hours first.
+}
+
+// departureTier is a DepartureTier with its threshold as a duration.
+type departureTier struct {
+	name       string
+	within     time.Duration
+	multiplier *big.Rat
+}
+
+func newDepartureStrategy(cfg PricingConfig) (Strategy, error) {
+	if len(cfg.Departure) == 0 {
+		return nil, fmt.Errorf("pricing.departure must list at least one tier")
+	}
+	s := &departureStrategy{}
+	seen := make(map[time.Duration]bool, len(cfg.Departure))
+	for i, tier := range cfg.Departure {
+		if tier.WithinHours < 0 {
+			return nil, fmt.Errorf("pricing.departure[%d].within_hours must not be negative", i)
+		}
+		multiplier, err := positive(tier.Multiplier, fmt.Sprintf("pricing.departure[%d].multiplier", i))
+		if err != nil {
+			return nil, err
+		}
+		within := time.Duration(tier.WithinHours * float64(time.Hour))
+		if seen[within] {
+			return nil, fmt.Errorf("pricing.departure[%d] repeats within_hours %g", i, tier.WithinHours)
+		}
+		seen[within] = true
+		if tier.Name == "" {
+			tier.Name = "<=" + strconv.FormatFloat(tier.WithinHours, 'f', -1, 64) + "h"
+		}
+		s.tiers = append(s.tiers, departureTier{name: tier.Name, within: within, multiplier: multiplier})
+	}
+	sort.Slice(s.tiers, func(i, j int) bool { return s.tiers[i].within < s.tiers[j].within })
+	return s, nil
+}
+
+func (s *departureStrategy) Name() string { return StrategyDeparture }
+
+// Multiplier applies the tier of the time left before departure; bookings after departure
+// fall in the nearest tier.
+func (s *departureStrategy) Multiplier(d Demand, bookedAt time.Time) (*big.Rat, string) {
+	if d.Departure.IsZero() {
+		return one, ""
+	}
+	left := max(0, d.Departure.Sub(bookedAt))
+	for _, tier := range s.tiers {
+		if left <= tier.within {
+			return tier.multiplier, tier.name
+		}
+	}
+	return one, ""
+}
+
+type sectionStrategy struct {
+	multipliers map[string]*big.Rat // Keyed by coach.
+}
+
+func newSectionStrategy(cfg PricingConfig) (Strategy, error) {
+	if len(cfg.Sections) == 0 {
+		return nil, fmt.Errorf("pricing.sections must price at least one coach")
+	}
+	s := &sectionStrategy{multipliers: make(map[string]*big.Rat, len(cfg.Sections))}
+	for coach, number := range cfg.Sections {
+		multiplier, err := positive(number, "pricing.sections."+coach)
+		if err != nil {
+			return nil, err
+		}
+		s.multipliers[coach] = multiplier
+	}
+	return s, nil
+}
+
+func (s *sectionStrategy) Name() string { return StrategySection }
+
+// Multiplier applies the multiplier of the seat's coach, which is also its tier.
+func (s *sectionStrategy) Multiplier(d Demand, _ time.Time) (*big.Rat, string) {
+	if multiplier, ok := s.multipliers[d.Coach]; ok {
+		return multiplier, d.Coach
+	}
+	return one, ""
+}
@@ -0,0 +1,86 @@
+package fare
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestUnit_PricingStrategies(t *testing.T) {
+	departure := time.Date(2024, 5, 10, 8, 0, 0, 0, time.UTC)
+	pricing := PricingConfig{
+		Occupancy: []OccupancyTier{{MinPercentSold: 50, Multiplier: "1.2"}, {Name: "last seats", MinPercentSold: 90, Multiplier: "1.5"}},
+		Departure: []DepartureTier{{WithinHours: 24, Multiplier: "1.2"}, {Name: "last call", WithinHours: 2, Multiplier: "1.5"}},
+		Sections:  map[string]json.Number{"F": "1.8"},
+	}
+
+	tests := []struct {
+		name     string
+		strategy string
+		demand   Demand
+		bookedAt time.Time
+		want     int64 // In cents.
+		tier     string
+	}{
+		{"Occupancy below every tier", StrategyOccupancy, Demand{SeatsSold: 4, SeatsTotal: 10}, departure, 2000, ""},
+		{"Occupancy tier", StrategyOccupancy, Demand{SeatsSold: 5, SeatsTotal: 10}, departure, 2400, "50%"},
+		{"Highest occupancy tier", StrategyOccupancy, Demand{SeatsSold: 9, SeatsTotal: 10}, departure, 3000, "last seats"},
+		{"Before every departure tier", StrategyDeparture, Demand{Departure: departure}, departure.Add(-48 * time.Hour), 2000, ""},
+		{"Departure tier", StrategyDeparture, Demand{Departure: departure}, departure.Add(-10 * time.Hour), 2400, "<=24h"},
+		{"Departure tier at its threshold", StrategyDeparture, Demand{Departure: departure}, departure.Add(-24 * time.Hour), 2400, "<=24h"},
+		{"Closest departure tier", StrategyDeparture, Demand{Departure: departure}, departure.Add(-time.Hour), 3000, "last call"},
+		{"After departure", StrategyDeparture, Demand{Departure: departure}, departure.Add(time.Hour), 3000, "last call"},
+		{"Undated journey", StrategyDeparture, Demand{}, departure, 2000, ""},
+		{"Priced coach", StrategySection, Demand{Coach: "F"}, departure, 3600, "F"},
+		{"Other coach", StrategySection, Demand{Coach: "C"}, departure, 2000, ""},
+		{"No seat yet", StrategySection, Demand{}, departure, 2000, ""},
+		{"No strategy", "", Demand{SeatsSold: 9, SeatsTotal: 10, Coach: "F"}, departure, 2000, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := pricing
+			cfg.Strategy = tt.strategy
+			table, err := Build(Config{BaseFare: "20", Pricing: cfg})
+			if err != nil {
+				t.Fatalf("unexpected error building the table: %v", err)
+			}
+			fare := table.Quote(Trip{From: "London", To: "Paris", Demand: tt.demand}, tt.bookedAt)
+			if fare.GetPrice().GetMinorUnits() != tt.want {
+				t.Errorf("expected %d cents, got %d (%v)", tt.want, fare.GetPrice().GetMinorUnits(), fare)
+			}
+			if fare.GetPricingStrategy() != tt.strategy || fare.GetPricingTier() != tt.tier {
+				t.Errorf("expected strategy %q and tier %q, got %q and %q", tt.strategy, tt.tier, fare.GetPricingStrategy(), fare.GetPricingTier())
+			}
+			if fare.GetCoach() != tt.demand.Coach {
+				t.Errorf("expected the fare to record coach %q, got %q", tt.demand.Coach, fare.GetCoach())
+			}
+		})
+	}
+}
+
+func TestUnit_BuildPricing(t *testing.T) {
+	tests := []struct {
+		name    string
+		pricing PricingConfig
+		want    string
+	}{
+		{"Unknown strategy", PricingConfig{Strategy: "surge"}, "pricing.strategy"},
+		{"Occupancy without tiers", PricingConfig{Strategy: StrategyOccupancy}, "pricing.occupancy"},
+		{"Occupancy over 100%", PricingConfig{Strategy: StrategyOccupancy, Occupancy: []OccupancyTier{{MinPercentSold: 120, Multiplier: "2"}}}, "pricing.occupancy[0].min_percent_sold"},
+		{"Free occupancy tier", PricingConfig{Strategy: StrategyOccupancy, Occupancy: []OccupancyTier{{MinPercentSold: 50}}}, "pricing.occupancy[0].multiplier"},
+		{"Repeated occupancy tier", PricingConfig{Strategy: StrategyOccupancy, Occupancy: []OccupancyTier{{MinPercentSold: 50, Multiplier: "1.2"}, {MinPercentSold: 50, Multiplier: "1.3"}}}, "pricing.occupancy[1]"},
+		{"Departure without tiers", PricingConfig{Strategy: StrategyDeparture}, "pricing.departure"},
+		{"Negative hours", PricingConfig{Strategy: StrategyDeparture, Departure: []DepartureTier{{WithinHours: -1, Multiplier: "1"}}}, "pricing.departure[0].within_hours"},
+		{"Repeated departure tier", PricingConfig{Strategy: StrategyDeparture, Departure: []DepartureTier{{WithinHours: 2, Multiplier: "1.5"}, {WithinHours: 2, Multiplier: "1.2"}}}, "pricing.departure[1]"},
+		{"Section without coaches", PricingConfig{Strategy: StrategySection}, "pricing.sections"},
+		{"Free section", PricingConfig{Strategy: StrategySection, Sections: map[string]json.Number{"F": "0"}}, "pricing.sections.F"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Build(Config{BaseFare: "20", Pricing: tt.pricing}); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected an error about %s, got %v", tt.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package fare
+
+import (
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/filewatch"
+)
+
+// Reloader serves the fare table in a fare table file, rereading the file when it
+// changes so that fares and pricing strategies can be swapped without a restart. A
+// table that fails to build keeps the previous one quoting until it is fixed.
+type Reloader struct {
+	table *filewatch.Value[*Table]
+}
+
+// NewReloader reads the fare table in path.
+func NewReloader(path string) (*Reloader, error) {
+	table, err := filewatch.New("fare table", func() (*Table, error) {
+		cfg, err := Load(path)
+		if err != nil {
+			return nil, err
+		}
+		return Build(cfg)
+	}, path)
+	if err != nil {
+		return nil, err
+	}
+	return &Reloader{table: table}, nil
+}
+
+// Table returns the fare table to quote with, as last read from the file.
+func (r *Reloader) Table() *Table {
+	return r.table.Get()
+}
@@ -0,0 +1,56 @@
+package fare
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestUnit_Reloader(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fares.json")
+	write := func(data string, modTime time.Time) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		// Set the modification time so changes are seen whatever the file system's resolution.
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// quote prices a trip in cents.
+	quote := func(r *Reloader) int64 {
+		return r.Table().Quote(Trip{From: "London", To: "Paris", Demand: Demand{Coach: "F"}}, time.Now()).GetPrice().GetMinorUnits()
+	}
+	start := time.Now().Add(-time.Hour)
+
+	write(`{"base_fare": 20}`, start)
+	r, err := NewReloader(path)
+	if err != nil {
+		t.Fatalf("unexpected error reading the table: %v", err)
+	}
+	if got := quote(r); got != 2000 {
+		t.Fatalf("expected 2000 cents, got %d", got)
+	}
+
+	// Switching on a pricing strategy takes effect on the next quote.
+	write(`{"base_fare": 20, "pricing": {"strategy": "section", "sections": {"F": 1.5}}}`, start.Add(time.Minute))
+	if got := quote(r); got != 3000 {
+		t.Errorf("expected the reloaded table to quote 3000 cents, got %d", got)
+	}
+
+	// A broken table is ignored until it is fixed.
+	write(`{"base_fare": 20, "pricing": {"strategy": "surge"}}`, start.Add(2*time.Minute))
+	if got := quote(r); got != 3000 {
+		t.Errorf("expected the previous table to stay in use, got %d cents", got)
+	}
+	write(`{"base_fare": 25}`, start.Add(3*time.Minute))
+	if got := quote(r); got != 2500 {
+		t.Errorf("expected the fixed table to quote 2500 cents, got %d", got)
+	}
+
+	if _, err := NewReloader(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Errorf("expected an error reading a missing table")
+	}
+}
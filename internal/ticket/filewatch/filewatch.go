@@ -0,0 +1,85 @@
+// Package filewatch keeps a value loaded from files on disk up to date. Instead of
+// watching for events it compares the files' modification times and sizes whenever
+// the value is read, which is cheap enough for values read once per request.
+package filewatch
+
+import (
+	"log"
+	"os"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Value holds what a load function last read from a set of files. Get rereads the
+// files when one has changed; a change that cannot be loaded is logged and the
+// previous value is kept, so a half-written file does not take the value away.
+type Value[T any] struct {
+	name  string // Names the value in log messages, e.g. "fare table".
+	files []string
+	load  func() (T, error)
+
+	mu      sync.Mutex
+	value   T
+	version []fileVersion // Versions of the files the value was read from.
+}
+
+// fileVersion identifies the contents of a file by its modification time and size.
+type fileVersion struct {
+	modTime time.Time
+	size    int64
+}
+
+func (v fileVersion) equal(o fileVersion) bool {
+	return v.modTime.Equal(o.modTime) && v.size == o.size
+}
+
+// New loads a value from files with load. name describes the value in log messages.
+// It fails when a file is missing or the value cannot be loaded.
+func New[T any](name string, load func() (T, error), files ...string) (*Value[T], error) {
+	v := &Value[T]{name: name, files: files, load: load}
+	version, err := v.stat()
+	if err != nil {
+		return nil, err
+	}
+	if v.value, err = load(); err != nil {
+		return nil, err
+	}
+	v.version = version
+	return v, nil
+}
+
+// Get returns the current value, first rereading the files if they changed since they were last read.
+func (v *Value[T]) Get() T {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	version, err := v.stat()
+	if err != nil {
+		log.Printf("[Reload] Keeping current %s: %v", v.name, err)
+		return v.value
+	}
+	if slices.EqualFunc(version, v.version, fileVersion.equal) {
+		return v.value
+	}
+	value, err := v.load()
+	if err != nil {
+		log.Printf("[Reload] Keeping current %s: %v", v.name, err)
+		return v.value
+	}
+	v.value, v.version = value, version
+	log.Printf("[Reload] Reloaded %s from %v", v.name, v.files)
+	return v.value
+}
+
+func (v *Value[T]) stat() ([]fileVersion, error) {
+	version := make([]fileVersion, len(v.files))
+	for i, f := range v.files {
+		info, err := os.Stat(f)
+		if err != nil {
+			return nil, err
+		}
+		version[i] = fileVersion{modTime: info.ModTime(), size: info.Size()}
+	}
+	return version, nil
+}
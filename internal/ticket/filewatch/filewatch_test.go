@@ -0,0 +1,69 @@
+package filewatch
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestUnit_Value(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "value.txt")
+	write := func(data string, modTime time.Time) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		// Set the modification time so changes are seen whatever the file system's resolution.
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	loads := 0
+	load := func() (string, error) {
+		loads++
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", err
+		}
+		if strings.HasPrefix(string(data), "bad") {
+			return "", errors.New("bad value")
+		}
+		return string(data), nil
+	}
+	start := time.Now().Add(-time.Hour)
+
+	write("one", start)
+	v, err := New("value", load, path)
+	if err != nil {
+		t.Fatalf("unexpected error loading: %v", err)
+	}
+	if got := v.Get(); got != "one" || loads != 1 {
+		t.Fatalf("expected one load of %q, got %d of %q", "one", loads, got)
+	}
+
+	write("two", start.Add(time.Minute))
+	if got := v.Get(); got != "two" {
+		t.Errorf("expected the changed file to be reread, got %q", got)
+	}
+	if v.Get(); loads != 2 {
+		t.Errorf("expected an unchanged file not to be reread, got %d loads", loads)
+	}
+
+	write("bad", start.Add(2*time.Minute))
+	if got := v.Get(); got != "two" {
+		t.Errorf("expected the previous value to be kept, got %q", got)
+	}
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if got := v.Get(); got != "two" {
+		t.Errorf("expected the previous value to be kept while the file is missing, got %q", got)
+	}
+
+	if _, err := New("value", load, filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Errorf("expected an error loading a missing file")
+	}
+}
@@ -0,0 +1,562 @@
+// Package gateway exposes the ticket service as a REST/JSON API. Requests and
+// responses are the gRPC messages encoded with protojson, so both surfaces
+// share one contract.
+package gateway
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	ticket "github.com/talk2sohail/train-ticket-api/internal/common/genproto/ticket"
+	"github.com/talk2sohail/train-ticket-api/internal/common/util"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/auth"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/money"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/ratelimit"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/service"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/types"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// MaxBodyBytes caps the size of a request body.
+const MaxBodyBytes = 1 << 20
+
+// kindStatuses maps the kinds of service failure to HTTP status codes.
+var kindStatuses = map[service.Kind]int{
+	service.KindInternal:           http.StatusInternalServerError,
+	service.KindInvalidArgument:    http.StatusBadRequest,
+	service.KindNotFound:           http.StatusNotFound,
+	service.KindFailedPrecondition: http.StatusConflict,
+	service.KindResourceExhausted:  http.StatusConflict,
+	service.KindUnavailable:        http.StatusServiceUnavailable,
+}
+
+// statusOf returns the HTTP status for a failed service call. An expired hold
+// cannot be confirmed again, so it is gone rather than in conflict, and a declined
+// payment calls for another payment method. A passenger over the ticket quota has
+// made too many requests, like a throttled caller; running out of seats remains a
+// conflict.
+func statusOf(err error) int {
+	if e, ok := service.AsError(err); ok {
+		switch e.Reason {
+		case service.ReasonHoldExpired:
+			return http.StatusGone
+		case service.ReasonTicketQuotaExceeded:
+			return http.StatusTooManyRequests
+		case service.ReasonPaymentDeclined:
+			return http.StatusPaymentRequired
+		}
+	}
+	return kindStatuses[service.KindOf(err)]
+}
+
+// route maps a REST endpoint onto a TrainTicketingService RPC. The table drives
+// both the router and the generated OpenAPI document.
+type route struct {
+	method string
+	path   string
+	rpc    string        // Name of the RPC the route calls; its response message is the response body.
+	op     string        // Operation ID of a route that calls the same RPC as another; defaults to rpc.
+	body   proto.Message // Message read from the request body, if any.
+	query  []string      // Query parameters the route reads.
+	handle func(*Gateway, http.ResponseWriter, *http.Request)
+}
+
+var routes = []route{
+	{method: http.MethodPost, path: "/v1/tickets", rpc: "PurchaseTicket", body: &ticket.PurchaseTicketRequest{}, handle: (*Gateway).purchaseTicket},
+	{method: http.MethodPost, path: "/v1/group-tickets", rpc: "PurchaseGroupTicket", body: &ticket.PurchaseGroupTicketRequest{}, handle: (*Gateway).purchaseGroupTicket},
+	{method: http.MethodGet, path: "/v1/tickets/{id}", rpc: "GetReceiptDetails", handle: (*Gateway).getReceiptDetails},
+	{method: http.MethodDelete, path: "/v1/tickets/{id}", rpc: "CancelTicket", handle: (*Gateway).cancelTicket},
+	{method: http.MethodGet, path: "/v1/tickets/{id}/history", rpc: "GetTicketHistory", handle: (*Gateway).getTicketHistory},
+	{method: http.MethodPatch, path: "/v1/tickets/{id}/seat", rpc: "ModifyUserSeat", body: &ticket.Seat{}, handle: (*Gateway).modifyUserSeat},
+	{method: http.MethodGet, path: "/v1/sections/{section}/passengers", rpc: "GetUsersBySection", query: []string{"journey_id"}, handle: (*Gateway).getUsersBySection},
+	{method: http.MethodDelete, path: "/v1/passengers/{email}", rpc: "RemoveUser", handle: (*Gateway).removeUser},
+	{method: http.MethodGet, path: "/v1/passengers/{email}/tickets", rpc: "ListTicketsForUser", query: []string{"page_size", "page_token"}, handle: (*Gateway).listTicketsForUser},
+	{method: http.MethodGet, path: "/v1/passengers/{email}/ticket", rpc: "GetReceiptDetails", op: "GetReceiptDetailsByEmail", handle: (*Gateway).getReceiptByEmail},
+	{method: http.MethodPatch, path: "/v1/passengers/{email}/seat", rpc: "ModifyUserSeat", op: "ModifyUserSeatByEmail", body: &ticket.Seat{}, handle: (*Gateway).modifyUserSeatByEmail},
+	{method: http.MethodGet, path: "/v1/refunds/{id}", rpc: "GetRefund", handle: (*Gateway).getRefund},
+	{method: http.MethodPost, path: "/v1/holds", rpc: "HoldSeat", body: &ticket.HoldSeatRequest{}, handle: (*Gateway).holdSeat},
+	{method: http.MethodPost, path: "/v1/holds/{id}/confirm", rpc: "ConfirmHold", body: &ticket.ConfirmHoldRequest{}, handle: (*Gateway).confirmHold},
+	{method: http.MethodPost, path: "/v1/waitlist", rpc: "JoinWaitlist", body: &ticket.JoinWaitlistRequest{}, handle: (*Gateway).joinWaitlist},
+	{method: http.MethodGet, path: "/v1/waitlist/{id}", rpc: "GetWaitlistStatus", handle: (*Gateway).getWaitlistStatus},
+	{method: http.MethodPost, path: "/v1/journeys", rpc: "CreateJourney", body: &ticket.CreateJourneyRequest{}, handle: (*Gateway).createJourney},
+	{method: http.MethodGet, path: "/v1/journeys", rpc: "ListJourneys", query: []string{"service_date"}, handle: (*Gateway).listJourneys},
+	{method: http.MethodGet, path: "/v1/journeys/{journey}/seats/{seat}/occupant", rpc: "GetSeatOccupant", query: []string{"at"}, handle: (*Gateway).getSeatOccupant},
+	{method: http.MethodGet, path: "/v1/fares", rpc: "QuoteFare", query: []string{"from_location", "to_location", "journey_id", "travel_class", "passenger_type", "coach", "currency_code"}, handle: (*Gateway).quoteFare},
+}
+
+// operationID is the route's operation ID in the OpenAPI document.
+func (rt route) operationID() string {
+	if rt.op != "" {
+		return rt.op
+	}
+	return rt.rpc
+}
+
+// successStatus is the status of a successful call: POST routes create a resource.
+func (rt route) successStatus() int {
+	if rt.method == http.MethodPost {
+		return http.StatusCreated
+	}
+	return http.StatusOK
+}
+
+// OpenAPIPath is where the gateway serves its OpenAPI document.
+const OpenAPIPath = "/openapi.json"
+
+// Gateway translates REST requests into calls on a TicketService.
+type Gateway struct {
+	ticketService types.TicketService
+	authorizer    *auth.Authorizer
+	idempotency   *service.IdempotencyStore
+	limiter       *ratelimit.Limiter
+	mux           *http.ServeMux
+}
+
+// Option configures a Gateway.
+type Option func(*Gateway)
+
+// WithAuthorizer requires every request to carry an "Authorization: Bearer" token that
+// authorizer accepts, and holds callers to the same policy as gRPC calls. A request
+// without a valid token is answered 401 and a refused one 403.
+func WithAuthorizer(authorizer *auth.Authorizer) Option {
+	return func(g *Gateway) {
+		g.authorizer = authorizer
+	}
+}
+
+// WithIdempotency replays the response to a retried POST, PATCH or DELETE carrying an
+// Idempotency-Key header from store.
+func WithIdempotency(store *service.IdempotencyStore) Option {
+	return func(g *Gateway) {
+		g.idempotency = store
+	}
+}
+
+// WithRateLimiter throttles the calls of each client address with limiter. A throttled
+// request is answered 429 with a Retry-After header.
+func WithRateLimiter(limiter *ratelimit.Limiter) Option {
+	return func(g *Gateway) {
+		g.limiter = limiter
+	}
+}
+
+// NewGateway creates a Gateway serving the REST routes for ticketService.
+func NewGateway(ticketService types.TicketService, opts ...Option) *Gateway {
+	g := &Gateway{ticketService: ticketService, mux: http.NewServeMux()}
+	for _, opt := range opts {
+		opt(g)
+	}
+	for _, rt := range routes {
+		handle := rt.handle
+		if g.idempotency != nil && rt.method != http.MethodGet {
+			handle = g.idempotent(handle)
+		}
+		if g.limiter != nil {
+			handle = g.throttled(rt.rpc, handle)
+		}
+		if g.authorizer != nil {
+			handle = g.authenticated(handle)
+		}
+		g.mux.HandleFunc(rt.method+" "+rt.path, func(w http.ResponseWriter, r *http.Request) {
+			handle(g, w, r)
+		})
+	}
+	g.mux.HandleFunc(http.MethodGet+" "+OpenAPIPath, serveOpenAPI)
+	return g
+}
+
+// ServeHTTP implements http.Handler.
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	g.mux.ServeHTTP(w, r)
+}
+
+// authenticated wraps a route so that it is only served to callers with a valid bearer
+// token, whose claims it adds to the request context. Each handler then checks that the
+// caller may make its call with allowed.
+func (g *Gateway) authenticated(handle func(*Gateway, http.ResponseWriter, *http.Request)) func(*Gateway, http.ResponseWriter, *http.Request) {
+	return func(g *Gateway, w http.ResponseWriter, r *http.Request) {
+		claims, err := g.authorizer.Authenticate(r.Header.Get("Authorization"))
+		if err != nil {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeError(w, http.StatusUnauthorized, status.Convert(err).Message())
+			return
+		}
+		handle(g, w, r.WithContext(auth.NewContext(r.Context(), claims)))
+	}
+}
+
+// allowed checks that the authenticated caller may make req on the RPC method, answering
+// 403 when not. Without an authorizer every caller is trusted.
+func (g *Gateway) allowed(w http.ResponseWriter, r *http.Request, method string, req proto.Message) bool {
+	if g.authorizer == nil {
+		return true
+	}
+	claims, _ := auth.FromContext(r.Context())
+	if err := g.authorizer.Authorize(method, claims, req); err != nil {
+		writeError(w, http.StatusForbidden, status.Convert(err).Message())
+		return false
+	}
+	return true
+}
+
+// throttled wraps a route calling rpc so that each client is held to its rate limit. Clients
+// are told apart by their token when authenticated, otherwise by their address.
+func (g *Gateway) throttled(rpc string, handle func(*Gateway, http.ResponseWriter, *http.Request)) func(*Gateway, http.ResponseWriter, *http.Request) {
+	return func(g *Gateway, w http.ResponseWriter, r *http.Request) {
+		principal := "peer:" + ratelimit.Host(r.RemoteAddr)
+		if _, ok := auth.FromContext(r.Context()); ok {
+			principal = ratelimit.Principal(r.Context())
+		}
+		if ok, retryAfter := g.limiter.Allow(rpc, principal); !ok {
+			log.Printf("[Gateway] Throttled %s for %s; retry in %s", rpc, principal, retryAfter)
+			w.Header().Set("Retry-After", ratelimit.RetryAfterSeconds(retryAfter))
+			writeError(w, http.StatusTooManyRequests, "rate limit exceeded for "+rpc)
+			return
+		}
+		handle(g, w, r)
+	}
+}
+
+func (g *Gateway) purchaseTicket(w http.ResponseWriter, r *http.Request) {
+	req := &ticket.PurchaseTicketRequest{}
+	if !decode(w, r, req) || !validate(w, util.ValidatePurchseRequestObject(req)) || !validate(w, money.ValidatePrice("price", req.GetPrice())) ||
+		!g.allowed(w, r, ticket.TrainTicketingService_PurchaseTicket_FullMethodName, req) {
+		return
+	}
+	resp, err := g.ticketService.PurchaseTicket(r.Context(), req)
+	respond(w, resp, err, http.StatusCreated)
+}
+
+func (g *Gateway) purchaseGroupTicket(w http.ResponseWriter, r *http.Request) {
+	req := &ticket.PurchaseGroupTicketRequest{}
+	if !decode(w, r, req) || !validate(w, util.ValidatePurchaseGroupRequestObject(req)) || !validate(w, money.ValidatePrice("price", req.GetPrice())) ||
+		!g.allowed(w, r, ticket.TrainTicketingService_PurchaseGroupTicket_FullMethodName, req) {
+		return
+	}
+	resp, err := g.ticketService.PurchaseGroupTicket(r.Context(), req)
+	respond(w, resp, err, http.StatusCreated)
+}
+
+func (g *Gateway) getReceiptDetails(w http.ResponseWriter, r *http.Request) {
+	req := &ticket.GetReceiptDetailsRequest{Identifier: &ticket.GetReceiptDetailsRequest_TicketId{TicketId: r.PathValue("id")}}
+	if !g.allowed(w, r, ticket.TrainTicketingService_GetReceiptDetails_FullMethodName, req) {
+		return
+	}
+	receipt, err := g.ticketService.GetReceiptDetails(r.Context(), r.PathValue("id"))
+	respond(w, &ticket.GetReceiptDetailsResponse{Receipt: receipt}, err, http.StatusOK)
+}
+
+// getReceiptByEmail answers for the only ticket held under an email; a passenger with several must use /v1/tickets/{id}.
+func (g *Gateway) getReceiptByEmail(w http.ResponseWriter, r *http.Request) {
+	req := &ticket.GetReceiptDetailsRequest{Identifier: &ticket.GetReceiptDetailsRequest_Email{Email: r.PathValue("email")}}
+	if !g.allowed(w, r, ticket.TrainTicketingService_GetReceiptDetails_FullMethodName, req) {
+		return
+	}
+	receipt, err := g.ticketService.GetReceiptByEmail(r.Context(), r.PathValue("email"))
+	respond(w, &ticket.GetReceiptDetailsResponse{Receipt: receipt}, err, http.StatusOK)
+}
+
+func (g *Gateway) getTicketHistory(w http.ResponseWriter, r *http.Request) {
+	if !g.allowed(w, r, ticket.TrainTicketingService_GetTicketHistory_FullMethodName, &ticket.GetTicketHistoryRequest{TicketId: r.PathValue("id")}) {
+		return
+	}
+	resp, err := g.ticketService.GetTicketHistory(r.Context(), r.PathValue("id"))
+	respond(w, resp, err, http.StatusOK)
+}
+
+// modifyUserSeat takes the new seat as the request body, e.g. {"seatNumber": "B2"}.
+func (g *Gateway) modifyUserSeat(w http.ResponseWriter, r *http.Request) {
+	g.changeSeat(w, r, &ticket.ModifyUserSeatRequest{
+		Identifier: &ticket.ModifyUserSeatRequest_TicketId{TicketId: r.PathValue("id")},
+	})
+}
+
+// modifyUserSeatByEmail moves the only ticket held under an email, taking the new seat as the request body.
+func (g *Gateway) modifyUserSeatByEmail(w http.ResponseWriter, r *http.Request) {
+	g.changeSeat(w, r, &ticket.ModifyUserSeatRequest{
+		Identifier: &ticket.ModifyUserSeatRequest_Email{Email: r.PathValue("email")},
+	})
+}
+
+func (g *Gateway) changeSeat(w http.ResponseWriter, r *http.Request, req *ticket.ModifyUserSeatRequest) {
+	newSeat := &ticket.Seat{}
+	if !decode(w, r, newSeat) {
+		return
+	}
+	req.NewSeat = newSeat
+	if !validate(w, util.ValidateModifyUserSeatRequestObject(req)) || !g.allowed(w, r, ticket.TrainTicketingService_ModifyUserSeat_FullMethodName, req) {
+		return
+	}
+	var (
+		receipt *ticket.Receipt
+		err     error
+	)
+	if req.GetTicketId() != "" {
+		receipt, err = g.ticketService.GetReceiptDetails(r.Context(), req.GetTicketId())
+	} else {
+		receipt, err = g.ticketService.GetReceiptByEmail(r.Context(), req.GetEmail())
+	}
+	if err != nil {
+		respond(w, nil, err, http.StatusOK)
+		return
+	}
+	resp, err := g.ticketService.ModifyUserSeat(r.Context(), receipt, newSeat)
+	respond(w, resp, err, http.StatusOK)
+}
+
+// getUsersBySection accepts a coach of the journey's layout or a legacy section, as "A" or "SECTION_A".
+// The journey is given with the journey_id query parameter and defaults to the default journey.
+func (g *Gateway) getUsersBySection(w http.ResponseWriter, r *http.Request) {
+	req := &ticket.GetUsersBySectionRequest{JourneyId: r.URL.Query().Get("journey_id"), Coach: strings.TrimPrefix(r.PathValue("section"), "SECTION_")}
+	if !g.allowed(w, r, ticket.TrainTicketingService_GetUsersBySection_FullMethodName, req) {
+		return
+	}
+	resp, err := g.ticketService.GetUsersBySection(r.Context(), req.GetJourneyId(), req.GetCoach())
+	respond(w, resp, err, http.StatusOK)
+}
+
+func (g *Gateway) removeUser(w http.ResponseWriter, r *http.Request) {
+	req := &ticket.RemoveUserRequest{Identifier: &ticket.RemoveUserRequest_Email{Email: r.PathValue("email")}}
+	if !g.allowed(w, r, ticket.TrainTicketingService_RemoveUser_FullMethodName, req) {
+		return
+	}
+	resp, err := g.ticketService.RemoveUser(r.Context(), r.PathValue("email"))
+	respond(w, resp, err, http.StatusOK)
+}
+
+func (g *Gateway) cancelTicket(w http.ResponseWriter, r *http.Request) {
+	if !g.allowed(w, r, ticket.TrainTicketingService_CancelTicket_FullMethodName, &ticket.CancelTicketRequest{TicketId: r.PathValue("id")}) {
+		return
+	}
+	resp, err := g.ticketService.CancelTicket(r.Context(), r.PathValue("id"))
+	respond(w, resp, err, http.StatusOK)
+}
+
+func (g *Gateway) getRefund(w http.ResponseWriter, r *http.Request) {
+	resp, err := g.ticketService.GetRefund(r.Context(), r.PathValue("id"))
+	respond(w, resp, err, http.StatusOK)
+}
+
+// listTicketsForUser pages with the optional page_size and page_token query parameters.
+func (g *Gateway) listTicketsForUser(w http.ResponseWriter, r *http.Request) {
+	req := &ticket.ListTicketsForUserRequest{Email: r.PathValue("email"), PageToken: r.URL.Query().Get("page_token")}
+	if value := r.URL.Query().Get("page_size"); value != "" {
+		size, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			validate(w, &util.FieldError{Field: "page_size", Description: "PageSize must be an integer"})
+			return
+		}
+		req.PageSize = int32(size)
+	}
+	if !validate(w, util.ValidateListTicketsForUserRequestObject(req)) || !g.allowed(w, r, ticket.TrainTicketingService_ListTicketsForUser_FullMethodName, req) {
+		return
+	}
+	resp, err := g.ticketService.ListTicketsForUser(r.Context(), req)
+	respond(w, resp, err, http.StatusOK)
+}
+
+func (g *Gateway) holdSeat(w http.ResponseWriter, r *http.Request) {
+	req := &ticket.HoldSeatRequest{}
+	if !decode(w, r, req) || !validate(w, util.ValidateHoldSeatRequestObject(req)) || !g.allowed(w, r, ticket.TrainTicketingService_HoldSeat_FullMethodName, req) {
+		return
+	}
+	resp, err := g.ticketService.HoldSeat(r.Context(), req)
+	respond(w, resp, err, http.StatusCreated)
+}
+
+// confirmHold takes the price as the request body, e.g. {"price": {"minorUnits": "2000", "currencyCode": "USD"}}.
+func (g *Gateway) confirmHold(w http.ResponseWriter, r *http.Request) {
+	req := &ticket.ConfirmHoldRequest{}
+	if !decode(w, r, req) {
+		return
+	}
+	req.HoldId = r.PathValue("id")
+	if !validate(w, util.ValidateConfirmHoldRequestObject(req)) || !validate(w, money.ValidatePrice("price", req.GetPrice())) ||
+		!g.allowed(w, r, ticket.TrainTicketingService_ConfirmHold_FullMethodName, req) {
+		return
+	}
+	resp, err := g.ticketService.ConfirmHold(r.Context(), req)
+	respond(w, resp, err, http.StatusCreated)
+}
+
+func (g *Gateway) joinWaitlist(w http.ResponseWriter, r *http.Request) {
+	req := &ticket.JoinWaitlistRequest{}
+	if !decode(w, r, req) || !validate(w, util.ValidateJoinWaitlistRequestObject(req)) || !validate(w, money.ValidatePrice("price", req.GetPrice())) ||
+		!g.allowed(w, r, ticket.TrainTicketingService_JoinWaitlist_FullMethodName, req) {
+		return
+	}
+	resp, err := g.ticketService.JoinWaitlist(r.Context(), req)
+	respond(w, resp, err, http.StatusCreated)
+}
+
+func (g *Gateway) getWaitlistStatus(w http.ResponseWriter, r *http.Request) {
+	if !g.allowed(w, r, ticket.TrainTicketingService_GetWaitlistStatus_FullMethodName, &ticket.GetWaitlistStatusRequest{WaitlistId: r.PathValue("id")}) {
+		return
+	}
+	resp, err := g.ticketService.GetWaitlistStatus(r.Context(), r.PathValue("id"))
+	respond(w, resp, err, http.StatusOK)
+}
+
+func (g *Gateway) createJourney(w http.ResponseWriter, r *http.Request) {
+	req := &ticket.CreateJourneyRequest{}
+	if !decode(w, r, req) || !validate(w, util.ValidateCreateJourneyRequestObject(req)) ||
+		!g.allowed(w, r, ticket.TrainTicketingService_CreateJourney_FullMethodName, req) {
+		return
+	}
+	resp, err := g.ticketService.CreateJourney(r.Context(), req)
+	respond(w, resp, err, http.StatusCreated)
+}
+
+// listJourneys filters by the optional service_date query parameter.
+func (g *Gateway) listJourneys(w http.ResponseWriter, r *http.Request) {
+	serviceDate := r.URL.Query().Get("service_date")
+	if serviceDate != "" && !validate(w, util.ValidateServiceDate(serviceDate)) ||
+		!g.allowed(w, r, ticket.TrainTicketingService_ListJourneys_FullMethodName, &ticket.ListJourneysRequest{ServiceDate: serviceDate}) {
+		return
+	}
+	resp, err := g.ticketService.ListJourneys(r.Context(), serviceDate)
+	respond(w, resp, err, http.StatusOK)
+}
+
+// getSeatOccupant answers for the time in the optional RFC 3339 "at" query parameter, or now.
+// The default journey is addressed as "default".
+func (g *Gateway) getSeatOccupant(w http.ResponseWriter, r *http.Request) {
+	at := time.Now()
+	if value := r.URL.Query().Get("at"); value != "" {
+		var err error
+		if at, err = time.Parse(time.RFC3339, value); !validate(w, err) {
+			return
+		}
+	}
+	req := &ticket.GetSeatOccupantRequest{SeatNumber: r.PathValue("seat"), At: timestamppb.New(at), JourneyId: r.PathValue("journey")}
+	if !g.allowed(w, r, ticket.TrainTicketingService_GetSeatOccupant_FullMethodName, req) {
+		return
+	}
+	resp, err := g.ticketService.GetSeatOccupant(r.Context(), r.PathValue("journey"), r.PathValue("seat"), at)
+	respond(w, resp, err, http.StatusOK)
+}
+
+// quoteFare reads the trip from query parameters; travel_class and passenger_type take
+// enum value names, e.g. "TRAVEL_CLASS_FIRST", coach prices a seat in that coach and
+// currency_code converts the price, e.g. "EUR".
+func (g *Gateway) quoteFare(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	req := &ticket.QuoteFareRequest{
+		FromLocation: query.Get("from_location"),
+		ToLocation:   query.Get("to_location"),
+		JourneyId:    query.Get("journey_id"),
+		CurrencyCode: query.Get("currency_code"),
+	}
+	if coach := query.Get("coach"); coach != "" {
+		req.Preferences = &ticket.SeatPreferences{Coach: coach}
+	}
+	if value := query.Get("travel_class"); value != "" {
+		class, ok := ticket.TravelClass_value[value]
+		if !ok {
+			validate(w, &util.FieldError{Field: "travel_class", Description: "TravelClass is invalid"})
+			return
+		}
+		req.TravelClass = ticket.TravelClass(class)
+	}
+	if value := query.Get("passenger_type"); value != "" {
+		passenger, ok := ticket.PassengerType_value[value]
+		if !ok {
+			validate(w, &util.FieldError{Field: "passenger_type", Description: "PassengerType is invalid"})
+			return
+		}
+		req.PassengerType = ticket.PassengerType(passenger)
+	}
+	if !validate(w, util.ValidateQuoteFareRequestObject(req)) || !validate(w, money.ValidateCurrency("currency_code", req.GetCurrencyCode())) ||
+		!g.allowed(w, r, ticket.TrainTicketingService_QuoteFare_FullMethodName, req) {
+		return
+	}
+	resp, err := g.ticketService.QuoteFare(r.Context(), req)
+	respond(w, resp, err, http.StatusOK)
+}
+
+// decode reads a protojson request body into msg, answering 400 if it cannot.
+func decode(w http.ResponseWriter, r *http.Request, msg proto.Message) bool {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxBodyBytes))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return false
+	}
+	if len(body) == 0 {
+		return true
+	}
+	if err := protojson.Unmarshal(body, msg); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return false
+	}
+	return true
+}
+
+// validate answers 400 for a failed request validation, naming the invalid field when known.
+func validate(w http.ResponseWriter, err error) bool {
+	if err == nil {
+		return true
+	}
+	body := errorBody{Error: err.Error()}
+	var fieldErr *util.FieldError
+	if errors.As(err, &fieldErr) {
+		body.Field = fieldErr.Field
+	}
+	writeJSON(w, http.StatusBadRequest, body)
+	return false
+}
+
+// respond writes the result of a service call. Failures are answered with the status of their
+// kind and an error body carrying the service's reason.
+func respond(w http.ResponseWriter, resp proto.Message, err error, successStatus int) {
+	if err != nil {
+		body := errorBody{Error: err.Error()}
+		if e, ok := service.AsError(err); ok {
+			body.Reason, body.Seat = e.Reason, e.Seat
+			if e.RetryAfter > 0 {
+				w.Header().Set("Retry-After", ratelimit.RetryAfterSeconds(e.RetryAfter))
+			}
+		}
+		writeJSON(w, statusOf(err), body)
+		return
+	}
+	body, err := protojson.Marshal(resp)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(successStatus)
+	if _, err := w.Write(body); err != nil {
+		log.Printf("[Gateway] Failed to write response: %v", err)
+	}
+}
+
+// errorBody is the JSON body of a failed request.
+type errorBody struct {
+	Error  string `json:"error"`
+	Reason string `json:"reason,omitempty"` // Reason of a service failure, such as SEAT_OCCUPIED.
+	Field  string `json:"field,omitempty"`  // Request field that failed validation.
+	Seat   string `json:"seat,omitempty"`   // Seat in conflict.
+}
+
+// writeError answers with a JSON body of the form {"error": "..."}.
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, errorBody{Error: message})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body errorBody) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		log.Printf("[Gateway] Failed to write error response: %v", err)
+	}
+}
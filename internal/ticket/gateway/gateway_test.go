@@ -0,0 +1,525 @@
+package gateway_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/golang/mock/gomock"
+	ticket "github.com/talk2sohail/train-ticket-api/internal/common/genproto/ticket"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/auth"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/fare"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/gateway"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/payment"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/ratelimit"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/service"
+	"github.com/talk2sohail/train-ticket-api/mock"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// do sends a request to the gateway and decodes a successful protojson body into out, if given.
+func do(t *testing.T, g http.Handler, method, path, body string, out proto.Message) *httptest.ResponseRecorder {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	g.ServeHTTP(rec, httptest.NewRequest(method, path, strings.NewReader(body)))
+	if out != nil && rec.Code < http.StatusBadRequest {
+		if err := protojson.Unmarshal(rec.Body.Bytes(), out); err != nil {
+			t.Fatalf("unexpected error decoding %s %s response %q: %v", method, path, rec.Body.String(), err)
+		}
+	}
+	return rec
+}
+
+const purchaseBody = `{"fromLocation": "London", "toLocation": "Paris", "user": {"firstName": "Alice", "lastName": "Smith", "email": "%s"}, "pricePaid": 20}`
+
+func purchase(t *testing.T, g http.Handler, email string) *ticket.Receipt {
+	t.Helper()
+	resp := &ticket.PurchaseTicketResponse{}
+	rec := do(t, g, http.MethodPost, "/v1/tickets", strings.Replace(purchaseBody, "%s", email, 1), resp)
+	if rec.Code != http.StatusCreated || !resp.GetSuccess() {
+		t.Fatalf("expected 201 for purchase, got %d: %s", rec.Code, rec.Body.String())
+	}
+	return resp.GetReceipt()
+}
+
+func TestUnit_GatewayTickets(t *testing.T) {
+	g := gateway.NewGateway(service.NewTicketService())
+	receipt := purchase(t, g, "alice@example.com")
+
+	t.Run("Get ticket", func(t *testing.T) {
+		resp := &ticket.GetReceiptDetailsResponse{}
+		rec := do(t, g, http.MethodGet, "/v1/tickets/"+receipt.GetTicketId(), "", resp)
+		if rec.Code != http.StatusOK || resp.GetReceipt().GetUser().GetEmail() != "alice@example.com" {
+			t.Errorf("expected 200 with alice's receipt, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+			t.Errorf("expected application/json, got %q", ct)
+		}
+	})
+
+	t.Run("Unknown ticket", func(t *testing.T) {
+		if rec := do(t, g, http.MethodGet, "/v1/tickets/missing", "", nil); rec.Code != http.StatusNotFound {
+			t.Errorf("expected 404, got %d: %s", rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("Invalid purchase", func(t *testing.T) {
+		for _, body := range []string{`{"fromLocation": "London"}`, `not json`, `{"unknownField": 1}`} {
+			if rec := do(t, g, http.MethodPost, "/v1/tickets", body, nil); rec.Code != http.StatusBadRequest {
+				t.Errorf("expected 400 for %s, got %d", body, rec.Code)
+			}
+		}
+	})
+
+	t.Run("Change seat", func(t *testing.T) {
+		resp := &ticket.ModifyUserSeatResponse{}
+		rec := do(t, g, http.MethodPatch, "/v1/tickets/"+receipt.GetTicketId()+"/seat", `{"seatNumber": "B2"}`, resp)
+		if rec.Code != http.StatusOK || resp.GetUpdatedReceipt().GetAllocatedSeat().GetSeatNumber() != "B2" {
+			t.Errorf("expected 200 with seat B2, got %d: %s", rec.Code, rec.Body.String())
+		}
+
+		other := purchase(t, g, "bob@example.com")
+		if rec := do(t, g, http.MethodPatch, "/v1/tickets/"+other.GetTicketId()+"/seat", `{"seatNumber": "B2"}`, nil); rec.Code != http.StatusConflict || !strings.Contains(rec.Body.String(), `"seat":"B2"`) {
+			t.Errorf("expected 409 naming the occupied seat, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if rec := do(t, g, http.MethodPatch, "/v1/tickets/"+other.GetTicketId()+"/seat", `{"seatNumber": "Z9"}`, nil); rec.Code != http.StatusNotFound {
+			t.Errorf("expected 404 for a seat not in the layout, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if rec := do(t, g, http.MethodPatch, "/v1/tickets/"+other.GetTicketId()+"/seat", `{}`, nil); rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), `"field":"new_seat.seat_number"`) {
+			t.Errorf("expected 400 naming the missing seat number, got %d: %s", rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("Passengers by section", func(t *testing.T) {
+		for _, section := range []string{"B", "SECTION_B"} {
+			resp := &ticket.GetUsersBySectionResponse{}
+			rec := do(t, g, http.MethodGet, "/v1/sections/"+section+"/passengers", "", resp)
+			if rec.Code != http.StatusOK || len(resp.GetUsersInSection()) != 1 {
+				t.Errorf("expected 200 with one passenger in %s, got %d: %s", section, rec.Code, rec.Body.String())
+			}
+		}
+		if rec := do(t, g, http.MethodGet, "/v1/sections/Z/passengers", "", nil); rec.Code != http.StatusNotFound {
+			t.Errorf("expected 404 for an unknown coach, got %d", rec.Code)
+		}
+	})
+
+	t.Run("Remove passenger", func(t *testing.T) {
+		if rec := do(t, g, http.MethodDelete, "/v1/passengers/alice@example.com", "", nil); rec.Code != http.StatusOK {
+			t.Errorf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		rec := do(t, g, http.MethodDelete, "/v1/passengers/alice@example.com", "", nil)
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("expected 404 once removed, got %d", rec.Code)
+		}
+		if !strings.Contains(rec.Body.String(), `"reason":"`+service.ReasonUserNotFound+`"`) {
+			t.Errorf("expected the service's reason in the body, got %s", rec.Body.String())
+		}
+	})
+
+	t.Run("Wrong method", func(t *testing.T) {
+		if rec := do(t, g, http.MethodPut, "/v1/tickets", "", nil); rec.Code != http.StatusMethodNotAllowed {
+			t.Errorf("expected 405, got %d", rec.Code)
+		}
+	})
+}
+
+func TestUnit_GatewayTicketsByEmail(t *testing.T) {
+	g := gateway.NewGateway(service.NewTicketService())
+	purchase(t, g, "carol@example.com")
+
+	t.Run("Get ticket", func(t *testing.T) {
+		resp := &ticket.GetReceiptDetailsResponse{}
+		rec := do(t, g, http.MethodGet, "/v1/passengers/carol@example.com/ticket", "", resp)
+		if rec.Code != http.StatusOK || resp.GetReceipt().GetUser().GetEmail() != "carol@example.com" {
+			t.Errorf("expected 200 with carol's receipt, got %d: %s", rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("Change seat", func(t *testing.T) {
+		resp := &ticket.ModifyUserSeatResponse{}
+		rec := do(t, g, http.MethodPatch, "/v1/passengers/carol@example.com/seat", `{"seatNumber": "B3"}`, resp)
+		if rec.Code != http.StatusOK || resp.GetUpdatedReceipt().GetAllocatedSeat().GetSeatNumber() != "B3" {
+			t.Errorf("expected 200 with seat B3, got %d: %s", rec.Code, rec.Body.String())
+		}
+	})
+
+	second := purchase(t, g, "carol@example.com")
+
+	t.Run("Several tickets are ambiguous", func(t *testing.T) {
+		rec := do(t, g, http.MethodGet, "/v1/passengers/carol@example.com/ticket", "", nil)
+		if rec.Code != http.StatusConflict || !strings.Contains(rec.Body.String(), `"reason":"`+service.ReasonEmailAmbiguous+`"`) {
+			t.Errorf("expected 409 for an ambiguous email, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if rec := do(t, g, http.MethodDelete, "/v1/passengers/carol@example.com", "", nil); rec.Code != http.StatusConflict {
+			t.Errorf("expected 409 removing an ambiguous email, got %d: %s", rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("List tickets", func(t *testing.T) {
+		first := &ticket.ListTicketsForUserResponse{}
+		rec := do(t, g, http.MethodGet, "/v1/passengers/carol@example.com/tickets?page_size=1", "", first)
+		if rec.Code != http.StatusOK || len(first.GetTickets()) != 1 || first.GetNextPageToken() == "" {
+			t.Fatalf("expected 200 with one ticket and a next page, got %d: %s", rec.Code, rec.Body.String())
+		}
+		next := &ticket.ListTicketsForUserResponse{}
+		rec = do(t, g, http.MethodGet, "/v1/passengers/carol@example.com/tickets?page_size=1&page_token="+first.GetNextPageToken(), "", next)
+		if rec.Code != http.StatusOK || len(next.GetTickets()) != 1 || next.GetTickets()[0].GetTicketId() != second.GetTicketId() || next.GetNextPageToken() != "" {
+			t.Errorf("expected 200 with the second ticket on the last page, got %d: %s", rec.Code, rec.Body.String())
+		}
+		for _, query := range []string{"page_size=many", "page_size=-1", "page_token=bogus"} {
+			if rec := do(t, g, http.MethodGet, "/v1/passengers/carol@example.com/tickets?"+query, "", nil); rec.Code != http.StatusBadRequest {
+				t.Errorf("expected 400 for %s, got %d: %s", query, rec.Code, rec.Body.String())
+			}
+		}
+	})
+
+	t.Run("Remove ticket", func(t *testing.T) {
+		if rec := do(t, g, http.MethodDelete, "/v1/tickets/"+second.GetTicketId(), "", nil); rec.Code != http.StatusOK {
+			t.Errorf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if rec := do(t, g, http.MethodDelete, "/v1/tickets/"+second.GetTicketId(), "", nil); rec.Code != http.StatusNotFound {
+			t.Errorf("expected 404 once removed, got %d", rec.Code)
+		}
+		// With one ticket left, the email identifies it again.
+		if rec := do(t, g, http.MethodGet, "/v1/passengers/carol@example.com/ticket", "", nil); rec.Code != http.StatusOK {
+			t.Errorf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+	})
+}
+
+func TestUnit_GatewayStatusCodes(t *testing.T) {
+	t.Run("Sold out is a conflict", func(t *testing.T) {
+		g := gateway.NewGateway(service.NewTicketService())
+		for i := 0; i < 2*service.MaxSeatsPerSection; i++ {
+			purchase(t, g, "user@example.com")
+		}
+		if rec := do(t, g, http.MethodPost, "/v1/tickets", strings.Replace(purchaseBody, "%s", "late@example.com", 1), nil); rec.Code != http.StatusConflict {
+			t.Errorf("expected 409, got %d: %s", rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("Exceeding the ticket quota is too many requests", func(t *testing.T) {
+		g := gateway.NewGateway(service.NewTicketService(service.WithTicketQuota(1), service.WithHoldTTL(90*time.Second)))
+		hold := `{"fromLocation": "London", "toLocation": "Paris", "user": {"email": "alice@example.com"}}`
+		if rec := do(t, g, http.MethodPost, "/v1/holds", hold, nil); rec.Code != http.StatusCreated {
+			t.Fatalf("expected 201 for the hold, got %d: %s", rec.Code, rec.Body.String())
+		}
+		rec := do(t, g, http.MethodPost, "/v1/tickets", strings.Replace(purchaseBody, "%s", "alice@example.com", 1), nil)
+		if rec.Code != http.StatusTooManyRequests || !strings.Contains(rec.Body.String(), service.ReasonTicketQuotaExceeded) {
+			t.Errorf("expected 429 with the quota reason, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if got := rec.Header().Get("Retry-After"); got != "90" && got != "89" {
+			t.Errorf("expected to retry when the hold expires, got Retry-After %q", got)
+		}
+
+		purchase(t, g, "bob@example.com")
+		rec = do(t, g, http.MethodPost, "/v1/tickets", strings.Replace(purchaseBody, "%s", "bob@example.com", 1), nil)
+		if rec.Code != http.StatusTooManyRequests || rec.Header().Get("Retry-After") != "" {
+			t.Errorf("expected 429 without Retry-After when no hold will expire, got %d with %q", rec.Code, rec.Header().Get("Retry-After"))
+		}
+	})
+
+	t.Run("Unnamed service errors are internal", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockSvc := mock.NewMockTicketService(ctrl)
+		mockSvc.EXPECT().GetTicketHistory(gomock.Any(), "t1").Return(nil, errors.New("disk full"))
+		g := gateway.NewGateway(mockSvc)
+		rec := do(t, g, http.MethodGet, "/v1/tickets/t1/history", "", nil)
+		if rec.Code != http.StatusInternalServerError || !strings.Contains(rec.Body.String(), "disk full") {
+			t.Errorf("expected 500 with the error, got %d: %s", rec.Code, rec.Body.String())
+		}
+	})
+}
+
+// authFixture is an authenticating gateway over a service holding a ticket, a hold
+// and a waitlist entry of alice. Its replacer fills them into route paths.
+type authFixture struct {
+	gateway  http.Handler
+	replacer *strings.Replacer
+}
+
+func newAuthFixture(t *testing.T) *authFixture {
+	t.Helper()
+	ctx := context.Background()
+	s := service.NewTicketService()
+	buy := func(email, journeyID string) string {
+		t.Helper()
+		resp, err := s.PurchaseTicket(ctx, &ticket.PurchaseTicketRequest{
+			FromLocation: "London", ToLocation: "Paris", User: &ticket.User{Email: email}, PricePaid: 20, JourneyId: journeyID,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error purchasing: %v", err)
+		}
+		return resp.GetReceipt().GetTicketId()
+	}
+	ticketID := buy("alice@example.com", "")
+	hold, err := s.HoldSeat(ctx, &ticket.HoldSeatRequest{FromLocation: "London", ToLocation: "Paris", User: &ticket.User{Email: "alice@example.com"}})
+	if err != nil {
+		t.Fatalf("unexpected error holding: %v", err)
+	}
+	// A one-seat-per-coach journey sold out to others, so that alice can join its waitlist.
+	journey, err := s.CreateJourney(ctx, &ticket.CreateJourneyRequest{
+		ServiceDate: "2025-05-01", DepartureTime: timestamppb.New(time.Date(2025, 5, 1, 8, 0, 0, 0, time.UTC)),
+		Origin: "London", Destination: "Paris", SeatsPerSection: 1,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error creating journey: %v", err)
+	}
+	journeyID := journey.GetJourney().GetJourneyId()
+	buy("b@example.com", journeyID)
+	buy("c@example.com", journeyID)
+	entry, err := s.JoinWaitlist(ctx, &ticket.JoinWaitlistRequest{
+		FromLocation: "London", ToLocation: "Paris", User: &ticket.User{Email: "alice@example.com"}, PricePaid: 20, JourneyId: journeyID,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error joining waitlist: %v", err)
+	}
+
+	verifier, err := auth.NewVerifier("", "", auth.Key{ID: "hs", Algorithm: "HS256", Secret: []byte("test-secret")})
+	if err != nil {
+		t.Fatalf("unexpected error creating verifier: %v", err)
+	}
+	return &authFixture{
+		gateway: gateway.NewGateway(s, gateway.WithAuthorizer(auth.NewAuthorizer(verifier, s))),
+		replacer: strings.NewReplacer(
+			"{ticket}", ticketID, "{hold}", hold.GetHoldId(),
+			"{waitlist}", entry.GetEntry().GetWaitlistId(), "{journey}", journeyID,
+		),
+	}
+}
+
+// token signs a token for a caller with the fixture's key; an empty role gives no token.
+func token(t *testing.T, role auth.Role, email string) string {
+	t.Helper()
+	if role == "" {
+		return ""
+	}
+	jwtToken := jwt.NewWithClaims(jwt.SigningMethodHS256, auth.Claims{
+		Email: email, Role: role, RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour))},
+	})
+	jwtToken.Header["kid"] = "hs"
+	signed, err := jwtToken.SignedString([]byte("test-secret"))
+	if err != nil {
+		t.Fatalf("unexpected error signing token: %v", err)
+	}
+	return "Bearer " + signed
+}
+
+func TestUnit_GatewayAuthorization(t *testing.T) {
+	callers := []struct {
+		name   string
+		role   auth.Role
+		email  string
+		column int // Column of allowed the caller is expected to follow.
+	}{
+		{"owner", auth.RolePassenger, "alice@example.com", 0},
+		{"other passenger", auth.RolePassenger, "mallory@example.com", 1},
+		// Tickets belong to the email exactly as they were bought under.
+		{"owner's email in capitals", auth.RolePassenger, "ALICE@example.com", 1},
+		{"staff", auth.RoleStaff, "staff@example.com", 2},
+		{"admin", auth.RoleAdmin, "admin@example.com", 3},
+	}
+	// Every route on resources of alice, with whether the owner, another passenger, staff
+	// and admins may call it.
+	requests := []struct {
+		method, path, body string
+		allowed            [4]bool
+	}{
+		{http.MethodPost, "/v1/tickets", strings.Replace(purchaseBody, "%s", "alice@example.com", 1), [4]bool{true, false, true, true}},
+		{http.MethodPost, "/v1/group-tickets", `{"fromLocation": "London", "toLocation": "Paris", "pricePaid": 20, "passengers": [{"email": "friend@example.com"}, {"email": "alice@example.com"}]}`, [4]bool{true, false, true, true}},
+		{http.MethodGet, "/v1/tickets/{ticket}", "", [4]bool{true, false, true, true}},
+		{http.MethodDelete, "/v1/tickets/{ticket}", "", [4]bool{true, false, true, true}},
+		{http.MethodGet, "/v1/tickets/{ticket}/history", "", [4]bool{true, false, true, true}},
+		{http.MethodPatch, "/v1/tickets/{ticket}/seat", `{"seatNumber": "B5"}`, [4]bool{true, false, true, true}},
+		{http.MethodGet, "/v1/sections/A/passengers", "", [4]bool{false, false, true, true}},
+		{http.MethodDelete, "/v1/passengers/alice@example.com", "", [4]bool{true, false, true, true}},
+		{http.MethodGet, "/v1/passengers/alice@example.com/tickets", "", [4]bool{true, false, true, true}},
+		{http.MethodGet, "/v1/passengers/alice@example.com/ticket", "", [4]bool{true, false, true, true}},
+		{http.MethodPatch, "/v1/passengers/alice@example.com/seat", `{"seatNumber": "B5"}`, [4]bool{true, false, true, true}},
+		{http.MethodPost, "/v1/holds", `{"fromLocation": "London", "toLocation": "Paris", "user": {"email": "alice@example.com"}}`, [4]bool{true, false, true, true}},
+		{http.MethodPost, "/v1/holds/{hold}/confirm", `{"pricePaid": 20}`, [4]bool{true, false, true, true}},
+		{http.MethodPost, "/v1/waitlist", `{"fromLocation": "London", "toLocation": "Paris", "user": {"email": "alice@example.com"}, "pricePaid": 20, "journeyId": "{journey}"}`, [4]bool{true, false, true, true}},
+		{http.MethodGet, "/v1/waitlist/{waitlist}", "", [4]bool{true, false, true, true}},
+		{http.MethodPost, "/v1/journeys", `{"serviceDate": "2025-05-02", "departureTime": "2025-05-02T08:00:00Z", "origin": "London", "destination": "Paris", "seatsPerSection": 2}`, [4]bool{false, false, false, true}},
+		{http.MethodGet, "/v1/journeys", "", [4]bool{true, true, true, true}},
+		{http.MethodGet, "/v1/journeys/default/seats/A1/occupant", "", [4]bool{false, false, true, true}},
+		{http.MethodGet, "/v1/fares?from_location=London&to_location=Paris", "", [4]bool{true, true, true, true}},
+	}
+
+	for _, req := range requests {
+		t.Run(req.method+" "+req.path, func(t *testing.T) {
+			for _, caller := range callers {
+				t.Run(caller.name, func(t *testing.T) {
+					f := newAuthFixture(t)
+					r := httptest.NewRequest(req.method, f.replacer.Replace(req.path), strings.NewReader(f.replacer.Replace(req.body)))
+					r.Header.Set("Authorization", token(t, caller.role, caller.email))
+					rec := httptest.NewRecorder()
+					f.gateway.ServeHTTP(rec, r)
+					if want := req.allowed[caller.column]; want && (rec.Code == http.StatusUnauthorized || rec.Code == http.StatusForbidden) {
+						t.Errorf("expected %s to be allowed, got %d: %s", caller.name, rec.Code, rec.Body.String())
+					} else if !want && rec.Code != http.StatusForbidden {
+						t.Errorf("expected 403 for %s, got %d: %s", caller.name, rec.Code, rec.Body.String())
+					}
+				})
+			}
+			t.Run("anonymous", func(t *testing.T) {
+				f := newAuthFixture(t)
+				rec := do(t, f.gateway, req.method, f.replacer.Replace(req.path), f.replacer.Replace(req.body), nil)
+				if rec.Code != http.StatusUnauthorized || rec.Header().Get("WWW-Authenticate") != "Bearer" {
+					t.Errorf("expected 401 with a bearer challenge, got %d: %s", rec.Code, rec.Body.String())
+				}
+			})
+		})
+	}
+}
+
+func TestUnit_GatewayIdempotency(t *testing.T) {
+	g := gateway.NewGateway(service.NewTicketService(), gateway.WithIdempotency(service.NewIdempotencyStore(0, nil)))
+
+	// send makes a request carrying an idempotency key.
+	send := func(method, path, key, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if key != "" {
+			req.Header.Set(gateway.IdempotencyKeyHeader, key)
+		}
+		rec := httptest.NewRecorder()
+		g.ServeHTTP(rec, req)
+		return rec
+	}
+	body := strings.Replace(purchaseBody, "%s", "alice@example.com", 1)
+
+	t.Run("Retried purchase", func(t *testing.T) {
+		first := send(http.MethodPost, "/v1/tickets", "purchase-1", body)
+		second := send(http.MethodPost, "/v1/tickets", "purchase-1", body)
+		if first.Code != http.StatusCreated || second.Code != http.StatusCreated {
+			t.Fatalf("expected 201 twice, got %d and %d: %s", first.Code, second.Code, second.Body.String())
+		}
+		if first.Body.String() != second.Body.String() {
+			t.Errorf("expected the retry to get the first response, got %s and %s", first.Body.String(), second.Body.String())
+		}
+		if first.Header().Get(gateway.IdempotentReplayedHeader) != "" || second.Header().Get(gateway.IdempotentReplayedHeader) != "true" {
+			t.Errorf("expected only the retry to be marked as replayed")
+		}
+		resp := &ticket.ListTicketsForUserResponse{}
+		do(t, g, http.MethodGet, "/v1/passengers/alice@example.com/tickets", "", resp)
+		if len(resp.GetTickets()) != 1 {
+			t.Errorf("expected one ticket, got %d", len(resp.GetTickets()))
+		}
+	})
+
+	t.Run("Key reused for a different body", func(t *testing.T) {
+		send(http.MethodPost, "/v1/tickets", "purchase-2", body)
+		rec := send(http.MethodPost, "/v1/tickets", "purchase-2", strings.Replace(purchaseBody, "%s", "bob@example.com", 1))
+		if rec.Code != http.StatusConflict || !strings.Contains(rec.Body.String(), service.ReasonIdempotencyKeyReused) {
+			t.Errorf("expected 409 %s, got %d: %s", service.ReasonIdempotencyKeyReused, rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("Key reused for another ticket", func(t *testing.T) {
+		first, second := purchase(t, g, "carol@example.com"), purchase(t, g, "dave@example.com")
+		if rec := send(http.MethodDelete, "/v1/tickets/"+first.GetTicketId(), "cancel-1", ""); rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if rec := send(http.MethodDelete, "/v1/tickets/"+second.GetTicketId(), "cancel-1", ""); rec.Code != http.StatusConflict {
+			t.Errorf("expected 409, got %d: %s", rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("Failures are not replayed", func(t *testing.T) {
+		if rec := send(http.MethodDelete, "/v1/tickets/missing", "cancel-2", ""); rec.Code != http.StatusNotFound {
+			t.Fatalf("expected 404, got %d: %s", rec.Code, rec.Body.String())
+		}
+		rec := send(http.MethodDelete, "/v1/tickets/missing", "cancel-2", "")
+		if rec.Code != http.StatusNotFound || rec.Header().Get(gateway.IdempotentReplayedHeader) != "" {
+			t.Errorf("expected the failed request to be served again, got %d", rec.Code)
+		}
+	})
+
+	t.Run("Requests without a key are served every time", func(t *testing.T) {
+		for range 2 {
+			if rec := send(http.MethodPost, "/v1/tickets", "", strings.Replace(purchaseBody, "%s", "erin@example.com", 1)); rec.Code != http.StatusCreated {
+				t.Fatalf("expected 201, got %d: %s", rec.Code, rec.Body.String())
+			}
+		}
+		resp := &ticket.ListTicketsForUserResponse{}
+		do(t, g, http.MethodGet, "/v1/passengers/erin@example.com/tickets", "", resp)
+		if len(resp.GetTickets()) != 2 {
+			t.Errorf("expected two tickets, got %d", len(resp.GetTickets()))
+		}
+	})
+}
+
+func TestUnit_GatewayRateLimit(t *testing.T) {
+	limiter := ratelimit.NewLimiter(nil, map[string]ratelimit.Limit{"PurchaseTicket": {PerSecond: 0.5, Burst: 1}})
+	g := gateway.NewGateway(service.NewTicketService(), gateway.WithRateLimiter(limiter))
+
+	purchase(t, g, "alice@example.com")
+	rec := do(t, g, http.MethodPost, "/v1/tickets", strings.Replace(purchaseBody, "%s", "alice@example.com", 1), nil)
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected 429, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if got := rec.Header().Get("Retry-After"); got != "2" {
+		t.Errorf("expected Retry-After: 2, got %q", got)
+	}
+	// Routes of other RPCs are not limited.
+	if rec := do(t, g, http.MethodGet, "/v1/passengers/alice@example.com/tickets", "", nil); rec.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestUnit_GatewayQuoteFare(t *testing.T) {
+	table, err := fare.Build(fare.Default())
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := gateway.NewGateway(service.NewTicketService(service.WithFareTable(table)))
+
+	resp := &ticket.QuoteFareResponse{}
+	rec := do(t, g, http.MethodGet, "/v1/fares?from_location=London&to_location=Paris&travel_class=TRAVEL_CLASS_FIRST", "", resp)
+	if rec.Code != http.StatusOK || resp.GetFare().GetAmount() != 30 {
+		t.Fatalf("expected 200 with a fare of 30, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	if rec := do(t, g, http.MethodGet, "/v1/fares?from_location=London&to_location=Paris&passenger_type=infant", "", nil); rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an unknown passenger type, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	// Paying less than the fare is refused.
+	body := strings.Replace(purchaseBody, "%s", "alice@example.com", 1)
+	body = strings.Replace(body, `"pricePaid": 20`, `"pricePaid": 20, "travelClass": "TRAVEL_CLASS_FIRST"`, 1)
+	rec = do(t, g, http.MethodPost, "/v1/tickets", body, nil)
+	if rec.Code != http.StatusConflict || !strings.Contains(rec.Body.String(), service.ReasonFareMismatch) {
+		t.Errorf("expected 409 %s, got %d: %s", service.ReasonFareMismatch, rec.Code, rec.Body.String())
+	}
+}
+
+func TestUnit_GatewayPayment(t *testing.T) {
+	fake := payment.NewFake()
+	fake.DeclineMethod("tok_broke", "insufficient_funds")
+	g := gateway.NewGateway(service.NewTicketService(service.WithPayments(fake)))
+
+	body := strings.Replace(purchaseBody, "%s", "alice@example.com", 1)
+	declined := strings.Replace(body, `"pricePaid": 20`, `"pricePaid": 20, "paymentMethod": "tok_broke"`, 1)
+	rec := do(t, g, http.MethodPost, "/v1/tickets", declined, nil)
+	if rec.Code != http.StatusPaymentRequired || !strings.Contains(rec.Body.String(), service.ReasonPaymentDeclined) {
+		t.Errorf("expected 402 %s, got %d: %s", service.ReasonPaymentDeclined, rec.Code, rec.Body.String())
+	}
+
+	fake.Script(payment.OpAuthorize, payment.Outcome{Err: errors.New("processor unavailable")})
+	if rec := do(t, g, http.MethodPost, "/v1/tickets", body, nil); rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected 503 while the processor is down, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	resp := &ticket.PurchaseTicketResponse{}
+	if rec := do(t, g, http.MethodPost, "/v1/tickets", body, resp); rec.Code != http.StatusCreated || resp.GetReceipt().GetPayment().GetPaymentId() == "" {
+		t.Errorf("expected 201 with the payment on the receipt, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
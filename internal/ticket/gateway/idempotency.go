@@ -0,0 +1,85 @@
+package gateway
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"maps"
+	"net/http"
+
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/auth"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/service"
+)
+
+const (
+	// IdempotencyKeyHeader is the request header clients send an idempotency key in.
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader is set on the response to a replayed request.
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+)
+
+// idempotent wraps a route so that a request retried with the same Idempotency-Key, method,
+// path and body as an earlier successful one is answered with the earlier response.
+// Keys are scoped to the route and, when callers are authenticated, to the caller.
+func (g *Gateway) idempotent(handle func(*Gateway, http.ResponseWriter, *http.Request)) func(*Gateway, http.ResponseWriter, *http.Request) {
+	return func(g *Gateway, w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(IdempotencyKeyHeader)
+		if key == "" {
+			handle(g, w, r)
+			return
+		}
+		if err := service.ValidateIdempotencyKey(key); err != nil {
+			respond(w, nil, err, 0)
+			return
+		}
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxBodyBytes))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		fingerprint := append([]byte(r.Method+" "+r.URL.RequestURI()+"\n"), body...)
+
+		scope := "http\x00" + r.Pattern
+		if claims, ok := auth.FromContext(r.Context()); ok {
+			scope += "\x00" + string(claims.Role) + ":" + claims.Email
+		}
+		resp, replayed, err := g.idempotency.Do(r.Context(), scope+"\x00"+key, fingerprint, func() (any, bool) {
+			rec := &recorder{header: make(http.Header), status: http.StatusOK}
+			handle(g, rec, r)
+			return rec, rec.status < http.StatusMultipleChoices
+		})
+		if err != nil {
+			respond(w, nil, err, 0)
+			return
+		}
+		rec := resp.(*recorder)
+		maps.Copy(w.Header(), rec.header)
+		if replayed {
+			w.Header().Set(IdempotentReplayedHeader, "true")
+		}
+		w.WriteHeader(rec.status)
+		if _, err := w.Write(rec.body.Bytes()); err != nil {
+			log.Printf("[Gateway] Failed to write response: %v", err)
+		}
+	}
+}
+
+// recorder captures the response a route writes so that it can be replayed.
+type recorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (r *recorder) Header() http.Header {
+	return r.header
+}
+
+func (r *recorder) Write(b []byte) (int, error) {
+	return r.body.Write(b)
+}
+
+func (r *recorder) WriteHeader(status int) {
+	r.status = status
+}
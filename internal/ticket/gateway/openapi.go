@@ -0,0 +1,280 @@
+package gateway
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+	"strings"
+
+	ticket "github.com/talk2sohail/train-ticket-api/internal/common/genproto/ticket"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/service"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// openAPISpec is the OpenAPI document generated by GenerateOpenAPI. It is checked
+// in so it can be reviewed and served as is; a test fails when it is out of date.
+//
+//go:embed openapi.json
+var openAPISpec []byte
+
+func serveOpenAPI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := w.Write(openAPISpec); err != nil {
+		log.Printf("[Gateway] Failed to write OpenAPI document: %v", err)
+	}
+}
+
+var pathParam = regexp.MustCompile(`\{(\w+)\}`)
+
+// queryParamSchemas describes the query parameters routes read.
+var queryParamSchemas = map[string]map[string]any{
+	"journey_id":     {"type": "string", "description": "Journey to query; the default journey when empty."},
+	"service_date":   {"type": "string", "description": "Only journeys on this date, as YYYY-MM-DD."},
+	"at":             {"type": "string", "format": "date-time", "description": "Point in time to answer for; now when empty."},
+	"page_size":      {"type": "integer", "format": "int32", "description": "Maximum items to return; the server's default when empty."},
+	"page_token":     {"type": "string", "description": "nextPageToken of the previous page; empty for the first page."},
+	"from_location":  {"type": "string", "description": "Stop the trip starts at."},
+	"to_location":    {"type": "string", "description": "Stop the trip ends at."},
+	"travel_class":   {"$ref": "#/components/schemas/TravelClass"},
+	"passenger_type": {"$ref": "#/components/schemas/PassengerType"},
+	"coach":          {"type": "string", "description": "Preferred coach of the seat to price, e.g., \"C\"."},
+	"currency_code":  {"type": "string", "description": "ISO 4217 code of the currency to quote in; the fare table's when empty."},
+}
+
+// GenerateOpenAPI builds an OpenAPI 3 document for the REST routes from the proto
+// descriptors compiled into the genproto package. Schemas follow the protojson
+// mapping: fields use their JSON names, enums their value names, 64-bit integers
+// are strings and timestamps are RFC 3339 strings.
+func GenerateOpenAPI() ([]byte, error) {
+	svc := ticket.File_ticket_proto.Services().ByName("TrainTicketingService")
+	if svc == nil {
+		return nil, fmt.Errorf("TrainTicketingService descriptor not found")
+	}
+
+	gen := &schemaGenerator{schemas: map[string]any{}, names: map[string]protoreflect.FullName{}}
+	gen.schemas["Error"] = map[string]any{
+		"type":     "object",
+		"required": []any{"error"},
+		"properties": map[string]any{
+			"error":  map[string]any{"type": "string"},
+			"reason": map[string]any{"type": "string", "description": "Reason of a service failure, such as SEAT_OCCUPIED."},
+			"field":  map[string]any{"type": "string", "description": "Request field that failed validation."},
+			"seat":   map[string]any{"type": "string", "description": "Seat in conflict."},
+		},
+	}
+
+	paths := map[string]map[string]any{}
+	for _, rt := range routes {
+		method := svc.Methods().ByName(protoreflect.Name(rt.rpc))
+		if method == nil {
+			return nil, fmt.Errorf("route %s %s calls unknown RPC %s", rt.method, rt.path, rt.rpc)
+		}
+		op, err := gen.operation(rt, method)
+		if err != nil {
+			return nil, err
+		}
+		if paths[rt.path] == nil {
+			paths[rt.path] = map[string]any{}
+		}
+		paths[rt.path][strings.ToLower(rt.method)] = op
+	}
+	// Every RPC's messages are described, including those only reachable over gRPC
+	// such as the identifier oneofs of requests whose REST routes take a path parameter.
+	for i := 0; i < svc.Methods().Len(); i++ {
+		gen.ref(svc.Methods().Get(i).Input())
+		gen.ref(svc.Methods().Get(i).Output())
+	}
+	if err := gen.err; err != nil {
+		return nil, err
+	}
+
+	doc := map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":       "Train Ticketing API",
+			"version":     "v1",
+			"description": "REST/JSON gateway of " + string(svc.FullName()) + ". Generated from proto/*.proto; do not edit by hand.",
+		},
+		"paths":      paths,
+		"components": map[string]any{"schemas": gen.schemas},
+	}
+	out, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(out, '\n'), nil
+}
+
+func (gen *schemaGenerator) operation(rt route, method protoreflect.MethodDescriptor) (map[string]any, error) {
+	var params []any
+	for _, match := range pathParam.FindAllStringSubmatch(rt.path, -1) {
+		params = append(params, map[string]any{
+			"name":     match[1],
+			"in":       "path",
+			"required": true,
+			"schema":   map[string]any{"type": "string"},
+		})
+	}
+	for _, name := range rt.query {
+		schema, ok := queryParamSchemas[name]
+		if !ok {
+			return nil, fmt.Errorf("route %s %s reads undocumented query parameter %s", rt.method, rt.path, name)
+		}
+		params = append(params, map[string]any{"name": name, "in": "query", "schema": schema})
+	}
+	if rt.method != http.MethodGet {
+		params = append(params, map[string]any{
+			"name":        IdempotencyKeyHeader,
+			"in":          "header",
+			"description": "Retrying with the same key and request replays the first successful response.",
+			"schema":      map[string]any{"type": "string", "maxLength": service.MaxIdempotencyKeyLength},
+		})
+	}
+
+	output := gen.ref(method.Output())
+	op := map[string]any{
+		"operationId": rt.operationID(),
+		"responses": map[string]any{
+			fmt.Sprint(rt.successStatus()): jsonContent("Success.", output),
+			"default":                      jsonContent("Failure.", map[string]any{"$ref": "#/components/schemas/Error"}),
+		},
+	}
+	if len(params) > 0 {
+		op["parameters"] = params
+	}
+	if rt.body != nil {
+		body := jsonContent("", gen.ref(rt.body.ProtoReflect().Descriptor()))
+		delete(body, "description")
+		body["required"] = true
+		op["requestBody"] = body
+	}
+	return op, nil
+}
+
+func jsonContent(description string, schema any) map[string]any {
+	return map[string]any{
+		"description": description,
+		"content":     map[string]any{"application/json": map[string]any{"schema": schema}},
+	}
+}
+
+// schemaGenerator collects component schemas for the messages and enums reachable from the routes.
+type schemaGenerator struct {
+	schemas map[string]any
+	names   map[string]protoreflect.FullName // Schema name to the descriptor it was generated from.
+	err     error
+}
+
+// schemaName drops the proto package, so trainticketing.entities.Seat.Section becomes Seat.Section.
+func schemaName(desc protoreflect.Descriptor) string {
+	return strings.TrimPrefix(string(desc.FullName()), string(desc.ParentFile().Package())+".")
+}
+
+// ref returns a reference to the schema of a message or enum, generating it on first use.
+func (gen *schemaGenerator) ref(desc protoreflect.Descriptor) map[string]any {
+	name := schemaName(desc)
+	ref := map[string]any{"$ref": "#/components/schemas/" + name}
+	if seen, ok := gen.names[name]; ok {
+		if seen != desc.FullName() && gen.err == nil {
+			gen.err = fmt.Errorf("schema name %s is used by both %s and %s", name, seen, desc.FullName())
+		}
+		return ref
+	}
+	gen.names[name] = desc.FullName()
+
+	switch d := desc.(type) {
+	case protoreflect.EnumDescriptor:
+		var values []any
+		for i := 0; i < d.Values().Len(); i++ {
+			values = append(values, string(d.Values().Get(i).Name()))
+		}
+		gen.schemas[name] = map[string]any{"type": "string", "enum": values}
+	case protoreflect.MessageDescriptor:
+		gen.schemas[name] = gen.message(d)
+	}
+	return ref
+}
+
+func (gen *schemaGenerator) message(md protoreflect.MessageDescriptor) map[string]any {
+	properties := map[string]any{}
+	for i := 0; i < md.Fields().Len(); i++ {
+		fd := md.Fields().Get(i)
+		schema := gen.field(fd)
+		if od := fd.ContainingOneof(); od != nil && !od.IsSynthetic() {
+			schema = map[string]any{
+				"allOf":       []any{schema},
+				"description": fmt.Sprintf("Member of oneof %s; set at most one of %s.", od.Name(), strings.Join(oneofMembers(od), ", ")),
+			}
+		}
+		properties[fd.JSONName()] = schema
+	}
+	schema := map[string]any{"type": "object", "properties": properties}
+
+	oneofs := map[string]any{}
+	for i := 0; i < md.Oneofs().Len(); i++ {
+		if od := md.Oneofs().Get(i); !od.IsSynthetic() {
+			oneofs[string(od.Name())] = oneofMembers(od)
+		}
+	}
+	if len(oneofs) > 0 {
+		schema["x-oneof"] = oneofs
+	}
+	return schema
+}
+
+func oneofMembers(od protoreflect.OneofDescriptor) []string {
+	var members []string
+	for i := 0; i < od.Fields().Len(); i++ {
+		members = append(members, od.Fields().Get(i).JSONName())
+	}
+	return members
+}
+
+func (gen *schemaGenerator) field(fd protoreflect.FieldDescriptor) map[string]any {
+	switch {
+	case fd.IsMap():
+		return map[string]any{"type": "object", "additionalProperties": gen.singular(fd.MapValue())}
+	case fd.IsList():
+		return map[string]any{"type": "array", "items": gen.singular(fd)}
+	default:
+		return gen.singular(fd)
+	}
+}
+
+// singular returns the schema of one value of a field, following the protojson mapping.
+func (gen *schemaGenerator) singular(fd protoreflect.FieldDescriptor) map[string]any {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return map[string]any{"type": "boolean"}
+	case protoreflect.StringKind:
+		return map[string]any{"type": "string"}
+	case protoreflect.BytesKind:
+		return map[string]any{"type": "string", "format": "byte"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return map[string]any{"type": "integer", "format": "int32"}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return map[string]any{"type": "integer", "format": "int64", "minimum": 0}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return map[string]any{"type": "string", "format": "int64"}
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return map[string]any{"type": "string", "format": "uint64"}
+	case protoreflect.FloatKind:
+		return map[string]any{"type": "number", "format": "float"}
+	case protoreflect.DoubleKind:
+		return map[string]any{"type": "number", "format": "double"}
+	case protoreflect.EnumKind:
+		return gen.ref(fd.Enum())
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		if fd.Message().FullName() == "google.protobuf.Timestamp" {
+			return map[string]any{"type": "string", "format": "date-time"}
+		}
+		return gen.ref(fd.Message())
+	}
+	if gen.err == nil {
+		gen.err = fmt.Errorf("field %s has unsupported kind %s", fd.FullName(), fd.Kind())
+	}
+	return map[string]any{}
+}
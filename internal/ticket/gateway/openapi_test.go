@@ -0,0 +1,124 @@
+package gateway
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	ticket "github.com/talk2sohail/train-ticket-api/internal/common/genproto/ticket"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+var update = flag.Bool("update", false, "rewrite openapi.json from the proto descriptors")
+
+func TestUnit_OpenAPISpecUpToDate(t *testing.T) {
+	generated, err := GenerateOpenAPI()
+	if err != nil {
+		t.Fatalf("unexpected error generating OpenAPI document: %v", err)
+	}
+	if *update {
+		if err := os.WriteFile("openapi.json", generated, 0o644); err != nil {
+			t.Fatalf("unexpected error writing openapi.json: %v", err)
+		}
+		return
+	}
+	if !bytes.Equal(generated, openAPISpec) {
+		t.Errorf("openapi.json is out of date with the protos; run `make openapi` and commit the result")
+	}
+}
+
+func TestUnit_OpenAPISpecContents(t *testing.T) {
+	var doc struct {
+		Paths      map[string]map[string]json.RawMessage `json:"paths"`
+		Components struct {
+			Schemas map[string]struct {
+				Enum       []string                   `json:"enum"`
+				Properties map[string]json.RawMessage `json:"properties"`
+				Oneofs     map[string][]string        `json:"x-oneof"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(openAPISpec, &doc); err != nil {
+		t.Fatalf("openapi.json is not valid JSON: %v", err)
+	}
+
+	for _, rt := range routes {
+		if _, ok := doc.Paths[rt.path][map[string]string{
+			http.MethodGet: "get", http.MethodPost: "post", http.MethodPatch: "patch", http.MethodDelete: "delete",
+		}[rt.method]]; !ok {
+			t.Errorf("expected %s %s to be documented", rt.method, rt.path)
+		}
+	}
+
+	section := doc.Components.Schemas["Seat.Section"]
+	if want := []string{"SECTION_UNKNOWN", "SECTION_A", "SECTION_B"}; len(section.Enum) != len(want) || section.Enum[1] != want[1] {
+		t.Errorf("expected Seat.Section enum %v, got %v", want, section.Enum)
+	}
+	for _, name := range []string{"GetReceiptDetailsRequest", "RemoveUserRequest", "ModifyUserSeatRequest"} {
+		if members := doc.Components.Schemas[name].Oneofs["identifier"]; len(members) == 0 {
+			t.Errorf("expected %s to document its identifier oneof, got %v", name, members)
+		}
+	}
+	receipt := doc.Components.Schemas["GetReceiptDetailsResponse"]
+	if _, ok := receipt.Properties["receipt"]; !ok {
+		t.Errorf("expected GetReceiptDetailsResponse to have a receipt property")
+	}
+
+	t.Run("Served by the gateway", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		NewGateway(nil).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, OpenAPIPath, nil))
+		if rec.Code != http.StatusOK || !bytes.Equal(rec.Body.Bytes(), openAPISpec) {
+			t.Errorf("expected the OpenAPI document at %s, got %d", OpenAPIPath, rec.Code)
+		}
+	})
+}
+
+// protoField matches a field declaration such as "repeated string stops = 8;".
+var protoField = regexp.MustCompile(`^\s*(?:repeated\s+|optional\s+)?[\w.]+\s+(\w+)\s*=\s*\d+\s*;`)
+
+// TestUnit_ProtoDescriptorsUpToDate guards the source of the OpenAPI document: every field
+// declared in proto/*.proto must be present in the compiled descriptors it is generated from.
+func TestUnit_ProtoDescriptorsUpToDate(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("..", "..", "..", "proto", "*.proto"))
+	if err != nil || len(files) == 0 {
+		t.Fatalf("expected proto files, got %v, %v", files, err)
+	}
+	_ = ticket.File_ticket_proto // Registers every generated file.
+
+	for _, path := range files {
+		fd, err := protoregistry.GlobalFiles.FindFileByPath(filepath.Base(path))
+		if err != nil {
+			t.Errorf("%s has no generated code; run `make gen`", filepath.Base(path))
+			continue
+		}
+		declared := map[string]bool{}
+		collectFields(fd.Messages(), declared)
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("unexpected error reading %s: %v", path, err)
+		}
+		for _, line := range bytes.Split(data, []byte("\n")) {
+			if m := protoField.FindSubmatch(line); m != nil && !declared[string(m[1])] {
+				t.Errorf("field %s of %s is missing from the generated code; run `make gen`", m[1], filepath.Base(path))
+			}
+		}
+	}
+}
+
+func collectFields(messages protoreflect.MessageDescriptors, into map[string]bool) {
+	for i := 0; i < messages.Len(); i++ {
+		md := messages.Get(i)
+		for j := 0; j < md.Fields().Len(); j++ {
+			into[string(md.Fields().Get(j).Name())] = true
+		}
+		collectFields(md.Messages(), into)
+	}
+}
@@ -2,11 +2,14 @@ package handler
 
 import (
 	"context"
-	"errors"
+	"fmt"
 	"log"
+	"time"
 
 	ticket "github.com/talk2sohail/train-ticket-api/internal/common/genproto/ticket"
 	"github.com/talk2sohail/train-ticket-api/internal/common/util"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/layout"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/money"
 	"github.com/talk2sohail/train-ticket-api/internal/ticket/types"
 	"google.golang.org/grpc"
 )
@@ -33,36 +36,145 @@ func (h *TicketGrpcHandler) PurchaseTicket(ctx context.Context, req *ticket.Purc
 
 	// Validate the request object.
 	err := util.ValidatePurchseRequestObject(req)
+	if err == nil {
+		err = money.ValidatePrice("price", req.GetPrice())
+	}
 	if err != nil {
 		log.Printf("Invalid PurchaseTicket request: %v", err)
-		return nil, err
+		return nil, invalidRequest(err)
 	}
 
-	log.Printf("Received PurchaseTicket request: From=%s, To=%s, User=%s %s (%s), Price=%.2f",
+	log.Printf("Received PurchaseTicket request: From=%s, To=%s, User=%s %s (%s), Price=%s",
 		req.GetFromLocation(), req.GetToLocation(),
 		req.GetUser().GetFirstName(), req.GetUser().GetLastName(), req.GetUser().GetEmail(),
-		req.GetPricePaid())
+		formatPrice(req.GetPrice(), req.GetPricePaid()))
 
 	response, err := h.ticketService.PurchaseTicket(ctx, req)
 	if err != nil {
 		log.Printf("Error processing PurchaseTicket request: %v", err)
-		return nil, err
+		return nil, failed(ctx, err)
 	}
-	return &response, nil
+	return response, nil
 }
 
-// GetReceiptDetails handles the retrieval of receipt details for a given ticket.
-func (h *TicketGrpcHandler) GetReceiptDetails(ctx context.Context, req *ticket.GetReceiptDetailsRequest) (*ticket.GetReceiptDetailsResponse, error) {
+// formatPrice formats the validated price of a request for logging: price when it is set,
+// or else the legacy price_paid, which holds USD.
+func formatPrice(price *ticket.Money, pricePaid float64) string {
+	if price == nil {
+		return fmt.Sprintf("%.2f %s", pricePaid, money.USD)
+	}
+	paid, _ := money.FromProto(price) // Already validated.
+	return paid.String()
+}
 
-	// Validate the ticketId.
-	if req.GetTicketId() == "" {
-		return nil, errors.New("ticketId is required")
+// PurchaseGroupTicket handles booking seats for a party of passengers together.
+func (h *TicketGrpcHandler) PurchaseGroupTicket(ctx context.Context, req *ticket.PurchaseGroupTicketRequest) (*ticket.PurchaseGroupTicketResponse, error) {
+	err := util.ValidatePurchaseGroupRequestObject(req)
+	if err == nil {
+		err = money.ValidatePrice("price", req.GetPrice())
+	}
+	if err != nil {
+		log.Printf("Invalid PurchaseGroupTicket request: %v", err)
+		return nil, invalidRequest(err)
+	}
+
+	log.Printf("Received PurchaseGroupTicket request: From=%s, To=%s, Passengers=%d, Price=%s",
+		req.GetFromLocation(), req.GetToLocation(), len(req.GetPassengers()), formatPrice(req.GetPrice(), req.GetPricePaid()))
+
+	response, err := h.ticketService.PurchaseGroupTicket(ctx, req)
+	if err != nil {
+		log.Printf("Error processing PurchaseGroupTicket request: %v", err)
+		return nil, failed(ctx, err)
+	}
+	return response, nil
+}
+
+// HoldSeat handles reserving a seat while a purchase is completed.
+func (h *TicketGrpcHandler) HoldSeat(ctx context.Context, req *ticket.HoldSeatRequest) (*ticket.HoldSeatResponse, error) {
+	if err := util.ValidateHoldSeatRequestObject(req); err != nil {
+		log.Printf("Invalid HoldSeat request: %v", err)
+		return nil, invalidRequest(err)
+	}
+
+	resp, err := h.ticketService.HoldSeat(ctx, req)
+	if err != nil {
+		log.Printf("Error in HoldSeat: %v", err)
+		return nil, failed(ctx, err)
+	}
+	return resp, nil
+}
+
+// ConfirmHold handles turning a seat hold into a ticket.
+func (h *TicketGrpcHandler) ConfirmHold(ctx context.Context, req *ticket.ConfirmHoldRequest) (*ticket.ConfirmHoldResponse, error) {
+	err := util.ValidateConfirmHoldRequestObject(req)
+	if err == nil {
+		err = money.ValidatePrice("price", req.GetPrice())
+	}
+	if err != nil {
+		log.Printf("Invalid ConfirmHold request: %v", err)
+		return nil, invalidRequest(err)
+	}
+
+	resp, err := h.ticketService.ConfirmHold(ctx, req)
+	if err != nil {
+		log.Printf("Error in ConfirmHold: %v", err)
+		return nil, toStatus(err)
+	}
+	return resp, nil
+}
+
+// JoinWaitlist handles queueing a passenger for a seat on a sold-out journey.
+func (h *TicketGrpcHandler) JoinWaitlist(ctx context.Context, req *ticket.JoinWaitlistRequest) (*ticket.JoinWaitlistResponse, error) {
+	err := util.ValidateJoinWaitlistRequestObject(req)
+	if err == nil {
+		err = money.ValidatePrice("price", req.GetPrice())
+	}
+	if err != nil {
+		log.Printf("Invalid JoinWaitlist request: %v", err)
+		return nil, invalidRequest(err)
+	}
+
+	resp, err := h.ticketService.JoinWaitlist(ctx, req)
+	if err != nil {
+		log.Printf("Error in JoinWaitlist: %v", err)
+		return nil, failed(ctx, err)
+	}
+	return resp, nil
+}
+
+// GetWaitlistStatus handles checking a waitlist entry's position or promotion.
+func (h *TicketGrpcHandler) GetWaitlistStatus(ctx context.Context, req *ticket.GetWaitlistStatusRequest) (*ticket.GetWaitlistStatusResponse, error) {
+	if req.GetWaitlistId() == "" {
+		return nil, requiredField("waitlist_id", "waitlistId is required")
+	}
+
+	resp, err := h.ticketService.GetWaitlistStatus(ctx, req.GetWaitlistId())
+	if err != nil {
+		log.Printf("Error retrieving waitlist status for waitlistID %s: %v", req.GetWaitlistId(), err)
+		return nil, toStatus(err)
+	}
+	return resp, nil
+}
+
+// WatchAvailability handles streaming a journey's seat availability to the caller.
+// gRPC flow control applies backpressure: Send blocks while the client is not reading.
+func (h *TicketGrpcHandler) WatchAvailability(req *ticket.WatchAvailabilityRequest, stream grpc.ServerStreamingServer[ticket.AvailabilityUpdate]) error {
+	if err := util.ValidateWatchAvailabilityRequestObject(req); err != nil {
+		log.Printf("Invalid WatchAvailability request: %v", err)
+		return invalidRequest(err)
 	}
 
-	// Optionally, validate request here if needed.
-	receipt, err := h.ticketService.GetReceiptDetails(ctx, req.GetTicketId())
+	if err := h.ticketService.WatchAvailability(stream.Context(), req, stream.Send); err != nil {
+		log.Printf("Error in WatchAvailability: %v", err)
+		return toStatus(err)
+	}
+	return nil
+}
+
+// GetReceiptDetails handles the retrieval of receipt details for a ticket identified by its ID or its passenger's email.
+func (h *TicketGrpcHandler) GetReceiptDetails(ctx context.Context, req *ticket.GetReceiptDetailsRequest) (*ticket.GetReceiptDetailsResponse, error) {
+	receipt, err := h.findReceipt(ctx, req.GetTicketId(), req.GetEmail())
 	if err != nil {
-		log.Printf("Error retrieving receipt for ticketID %s: %v", req.GetTicketId(), err)
 		return nil, err
 	}
 	return &ticket.GetReceiptDetailsResponse{
@@ -70,35 +182,109 @@ func (h *TicketGrpcHandler) GetReceiptDetails(ctx context.Context, req *ticket.G
 	}, nil
 }
 
-// GetUsersBySection handles the retrieval of users and their seats by section.
+// findReceipt retrieves the receipt named by whichever identifier a request carries.
+func (h *TicketGrpcHandler) findReceipt(ctx context.Context, ticketID, email string) (*ticket.Receipt, error) {
+	var (
+		receipt *ticket.Receipt
+		err     error
+	)
+	switch {
+	case ticketID != "":
+		receipt, err = h.ticketService.GetReceiptDetails(ctx, ticketID)
+	case email != "":
+		receipt, err = h.ticketService.GetReceiptByEmail(ctx, email)
+	default:
+		return nil, requiredField("identifier", "ticketId or email is required")
+	}
+	if err != nil {
+		log.Printf("Error retrieving receipt for ticketID %q, email %q: %v", ticketID, email, err)
+		return nil, toStatus(err)
+	}
+	return receipt, nil
+}
+
+// GetUsersBySection handles the retrieval of users and their seats by coach or section.
 func (h *TicketGrpcHandler) GetUsersBySection(ctx context.Context, req *ticket.GetUsersBySectionRequest) (*ticket.GetUsersBySectionResponse, error) {
 	// Validate the section.
 	err := util.ValidateSection(req)
 	if err != nil {
 		log.Printf("Invalid section in GetUsersBySection request: %v", err)
-		return nil, err
+		return nil, invalidRequest(err)
 	}
 
-	resp, err := h.ticketService.GetUsersBySection(ctx, req.GetSection())
+	// A legacy section names the coach of the same letter.
+	coach := layout.CoachOf(&ticket.Seat{Coach: req.GetCoach(), Section: req.GetSection()})
+	resp, err := h.ticketService.GetUsersBySection(ctx, req.GetJourneyId(), coach)
 	if err != nil {
 		log.Printf("Error in GetUsersBySection: %v", err)
-		return nil, err
+		return nil, toStatus(err)
 	}
-	return &resp, nil
+	return resp, nil
 }
 
-// RemoveUser handles removing a user from the train.
+// RemoveUser handles removing a user from the train, cancelling the ticket identified by its ID or its passenger's email.
 func (h *TicketGrpcHandler) RemoveUser(ctx context.Context, req *ticket.RemoveUserRequest) (*ticket.RemoveUserResponse, error) {
-	email := req.GetEmail()
-	if email == "" {
-		return nil, errors.New("email is required")
+	switch {
+	case req.GetTicketId() != "":
+		resp, err := h.ticketService.CancelTicket(ctx, req.GetTicketId())
+		if err != nil {
+			log.Printf("Error in RemoveUser: %v", err)
+			return nil, toStatus(err)
+		}
+		return &ticket.RemoveUserResponse{Success: resp.GetSuccess(), Message: resp.GetMessage(), Refund: resp.GetRefund()}, nil
+	case req.GetEmail() != "":
+		resp, err := h.ticketService.RemoveUser(ctx, req.GetEmail())
+		if err != nil {
+			log.Printf("Error in RemoveUser: %v", err)
+			return nil, toStatus(err)
+		}
+		return resp, nil
+	default:
+		return nil, requiredField("identifier", "ticketId or email is required")
 	}
-	resp, err := h.ticketService.RemoveUser(ctx, email)
+}
+
+// CancelTicket handles cancelling one specific ticket.
+func (h *TicketGrpcHandler) CancelTicket(ctx context.Context, req *ticket.CancelTicketRequest) (*ticket.CancelTicketResponse, error) {
+	if req.GetTicketId() == "" {
+		return nil, requiredField("ticket_id", "ticketId is required")
+	}
+
+	resp, err := h.ticketService.CancelTicket(ctx, req.GetTicketId())
 	if err != nil {
-		log.Printf("Error in RemoveUser: %v", err)
-		return nil, err
+		log.Printf("Error in CancelTicket for ticketID %s: %v", req.GetTicketId(), err)
+		return nil, toStatus(err)
+	}
+	return resp, nil
+}
+
+// GetRefund handles retrieving the refund given for a cancelled ticket.
+func (h *TicketGrpcHandler) GetRefund(ctx context.Context, req *ticket.GetRefundRequest) (*ticket.GetRefundResponse, error) {
+	if req.GetRefundId() == "" {
+		return nil, requiredField("refund_id", "refundId is required")
+	}
+
+	resp, err := h.ticketService.GetRefund(ctx, req.GetRefundId())
+	if err != nil {
+		log.Printf("Error retrieving refund for refundID %s: %v", req.GetRefundId(), err)
+		return nil, toStatus(err)
+	}
+	return resp, nil
+}
+
+// ListTicketsForUser handles listing a passenger's tickets a page at a time.
+func (h *TicketGrpcHandler) ListTicketsForUser(ctx context.Context, req *ticket.ListTicketsForUserRequest) (*ticket.ListTicketsForUserResponse, error) {
+	if err := util.ValidateListTicketsForUserRequestObject(req); err != nil {
+		log.Printf("Invalid ListTicketsForUser request: %v", err)
+		return nil, invalidRequest(err)
 	}
-	return &resp, nil
+
+	resp, err := h.ticketService.ListTicketsForUser(ctx, req)
+	if err != nil {
+		log.Printf("Error in ListTicketsForUser: %v", err)
+		return nil, toStatus(err)
+	}
+	return resp, nil
 }
 
 // ModifyUserSeat handles updating a user's seat allocation.
@@ -108,13 +294,12 @@ func (h *TicketGrpcHandler) ModifyUserSeat(ctx context.Context, req *ticket.Modi
 	err := util.ValidateModifyUserSeatRequestObject(req)
 	if err != nil {
 		log.Printf("Invalid ModifyUserSeat request: %v", err)
-		return nil, err
+		return nil, invalidRequest(err)
 	}
 
 	// Retrieve existing receipt.
-	receipt, err := h.ticketService.GetReceiptDetails(ctx, req.GetTicketId())
+	receipt, err := h.findReceipt(ctx, req.GetTicketId(), req.GetEmail())
 	if err != nil {
-		log.Printf("Error retrieving receipt for ticketID %s: %v", req.GetTicketId(), err)
 		return nil, err
 	}
 
@@ -122,7 +307,92 @@ func (h *TicketGrpcHandler) ModifyUserSeat(ctx context.Context, req *ticket.Modi
 	resp, err := h.ticketService.ModifyUserSeat(ctx, receipt, req.GetNewSeat())
 	if err != nil {
 		log.Printf("Error in ModifyUserSeat: %v", err)
-		return nil, err
+		return nil, toStatus(err)
+	}
+	return resp, nil
+}
+
+// GetTicketHistory handles the retrieval of the booking events recorded for a ticket.
+func (h *TicketGrpcHandler) GetTicketHistory(ctx context.Context, req *ticket.GetTicketHistoryRequest) (*ticket.GetTicketHistoryResponse, error) {
+	if req.GetTicketId() == "" {
+		return nil, requiredField("ticket_id", "ticketId is required")
+	}
+
+	resp, err := h.ticketService.GetTicketHistory(ctx, req.GetTicketId())
+	if err != nil {
+		log.Printf("Error retrieving history for ticketID %s: %v", req.GetTicketId(), err)
+		return nil, toStatus(err)
+	}
+	return resp, nil
+}
+
+// GetSeatOccupant handles finding which ticket held a seat at a point in time.
+func (h *TicketGrpcHandler) GetSeatOccupant(ctx context.Context, req *ticket.GetSeatOccupantRequest) (*ticket.GetSeatOccupantResponse, error) {
+	if req.GetSeatNumber() == "" {
+		return nil, requiredField("seat_number", "seatNumber is required")
+	}
+
+	// Without a timestamp the question is who sits there now.
+	at := time.Now()
+	if req.GetAt() != nil {
+		at = req.GetAt().AsTime()
+	}
+
+	resp, err := h.ticketService.GetSeatOccupant(ctx, req.GetJourneyId(), req.GetSeatNumber(), at)
+	if err != nil {
+		log.Printf("Error in GetSeatOccupant: %v", err)
+		return nil, toStatus(err)
+	}
+	return resp, nil
+}
+
+// CreateJourney handles scheduling a new journey.
+func (h *TicketGrpcHandler) CreateJourney(ctx context.Context, req *ticket.CreateJourneyRequest) (*ticket.CreateJourneyResponse, error) {
+	err := util.ValidateCreateJourneyRequestObject(req)
+	if err != nil {
+		log.Printf("Invalid CreateJourney request: %v", err)
+		return nil, invalidRequest(err)
+	}
+
+	resp, err := h.ticketService.CreateJourney(ctx, req)
+	if err != nil {
+		log.Printf("Error in CreateJourney: %v", err)
+		return nil, toStatus(err)
+	}
+	return resp, nil
+}
+
+// ListJourneys handles listing the scheduled journeys.
+func (h *TicketGrpcHandler) ListJourneys(ctx context.Context, req *ticket.ListJourneysRequest) (*ticket.ListJourneysResponse, error) {
+	if req.GetServiceDate() != "" {
+		if err := util.ValidateServiceDate(req.GetServiceDate()); err != nil {
+			return nil, invalidRequest(err)
+		}
+	}
+
+	resp, err := h.ticketService.ListJourneys(ctx, req.GetServiceDate())
+	if err != nil {
+		log.Printf("Error in ListJourneys: %v", err)
+		return nil, toStatus(err)
+	}
+	return resp, nil
+}
+
+// QuoteFare handles pricing a trip without booking it.
+func (h *TicketGrpcHandler) QuoteFare(ctx context.Context, req *ticket.QuoteFareRequest) (*ticket.QuoteFareResponse, error) {
+	err := util.ValidateQuoteFareRequestObject(req)
+	if err == nil {
+		err = money.ValidateCurrency("currency_code", req.GetCurrencyCode())
+	}
+	if err != nil {
+		log.Printf("Invalid QuoteFare request: %v", err)
+		return nil, invalidRequest(err)
+	}
+
+	resp, err := h.ticketService.QuoteFare(ctx, req)
+	if err != nil {
+		log.Printf("Error in QuoteFare: %v", err)
+		return nil, toStatus(err)
 	}
-	return &resp, nil
+	return resp, nil
 }
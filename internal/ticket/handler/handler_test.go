@@ -4,12 +4,19 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	ticket "github.com/talk2sohail/train-ticket-api/internal/common/genproto/ticket"
 	"github.com/talk2sohail/train-ticket-api/internal/ticket/handler"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/ratelimit"
 	"github.com/talk2sohail/train-ticket-api/internal/ticket/service"
 	"github.com/talk2sohail/train-ticket-api/mock"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func TestUnit_HandlerPurchaseTicket(t *testing.T) {
@@ -39,14 +46,80 @@ func TestUnit_HandlerPurchaseTicket(t *testing.T) {
 		}
 	})
 
+	t.Run("invalid preferences", func(t *testing.T) {
+		req := &ticket.PurchaseTicketRequest{
+			FromLocation: validReq.FromLocation,
+			ToLocation:   validReq.ToLocation,
+			User:         validReq.User,
+			PricePaid:    validReq.PricePaid,
+			Preferences:  &ticket.SeatPreferences{Position: ticket.SeatPreferences_Position(42)},
+		}
+		mockSvc := mock.NewMockTicketService(ctrl)
+		h := handler.NewTicketGrpcHandler(mockSvc)
+		if _, err := h.PurchaseTicket(ctx, req); err == nil {
+			t.Errorf("expected error for invalid preferences, got nil")
+		}
+	})
+
+	t.Run("invalid price", func(t *testing.T) {
+		prices := []struct {
+			price     *ticket.Money
+			pricePaid float64
+			field     string
+		}{
+			{&ticket.Money{MinorUnits: 5000, CurrencyCode: "usd"}, 0, "price.currency_code"},
+			{&ticket.Money{CurrencyCode: "USD"}, 0, "price.minor_units"},
+			{&ticket.Money{MinorUnits: 5000, CurrencyCode: "USD"}, 50, "price_paid"},
+		}
+		// Every request that pays is checked alike.
+		calls := map[string]func(h *handler.TicketGrpcHandler, price *ticket.Money, pricePaid float64) error{
+			"PurchaseTicket": func(h *handler.TicketGrpcHandler, price *ticket.Money, pricePaid float64) error {
+				_, err := h.PurchaseTicket(ctx, &ticket.PurchaseTicketRequest{
+					FromLocation: validReq.FromLocation, ToLocation: validReq.ToLocation, User: validReq.User, PricePaid: pricePaid, Price: price,
+				})
+				return err
+			},
+			"PurchaseGroupTicket": func(h *handler.TicketGrpcHandler, price *ticket.Money, pricePaid float64) error {
+				_, err := h.PurchaseGroupTicket(ctx, &ticket.PurchaseGroupTicketRequest{
+					FromLocation: validReq.FromLocation, ToLocation: validReq.ToLocation, Passengers: []*ticket.User{validReq.User}, PricePaid: pricePaid, Price: price,
+				})
+				return err
+			},
+			"ConfirmHold": func(h *handler.TicketGrpcHandler, price *ticket.Money, pricePaid float64) error {
+				_, err := h.ConfirmHold(ctx, &ticket.ConfirmHoldRequest{HoldId: "hold-1", PricePaid: pricePaid, Price: price})
+				return err
+			},
+			"JoinWaitlist": func(h *handler.TicketGrpcHandler, price *ticket.Money, pricePaid float64) error {
+				_, err := h.JoinWaitlist(ctx, &ticket.JoinWaitlistRequest{
+					FromLocation: validReq.FromLocation, ToLocation: validReq.ToLocation, User: validReq.User, PricePaid: pricePaid, Price: price,
+				})
+				return err
+			},
+		}
+		for rpc, call := range calls {
+			for _, p := range prices {
+				err := call(handler.NewTicketGrpcHandler(mock.NewMockTicketService(ctrl)), p.price, p.pricePaid)
+				var violations []*errdetails.BadRequest_FieldViolation
+				for _, detail := range status.Convert(err).Details() {
+					if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+						violations = badRequest.GetFieldViolations()
+					}
+				}
+				if len(violations) != 1 || violations[0].GetField() != p.field {
+					t.Errorf("%s: expected a violation of %s, got %v", rpc, p.field, err)
+				}
+			}
+		}
+	})
+
 	t.Run("service error", func(t *testing.T) {
 		expectedErr := errors.New("service failure")
 		mockSvc := mock.NewMockTicketService(ctrl)
-		mockSvc.EXPECT().PurchaseTicket(ctx, validReq).Return(ticket.PurchaseTicketResponse{}, expectedErr)
+		mockSvc.EXPECT().PurchaseTicket(ctx, validReq).Return(nil, expectedErr)
 
 		h := handler.NewTicketGrpcHandler(mockSvc)
 		resp, err := h.PurchaseTicket(ctx, validReq)
-		if err == nil || err.Error() != expectedErr.Error() {
+		if st := status.Convert(err); st.Code() != codes.Internal || st.Message() != expectedErr.Error() {
 			t.Errorf("expected error %v, got %v", expectedErr, err)
 		}
 		if resp != nil {
@@ -55,7 +128,7 @@ func TestUnit_HandlerPurchaseTicket(t *testing.T) {
 	})
 
 	t.Run("successful purchase", func(t *testing.T) {
-		expectedResp := ticket.PurchaseTicketResponse{
+		expectedResp := &ticket.PurchaseTicketResponse{
 			Message: service.MsgTicketPurchaseSuccess,
 			Success: true,
 			Receipt: &ticket.Receipt{
@@ -87,6 +160,301 @@ func TestUnit_HandlerPurchaseTicket(t *testing.T) {
 
 }
 
+func TestUnit_HandlerPurchaseGroupTicket(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+
+	validReq := &ticket.PurchaseGroupTicketRequest{
+		FromLocation: "Station A",
+		ToLocation:   "Station B",
+		Passengers: []*ticket.User{
+			{FirstName: "Alice", LastName: "Smith", Email: "alice.smith@example.com"},
+			{FirstName: "Bob", LastName: "Smith", Email: "bob.smith@example.com"},
+		},
+		PricePaid: 50.0,
+	}
+
+	t.Run("invalid request", func(t *testing.T) {
+		invalid := []*ticket.PurchaseGroupTicketRequest{
+			{},
+			{FromLocation: "Station A", ToLocation: "Station B", PricePaid: 50.0},
+			{FromLocation: "Station A", ToLocation: "Station B", PricePaid: 50.0, Passengers: []*ticket.User{{FirstName: "No email"}}},
+		}
+		for _, req := range invalid {
+			mockSvc := mock.NewMockTicketService(ctrl)
+			h := handler.NewTicketGrpcHandler(mockSvc)
+			if _, err := h.PurchaseGroupTicket(ctx, req); err == nil {
+				t.Errorf("expected error for invalid request %v, got nil", req)
+			}
+		}
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		expectedErr := errors.New("service failure")
+		mockSvc := mock.NewMockTicketService(ctrl)
+		mockSvc.EXPECT().PurchaseGroupTicket(ctx, validReq).Return(nil, expectedErr)
+
+		h := handler.NewTicketGrpcHandler(mockSvc)
+		resp, err := h.PurchaseGroupTicket(ctx, validReq)
+		if st := status.Convert(err); st.Code() != codes.Internal || st.Message() != expectedErr.Error() {
+			t.Errorf("expected error %v, got %v", expectedErr, err)
+		}
+		if resp != nil {
+			t.Errorf("expected nil response on error, got %v", resp)
+		}
+	})
+
+	t.Run("successful purchase", func(t *testing.T) {
+		expectedResp := &ticket.PurchaseGroupTicketResponse{
+			Success:          true,
+			Message:          service.MsgGroupPurchaseSuccess,
+			BookingReference: "booking-1",
+		}
+		mockSvc := mock.NewMockTicketService(ctrl)
+		mockSvc.EXPECT().PurchaseGroupTicket(ctx, validReq).Return(expectedResp, nil)
+
+		h := handler.NewTicketGrpcHandler(mockSvc)
+		resp, err := h.PurchaseGroupTicket(ctx, validReq)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if resp.GetBookingReference() != "booking-1" {
+			t.Errorf("expected booking reference booking-1, got %v", resp)
+		}
+	})
+}
+
+func TestUnit_HandlerHoldSeat(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+
+	t.Run("invalid request", func(t *testing.T) {
+		mockSvc := mock.NewMockTicketService(ctrl)
+		h := handler.NewTicketGrpcHandler(mockSvc)
+		if _, err := h.HoldSeat(ctx, &ticket.HoldSeatRequest{FromLocation: "Station A"}); err == nil {
+			t.Errorf("expected error for invalid request, got nil")
+		}
+	})
+
+	t.Run("successful hold", func(t *testing.T) {
+		req := &ticket.HoldSeatRequest{
+			FromLocation: "Station A",
+			ToLocation:   "Station B",
+			User:         &ticket.User{Email: "alice.smith@example.com"},
+		}
+		mockSvc := mock.NewMockTicketService(ctrl)
+		mockSvc.EXPECT().HoldSeat(ctx, req).Return(&ticket.HoldSeatResponse{Success: true, HoldId: "hold-1"}, nil)
+		h := handler.NewTicketGrpcHandler(mockSvc)
+		resp, err := h.HoldSeat(ctx, req)
+		if err != nil || resp.GetHoldId() != "hold-1" {
+			t.Errorf("expected hold-1, got %v, %v", resp, err)
+		}
+	})
+
+	t.Run("quota exceeded until a hold expires", func(t *testing.T) {
+		req := &ticket.HoldSeatRequest{
+			FromLocation: "Station A",
+			ToLocation:   "Station B",
+			User:         &ticket.User{Email: "alice.smith@example.com"},
+		}
+		quotaErr := &service.Error{
+			Kind:       service.KindResourceExhausted,
+			Reason:     service.ReasonTicketQuotaExceeded,
+			Message:    service.ErrTicketQuotaExceeded,
+			Subject:    "alice.smith@example.com",
+			RetryAfter: 2500 * time.Millisecond,
+		}
+		mockSvc := mock.NewMockTicketService(ctrl)
+		mockSvc.EXPECT().HoldSeat(gomock.Any(), req).Return(nil, quotaErr)
+		h := handler.NewTicketGrpcHandler(mockSvc)
+		stream := &fakeTransportStream{method: ticket.TrainTicketingService_HoldSeat_FullMethodName}
+		_, err := h.HoldSeat(grpc.NewContextWithServerTransportStream(ctx, stream), req)
+		st := status.Convert(err)
+		if st.Code() != codes.ResourceExhausted {
+			t.Fatalf("expected ResourceExhausted, got %v", err)
+		}
+		var retry *errdetails.RetryInfo
+		for _, detail := range st.Details() {
+			if d, ok := detail.(*errdetails.RetryInfo); ok {
+				retry = d
+			}
+		}
+		if retry.GetRetryDelay().AsDuration() != 2500*time.Millisecond {
+			t.Errorf("expected a RetryInfo of 2.5s, got %v", retry)
+		}
+		if got := stream.header.Get(ratelimit.RetryAfterHeader); len(got) != 1 || got[0] != "3" {
+			t.Errorf("expected %s: 3, got %v", ratelimit.RetryAfterHeader, got)
+		}
+	})
+}
+
+func TestUnit_HandlerConfirmHold(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+
+	t.Run("invalid request", func(t *testing.T) {
+		mockSvc := mock.NewMockTicketService(ctrl)
+		h := handler.NewTicketGrpcHandler(mockSvc)
+		for _, req := range []*ticket.ConfirmHoldRequest{{PricePaid: 20}, {HoldId: "hold-1"}} {
+			if _, err := h.ConfirmHold(ctx, req); err == nil {
+				t.Errorf("expected error for invalid request %v, got nil", req)
+			}
+		}
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		req := &ticket.ConfirmHoldRequest{HoldId: "hold-1", PricePaid: 20}
+		expectedErr := errors.New("service failure")
+		mockSvc := mock.NewMockTicketService(ctrl)
+		mockSvc.EXPECT().ConfirmHold(ctx, req).Return(nil, expectedErr)
+		h := handler.NewTicketGrpcHandler(mockSvc)
+		resp, err := h.ConfirmHold(ctx, req)
+		if st := status.Convert(err); st.Code() != codes.Internal || st.Message() != expectedErr.Error() {
+			t.Errorf("expected error %v, got %v", expectedErr, err)
+		}
+		if resp != nil {
+			t.Errorf("expected nil response on error, got %v", resp)
+		}
+	})
+}
+
+func TestUnit_HandlerJoinWaitlist(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+
+	t.Run("invalid request", func(t *testing.T) {
+		mockSvc := mock.NewMockTicketService(ctrl)
+		h := handler.NewTicketGrpcHandler(mockSvc)
+		req := &ticket.JoinWaitlistRequest{FromLocation: "Station A", ToLocation: "Station B", User: &ticket.User{Email: "alice.smith@example.com"}}
+		if _, err := h.JoinWaitlist(ctx, req); err == nil {
+			t.Errorf("expected error for missing price, got nil")
+		}
+	})
+
+	t.Run("successful join", func(t *testing.T) {
+		req := &ticket.JoinWaitlistRequest{
+			FromLocation: "Station A",
+			ToLocation:   "Station B",
+			User:         &ticket.User{Email: "alice.smith@example.com"},
+			PricePaid:    20,
+		}
+		mockSvc := mock.NewMockTicketService(ctrl)
+		mockSvc.EXPECT().JoinWaitlist(ctx, req).Return(&ticket.JoinWaitlistResponse{Success: true, Position: 1}, nil)
+		h := handler.NewTicketGrpcHandler(mockSvc)
+		resp, err := h.JoinWaitlist(ctx, req)
+		if err != nil || resp.GetPosition() != 1 {
+			t.Errorf("expected position 1, got %v, %v", resp, err)
+		}
+	})
+}
+
+func TestUnit_HandlerGetWaitlistStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+
+	t.Run("missing waitlistId", func(t *testing.T) {
+		mockSvc := mock.NewMockTicketService(ctrl)
+		h := handler.NewTicketGrpcHandler(mockSvc)
+		if _, err := h.GetWaitlistStatus(ctx, &ticket.GetWaitlistStatusRequest{}); err == nil {
+			t.Errorf("expected error for missing waitlistId, got nil")
+		}
+	})
+
+	t.Run("promoted entry", func(t *testing.T) {
+		expected := &ticket.GetWaitlistStatusResponse{
+			Success: true,
+			Entry:   &ticket.WaitlistEntry{WaitlistId: "w1", Status: ticket.WaitlistEntry_STATUS_PROMOTED, TicketId: "t1"},
+			Receipt: &ticket.Receipt{TicketId: "t1"},
+		}
+		mockSvc := mock.NewMockTicketService(ctrl)
+		mockSvc.EXPECT().GetWaitlistStatus(ctx, "w1").Return(expected, nil)
+		h := handler.NewTicketGrpcHandler(mockSvc)
+		resp, err := h.GetWaitlistStatus(ctx, &ticket.GetWaitlistStatusRequest{WaitlistId: "w1"})
+		if err != nil || resp.GetReceipt().GetTicketId() != "t1" {
+			t.Errorf("expected receipt t1, got %v, %v", resp, err)
+		}
+	})
+}
+
+func TestUnit_HandlerGetRefund(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+
+	t.Run("missing refundId", func(t *testing.T) {
+		mockSvc := mock.NewMockTicketService(ctrl)
+		h := handler.NewTicketGrpcHandler(mockSvc)
+		if _, err := h.GetRefund(ctx, &ticket.GetRefundRequest{}); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("expected %v for missing refundId, got %v", codes.InvalidArgument, err)
+		}
+	})
+
+	t.Run("refund found", func(t *testing.T) {
+		expected := &ticket.GetRefundResponse{
+			Success: true,
+			Refund:  &ticket.Refund{RefundId: "r1", TicketId: "t1", RefundPercent: 50},
+		}
+		mockSvc := mock.NewMockTicketService(ctrl)
+		mockSvc.EXPECT().GetRefund(ctx, "r1").Return(expected, nil)
+		h := handler.NewTicketGrpcHandler(mockSvc)
+		resp, err := h.GetRefund(ctx, &ticket.GetRefundRequest{RefundId: "r1"})
+		if err != nil || resp.GetRefund().GetTicketId() != "t1" {
+			t.Errorf("expected the refund of t1, got %v, %v", resp, err)
+		}
+	})
+}
+
+// fakeAvailabilityStream records the updates a handler sends on a server stream.
+type fakeAvailabilityStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent []*ticket.AvailabilityUpdate
+}
+
+func (f *fakeAvailabilityStream) Context() context.Context { return f.ctx }
+
+func (f *fakeAvailabilityStream) Send(update *ticket.AvailabilityUpdate) error {
+	f.sent = append(f.sent, update)
+	return nil
+}
+
+func TestUnit_HandlerWatchAvailability(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+
+	t.Run("invalid request", func(t *testing.T) {
+		mockSvc := mock.NewMockTicketService(ctrl)
+		h := handler.NewTicketGrpcHandler(mockSvc)
+		req := &ticket.WatchAvailabilityRequest{FromLocation: "Station A"}
+		if err := h.WatchAvailability(req, &fakeAvailabilityStream{ctx: ctx}); err == nil {
+			t.Errorf("expected error for a from location without a to location, got nil")
+		}
+	})
+
+	t.Run("updates are sent on the stream", func(t *testing.T) {
+		req := &ticket.WatchAvailabilityRequest{JourneyId: "j1"}
+		stream := &fakeAvailabilityStream{ctx: ctx}
+		mockSvc := mock.NewMockTicketService(ctrl)
+		mockSvc.EXPECT().WatchAvailability(ctx, req, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ *ticket.WatchAvailabilityRequest, send func(*ticket.AvailabilityUpdate) error) error {
+				return send(&ticket.AvailabilityUpdate{Kind: ticket.AvailabilityUpdate_KIND_SNAPSHOT, FreeSeats: []string{"A1"}})
+			})
+		h := handler.NewTicketGrpcHandler(mockSvc)
+		if err := h.WatchAvailability(req, stream); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(stream.sent) != 1 || stream.sent[0].GetKind() != ticket.AvailabilityUpdate_KIND_SNAPSHOT {
+			t.Errorf("expected the snapshot to be sent, got %v", stream.sent)
+		}
+	})
+}
+
 func TestUnit_HandlerGetReceiptDetails(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -105,7 +473,7 @@ func TestUnit_HandlerGetReceiptDetails(t *testing.T) {
 			Return(nil, expectedErr)
 		h := handler.NewTicketGrpcHandler(mockSvc)
 		resp, err := h.GetReceiptDetails(ctx, validReq)
-		if err == nil || err.Error() != expectedErr.Error() {
+		if st := status.Convert(err); st.Code() != codes.Internal || st.Message() != expectedErr.Error() {
 			t.Errorf("expected error %v, got %v", expectedErr, err)
 		}
 		if resp != nil {
@@ -131,6 +499,24 @@ func TestUnit_HandlerGetReceiptDetails(t *testing.T) {
 		}
 	})
 
+	t.Run("retrieval by email", func(t *testing.T) {
+		req := &ticket.GetReceiptDetailsRequest{
+			Identifier: &ticket.GetReceiptDetailsRequest_Email{Email: "user@example.com"},
+		}
+		expectedReceipt := &ticket.Receipt{TicketId: "ticket-123"}
+		mockSvc := mock.NewMockTicketService(ctrl)
+		mockSvc.EXPECT().
+			GetReceiptByEmail(ctx, "user@example.com").
+			Return(expectedReceipt, nil)
+		h := handler.NewTicketGrpcHandler(mockSvc)
+		resp, err := h.GetReceiptDetails(ctx, req)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if resp.GetReceipt().GetTicketId() != expectedReceipt.GetTicketId() {
+			t.Errorf("expected receipt ticketId %s, got %v", expectedReceipt.TicketId, resp)
+		}
+	})
 }
 
 func TestUnit_HandlerGetUsersBySection(t *testing.T) {
@@ -157,11 +543,11 @@ func TestUnit_HandlerGetUsersBySection(t *testing.T) {
 		expectedErr := errors.New("service failure")
 		mockSvc := mock.NewMockTicketService(ctrl)
 		mockSvc.EXPECT().
-			GetUsersBySection(ctx, ticket.Seat_SECTION_A).
-			Return(ticket.GetUsersBySectionResponse{}, expectedErr)
+			GetUsersBySection(ctx, "", "A").
+			Return(nil, expectedErr)
 		h := handler.NewTicketGrpcHandler(mockSvc)
 		resp, err := h.GetUsersBySection(ctx, req)
-		if err == nil || err.Error() != expectedErr.Error() {
+		if st := status.Convert(err); st.Code() != codes.Internal || st.Message() != expectedErr.Error() {
 			t.Errorf("expected error %v, got %v", expectedErr, err)
 		}
 		if resp != nil {
@@ -173,10 +559,10 @@ func TestUnit_HandlerGetUsersBySection(t *testing.T) {
 		req := &ticket.GetUsersBySectionRequest{
 			Section: ticket.Seat_SECTION_A,
 		}
-		expectedResp := ticket.GetUsersBySectionResponse{}
+		expectedResp := &ticket.GetUsersBySectionResponse{}
 		mockSvc := mock.NewMockTicketService(ctrl)
 		mockSvc.EXPECT().
-			GetUsersBySection(ctx, ticket.Seat_SECTION_A).
+			GetUsersBySection(ctx, "", "A").
 			Return(expectedResp, nil)
 		h := handler.NewTicketGrpcHandler(mockSvc)
 		resp, err := h.GetUsersBySection(ctx, req)
@@ -187,6 +573,21 @@ func TestUnit_HandlerGetUsersBySection(t *testing.T) {
 			t.Errorf("expected a non-nil response")
 		}
 	})
+
+	t.Run("coach takes precedence over section", func(t *testing.T) {
+		req := &ticket.GetUsersBySectionRequest{
+			Coach:     "C",
+			JourneyId: "journey-1",
+		}
+		mockSvc := mock.NewMockTicketService(ctrl)
+		mockSvc.EXPECT().
+			GetUsersBySection(ctx, "journey-1", "C").
+			Return(&ticket.GetUsersBySectionResponse{}, nil)
+		h := handler.NewTicketGrpcHandler(mockSvc)
+		if _, err := h.GetUsersBySection(ctx, req); err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+	})
 }
 
 func TestUnit_HandlerRemoveUser(t *testing.T) {
@@ -214,10 +615,10 @@ func TestUnit_HandlerRemoveUser(t *testing.T) {
 		mockSvc := mock.NewMockTicketService(ctrl)
 		mockSvc.EXPECT().
 			RemoveUser(ctx, "user@example.com").
-			Return(ticket.RemoveUserResponse{}, expectedErr)
+			Return(nil, expectedErr)
 		h := handler.NewTicketGrpcHandler(mockSvc)
 		resp, err := h.RemoveUser(ctx, req)
-		if err == nil || err.Error() != expectedErr.Error() {
+		if st := status.Convert(err); st.Code() != codes.Internal || st.Message() != expectedErr.Error() {
 			t.Errorf("expected error %v, got %v", expectedErr, err)
 		}
 		if resp != nil {
@@ -229,7 +630,7 @@ func TestUnit_HandlerRemoveUser(t *testing.T) {
 		req := &ticket.RemoveUserRequest{
 			Identifier: &ticket.RemoveUserRequest_Email{Email: "user@example.com"},
 		}
-		expectedResp := ticket.RemoveUserResponse{}
+		expectedResp := &ticket.RemoveUserResponse{}
 		mockSvc := mock.NewMockTicketService(ctrl)
 		mockSvc.EXPECT().
 			RemoveUser(ctx, "user@example.com").
@@ -243,6 +644,95 @@ func TestUnit_HandlerRemoveUser(t *testing.T) {
 			t.Errorf("expected a non-nil response")
 		}
 	})
+
+	t.Run("removal by ticketId", func(t *testing.T) {
+		req := &ticket.RemoveUserRequest{
+			Identifier: &ticket.RemoveUserRequest_TicketId{TicketId: "ticket-123"},
+		}
+		mockSvc := mock.NewMockTicketService(ctrl)
+		mockSvc.EXPECT().
+			CancelTicket(ctx, "ticket-123").
+			Return(&ticket.CancelTicketResponse{Success: true, Refund: &ticket.Refund{RefundId: "r1"}}, nil)
+		h := handler.NewTicketGrpcHandler(mockSvc)
+		resp, err := h.RemoveUser(ctx, req)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if !resp.GetSuccess() {
+			t.Errorf("expected a successful response, got %v", resp)
+		}
+		if resp.GetRefund().GetRefundId() != "r1" {
+			t.Errorf("expected the refund r1, got %v", resp.GetRefund())
+		}
+	})
+}
+
+func TestUnit_HandlerCancelTicket(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+
+	t.Run("missing ticketId", func(t *testing.T) {
+		h := handler.NewTicketGrpcHandler(mock.NewMockTicketService(ctrl))
+		_, err := h.CancelTicket(ctx, &ticket.CancelTicketRequest{})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("expected %v for missing ticketId, got %v", codes.InvalidArgument, err)
+		}
+	})
+
+	t.Run("successful cancellation", func(t *testing.T) {
+		mockSvc := mock.NewMockTicketService(ctrl)
+		mockSvc.EXPECT().
+			CancelTicket(ctx, "ticket-123").
+			Return(&ticket.CancelTicketResponse{Success: true, Receipt: &ticket.Receipt{TicketId: "ticket-123"}}, nil)
+		h := handler.NewTicketGrpcHandler(mockSvc)
+		resp, err := h.CancelTicket(ctx, &ticket.CancelTicketRequest{TicketId: "ticket-123"})
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if resp.GetReceipt().GetTicketId() != "ticket-123" {
+			t.Errorf("expected the cancelled ticket, got %v", resp)
+		}
+	})
+}
+
+func TestUnit_HandlerListTicketsForUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+
+	t.Run("invalid request", func(t *testing.T) {
+		h := handler.NewTicketGrpcHandler(mock.NewMockTicketService(ctrl))
+		for _, req := range []*ticket.ListTicketsForUserRequest{
+			{},
+			{Email: "user@example.com", PageSize: -1},
+		} {
+			if _, err := h.ListTicketsForUser(ctx, req); status.Code(err) != codes.InvalidArgument {
+				t.Errorf("expected %v for %v, got %v", codes.InvalidArgument, req, err)
+			}
+		}
+	})
+
+	t.Run("successful listing", func(t *testing.T) {
+		req := &ticket.ListTicketsForUserRequest{Email: "user@example.com", PageSize: 2}
+		expectedResp := &ticket.ListTicketsForUserResponse{
+			Success:       true,
+			Tickets:       []*ticket.Receipt{{TicketId: "t1"}, {TicketId: "t2"}},
+			NextPageToken: "next",
+		}
+		mockSvc := mock.NewMockTicketService(ctrl)
+		mockSvc.EXPECT().
+			ListTicketsForUser(ctx, req).
+			Return(expectedResp, nil)
+		h := handler.NewTicketGrpcHandler(mockSvc)
+		resp, err := h.ListTicketsForUser(ctx, req)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if len(resp.GetTickets()) != 2 || resp.GetNextPageToken() != "next" {
+			t.Errorf("expected the service's page, got %v", resp)
+		}
+	})
 }
 
 func TestUnit_HandlerModifyUserSeat(t *testing.T) {
@@ -288,7 +778,7 @@ func TestUnit_HandlerModifyUserSeat(t *testing.T) {
 			Return(nil, expectedErr)
 		h := handler.NewTicketGrpcHandler(mockSvc)
 		_, err := h.ModifyUserSeat(ctx, req)
-		if err == nil || err.Error() != expectedErr.Error() {
+		if st := status.Convert(err); st.Code() != codes.Internal || st.Message() != expectedErr.Error() {
 			t.Errorf("expected error %v, got %v", expectedErr, err)
 		}
 	})
@@ -306,10 +796,10 @@ func TestUnit_HandlerModifyUserSeat(t *testing.T) {
 			Return(expectedReceipt, nil)
 		mockSvc.EXPECT().
 			ModifyUserSeat(ctx, expectedReceipt, req.GetNewSeat()).
-			Return(ticket.ModifyUserSeatResponse{}, expectedErr)
+			Return(nil, expectedErr)
 		h := handler.NewTicketGrpcHandler(mockSvc)
 		_, err := h.ModifyUserSeat(ctx, req)
-		if err == nil || err.Error() != expectedErr.Error() {
+		if st := status.Convert(err); st.Code() != codes.Internal || st.Message() != expectedErr.Error() {
 			t.Errorf("expected error %v, got %v", expectedErr, err)
 		}
 	})
@@ -320,7 +810,7 @@ func TestUnit_HandlerModifyUserSeat(t *testing.T) {
 			NewSeat:    &ticket.Seat{SeatNumber: "B2"},
 		}
 		expectedReceipt := &ticket.Receipt{TicketId: "ticket-123"}
-		expectedResp := ticket.ModifyUserSeatResponse{
+		expectedResp := &ticket.ModifyUserSeatResponse{
 			Message: "seat updated successfully",
 		}
 		mockSvc := mock.NewMockTicketService(ctrl)
@@ -342,4 +832,399 @@ func TestUnit_HandlerModifyUserSeat(t *testing.T) {
 			t.Errorf("expected message %s, got %s", expectedResp.Message, resp.GetMessage())
 		}
 	})
+
+	t.Run("modification by email", func(t *testing.T) {
+		req := &ticket.ModifyUserSeatRequest{
+			Identifier: &ticket.ModifyUserSeatRequest_Email{Email: "user@example.com"},
+			NewSeat:    &ticket.Seat{SeatNumber: "B2"},
+		}
+		expectedReceipt := &ticket.Receipt{TicketId: "ticket-123"}
+		mockSvc := mock.NewMockTicketService(ctrl)
+		mockSvc.EXPECT().
+			GetReceiptByEmail(ctx, "user@example.com").
+			Return(expectedReceipt, nil)
+		mockSvc.EXPECT().
+			ModifyUserSeat(ctx, expectedReceipt, req.GetNewSeat()).
+			Return(&ticket.ModifyUserSeatResponse{Success: true}, nil)
+		h := handler.NewTicketGrpcHandler(mockSvc)
+		if _, err := h.ModifyUserSeat(ctx, req); err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+	})
+}
+
+func TestUnit_HandlerGetTicketHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+
+	t.Run("missing ticketId", func(t *testing.T) {
+		mockSvc := mock.NewMockTicketService(ctrl)
+		h := handler.NewTicketGrpcHandler(mockSvc)
+		_, err := h.GetTicketHistory(ctx, &ticket.GetTicketHistoryRequest{})
+		if err == nil {
+			t.Errorf("expected error for missing ticketId, got nil")
+		}
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		expectedErr := errors.New("history retrieval failed")
+		mockSvc := mock.NewMockTicketService(ctrl)
+		mockSvc.EXPECT().
+			GetTicketHistory(ctx, "ticket-123").
+			Return(nil, expectedErr)
+		h := handler.NewTicketGrpcHandler(mockSvc)
+		resp, err := h.GetTicketHistory(ctx, &ticket.GetTicketHistoryRequest{TicketId: "ticket-123"})
+		if st := status.Convert(err); st.Code() != codes.Internal || st.Message() != expectedErr.Error() {
+			t.Errorf("expected error %v, got %v", expectedErr, err)
+		}
+		if resp != nil {
+			t.Errorf("expected nil response on error, got %v", resp)
+		}
+	})
+
+	t.Run("successful retrieval", func(t *testing.T) {
+		expectedResp := &ticket.GetTicketHistoryResponse{
+			Success: true,
+			Events:  []*ticket.BookingEvent{{Sequence: 1, TicketId: "ticket-123"}},
+		}
+		mockSvc := mock.NewMockTicketService(ctrl)
+		mockSvc.EXPECT().
+			GetTicketHistory(ctx, "ticket-123").
+			Return(expectedResp, nil)
+		h := handler.NewTicketGrpcHandler(mockSvc)
+		resp, err := h.GetTicketHistory(ctx, &ticket.GetTicketHistoryRequest{TicketId: "ticket-123"})
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if len(resp.GetEvents()) != 1 {
+			t.Errorf("expected 1 event, got %v", resp)
+		}
+	})
+}
+
+func TestUnit_HandlerGetSeatOccupant(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+
+	t.Run("missing seat number", func(t *testing.T) {
+		mockSvc := mock.NewMockTicketService(ctrl)
+		h := handler.NewTicketGrpcHandler(mockSvc)
+		_, err := h.GetSeatOccupant(ctx, &ticket.GetSeatOccupantRequest{})
+		if err == nil {
+			t.Errorf("expected error for missing seat number, got nil")
+		}
+	})
+
+	t.Run("uses requested time", func(t *testing.T) {
+		at := time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC)
+		expectedResp := &ticket.GetSeatOccupantResponse{
+			Success: true,
+			Receipt: &ticket.Receipt{TicketId: "ticket-123"},
+		}
+		mockSvc := mock.NewMockTicketService(ctrl)
+		mockSvc.EXPECT().
+			GetSeatOccupant(ctx, "", "B2", at).
+			Return(expectedResp, nil)
+		h := handler.NewTicketGrpcHandler(mockSvc)
+		resp, err := h.GetSeatOccupant(ctx, &ticket.GetSeatOccupantRequest{SeatNumber: "B2", At: timestamppb.New(at)})
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if resp.GetReceipt().GetTicketId() != "ticket-123" {
+			t.Errorf("expected ticket-123, got %v", resp)
+		}
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		expectedErr := errors.New("occupant lookup failed")
+		mockSvc := mock.NewMockTicketService(ctrl)
+		mockSvc.EXPECT().
+			GetSeatOccupant(ctx, "", "B2", gomock.Any()).
+			Return(nil, expectedErr)
+		h := handler.NewTicketGrpcHandler(mockSvc)
+		resp, err := h.GetSeatOccupant(ctx, &ticket.GetSeatOccupantRequest{SeatNumber: "B2"})
+		if st := status.Convert(err); st.Code() != codes.Internal || st.Message() != expectedErr.Error() {
+			t.Errorf("expected error %v, got %v", expectedErr, err)
+		}
+		if resp != nil {
+			t.Errorf("expected nil response on error, got %v", resp)
+		}
+	})
+}
+
+func TestUnit_HandlerCreateJourney(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+
+	validReq := &ticket.CreateJourneyRequest{
+		ServiceDate:   "2025-05-01",
+		DepartureTime: timestamppb.New(time.Date(2025, 5, 1, 8, 0, 0, 0, time.UTC)),
+		Origin:        "London",
+		Destination:   "Paris",
+	}
+
+	t.Run("invalid request", func(t *testing.T) {
+		invalid := []*ticket.CreateJourneyRequest{
+			{},
+			{ServiceDate: "01/05/2025", DepartureTime: validReq.DepartureTime, Origin: "London", Destination: "Paris"},
+			{ServiceDate: "2025-05-01", Origin: "London", Destination: "Paris"},
+			{ServiceDate: "2025-05-01", DepartureTime: validReq.DepartureTime, Origin: "London", Destination: "London"},
+			{ServiceDate: "2025-05-01", DepartureTime: validReq.DepartureTime, Origin: "London", Destination: "Paris", TimeZone: "Europe/Atlantis"},
+		}
+		mockSvc := mock.NewMockTicketService(ctrl)
+		h := handler.NewTicketGrpcHandler(mockSvc)
+		for _, req := range invalid {
+			if _, err := h.CreateJourney(ctx, req); err == nil {
+				t.Errorf("expected error for invalid request %v, got nil", req)
+			}
+		}
+	})
+
+	t.Run("successful creation", func(t *testing.T) {
+		expectedResp := &ticket.CreateJourneyResponse{
+			Success: true,
+			Journey: &ticket.Journey{JourneyId: "journey-1"},
+		}
+		mockSvc := mock.NewMockTicketService(ctrl)
+		mockSvc.EXPECT().CreateJourney(ctx, validReq).Return(expectedResp, nil)
+		h := handler.NewTicketGrpcHandler(mockSvc)
+		resp, err := h.CreateJourney(ctx, validReq)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if resp.GetJourney().GetJourneyId() != "journey-1" {
+			t.Errorf("expected journey-1, got %v", resp)
+		}
+	})
+}
+
+func TestUnit_HandlerListJourneys(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+
+	t.Run("invalid service date", func(t *testing.T) {
+		mockSvc := mock.NewMockTicketService(ctrl)
+		h := handler.NewTicketGrpcHandler(mockSvc)
+		if _, err := h.ListJourneys(ctx, &ticket.ListJourneysRequest{ServiceDate: "tomorrow"}); err == nil {
+			t.Errorf("expected error for invalid service date, got nil")
+		}
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		expectedErr := errors.New("listing failed")
+		mockSvc := mock.NewMockTicketService(ctrl)
+		mockSvc.EXPECT().ListJourneys(ctx, "").Return(nil, expectedErr)
+		h := handler.NewTicketGrpcHandler(mockSvc)
+		resp, err := h.ListJourneys(ctx, &ticket.ListJourneysRequest{})
+		if st := status.Convert(err); st.Code() != codes.Internal || st.Message() != expectedErr.Error() {
+			t.Errorf("expected error %v, got %v", expectedErr, err)
+		}
+		if resp != nil {
+			t.Errorf("expected nil response on error, got %v", resp)
+		}
+	})
+
+	t.Run("successful listing", func(t *testing.T) {
+		expectedResp := &ticket.ListJourneysResponse{
+			Success:  true,
+			Journeys: []*ticket.Journey{{JourneyId: "journey-1"}},
+		}
+		mockSvc := mock.NewMockTicketService(ctrl)
+		mockSvc.EXPECT().ListJourneys(ctx, "2025-05-01").Return(expectedResp, nil)
+		h := handler.NewTicketGrpcHandler(mockSvc)
+		resp, err := h.ListJourneys(ctx, &ticket.ListJourneysRequest{ServiceDate: "2025-05-01"})
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if len(resp.GetJourneys()) != 1 {
+			t.Errorf("expected 1 journey, got %v", resp)
+		}
+	})
+}
+
+func TestUnit_HandlerQuoteFare(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+
+	t.Run("invalid requests", func(t *testing.T) {
+		mockSvc := mock.NewMockTicketService(ctrl)
+		h := handler.NewTicketGrpcHandler(mockSvc)
+		for _, req := range []*ticket.QuoteFareRequest{
+			{ToLocation: "Paris"},
+			{FromLocation: "London"},
+			{FromLocation: "London", ToLocation: "Paris", TravelClass: ticket.TravelClass(7)},
+			{FromLocation: "London", ToLocation: "Paris", PassengerType: ticket.PassengerType(7)},
+		} {
+			if _, err := h.QuoteFare(ctx, req); status.Code(err) != codes.InvalidArgument {
+				t.Errorf("expected InvalidArgument for %v, got %v", req, err)
+			}
+		}
+	})
+
+	t.Run("fare mismatch", func(t *testing.T) {
+		mockSvc := mock.NewMockTicketService(ctrl)
+		h := handler.NewTicketGrpcHandler(mockSvc)
+		req := &ticket.PurchaseTicketRequest{
+			FromLocation: "London",
+			ToLocation:   "Paris",
+			User:         &ticket.User{Email: "alice@example.com"},
+			PricePaid:    20,
+		}
+		mockSvc.EXPECT().PurchaseTicket(ctx, req).Return(nil, &service.Error{
+			Kind:    service.KindFailedPrecondition,
+			Reason:  service.ReasonFareMismatch,
+			Message: service.ErrFareMismatch,
+			Subject: "30.00",
+		})
+		_, err := h.PurchaseTicket(ctx, req)
+		if status.Code(err) != codes.FailedPrecondition {
+			t.Errorf("expected FailedPrecondition, got %v", err)
+		}
+	})
+
+	t.Run("successful quote", func(t *testing.T) {
+		req := &ticket.QuoteFareRequest{FromLocation: "London", ToLocation: "Paris", TravelClass: ticket.TravelClass_TRAVEL_CLASS_FIRST}
+		mockSvc := mock.NewMockTicketService(ctrl)
+		mockSvc.EXPECT().QuoteFare(ctx, req).Return(&ticket.QuoteFareResponse{Success: true, Fare: &ticket.Fare{Amount: 30}}, nil)
+		h := handler.NewTicketGrpcHandler(mockSvc)
+		resp, err := h.QuoteFare(ctx, req)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if resp.GetFare().GetAmount() != 30 {
+			t.Errorf("expected a fare of 30, got %v", resp)
+		}
+	})
+}
+
+func TestUnit_HandlerErrorStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+
+	t.Run("invalid field is described", func(t *testing.T) {
+		h := handler.NewTicketGrpcHandler(mock.NewMockTicketService(ctrl))
+		_, err := h.PurchaseTicket(ctx, &ticket.PurchaseTicketRequest{FromLocation: "Station A"})
+		st := status.Convert(err)
+		if st.Code() != codes.InvalidArgument {
+			t.Fatalf("expected %v, got %v", codes.InvalidArgument, err)
+		}
+		var violations []*errdetails.BadRequest_FieldViolation
+		for _, detail := range st.Details() {
+			if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+				violations = badRequest.GetFieldViolations()
+			}
+		}
+		if len(violations) != 1 || violations[0].GetField() != "to_location" {
+			t.Errorf("expected a violation of to_location, got %v", violations)
+		}
+	})
+
+	t.Run("missing identifier is described", func(t *testing.T) {
+		h := handler.NewTicketGrpcHandler(mock.NewMockTicketService(ctrl))
+		_, err := h.GetReceiptDetails(ctx, &ticket.GetReceiptDetailsRequest{})
+		if st := status.Convert(err); st.Code() != codes.InvalidArgument || len(st.Details()) != 1 {
+			t.Errorf("expected %v with a field violation, got %v", codes.InvalidArgument, err)
+		}
+	})
+
+	// Real service failures, so that the mapping follows the service's classification.
+	s := service.NewTicketService()
+	h := handler.NewTicketGrpcHandler(s)
+	purchase := func(email string) *ticket.Receipt {
+		resp, err := h.PurchaseTicket(ctx, &ticket.PurchaseTicketRequest{
+			FromLocation: "London", ToLocation: "Paris", User: &ticket.User{Email: email}, PricePaid: 20,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return resp.GetReceipt()
+	}
+	first, second := purchase("a@example.com"), purchase("b@example.com")
+	purchase("twice@example.com")
+	purchase("twice@example.com")
+
+	tests := []struct {
+		name   string
+		call   func() error
+		code   codes.Code
+		reason string
+	}{
+		{"unknown ticket", func() error {
+			_, err := h.GetReceiptDetails(ctx, &ticket.GetReceiptDetailsRequest{Identifier: &ticket.GetReceiptDetailsRequest_TicketId{TicketId: "missing"}})
+			return err
+		}, codes.NotFound, service.ReasonReceiptNotFound},
+		{"unknown email", func() error {
+			_, err := h.RemoveUser(ctx, &ticket.RemoveUserRequest{Identifier: &ticket.RemoveUserRequest_Email{Email: "missing@example.com"}})
+			return err
+		}, codes.NotFound, service.ReasonUserNotFound},
+		{"email holding several tickets", func() error {
+			_, err := h.GetReceiptDetails(ctx, &ticket.GetReceiptDetailsRequest{Identifier: &ticket.GetReceiptDetailsRequest_Email{Email: "twice@example.com"}})
+			return err
+		}, codes.FailedPrecondition, service.ReasonEmailAmbiguous},
+		{"occupied seat", func() error {
+			_, err := h.ModifyUserSeat(ctx, &ticket.ModifyUserSeatRequest{
+				Identifier: &ticket.ModifyUserSeatRequest_TicketId{TicketId: first.GetTicketId()},
+				NewSeat:    &ticket.Seat{SeatNumber: second.GetAllocatedSeat().GetSeatNumber()},
+			})
+			return err
+		}, codes.FailedPrecondition, service.ReasonSeatOccupied},
+		{"waitlist while seats are free", func() error {
+			_, err := h.JoinWaitlist(ctx, &ticket.JoinWaitlistRequest{
+				FromLocation: "London", ToLocation: "Paris", User: &ticket.User{Email: "c@example.com"}, PricePaid: 20,
+			})
+			return err
+		}, codes.FailedPrecondition, service.ReasonSeatsAvailable},
+		{"unknown journey", func() error {
+			_, err := h.GetUsersBySection(ctx, &ticket.GetUsersBySectionRequest{JourneyId: "missing", Coach: "A"})
+			return err
+		}, codes.NotFound, service.ReasonJourneyNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := status.Convert(tt.call())
+			if st.Code() != tt.code {
+				t.Fatalf("expected %v, got %v: %s", tt.code, st.Code(), st.Message())
+			}
+			var info *errdetails.ErrorInfo
+			for _, detail := range st.Details() {
+				if d, ok := detail.(*errdetails.ErrorInfo); ok {
+					info = d
+				}
+			}
+			if info.GetReason() != tt.reason || info.GetDomain() != handler.ErrorDomain {
+				t.Errorf("expected reason %s in domain %s, got %v", tt.reason, handler.ErrorDomain, info)
+			}
+		})
+	}
+
+	t.Run("conflicting seat is attached", func(t *testing.T) {
+		_, err := h.ModifyUserSeat(ctx, &ticket.ModifyUserSeatRequest{
+			Identifier: &ticket.ModifyUserSeatRequest_TicketId{TicketId: first.GetTicketId()},
+			NewSeat:    &ticket.Seat{SeatNumber: second.GetAllocatedSeat().GetSeatNumber()},
+		})
+		var resource *errdetails.ResourceInfo
+		for _, detail := range status.Convert(err).Details() {
+			if d, ok := detail.(*errdetails.ResourceInfo); ok {
+				resource = d
+			}
+		}
+		if resource.GetResourceType() != "seat" || resource.GetResourceName() != second.GetAllocatedSeat().GetSeatNumber() {
+			t.Errorf("expected seat %s as the conflicting resource, got %v", second.GetAllocatedSeat().GetSeatNumber(), resource)
+		}
+	})
+
+	t.Run("cancelled stream", func(t *testing.T) {
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+		err := h.WatchAvailability(&ticket.WatchAvailabilityRequest{}, &fakeAvailabilityStream{ctx: cancelled})
+		if status.Code(err) != codes.Canceled {
+			t.Errorf("expected %v, got %v", codes.Canceled, err)
+		}
+	})
 }
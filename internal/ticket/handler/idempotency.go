@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"context"
+
+	ticket "github.com/talk2sohail/train-ticket-api/internal/common/genproto/ticket"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/auth"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// IdempotencyKeyHeader is the metadata key clients send an idempotency key in.
+	IdempotencyKeyHeader = "idempotency-key"
+	// IdempotentReplayedHeader is set in the response header metadata of a replayed call.
+	IdempotentReplayedHeader = "idempotent-replayed"
+)
+
+// mutatingMethods are the RPCs that change bookings or journeys and so honour idempotency keys.
+var mutatingMethods = map[string]bool{
+	ticket.TrainTicketingService_PurchaseTicket_FullMethodName:      true,
+	ticket.TrainTicketingService_PurchaseGroupTicket_FullMethodName: true,
+	ticket.TrainTicketingService_HoldSeat_FullMethodName:            true,
+	ticket.TrainTicketingService_ConfirmHold_FullMethodName:         true,
+	ticket.TrainTicketingService_JoinWaitlist_FullMethodName:        true,
+	ticket.TrainTicketingService_RemoveUser_FullMethodName:          true,
+	ticket.TrainTicketingService_CancelTicket_FullMethodName:        true,
+	ticket.TrainTicketingService_ModifyUserSeat_FullMethodName:      true,
+	ticket.TrainTicketingService_CreateJourney_FullMethodName:       true,
+}
+
+// IdempotencyInterceptor answers a retried mutating call carrying the same idempotency
+// key as an earlier successful one with that call's response, without serving it again.
+// Keys are scoped to the RPC and, with authentication on, to the caller. It must run
+// after the authentication interceptor.
+func IdempotencyInterceptor(store *service.IdempotencyStore) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		key := idempotencyKey(ctx)
+		if key == "" || !mutatingMethods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+		if err := service.ValidateIdempotencyKey(key); err != nil {
+			return nil, toStatus(err)
+		}
+		fingerprint, err := proto.MarshalOptions{Deterministic: true}.Marshal(req.(proto.Message))
+		if err != nil {
+			return nil, toStatus(err)
+		}
+
+		scope := info.FullMethod
+		if claims, ok := auth.FromContext(ctx); ok {
+			scope += "\x00" + string(claims.Role) + ":" + claims.Email
+		}
+		var callErr error
+		resp, replayed, err := store.Do(ctx, scope+"\x00"+key, fingerprint, func() (any, bool) {
+			var resp any
+			resp, callErr = handler(ctx, req)
+			return resp, callErr == nil
+		})
+		if err != nil {
+			return nil, toStatus(err)
+		}
+		if replayed {
+			if err := grpc.SetHeader(ctx, metadata.Pairs(IdempotentReplayedHeader, "true")); err != nil {
+				return nil, toStatus(err)
+			}
+			return resp, nil
+		}
+		return resp, callErr
+	}
+}
+
+func idempotencyKey(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(IdempotencyKeyHeader); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
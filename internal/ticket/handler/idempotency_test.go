@@ -0,0 +1,156 @@
+package handler_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	ticket "github.com/talk2sohail/train-ticket-api/internal/common/genproto/ticket"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/auth"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/handler"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/service"
+	"github.com/talk2sohail/train-ticket-api/mock"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// fakeTransportStream records the header metadata a handler sets.
+type fakeTransportStream struct {
+	method string
+	header metadata.MD
+}
+
+func (f *fakeTransportStream) Method() string { return f.method }
+
+func (f *fakeTransportStream) SetHeader(md metadata.MD) error {
+	f.header = metadata.Join(f.header, md)
+	return nil
+}
+
+func (f *fakeTransportStream) SendHeader(md metadata.MD) error { return f.SetHeader(md) }
+
+func (f *fakeTransportStream) SetTrailer(metadata.MD) error { return nil }
+
+func TestUnit_IdempotencyInterceptor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	req := &ticket.PurchaseTicketRequest{
+		FromLocation: "Station A",
+		ToLocation:   "Station B",
+		User:         &ticket.User{FirstName: "Alice", LastName: "Smith", Email: "alice.smith@example.com"},
+		PricePaid:    50.0,
+	}
+	purchase := &grpc.UnaryServerInfo{FullMethod: ticket.TrainTicketingService_PurchaseTicket_FullMethodName}
+
+	// invoke calls PurchaseTicket on h through the interceptor with the given idempotency key.
+	invoke := func(interceptor grpc.UnaryServerInterceptor, h *handler.TicketGrpcHandler, ctx context.Context, key string, req *ticket.PurchaseTicketRequest) (*ticket.PurchaseTicketResponse, *fakeTransportStream, error) {
+		stream := &fakeTransportStream{method: purchase.FullMethod}
+		ctx = grpc.NewContextWithServerTransportStream(ctx, stream)
+		if key != "" {
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(handler.IdempotencyKeyHeader, key))
+		}
+		resp, err := interceptor(ctx, req, purchase, func(ctx context.Context, req any) (any, error) {
+			return h.PurchaseTicket(ctx, req.(*ticket.PurchaseTicketRequest))
+		})
+		if err != nil {
+			return nil, stream, err
+		}
+		return resp.(*ticket.PurchaseTicketResponse), stream, nil
+	}
+
+	newHandler := func(times int) (*handler.TicketGrpcHandler, grpc.UnaryServerInterceptor) {
+		mockSvc := mock.NewMockTicketService(ctrl)
+		mockSvc.EXPECT().PurchaseTicket(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(context.Context, *ticket.PurchaseTicketRequest) (*ticket.PurchaseTicketResponse, error) {
+				return &ticket.PurchaseTicketResponse{Success: true, Receipt: &ticket.Receipt{TicketId: uuid.New().String()}}, nil
+			}).Times(times)
+		return handler.NewTicketGrpcHandler(mockSvc), handler.IdempotencyInterceptor(service.NewIdempotencyStore(0, nil))
+	}
+
+	t.Run("Retry is replayed", func(t *testing.T) {
+		h, interceptor := newHandler(1)
+		first, stream, err := invoke(interceptor, h, context.Background(), "retry-1", req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(stream.header.Get(handler.IdempotentReplayedHeader)) != 0 {
+			t.Errorf("expected the first call not to be marked as replayed")
+		}
+		second, stream, err := invoke(interceptor, h, context.Background(), "retry-1", req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if second.GetReceipt().GetTicketId() != first.GetReceipt().GetTicketId() {
+			t.Errorf("expected the retry to get ticket %s, got %s", first.GetReceipt().GetTicketId(), second.GetReceipt().GetTicketId())
+		}
+		if got := stream.header.Get(handler.IdempotentReplayedHeader); len(got) != 1 || got[0] != "true" {
+			t.Errorf("expected the retry to be marked as replayed, got %v", got)
+		}
+	})
+
+	t.Run("Calls without a key are served every time", func(t *testing.T) {
+		h, interceptor := newHandler(2)
+		for range 2 {
+			if _, _, err := invoke(interceptor, h, context.Background(), "", req); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+	})
+
+	t.Run("Key reused for a different request", func(t *testing.T) {
+		h, interceptor := newHandler(1)
+		if _, _, err := invoke(interceptor, h, context.Background(), "retry-1", req); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		other := &ticket.PurchaseTicketRequest{FromLocation: req.FromLocation, ToLocation: req.ToLocation, User: req.User, PricePaid: 60}
+		_, _, err := invoke(interceptor, h, context.Background(), "retry-1", other)
+		if status.Code(err) != codes.FailedPrecondition {
+			t.Fatalf("expected %v, got %v", codes.FailedPrecondition, err)
+		}
+		for _, detail := range status.Convert(err).Details() {
+			if info, ok := detail.(*errdetails.ErrorInfo); ok && info.GetReason() != service.ReasonIdempotencyKeyReused {
+				t.Errorf("expected reason %s, got %s", service.ReasonIdempotencyKeyReused, info.GetReason())
+			}
+		}
+	})
+
+	t.Run("Keys are scoped to the caller", func(t *testing.T) {
+		h, interceptor := newHandler(2)
+		for _, email := range []string{"alice.smith@example.com", "staff@example.com"} {
+			ctx := auth.NewContext(context.Background(), &auth.Claims{Email: email, Role: auth.RoleStaff})
+			if _, stream, err := invoke(interceptor, h, ctx, "retry-1", req); err != nil || len(stream.header) != 0 {
+				t.Fatalf("expected a fresh call for %s, got header %v and error %v", email, stream.header, err)
+			}
+		}
+	})
+
+	t.Run("Key too long", func(t *testing.T) {
+		h, interceptor := newHandler(0)
+		_, _, err := invoke(interceptor, h, context.Background(), strings.Repeat("k", service.MaxIdempotencyKeyLength+1), req)
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("expected %v, got %v", codes.InvalidArgument, err)
+		}
+	})
+
+	t.Run("Read-only calls are not recorded", func(t *testing.T) {
+		mockSvc := mock.NewMockTicketService(ctrl)
+		mockSvc.EXPECT().ListJourneys(gomock.Any(), "").Return(&ticket.ListJourneysResponse{Success: true}, nil).Times(2)
+		h := handler.NewTicketGrpcHandler(mockSvc)
+		interceptor := handler.IdempotencyInterceptor(service.NewIdempotencyStore(0, nil))
+		info := &grpc.UnaryServerInfo{FullMethod: ticket.TrainTicketingService_ListJourneys_FullMethodName}
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(handler.IdempotencyKeyHeader, "retry-1"))
+		for range 2 {
+			if _, err := interceptor(ctx, &ticket.ListJourneysRequest{}, info, func(ctx context.Context, req any) (any, error) {
+				return h.ListJourneys(ctx, req.(*ticket.ListJourneysRequest))
+			}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+	})
+}
@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	"log"
+
+	"github.com/talk2sohail/train-ticket-api/internal/common/util"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/ratelimit"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/service"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// ErrorDomain is the domain of the ErrorInfo details attached to service failures.
+const ErrorDomain = "trainticketing"
+
+// kindCodes maps the kinds of service failure to gRPC status codes.
+var kindCodes = map[service.Kind]codes.Code{
+	service.KindInternal:           codes.Internal,
+	service.KindInvalidArgument:    codes.InvalidArgument,
+	service.KindNotFound:           codes.NotFound,
+	service.KindFailedPrecondition: codes.FailedPrecondition,
+	service.KindResourceExhausted:  codes.ResourceExhausted,
+	service.KindUnavailable:        codes.Unavailable,
+}
+
+// toStatus converts an error from request validation or the service into a gRPC status error.
+// Invalid fields are described with BadRequest details; service failures carry an ErrorInfo
+// with their reason, a ResourceInfo for the seat in conflict, and a RetryInfo when retrying
+// later may succeed. Errors that are already
+// statuses, such as those from a stream's Send, are returned as is.
+func toStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	var fieldErr *util.FieldError
+	if errors.As(err, &fieldErr) {
+		return withDetails(status.New(codes.InvalidArgument, err.Error()), &errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: fieldErr.Field, Description: fieldErr.Description}},
+		})
+	}
+
+	if svcErr, ok := service.AsError(err); ok {
+		info := &errdetails.ErrorInfo{Reason: svcErr.Reason, Domain: ErrorDomain}
+		if svcErr.Subject != "" {
+			info.Metadata = map[string]string{"subject": svcErr.Subject}
+		}
+		details := []protoadapt.MessageV1{info}
+		if svcErr.Seat != "" {
+			details = append(details, &errdetails.ResourceInfo{
+				ResourceType: "seat",
+				ResourceName: svcErr.Seat,
+				Description:  svcErr.Message,
+			})
+		}
+		if svcErr.RetryAfter > 0 {
+			details = append(details, &errdetails.RetryInfo{RetryDelay: durationpb.New(svcErr.RetryAfter)})
+		}
+		return withDetails(status.New(kindCodes[svcErr.Kind], err.Error()), details...)
+	}
+
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err).Err()
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+// failed converts a service failure into a gRPC status error like toStatus. For a failure that
+// may succeed later, such as a ticket quota freed by an expiring hold, it also sets the
+// retry-after header the rate limiter sends, so clients wait the same way for both.
+func failed(ctx context.Context, err error) error {
+	if svcErr, ok := service.AsError(err); ok && svcErr.RetryAfter > 0 {
+		if err := grpc.SetHeader(ctx, metadata.Pairs(ratelimit.RetryAfterHeader, ratelimit.RetryAfterSeconds(svcErr.RetryAfter))); err != nil {
+			log.Printf("Failed to set %s: %v", ratelimit.RetryAfterHeader, err)
+		}
+	}
+	return toStatus(err)
+}
+
+// invalidRequest reports a request that failed validation.
+func invalidRequest(err error) error {
+	var fieldErr *util.FieldError
+	if errors.As(err, &fieldErr) {
+		return toStatus(err)
+	}
+	return status.Error(codes.InvalidArgument, err.Error())
+}
+
+// requiredField reports a missing request field.
+func requiredField(field, description string) error {
+	return toStatus(&util.FieldError{Field: field, Description: description})
+}
+
+func withDetails(st *status.Status, details ...protoadapt.MessageV1) error {
+	detailed, err := st.WithDetails(details...)
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
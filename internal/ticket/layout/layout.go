@@ -0,0 +1,224 @@
+// Package layout builds train seating plans from configuration.
+package layout
+
+import (
+	"fmt"
+	"strconv"
+
+	ticket "github.com/talk2sohail/train-ticket-api/internal/common/genproto/ticket"
+)
+
+// StandardName is the name of the built-in layout with sections A and B.
+const StandardName = "standard"
+
+// Column positions a seat can have within its row.
+const (
+	PositionWindow = "window"
+	PositionAisle  = "aisle"
+	PositionMiddle = "middle"
+)
+
+// Directions a seat can face relative to the direction of travel.
+const (
+	FacingForward  = "forward"
+	FacingBackward = "backward"
+)
+
+// Config describes a train layout as read from the server config file.
+type Config struct {
+	Coaches []CoachConfig `json:"coaches"` // Coaches in train order.
+}
+
+// CoachConfig describes a coach as a grid of rows and columns.
+// Seat numbers are the coach ID followed by the row and the column letter, e.g. "C12D".
+type CoachConfig struct {
+	ID            string                `json:"id"`             // e.g. "C"
+	Rows          int                   `json:"rows"`           // Number of rows, numbered from 1.
+	Columns       []ColumnConfig        `json:"columns"`        // Columns of every row; a single unnamed column when empty.
+	Attributes    Attributes            `json:"attributes"`     // Features shared by every seat in the coach, e.g. a quiet zone.
+	RowAttributes map[int]Attributes    `json:"row_attributes"` // Features of every seat in a row, e.g. facing backward or near a door.
+	Seats         map[string]Attributes `json:"seats"`          // Extra features of single seats, keyed by row and column, e.g. "1A".
+}
+
+// ColumnConfig describes one column of seats in a coach.
+type ColumnConfig struct {
+	Letter   string `json:"letter"`   // e.g. "A"
+	Position string `json:"position"` // "window", "aisle" or "middle"
+}
+
+// Attributes lists seat features. Features set at several levels are combined;
+// the facing direction of the most specific level wins.
+type Attributes struct {
+	Table       bool   `json:"table"`
+	PowerSocket bool   `json:"power_socket"`
+	Accessible  bool   `json:"accessible"`
+	QuietZone   bool   `json:"quiet_zone"`
+	NearDoor    bool   `json:"near_door"`
+	Facing      string `json:"facing"` // "forward" or "backward"
+}
+
+// Standard returns the original layout: coaches A and B with seatsPerSection seats each,
+// numbered "A1", "A2", ... in the legacy sections.
+func Standard(seatsPerSection int) *ticket.TrainLayout {
+	layout, _ := Build(StandardName, Config{Coaches: []CoachConfig{
+		{ID: "A", Rows: seatsPerSection},
+		{ID: "B", Rows: seatsPerSection},
+	}})
+	return layout
+}
+
+// Build expands a layout config into the seats of every coach.
+func Build(name string, cfg Config) (*ticket.TrainLayout, error) {
+	if len(cfg.Coaches) == 0 {
+		return nil, fmt.Errorf("layout %s has no coaches", name)
+	}
+	layout := &ticket.TrainLayout{Name: name}
+	seen := make(map[string]bool)
+	coachIDs := make(map[string]bool)
+	for _, coachCfg := range cfg.Coaches {
+		if coachCfg.ID == "" {
+			return nil, fmt.Errorf("layout %s has a coach without an id", name)
+		}
+		if coachIDs[coachCfg.ID] {
+			return nil, fmt.Errorf("layout %s has coach %s more than once", name, coachCfg.ID)
+		}
+		coachIDs[coachCfg.ID] = true
+		if coachCfg.Rows < 0 {
+			return nil, fmt.Errorf("layout %s coach %s has a negative number of rows", name, coachCfg.ID)
+		}
+		columns := coachCfg.Columns
+		if len(columns) == 0 {
+			columns = []ColumnConfig{{}}
+		}
+
+		for row := range coachCfg.RowAttributes {
+			if row < 1 || row > coachCfg.Rows {
+				return nil, fmt.Errorf("layout %s coach %s has no row %d", name, coachCfg.ID, row)
+			}
+		}
+
+		coach := &ticket.Coach{CoachId: coachCfg.ID}
+		used := make(map[string]bool, len(coachCfg.Seats))
+		for row := 1; row <= coachCfg.Rows; row++ {
+			for _, column := range columns {
+				attributes, err := positionAttributes(column.Position)
+				if err != nil {
+					return nil, fmt.Errorf("layout %s coach %s column %q: %w", name, coachCfg.ID, column.Letter, err)
+				}
+				place := strconv.Itoa(row) + column.Letter
+				levels := []Attributes{coachCfg.Attributes, coachCfg.RowAttributes[row]}
+				if extra, ok := coachCfg.Seats[place]; ok {
+					levels = append(levels, extra)
+					used[place] = true
+				}
+				for _, level := range levels {
+					if err := merge(attributes, level); err != nil {
+						return nil, fmt.Errorf("layout %s seat %s: %w", name, coachCfg.ID+place, err)
+					}
+				}
+
+				seatNumber := coachCfg.ID + place
+				if seen[seatNumber] {
+					return nil, fmt.Errorf("layout %s numbers two seats %s", name, seatNumber)
+				}
+				seen[seatNumber] = true
+				coach.Seats = append(coach.Seats, &ticket.Seat{
+					Section:    SectionOf(coachCfg.ID),
+					SeatNumber: seatNumber,
+					Coach:      coachCfg.ID,
+					Row:        int32(row),
+					Column:     column.Letter,
+					Attributes: attributes,
+				})
+			}
+		}
+		for place := range coachCfg.Seats {
+			if !used[place] {
+				return nil, fmt.Errorf("layout %s coach %s has no seat %s", name, coachCfg.ID, place)
+			}
+		}
+		layout.Coaches = append(layout.Coaches, coach)
+	}
+	return layout, nil
+}
+
+// SectionOf maps a coach to the legacy section enum, which only knows coaches A and B.
+func SectionOf(coachID string) ticket.Seat_Section {
+	switch coachID {
+	case "A":
+		return ticket.Seat_SECTION_A
+	case "B":
+		return ticket.Seat_SECTION_B
+	default:
+		return ticket.Seat_SECTION_UNKNOWN
+	}
+}
+
+// CoachOf returns the coach a seat is in. Seats allocated before layouts
+// existed only carry a section, which names the coach of the standard layout.
+func CoachOf(seat *ticket.Seat) string {
+	if seat.GetCoach() != "" {
+		return seat.GetCoach()
+	}
+	switch seat.GetSection() {
+	case ticket.Seat_SECTION_A:
+		return "A"
+	case ticket.Seat_SECTION_B:
+		return "B"
+	default:
+		return ""
+	}
+}
+
+// FindSeat looks up a seat of the layout by its seat number.
+func FindSeat(layout *ticket.TrainLayout, seatNumber string) (*ticket.Seat, bool) {
+	for _, coach := range layout.GetCoaches() {
+		for _, seat := range coach.GetSeats() {
+			if seat.GetSeatNumber() == seatNumber {
+				return seat, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// HasCoach reports whether the layout contains a coach.
+func HasCoach(layout *ticket.TrainLayout, coachID string) bool {
+	for _, coach := range layout.GetCoaches() {
+		if coach.GetCoachId() == coachID {
+			return true
+		}
+	}
+	return false
+}
+
+func positionAttributes(position string) (*ticket.SeatAttributes, error) {
+	switch position {
+	case PositionWindow:
+		return &ticket.SeatAttributes{Window: true}, nil
+	case PositionAisle:
+		return &ticket.SeatAttributes{Aisle: true}, nil
+	case PositionMiddle, "":
+		return &ticket.SeatAttributes{}, nil
+	default:
+		return nil, fmt.Errorf("unknown position %q", position)
+	}
+}
+
+func merge(dst *ticket.SeatAttributes, src Attributes) error {
+	dst.Table = dst.Table || src.Table
+	dst.PowerSocket = dst.PowerSocket || src.PowerSocket
+	dst.Accessible = dst.Accessible || src.Accessible
+	dst.QuietZone = dst.QuietZone || src.QuietZone
+	dst.NearDoor = dst.NearDoor || src.NearDoor
+	switch src.Facing {
+	case "":
+	case FacingForward:
+		dst.Facing = ticket.SeatAttributes_FACING_FORWARD
+	case FacingBackward:
+		dst.Facing = ticket.SeatAttributes_FACING_BACKWARD
+	default:
+		return fmt.Errorf("unknown facing %q", src.Facing)
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package layout
+
+import (
+	"testing"
+
+	ticket "github.com/talk2sohail/train-ticket-api/internal/common/genproto/ticket"
+)
+
+func TestUnit_Standard(t *testing.T) {
+	l := Standard(2)
+	want := []string{"A1", "A2", "B1", "B2"}
+	var got []*ticket.Seat
+	for _, coach := range l.GetCoaches() {
+		got = append(got, coach.GetSeats()...)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d seats, got %d", len(want), len(got))
+	}
+	for i, seat := range got {
+		if seat.GetSeatNumber() != want[i] {
+			t.Errorf("expected seat %d to be %s, got %s", i, want[i], seat.GetSeatNumber())
+		}
+	}
+	if got[0].GetSection() != ticket.Seat_SECTION_A || got[2].GetSection() != ticket.Seat_SECTION_B {
+		t.Errorf("expected standard seats to keep their legacy sections, got %v and %v", got[0].GetSection(), got[2].GetSection())
+	}
+}
+
+func TestUnit_Build(t *testing.T) {
+	cfg := Config{Coaches: []CoachConfig{{
+		ID:   "C",
+		Rows: 2,
+		Columns: []ColumnConfig{
+			{Letter: "A", Position: PositionWindow},
+			{Letter: "B", Position: PositionAisle},
+		},
+		Attributes: Attributes{QuietZone: true},
+		Seats:      map[string]Attributes{"2B": {Table: true, Accessible: true}},
+	}}}
+
+	t.Run("Numbers seats by coach, row and column", func(t *testing.T) {
+		l, err := Build("e320", cfg)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		seats := l.GetCoaches()[0].GetSeats()
+		want := []string{"C1A", "C1B", "C2A", "C2B"}
+		for i, seat := range seats {
+			if seat.GetSeatNumber() != want[i] {
+				t.Errorf("expected seat %d to be %s, got %s", i, want[i], seat.GetSeatNumber())
+			}
+		}
+		if seats[0].GetSection() != ticket.Seat_SECTION_UNKNOWN {
+			t.Errorf("expected coach C to have no legacy section, got %v", seats[0].GetSection())
+		}
+	})
+
+	t.Run("Combines attributes", func(t *testing.T) {
+		l, _ := Build("e320", cfg)
+		seat, ok := FindSeat(l, "C2B")
+		if !ok {
+			t.Fatalf("expected seat C2B in the layout")
+		}
+		a := seat.GetAttributes()
+		if !a.GetAisle() || a.GetWindow() || !a.GetTable() || !a.GetAccessible() || !a.GetQuietZone() || a.GetPowerSocket() {
+			t.Errorf("unexpected attributes for C2B: %v", a)
+		}
+		if seat.GetRow() != 2 || seat.GetColumn() != "B" {
+			t.Errorf("expected row 2 column B, got %d %s", seat.GetRow(), seat.GetColumn())
+		}
+	})
+
+	t.Run("Row attributes and facing", func(t *testing.T) {
+		l, err := Build("rows", Config{Coaches: []CoachConfig{{
+			ID:            "A",
+			Rows:          2,
+			Attributes:    Attributes{Facing: FacingForward},
+			RowAttributes: map[int]Attributes{1: {Facing: FacingBackward, NearDoor: true}},
+		}}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		first, _ := FindSeat(l, "A1")
+		second, _ := FindSeat(l, "A2")
+		if first.GetAttributes().GetFacing() != ticket.SeatAttributes_FACING_BACKWARD || !first.GetAttributes().GetNearDoor() {
+			t.Errorf("expected A1 to face backward near the door, got %v", first.GetAttributes())
+		}
+		if second.GetAttributes().GetFacing() != ticket.SeatAttributes_FACING_FORWARD || second.GetAttributes().GetNearDoor() {
+			t.Errorf("expected A2 to face forward away from the door, got %v", second.GetAttributes())
+		}
+	})
+
+	invalid := []struct {
+		name string
+		cfg  Config
+	}{
+		{name: "No coaches", cfg: Config{}},
+		{name: "Missing coach id", cfg: Config{Coaches: []CoachConfig{{Rows: 1}}}},
+		{name: "Duplicate coach", cfg: Config{Coaches: []CoachConfig{{ID: "A", Rows: 1}, {ID: "A", Rows: 1}}}},
+		{name: "Unknown position", cfg: Config{Coaches: []CoachConfig{{ID: "A", Rows: 1, Columns: []ColumnConfig{{Letter: "A", Position: "roof"}}}}}},
+		{name: "Override for a missing seat", cfg: Config{Coaches: []CoachConfig{{ID: "A", Rows: 1, Seats: map[string]Attributes{"9": {Table: true}}}}}},
+		{name: "Unknown facing", cfg: Config{Coaches: []CoachConfig{{ID: "A", Rows: 1, Attributes: Attributes{Facing: "sideways"}}}}},
+		{name: "Attributes for a missing row", cfg: Config{Coaches: []CoachConfig{{ID: "A", Rows: 1, RowAttributes: map[int]Attributes{2: {NearDoor: true}}}}}},
+		{name: "Ambiguous seat numbers", cfg: Config{Coaches: []CoachConfig{{ID: "1", Rows: 11}, {ID: "11", Rows: 1}}}},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Build("bad", tt.cfg); err == nil {
+				t.Errorf("expected an error, got nil")
+			}
+		})
+	}
+}
+
+func TestUnit_CoachOf(t *testing.T) {
+	if got := CoachOf(&ticket.Seat{Section: ticket.Seat_SECTION_B, SeatNumber: "B1"}); got != "B" {
+		t.Errorf("expected a legacy section B seat to be in coach B, got %q", got)
+	}
+	if got := CoachOf(&ticket.Seat{Coach: "C", SeatNumber: "C1A"}); got != "C" {
+		t.Errorf("expected coach C, got %q", got)
+	}
+}
@@ -1,8 +1,29 @@
 package main
 
-import "github.com/talk2sohail/train-ticket-api/internal/ticket/server"
+import (
+	"flag"
+	"log"
+	_ "time/tzdata" // Journeys name their time zones; embed the database for hosts without one.
+
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/config"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/server"
+)
 
 func main() {
-	grpcServer := server.NewTicketGRPCServer(":9001")
-	grpcServer.Run()
+	configPath := flag.String("config", "", "path to a JSON config file")
+	flag.Parse()
+
+	cfg := config.Default()
+	if *configPath != "" {
+		var err error
+		cfg, err = config.Load(*configPath)
+		if err != nil {
+			log.Fatalf("invalid config: %v", err)
+		}
+	}
+
+	grpcServer := server.NewTicketGRPCServer(":9001", cfg)
+	if err := grpcServer.Run(); err != nil {
+		log.Fatalf("server stopped: %v", err)
+	}
 }
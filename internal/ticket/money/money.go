@@ -0,0 +1,134 @@
+// Package money represents prices exactly, as whole numbers of a currency's
+// minor units, and converts them between currencies at configured rates.
+package money
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	ticket "github.com/talk2sohail/train-ticket-api/internal/common/genproto/ticket"
+)
+
+// USD is the currency of amounts given as plain numbers, such as the legacy price_paid fields.
+const USD = "USD"
+
+// exponents holds the number of minor-unit digits of each ISO 4217 currency that
+// prices may be given in.
+var exponents = map[string]int{
+	"AUD": 2,
+	"BHD": 3,
+	"CAD": 2,
+	"CHF": 2,
+	"CNY": 2,
+	"DKK": 2,
+	"EUR": 2,
+	"GBP": 2,
+	"INR": 2,
+	"JPY": 0,
+	"KRW": 0,
+	"KWD": 3,
+	"NOK": 2,
+	"NZD": 2,
+	"SEK": 2,
+	"USD": 2,
+}
+
+// Exponent returns the number of minor-unit digits of an ISO 4217 currency code,
+// e.g. 2 for "USD" and 0 for "JPY", and whether the currency is supported.
+func Exponent(currency string) (int, bool) {
+	exp, ok := exponents[currency]
+	return exp, ok
+}
+
+// Money is an exact amount of a currency.
+type Money struct {
+	Minor    int64  // Amount in minor units, e.g. 2050 for USD 20.50.
+	Currency string // ISO 4217 code, e.g. "USD".
+}
+
+// New returns minor units of currency, or an error if the currency is not supported.
+func New(minor int64, currency string) (Money, error) {
+	if _, ok := Exponent(currency); !ok {
+		return Money{}, fmt.Errorf("currency %q is not supported", currency)
+	}
+	return Money{Minor: minor, Currency: currency}, nil
+}
+
+// FromMajor rounds an amount in major units, e.g. 20.5 dollars, to the nearest minor unit
+// of currency, halves away from zero.
+func FromMajor(amount float64, currency string) (Money, error) {
+	exp, ok := Exponent(currency)
+	if !ok {
+		return Money{}, fmt.Errorf("currency %q is not supported", currency)
+	}
+	return Money{Minor: int64(math.Round(amount * math.Pow10(exp))), Currency: currency}, nil
+}
+
+// FromRat rounds an exact amount in major units, e.g. 41/2 dollars, to the nearest minor unit
+// of currency, halves away from zero. Prices worked out from exact amounts are rounded only here.
+func FromRat(amount *big.Rat, currency string) (Money, error) {
+	exp, ok := Exponent(currency)
+	if !ok {
+		return Money{}, fmt.Errorf("currency %q is not supported", currency)
+	}
+	minor := new(big.Rat).Mul(amount, new(big.Rat).SetInt(pow10(exp)))
+	return Money{Minor: round(minor), Currency: currency}, nil
+}
+
+// FromProto reads a Money message.
+func FromProto(m *ticket.Money) (Money, error) {
+	return New(m.GetMinorUnits(), m.GetCurrencyCode())
+}
+
+// Proto returns m as a Money message.
+func (m Money) Proto() *ticket.Money {
+	return &ticket.Money{MinorUnits: m.Minor, CurrencyCode: m.Currency}
+}
+
+// Mul returns m times n, e.g. the total of n tickets at price m, or an error if the total
+// is too large to count in minor units.
+func (m Money) Mul(n int64) (Money, error) {
+	total := new(big.Int).Mul(big.NewInt(m.Minor), big.NewInt(n))
+	if !total.IsInt64() {
+		return Money{}, fmt.Errorf("%s times %d is too large", m, n)
+	}
+	return Money{Minor: total.Int64(), Currency: m.Currency}, nil
+}
+
+// Share returns fraction of m, e.g. 1/2 for half, rounded to the nearest minor unit,
+// halves away from zero. fraction must be between 0 and 1.
+func (m Money) Share(fraction *big.Rat) Money {
+	amount := new(big.Rat).Mul(new(big.Rat).SetInt64(m.Minor), fraction)
+	return Money{Minor: round(amount), Currency: m.Currency}
+}
+
+// Major returns m in major units, e.g. 20.5 for USD 20.50, for fields that still hold prices as doubles.
+func (m Money) Major() float64 {
+	exp, _ := Exponent(m.Currency)
+	return float64(m.Minor) / math.Pow10(exp)
+}
+
+// Decimal formats m in major units with every minor-unit digit, e.g. "20.50".
+func (m Money) Decimal() string {
+	exp, _ := Exponent(m.Currency)
+	digits := strconv.FormatInt(m.Minor, 10)
+	sign := ""
+	if m.Minor < 0 {
+		sign, digits = "-", digits[1:]
+	}
+	if exp == 0 {
+		return sign + digits
+	}
+	if len(digits) <= exp {
+		digits = strings.Repeat("0", exp-len(digits)+1) + digits
+	}
+	return sign + digits[:len(digits)-exp] + "." + digits[len(digits)-exp:]
+}
+
+// String formats m with its currency, e.g. "20.50 USD".
+func (m Money) String() string {
+	return m.Decimal() + " " + m.Currency
+}
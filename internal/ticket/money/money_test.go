@@ -0,0 +1,146 @@
+package money
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestUnit_FromMajor(t *testing.T) {
+	tests := []struct {
+		amount   float64
+		currency string
+		want     int64
+	}{
+		{20, "USD", 2000},
+		{20.005, "USD", 2001},
+		{12.6, "USD", 1260},
+		{1750.4, "JPY", 1750},
+		{1.2345, "KWD", 1235},
+		{-3.5, "JPY", -4},
+	}
+	for _, tt := range tests {
+		m, err := FromMajor(tt.amount, tt.currency)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if m.Minor != tt.want || m.Currency != tt.currency {
+			t.Errorf("FromMajor(%v, %s): expected %d minor units, got %v", tt.amount, tt.currency, tt.want, m)
+		}
+	}
+	if _, err := FromMajor(20, "usd"); err == nil {
+		t.Errorf("expected an error for a currency code that is not ISO 4217")
+	}
+}
+
+func TestUnit_FromRat(t *testing.T) {
+	tests := []struct {
+		amount   string
+		currency string
+		want     int64
+	}{
+		{"20", "USD", 2000},
+		{"20.005", "USD", 2001},
+		{"1.005", "USD", 101}, // 1.005 as a float64 is just below 1.005 and would round down.
+		{"12.6", "USD", 1260},
+		{"1750.5", "JPY", 1751},
+		{"1.2345", "KWD", 1235},
+		{"-3.5", "JPY", -4},
+		{"1/3", "USD", 33},
+	}
+	for _, tt := range tests {
+		amount, _ := new(big.Rat).SetString(tt.amount)
+		m, err := FromRat(amount, tt.currency)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if m.Minor != tt.want || m.Currency != tt.currency {
+			t.Errorf("FromRat(%s, %s): expected %d minor units, got %v", tt.amount, tt.currency, tt.want, m)
+		}
+	}
+	if _, err := FromRat(big.NewRat(20, 1), "usd"); err == nil {
+		t.Errorf("expected an error for a currency code that is not ISO 4217")
+	}
+}
+
+func TestUnit_Decimal(t *testing.T) {
+	tests := []struct {
+		m    Money
+		want string
+	}{
+		{Money{Minor: 2050, Currency: "USD"}, "20.50"},
+		{Money{Minor: 5, Currency: "USD"}, "0.05"},
+		{Money{Minor: 0, Currency: "EUR"}, "0.00"},
+		{Money{Minor: -120, Currency: "GBP"}, "-1.20"},
+		{Money{Minor: 2050, Currency: "JPY"}, "2050"},
+		{Money{Minor: 1235, Currency: "KWD"}, "1.235"},
+	}
+	for _, tt := range tests {
+		if got := tt.m.Decimal(); got != tt.want {
+			t.Errorf("expected %s, got %s", tt.want, got)
+		}
+	}
+	if got := (Money{Minor: 2050, Currency: "USD"}).String(); got != "20.50 USD" {
+		t.Errorf("expected 20.50 USD, got %s", got)
+	}
+	if got := (Money{Minor: 2050, Currency: "USD"}).Major(); got != 20.5 {
+		t.Errorf("expected 20.5, got %v", got)
+	}
+}
+
+func TestUnit_Mul(t *testing.T) {
+	tests := []struct {
+		m       Money
+		n       int64
+		want    Money
+		wantErr bool
+	}{
+		{Money{Minor: 2050, Currency: "USD"}, 3, Money{Minor: 6150, Currency: "USD"}, false},
+		{Money{Minor: 2050, Currency: "USD"}, 0, Money{Minor: 0, Currency: "USD"}, false},
+		{Money{Minor: math.MaxInt64 / 2, Currency: "JPY"}, 2, Money{Minor: math.MaxInt64 - 1, Currency: "JPY"}, false},
+		{Money{Minor: math.MaxInt64 / 2, Currency: "JPY"}, 3, Money{}, true},
+		{Money{Minor: math.MinInt64, Currency: "JPY"}, -1, Money{}, true},
+	}
+	for _, tt := range tests {
+		got, err := tt.m.Mul(tt.n)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("expected %s times %d to overflow, got %s", tt.m, tt.n, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("expected %s times %d to be %s, got %s, %v", tt.m, tt.n, tt.want, got, err)
+		}
+	}
+}
+
+func TestUnit_Share(t *testing.T) {
+	tests := []struct {
+		m        Money
+		fraction *big.Rat
+		want     int64
+	}{
+		{Money{Minor: 2050, Currency: "USD"}, big.NewRat(1, 2), 1025},
+		{Money{Minor: 2050, Currency: "USD"}, big.NewRat(1, 4), 513},
+		{Money{Minor: 2050, Currency: "USD"}, big.NewRat(1, 1), 2050},
+		{Money{Minor: 2050, Currency: "USD"}, new(big.Rat), 0},
+		{Money{Minor: 1001, Currency: "JPY"}, big.NewRat(1, 3), 334},
+		{Money{Minor: math.MaxInt64, Currency: "JPY"}, big.NewRat(99, 100), 9131138316486228049},
+	}
+	for _, tt := range tests {
+		if got := tt.m.Share(tt.fraction); got != (Money{Minor: tt.want, Currency: tt.m.Currency}) {
+			t.Errorf("expected %s of %s to be %d, got %s", tt.fraction, tt.m, tt.want, got)
+		}
+	}
+}
+
+func TestUnit_FromProto(t *testing.T) {
+	m, err := FromProto(Money{Minor: 2050, Currency: "EUR"}.Proto())
+	if err != nil || m != (Money{Minor: 2050, Currency: "EUR"}) {
+		t.Errorf("expected the money to survive a round trip, got %v, %v", m, err)
+	}
+	if _, err := FromProto(nil); err == nil {
+		t.Errorf("expected an error for money without a currency")
+	}
+}
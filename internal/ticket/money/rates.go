@@ -0,0 +1,108 @@
+package money
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+)
+
+// RatesConfig describes exchange rates as read from a rates file: how many units of
+// each currency one unit of the base currency buys, e.g. {"base": "USD", "rates": {"EUR": 0.92}}.
+type RatesConfig struct {
+	Base  string                 `json:"base"`
+	Rates map[string]json.Number `json:"rates"` // Kept as written so rates are exact.
+}
+
+// LoadRates reads a JSON rates file.
+func LoadRates(path string) (RatesConfig, error) {
+	var cfg RatesConfig
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cfg, fmt.Errorf("read rates %s: %w", path, err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&cfg); err != nil {
+		return cfg, fmt.Errorf("parse rates %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// Rates converts money between currencies. A nil *Rates converts nothing but
+// money already in the currency asked for. Rates are not changed once built and
+// are safe for concurrent use.
+type Rates struct {
+	rates map[string]*big.Rat // Units of each currency per unit of the base currency, including the base itself.
+}
+
+// BuildRates checks a rates config and prepares it for converting.
+func BuildRates(cfg RatesConfig) (*Rates, error) {
+	if _, ok := Exponent(cfg.Base); !ok {
+		return nil, fmt.Errorf("base currency %q is not supported", cfg.Base)
+	}
+	r := &Rates{rates: map[string]*big.Rat{cfg.Base: big.NewRat(1, 1)}}
+	for currency, number := range cfg.Rates {
+		if _, ok := Exponent(currency); !ok {
+			return nil, fmt.Errorf("rates.%s: currency is not supported", currency)
+		}
+		if currency == cfg.Base {
+			return nil, fmt.Errorf("rates.%s: the base currency has no rate", currency)
+		}
+		rate, ok := new(big.Rat).SetString(number.String())
+		if !ok || rate.Sign() <= 0 {
+			return nil, fmt.Errorf("rates.%s must be a positive number", currency)
+		}
+		r.rates[currency] = rate
+	}
+	return r, nil
+}
+
+// Convert returns m in currency, rounded to its nearest minor unit, halves away from zero.
+func (r *Rates) Convert(m Money, currency string) (Money, error) {
+	if m.Currency == currency {
+		return m, nil
+	}
+	toExp, ok := Exponent(currency)
+	if !ok {
+		return Money{}, fmt.Errorf("currency %q is not supported", currency)
+	}
+	if r == nil {
+		return Money{}, fmt.Errorf("no exchange rates to convert %s to %s", m.Currency, currency)
+	}
+	from, ok := r.rates[m.Currency]
+	if !ok {
+		return Money{}, fmt.Errorf("no exchange rate for %s", m.Currency)
+	}
+	to, ok := r.rates[currency]
+	if !ok {
+		return Money{}, fmt.Errorf("no exchange rate for %s", currency)
+	}
+	fromExp, _ := Exponent(m.Currency)
+
+	// minor units in currency = m.Minor / 10^fromExp / from * to * 10^toExp
+	amount := new(big.Rat).SetInt64(m.Minor)
+	amount.Mul(amount, to)
+	amount.Quo(amount, from)
+	amount.Mul(amount, new(big.Rat).SetInt(pow10(toExp)))
+	amount.Quo(amount, new(big.Rat).SetInt(pow10(fromExp)))
+	return Money{Minor: round(amount), Currency: currency}, nil
+}
+
+func pow10(exp int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exp)), nil)
+}
+
+// round rounds x to the nearest integer, halves away from zero.
+func round(x *big.Rat) int64 {
+	num, den := new(big.Int).Abs(x.Num()), x.Denom()
+	q, rem := new(big.Int).QuoRem(num, den, new(big.Int))
+	if rem.Lsh(rem, 1).Cmp(den) >= 0 {
+		q.Add(q, big.NewInt(1))
+	}
+	if x.Sign() < 0 {
+		q.Neg(q)
+	}
+	return q.Int64()
+}
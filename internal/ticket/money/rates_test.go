@@ -0,0 +1,95 @@
+package money
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestUnit_Convert(t *testing.T) {
+	rates, err := BuildRates(RatesConfig{Base: "USD", Rates: map[string]json.Number{"EUR": "0.92", "JPY": "151.5", "GBP": "0.79"}})
+	if err != nil {
+		t.Fatalf("unexpected error building rates: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		m    Money
+		to   string
+		want int64
+	}{
+		{"Same currency", Money{Minor: 2000, Currency: "USD"}, "USD", 2000},
+		{"From the base", Money{Minor: 2000, Currency: "USD"}, "EUR", 1840},
+		{"To the base", Money{Minor: 1840, Currency: "EUR"}, "USD", 2000},
+		{"Between other currencies", Money{Minor: 1000, Currency: "EUR"}, "GBP", 859},
+		{"Into a currency without minor units", Money{Minor: 2000, Currency: "USD"}, "JPY", 3030},
+		{"Out of a currency without minor units", Money{Minor: 3030, Currency: "JPY"}, "USD", 2000},
+		{"Halves round away from zero", Money{Minor: 100, Currency: "USD"}, "JPY", 152},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := rates.Convert(tt.m, tt.to)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != (Money{Minor: tt.want, Currency: tt.to}) {
+				t.Errorf("expected %d %s, got %v", tt.want, tt.to, got)
+			}
+		})
+	}
+
+	if _, err := rates.Convert(Money{Minor: 2000, Currency: "USD"}, "CHF"); err == nil {
+		t.Errorf("expected an error converting to a currency without a rate")
+	}
+	var none *Rates
+	if got, err := none.Convert(Money{Minor: 2000, Currency: "EUR"}, "EUR"); err != nil || got.Minor != 2000 {
+		t.Errorf("expected money to need no rates to stay in its currency, got %v, %v", got, err)
+	}
+	if _, err := none.Convert(Money{Minor: 2000, Currency: "USD"}, "EUR"); err == nil {
+		t.Errorf("expected an error converting without rates")
+	}
+}
+
+func TestUnit_BuildRates(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  RatesConfig
+		want string
+	}{
+		{"No base", RatesConfig{}, "base currency"},
+		{"Unknown currency", RatesConfig{Base: "USD", Rates: map[string]json.Number{"XYZ": "1"}}, "rates.XYZ"},
+		{"Rate for the base", RatesConfig{Base: "USD", Rates: map[string]json.Number{"USD": "1"}}, "rates.USD"},
+		{"Zero rate", RatesConfig{Base: "USD", Rates: map[string]json.Number{"EUR": "0"}}, "rates.EUR"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := BuildRates(tt.cfg); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected an error about %s, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestUnit_LoadRates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rates.json")
+	if err := os.WriteFile(path, []byte(`{"base": "EUR", "rates": {"USD": 1.0869565}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := LoadRates(path)
+	if err != nil {
+		t.Fatalf("unexpected error loading rates: %v", err)
+	}
+	rates, err := BuildRates(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error building rates: %v", err)
+	}
+	if got, err := rates.Convert(Money{Minor: 1840, Currency: "EUR"}, "USD"); err != nil || got.Minor != 2000 {
+		t.Errorf("expected 20.00 USD, got %v, %v", got, err)
+	}
+
+	if _, err := LoadRates(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Errorf("expected an error loading a missing file")
+	}
+}
@@ -0,0 +1,34 @@
+package money
+
+import (
+	ticket "github.com/talk2sohail/train-ticket-api/internal/common/genproto/ticket"
+	"github.com/talk2sohail/train-ticket-api/internal/common/util"
+)
+
+// ValidatePrice checks a price given in a request: a positive number of minor units of a
+// supported currency. field names the price in the request. A request without a price
+// pays its legacy price_paid, which the request's own validation checks.
+func ValidatePrice(field string, m *ticket.Money) error {
+	if m == nil {
+		return nil
+	}
+	if m.GetMinorUnits() <= 0 {
+		return &util.FieldError{Field: field + ".minor_units", Description: "MinorUnits must be greater than zero"}
+	}
+	if _, ok := Exponent(m.GetCurrencyCode()); !ok {
+		return &util.FieldError{Field: field + ".currency_code", Description: "CurrencyCode is not a supported ISO 4217 code"}
+	}
+	return nil
+}
+
+// ValidateCurrency checks an optional currency code given in a request: empty, or a
+// supported ISO 4217 code. field names the code in the request.
+func ValidateCurrency(field, code string) error {
+	if code == "" {
+		return nil
+	}
+	if _, ok := Exponent(code); !ok {
+		return &util.FieldError{Field: field, Description: "CurrencyCode is not a supported ISO 4217 code"}
+	}
+	return nil
+}
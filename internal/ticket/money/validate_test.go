@@ -0,0 +1,36 @@
+package money
+
+import (
+	"errors"
+	"testing"
+
+	ticket "github.com/talk2sohail/train-ticket-api/internal/common/genproto/ticket"
+	"github.com/talk2sohail/train-ticket-api/internal/common/util"
+)
+
+func TestUnit_Validate(t *testing.T) {
+	tests := []struct {
+		name  string
+		err   error
+		field string // Field the error names; empty when the value is valid.
+	}{
+		{"No price", ValidatePrice("price", nil), ""},
+		{"Valid price", ValidatePrice("price", &ticket.Money{MinorUnits: 2000, CurrencyCode: "USD"}), ""},
+		{"Price of nothing", ValidatePrice("price", &ticket.Money{CurrencyCode: "USD"}), "price.minor_units"},
+		{"Lowercase currency", ValidatePrice("price", &ticket.Money{MinorUnits: 2000, CurrencyCode: "usd"}), "price.currency_code"},
+		{"No currency code", ValidateCurrency("currency_code", ""), ""},
+		{"Supported currency code", ValidateCurrency("currency_code", "JPY"), ""},
+		{"Unsupported currency code", ValidateCurrency("currency_code", "XXX"), "currency_code"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fieldErr *util.FieldError
+			switch {
+			case tt.field == "" && tt.err != nil:
+				t.Errorf("expected no error, got %v", tt.err)
+			case tt.field != "" && (!errors.As(tt.err, &fieldErr) || fieldErr.Field != tt.field):
+				t.Errorf("expected an error about %s, got %v", tt.field, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,225 @@
+package payment
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/money"
+)
+
+// FakeName is the Name of the Fake provider.
+const FakeName = "fake"
+
+// Op is an operation of a Provider, for scripting the Fake.
+type Op string
+
+const (
+	OpAuthorize Op = "authorize"
+	OpCapture   Op = "capture"
+	OpVoid      Op = "void"
+	OpRefund    Op = "refund"
+)
+
+// Outcome scripts a single call to the Fake.
+type Outcome struct {
+	Latency time.Duration // How long the call takes.
+	Decline string        // Declines the call with this code, if set.
+	Err     error         // Fails the call with this error, if set.
+}
+
+// State is how far a payment held by the Fake has gone.
+type State string
+
+const (
+	StateAuthorized State = "authorized"
+	StateCaptured   State = "captured"
+	StateVoided     State = "voided"
+	StateRefunded   State = "refunded" // Captured and refunded in full.
+)
+
+// Record is a payment as the Fake holds it.
+type Record struct {
+	Method   string
+	Amount   money.Money
+	Refunded money.Money // Refunded so far.
+	State    State
+}
+
+// Fake is a Provider that takes payments in process, for development and tests. It
+// approves every call after its latency unless told otherwise: payment methods may
+// be declined outright, and the outcome of the next calls of each operation may be
+// scripted. Payments are kept in memory and forgotten when the process stops. A
+// Fake is safe for concurrent use.
+type Fake struct {
+	mu       sync.Mutex
+	latency  time.Duration
+	declines map[string]string  // Decline codes keyed by the payment methods they decline.
+	script   map[Op][]Outcome   // Outcomes of the next calls of each operation, in order.
+	payments map[string]*Record // Keyed by payment ID.
+	next     int
+}
+
+// NewFake creates a Fake that approves every call at once.
+func NewFake() *Fake {
+	return &Fake{
+		declines: make(map[string]string),
+		script:   make(map[Op][]Outcome),
+		payments: make(map[string]*Record),
+	}
+}
+
+// SetLatency sets how long unscripted calls take.
+func (f *Fake) SetLatency(latency time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.latency = latency
+}
+
+// DeclineMethod declines every authorization of a payment method with code.
+func (f *Fake) DeclineMethod(method, code string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.declines[method] = code
+}
+
+// Script queues the outcomes of the next calls of op, one call each. Calls after
+// the script runs out behave as unscripted.
+func (f *Fake) Script(op Op, outcomes ...Outcome) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.script[op] = append(f.script[op], outcomes...)
+}
+
+// Payment returns a payment the Fake holds.
+func (f *Fake) Payment(paymentID string) (Record, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	p, ok := f.payments[paymentID]
+	if !ok {
+		return Record{}, false
+	}
+	return *p, true
+}
+
+// Name returns FakeName.
+func (f *Fake) Name() string {
+	return FakeName
+}
+
+// Authorize approves a positive amount unless the method or the script declines it.
+func (f *Fake) Authorize(ctx context.Context, method string, amount money.Money) (string, error) {
+	if err := f.call(ctx, OpAuthorize); err != nil {
+		return "", err
+	}
+	if amount.Minor <= 0 {
+		return "", fmt.Errorf("authorize %s: amount must be positive", amount)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if code, ok := f.declines[method]; ok {
+		return "", &Declined{Code: code}
+	}
+	f.next++
+	paymentID := fmt.Sprintf("%s_%d", FakeName, f.next)
+	f.payments[paymentID] = &Record{
+		Method:   method,
+		Amount:   amount,
+		Refunded: money.Money{Currency: amount.Currency},
+		State:    StateAuthorized,
+	}
+	return paymentID, nil
+}
+
+// Capture takes an authorized payment.
+func (f *Fake) Capture(ctx context.Context, paymentID string) error {
+	return f.move(ctx, OpCapture, paymentID, StateAuthorized, StateCaptured)
+}
+
+// Void releases an authorized payment.
+func (f *Fake) Void(ctx context.Context, paymentID string) error {
+	return f.move(ctx, OpVoid, paymentID, StateAuthorized, StateVoided)
+}
+
+// Refund returns part or all of what is left of a captured payment.
+func (f *Fake) Refund(ctx context.Context, paymentID string, amount money.Money) error {
+	if err := f.call(ctx, OpRefund); err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	p, err := f.lookup(OpRefund, paymentID, StateCaptured)
+	if err != nil {
+		return err
+	}
+	if amount.Currency != p.Amount.Currency {
+		return fmt.Errorf("refund %s: payment is in %s, not %s", paymentID, p.Amount.Currency, amount.Currency)
+	}
+	if amount.Minor <= 0 || amount.Minor > p.Amount.Minor-p.Refunded.Minor {
+		return fmt.Errorf("refund %s: %s is more than the %s left to refund", paymentID, amount, money.Money{Minor: p.Amount.Minor - p.Refunded.Minor, Currency: amount.Currency})
+	}
+	p.Refunded.Minor += amount.Minor
+	if p.Refunded == p.Amount {
+		p.State = StateRefunded
+	}
+	return nil
+}
+
+// move takes a payment from one state to the next.
+func (f *Fake) move(ctx context.Context, op Op, paymentID string, from, to State) error {
+	if err := f.call(ctx, op); err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	p, err := f.lookup(op, paymentID, from)
+	if err != nil {
+		return err
+	}
+	p.State = to
+	return nil
+}
+
+// lookup finds a payment op may be applied to; the caller holds f.mu.
+func (f *Fake) lookup(op Op, paymentID string, state State) (*Record, error) {
+	p, ok := f.payments[paymentID]
+	if !ok {
+		return nil, fmt.Errorf("%s %s: %w", op, paymentID, ErrPaymentNotFound)
+	}
+	if p.State != state {
+		return nil, fmt.Errorf("%s %s: payment is %s", op, paymentID, p.State)
+	}
+	return p, nil
+}
+
+// call plays out the next outcome of op: it waits for its latency, or until ctx is
+// done, and returns the decline or error it was scripted with.
+func (f *Fake) call(ctx context.Context, op Op) error {
+	f.mu.Lock()
+	outcome := Outcome{Latency: f.latency}
+	if queued := f.script[op]; len(queued) > 0 {
+		outcome, f.script[op] = queued[0], queued[1:]
+	}
+	f.mu.Unlock()
+
+	if outcome.Latency > 0 {
+		timer := time.NewTimer(outcome.Latency)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return fmt.Errorf("%s: %w", op, ctx.Err())
+		}
+	}
+	if outcome.Err != nil {
+		return outcome.Err
+	}
+	if outcome.Decline != "" {
+		return &Declined{Code: outcome.Decline}
+	}
+	return nil
+}
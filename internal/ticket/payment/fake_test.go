@@ -0,0 +1,117 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/money"
+)
+
+func TestUnit_FakeLifecycle(t *testing.T) {
+	ctx := context.Background()
+	price := money.Money{Minor: 2000, Currency: money.USD}
+
+	t.Run("Capture and refund", func(t *testing.T) {
+		f := NewFake()
+		id, err := f.Authorize(ctx, "tok_visa", price)
+		if err != nil {
+			t.Fatalf("unexpected error authorizing: %v", err)
+		}
+		if err := f.Capture(ctx, id); err != nil {
+			t.Fatalf("unexpected error capturing: %v", err)
+		}
+		if err := f.Void(ctx, id); err == nil {
+			t.Errorf("expected a captured payment not to be voided")
+		}
+		if err := f.Refund(ctx, id, money.Money{Minor: 500, Currency: money.USD}); err != nil {
+			t.Fatalf("unexpected error refunding part: %v", err)
+		}
+		if err := f.Refund(ctx, id, price); err == nil {
+			t.Errorf("expected refunding more than is left to fail")
+		}
+		if err := f.Refund(ctx, id, money.Money{Minor: 1500, Currency: money.USD}); err != nil {
+			t.Fatalf("unexpected error refunding the rest: %v", err)
+		}
+		if p, _ := f.Payment(id); p.State != StateRefunded || p.Refunded != price {
+			t.Errorf("expected the payment to be refunded in full, got %+v", p)
+		}
+	})
+
+	t.Run("Void", func(t *testing.T) {
+		f := NewFake()
+		id, _ := f.Authorize(ctx, "tok_visa", price)
+		if err := f.Void(ctx, id); err != nil {
+			t.Fatalf("unexpected error voiding: %v", err)
+		}
+		if err := f.Capture(ctx, id); err == nil {
+			t.Errorf("expected a voided payment not to be captured")
+		}
+		if err := f.Refund(ctx, id, price); err == nil {
+			t.Errorf("expected a voided payment not to be refunded")
+		}
+		if p, _ := f.Payment(id); p.State != StateVoided {
+			t.Errorf("expected the payment to be voided, got %+v", p)
+		}
+	})
+
+	t.Run("Unknown payment", func(t *testing.T) {
+		if err := NewFake().Capture(ctx, "fake_42"); !errors.Is(err, ErrPaymentNotFound) {
+			t.Errorf("expected %v, got %v", ErrPaymentNotFound, err)
+		}
+	})
+}
+
+func TestUnit_FakeScript(t *testing.T) {
+	ctx := context.Background()
+	price := money.Money{Minor: 2000, Currency: money.USD}
+
+	t.Run("Declined method", func(t *testing.T) {
+		f := NewFake()
+		f.DeclineMethod("tok_broke", "insufficient_funds")
+		_, err := f.Authorize(ctx, "tok_broke", price)
+		if d, ok := AsDeclined(err); !ok || d.Code != "insufficient_funds" {
+			t.Errorf("expected a decline for insufficient_funds, got %v", err)
+		}
+		if _, err := f.Authorize(ctx, "tok_visa", price); err != nil {
+			t.Errorf("expected other methods to be approved, got %v", err)
+		}
+	})
+
+	t.Run("Scripted outcomes", func(t *testing.T) {
+		f := NewFake()
+		outage := errors.New("processor unavailable")
+		f.Script(OpAuthorize, Outcome{Decline: "card_declined"}, Outcome{Err: outage})
+		if _, err := f.Authorize(ctx, "tok_visa", price); err == nil {
+			t.Fatalf("expected the first call to be declined")
+		} else if d, ok := AsDeclined(err); !ok || d.Code != "card_declined" {
+			t.Errorf("expected a decline for card_declined, got %v", err)
+		}
+		if _, err := f.Authorize(ctx, "tok_visa", price); !errors.Is(err, outage) {
+			t.Errorf("expected %v, got %v", outage, err)
+		}
+		if _, err := f.Authorize(ctx, "tok_visa", price); err != nil {
+			t.Errorf("expected calls after the script to be approved, got %v", err)
+		}
+	})
+
+	t.Run("Latency", func(t *testing.T) {
+		f := NewFake()
+		f.Script(OpAuthorize, Outcome{Latency: time.Hour})
+		ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+		if _, err := f.Authorize(ctx, "tok_visa", price); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected a slow call to give up with its context, got %v", err)
+		}
+
+		f.SetLatency(20 * time.Millisecond)
+		start := time.Now()
+		if _, err := f.Authorize(context.Background(), "tok_visa", price); err != nil {
+			t.Fatalf("unexpected error authorizing: %v", err)
+		}
+		if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+			t.Errorf("expected the call to take the fake's latency, took %s", elapsed)
+		}
+	})
+}
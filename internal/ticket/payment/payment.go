@@ -0,0 +1,45 @@
+// Package payment takes payment for tickets through a payment processor.
+package payment
+
+import (
+	"context"
+	"errors"
+
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/money"
+)
+
+// Provider is a payment processor. A payment is first authorized, reserving its
+// amount on the payment method, and then either captured, taking the money, or
+// voided, releasing it. Captured money may be refunded.
+type Provider interface {
+	// Name identifies the provider on the payments it takes, e.g. "fake".
+	Name() string
+	// Authorize reserves amount on a payment method and returns the ID of the payment.
+	Authorize(ctx context.Context, method string, amount money.Money) (string, error)
+	// Capture takes the money an authorized payment reserved.
+	Capture(ctx context.Context, paymentID string) error
+	// Void releases the money an authorized payment reserved without taking it.
+	Void(ctx context.Context, paymentID string) error
+	// Refund returns amount of a captured payment to its payment method.
+	Refund(ctx context.Context, paymentID string, amount money.Money) error
+}
+
+// ErrPaymentNotFound is returned for a payment ID the provider does not know.
+var ErrPaymentNotFound = errors.New("payment not found")
+
+// Declined is the error of a payment the processor refused, such as a card with
+// insufficient funds. Other errors are failures to reach or use the processor.
+type Declined struct {
+	Code string // Why the payment was refused, e.g. "insufficient_funds".
+}
+
+func (d *Declined) Error() string {
+	return "payment declined: " + d.Code
+}
+
+// AsDeclined returns the Declined in err's chain, if there is one.
+func AsDeclined(err error) (*Declined, bool) {
+	var d *Declined
+	ok := errors.As(err, &d)
+	return d, ok
+}
@@ -0,0 +1,65 @@
+package ratelimit
+
+import (
+	"context"
+	"log"
+	"math"
+	"path"
+	"strconv"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// RetryAfterHeader is the metadata key telling a throttled client how many seconds to wait.
+const RetryAfterHeader = "retry-after"
+
+// UnaryServerInterceptor throttles unary calls. With authentication on it must run after
+// the authentication interceptor, so that calls are counted against the caller.
+func (l *Limiter) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := l.check(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor throttles the opening of streams.
+func (l *Limiter) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := l.check(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+func (l *Limiter) check(ctx context.Context, fullMethod string) error {
+	rpc := path.Base(fullMethod)
+	principal := Principal(ctx)
+	ok, retryAfter := l.Allow(rpc, principal)
+	if ok {
+		return nil
+	}
+	log.Printf("[RateLimit] Throttled %s for %s; retry in %s", rpc, principal, retryAfter)
+	if err := grpc.SetHeader(ctx, metadata.Pairs(RetryAfterHeader, RetryAfterSeconds(retryAfter))); err != nil {
+		log.Printf("[RateLimit] Failed to set %s: %v", RetryAfterHeader, err)
+	}
+	st, err := status.New(codes.ResourceExhausted, "rate limit exceeded for "+rpc).
+		WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(retryAfter)})
+	if err != nil {
+		return status.Error(codes.ResourceExhausted, "rate limit exceeded for "+rpc)
+	}
+	return st.Err()
+}
+
+// RetryAfterSeconds formats a wait as whole seconds, rounded up, as in an HTTP Retry-After header.
+func RetryAfterSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
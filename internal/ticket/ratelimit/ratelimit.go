@@ -0,0 +1,157 @@
+// Package ratelimit throttles the calls each client makes to the ticket service
+// with a token bucket per client and RPC.
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/auth"
+	"google.golang.org/grpc/peer"
+)
+
+// sweepInterval is how often buckets that have refilled are dropped.
+const sweepInterval = time.Minute
+
+// Limit is the rate at which a client may call an RPC.
+type Limit struct {
+	PerSecond float64 // Sustained calls per second.
+	Burst     int     // Calls that may be made at once after a quiet period.
+}
+
+// Clock tells the limiter the current time. Tests substitute a fake clock.
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+// Option configures optional behaviour of a Limiter.
+type Option func(*Limiter)
+
+// WithClock makes the limiter read the current time from clock instead of the system clock.
+func WithClock(clock Clock) Option {
+	return func(l *Limiter) {
+		l.clock = clock
+	}
+}
+
+// Limiter keeps a token bucket for every client and RPC it has seen recently.
+type Limiter struct {
+	mu        sync.Mutex
+	limits    map[string]Limit // Limits of individual RPCs, keyed by RPC name.
+	fallback  *Limit           // Limit of the other RPCs; they are unlimited when nil.
+	clock     Clock
+	buckets   map[bucketKey]*bucket
+	lastSweep time.Time
+}
+
+type bucketKey struct {
+	rpc       string
+	principal string
+}
+
+// bucket holds the tokens a client has left for an RPC as of updated.
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// NewLimiter creates a Limiter applying limits to the RPCs they name, such as
+// "GetUsersBySection", and fallback, if not nil, to every other RPC.
+func NewLimiter(fallback *Limit, limits map[string]Limit, opts ...Option) *Limiter {
+	l := &Limiter{limits: limits, fallback: fallback, clock: systemClock{}, buckets: make(map[bucketKey]*bucket)}
+	for _, opt := range opts {
+		opt(l)
+	}
+	l.lastSweep = l.clock.Now()
+	return l
+}
+
+func (l *Limiter) limitOf(rpc string) (Limit, bool) {
+	if limit, ok := l.limits[rpc]; ok {
+		return limit, true
+	}
+	if l.fallback != nil {
+		return *l.fallback, true
+	}
+	return Limit{}, false
+}
+
+// Allow takes a token from principal's bucket for rpc. When the bucket is empty it
+// returns false and how long until a token is available.
+func (l *Limiter) Allow(rpc, principal string) (ok bool, retryAfter time.Duration) {
+	limit, limited := l.limitOf(rpc)
+	if !limited {
+		return true, 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.clock.Now()
+	l.sweep(now)
+
+	key := bucketKey{rpc: rpc, principal: principal}
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Burst), updated: now}
+		l.buckets[key] = b
+	}
+	b.tokens = refill(b, limit, now)
+	b.updated = now
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	wait := time.Duration(math.Ceil((1 - b.tokens) / limit.PerSecond * float64(time.Second)))
+	return false, wait
+}
+
+func refill(b *bucket, limit Limit, now time.Time) float64 {
+	elapsed := now.Sub(b.updated).Seconds()
+	return math.Min(float64(limit.Burst), b.tokens+elapsed*limit.PerSecond)
+}
+
+// sweep drops the buckets that have refilled, which behave like new ones; the caller holds l.mu.
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	l.lastSweep = now
+	for key, b := range l.buckets {
+		if limit, ok := l.limitOf(key.rpc); !ok || refill(b, limit, now) >= float64(limit.Burst) {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// Principal names the client making a call: the authenticated caller when there is
+// one, otherwise the host of the peer's address.
+func Principal(ctx context.Context) string {
+	if claims, ok := auth.FromContext(ctx); ok {
+		switch {
+		case claims.Email != "":
+			return "user:" + claims.Email
+		case claims.Subject != "":
+			return "subject:" + claims.Subject
+		}
+		return "role:" + string(claims.Role)
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		return "peer:" + Host(p.Addr.String())
+	}
+	return "peer:unknown"
+}
+
+// Host strips the port from a network address.
+func Host(addr string) string {
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return addr
+}
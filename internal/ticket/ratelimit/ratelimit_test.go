@@ -0,0 +1,170 @@
+package ratelimit_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	ticket "github.com/talk2sohail/train-ticket-api/internal/common/genproto/ticket"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/auth"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/ratelimit"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+type fakeClock struct{ now time.Time }
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+// fakeTransportStream records the header metadata an interceptor sets.
+type fakeTransportStream struct {
+	method string
+	header metadata.MD
+}
+
+func (f *fakeTransportStream) Method() string { return f.method }
+
+func (f *fakeTransportStream) SetHeader(md metadata.MD) error {
+	f.header = metadata.Join(f.header, md)
+	return nil
+}
+
+func (f *fakeTransportStream) SendHeader(md metadata.MD) error { return f.SetHeader(md) }
+
+func (f *fakeTransportStream) SetTrailer(metadata.MD) error { return nil }
+
+func TestUnit_Limiter(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)}
+	limiter := ratelimit.NewLimiter(
+		&ratelimit.Limit{PerSecond: 1, Burst: 2},
+		map[string]ratelimit.Limit{"GetUsersBySection": {PerSecond: 0.5, Burst: 1}},
+		ratelimit.WithClock(clock),
+	)
+
+	t.Run("Burst then refill", func(t *testing.T) {
+		for i := range 2 {
+			if ok, _ := limiter.Allow("PurchaseTicket", "user:alice@example.com"); !ok {
+				t.Fatalf("expected call %d within the burst to be allowed", i+1)
+			}
+		}
+		ok, retryAfter := limiter.Allow("PurchaseTicket", "user:alice@example.com")
+		if ok || retryAfter != time.Second {
+			t.Fatalf("expected the third call to wait 1s, got allowed=%v retry after %s", ok, retryAfter)
+		}
+		clock.Advance(500 * time.Millisecond)
+		if _, retryAfter := limiter.Allow("PurchaseTicket", "user:alice@example.com"); retryAfter != 500*time.Millisecond {
+			t.Errorf("expected to wait the rest of the second, got %s", retryAfter)
+		}
+		clock.Advance(500 * time.Millisecond)
+		if ok, _ := limiter.Allow("PurchaseTicket", "user:alice@example.com"); !ok {
+			t.Errorf("expected a refilled token to be allowed")
+		}
+	})
+
+	t.Run("Clients and RPCs have their own buckets", func(t *testing.T) {
+		if ok, _ := limiter.Allow("PurchaseTicket", "user:bob@example.com"); !ok {
+			t.Errorf("expected another client to be allowed")
+		}
+		if ok, _ := limiter.Allow("GetReceiptDetails", "user:alice@example.com"); !ok {
+			t.Errorf("expected another RPC to be allowed")
+		}
+	})
+
+	t.Run("Per-RPC limit overrides the default", func(t *testing.T) {
+		if ok, _ := limiter.Allow("GetUsersBySection", "role:admin"); !ok {
+			t.Fatalf("expected the first call to be allowed")
+		}
+		if ok, retryAfter := limiter.Allow("GetUsersBySection", "role:admin"); ok || retryAfter != 2*time.Second {
+			t.Errorf("expected to wait 2s, got allowed=%v retry after %s", ok, retryAfter)
+		}
+	})
+
+	t.Run("Unlimited without a default", func(t *testing.T) {
+		limiter := ratelimit.NewLimiter(nil, map[string]ratelimit.Limit{"GetUsersBySection": {PerSecond: 1, Burst: 1}})
+		for range 10 {
+			if ok, _ := limiter.Allow("PurchaseTicket", "peer:10.0.0.1"); !ok {
+				t.Fatalf("expected an RPC without a limit to be allowed")
+			}
+		}
+	})
+}
+
+func TestUnit_Principal(t *testing.T) {
+	addr := &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 5123}
+	withPeer := peer.NewContext(context.Background(), &peer.Peer{Addr: addr})
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{"Passenger", auth.NewContext(withPeer, &auth.Claims{Role: auth.RolePassenger, Email: "alice@example.com"}), "user:alice@example.com"},
+		{"Staff", auth.NewContext(withPeer, &auth.Claims{Role: auth.RoleStaff, RegisteredClaims: jwt.RegisteredClaims{Subject: "agent-7"}}), "subject:agent-7"},
+		{"Peer", withPeer, "peer:10.0.0.1"},
+		{"Unknown", context.Background(), "peer:unknown"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ratelimit.Principal(tt.ctx); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestUnit_UnaryServerInterceptor(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)}
+	limiter := ratelimit.NewLimiter(&ratelimit.Limit{PerSecond: 0.25, Burst: 1}, nil, ratelimit.WithClock(clock))
+	interceptor := limiter.UnaryServerInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: ticket.TrainTicketingService_PurchaseTicket_FullMethodName}
+	handler := func(context.Context, any) (any, error) { return &ticket.PurchaseTicketResponse{Success: true}, nil }
+
+	call := func() (*fakeTransportStream, error) {
+		stream := &fakeTransportStream{method: info.FullMethod}
+		ctx := auth.NewContext(context.Background(), &auth.Claims{Role: auth.RolePassenger, Email: "alice@example.com"})
+		_, err := interceptor(grpc.NewContextWithServerTransportStream(ctx, stream), &ticket.PurchaseTicketRequest{}, info, handler)
+		return stream, err
+	}
+
+	if _, err := call(); err != nil {
+		t.Fatalf("unexpected error on the first call: %v", err)
+	}
+	stream, err := call()
+	st := status.Convert(err)
+	if st.Code() != codes.ResourceExhausted {
+		t.Fatalf("expected ResourceExhausted, got %v", err)
+	}
+	if got := stream.header.Get(ratelimit.RetryAfterHeader); len(got) != 1 || got[0] != "4" {
+		t.Errorf("expected %s: 4, got %v", ratelimit.RetryAfterHeader, got)
+	}
+	var retryInfo *errdetails.RetryInfo
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok {
+			retryInfo = info
+		}
+	}
+	if retryInfo == nil || retryInfo.GetRetryDelay().AsDuration() != 4*time.Second {
+		t.Errorf("expected a retry delay of 4s, got %v", retryInfo)
+	}
+
+	clock.Advance(4 * time.Second)
+	if _, err := call(); err != nil {
+		t.Errorf("unexpected error after waiting: %v", err)
+	}
+}
+
+func TestUnit_RetryAfterSeconds(t *testing.T) {
+	for d, want := range map[time.Duration]string{0: "0", time.Millisecond: "1", time.Second: "1", 1500 * time.Millisecond: "2"} {
+		if got := ratelimit.RetryAfterSeconds(d); got != want {
+			t.Errorf("RetryAfterSeconds(%s): expected %s, got %s", d, want, got)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+)
+
+// Records are framed as a 4 byte big-endian payload length, a 4 byte CRC-32C
+// checksum of the payload and the payload itself.
+const frameHeaderSize = 8
+
+// maxFrameSize guards against allocating huge buffers for a corrupt length prefix.
+const maxFrameSize = 16 << 20
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+var (
+	// errTornFrame is returned when the stream ends in the middle of a frame.
+	errTornFrame = errors.New("torn record frame")
+	// errCorruptFrame is returned when a frame fails its checksum or has an impossible length.
+	errCorruptFrame = errors.New("corrupt record frame")
+)
+
+// writeFrame appends a single framed payload to w.
+func writeFrame(w io.Writer, payload []byte) error {
+	var header [frameHeaderSize]byte
+	binary.BigEndian.PutUint32(header[0:4], uint32(len(payload)))
+	binary.BigEndian.PutUint32(header[4:8], crc32.Checksum(payload, crcTable))
+	if _, err := w.Write(header[:]); err != nil {
+		return err
+	}
+	_, err := w.Write(payload)
+	return err
+}
+
+// readFrame reads the next framed payload from r. It returns io.EOF when the
+// stream ends cleanly on a frame boundary, errTornFrame when it ends within the
+// frame and errCorruptFrame when the frame's checksum does not match.
+func readFrame(r *bufio.Reader) ([]byte, error) {
+	var header [frameHeaderSize]byte
+	n, err := io.ReadFull(r, header[:])
+	if err == io.EOF {
+		return nil, io.EOF
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: short header (%d bytes)", errTornFrame, n)
+	}
+	size := binary.BigEndian.Uint32(header[0:4])
+	if size > maxFrameSize {
+		return nil, fmt.Errorf("%w: frame size %d exceeds limit", errCorruptFrame, size)
+	}
+	payload := make([]byte, size)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return nil, fmt.Errorf("%w: short payload", errTornFrame)
+	}
+	if crc32.Checksum(payload, crcTable) != binary.BigEndian.Uint32(header[4:8]) {
+		return nil, fmt.Errorf("%w: checksum mismatch", errCorruptFrame)
+	}
+	return payload, nil
+}
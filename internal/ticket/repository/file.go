@@ -0,0 +1,211 @@
+package repository
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+
+	ticket "github.com/talk2sohail/train-ticket-api/internal/common/genproto/ticket"
+)
+
+const (
+	snapshotFileName = "snapshot.db"
+	walFileName      = "wal.log"
+	ledgerFileName   = "ledger.log"
+
+	// DefaultSnapshotEvery is how many log records are written between snapshots
+	// when no interval is configured.
+	DefaultSnapshotEvery = 1000
+)
+
+// FileRepository is a durable repository stored in a data directory.
+// Every appended batch of events is written to a write-ahead log and fsynced
+// before it becomes visible to readers. Every snapshotEvery records the log is
+// compacted: its events are moved to the ledger archive, which only grows by
+// the events compacted, the state derived from them is written to a snapshot
+// and the log is emptied. On open the snapshot is loaded, the archive read for
+// history queries and the log replayed on top. Reads are served from memory.
+type FileRepository struct {
+	mu            sync.Mutex // Serialises writers so the log and the in-memory copy never diverge.
+	dir           string
+	snapshotEvery int
+	pending       int // Records appended since the last snapshot.
+	log           *wal
+	ledger        *wal   // Archive of the events compacted out of the log.
+	archived      uint64 // Sequence number of the newest event in the archive.
+	mem           *MemoryRepository
+}
+
+// NewFileRepository opens the data directory, creating it if needed, and
+// rebuilds the repository from its snapshot and write-ahead log. A
+// non-positive snapshotEvery uses DefaultSnapshotEvery.
+func NewFileRepository(dir string, snapshotEvery int) (*FileRepository, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create data directory: %w", err)
+	}
+	if snapshotEvery <= 0 {
+		snapshotEvery = DefaultSnapshotEvery
+	}
+	r := &FileRepository{dir: dir, snapshotEvery: snapshotEvery, mem: NewMemoryRepository()}
+
+	state, err := readSnapshot(r.snapshotPath())
+	if err != nil {
+		return nil, err
+	}
+	r.mem.restore(state)
+	if r.ledger, err = openWAL(filepath.Join(dir, ledgerFileName), r.mem.record); err != nil {
+		return nil, err
+	}
+	r.archived = r.mem.lastSequence()
+
+	replayed := 0
+	r.log, err = openWAL(filepath.Join(dir, walFileName), func(event *ticket.BookingEvent) {
+		r.mem.apply(event)
+		replayed++
+	})
+	if err != nil {
+		r.ledger.close()
+		return nil, err
+	}
+	// Counting replayed events rather than records overestimates the log
+	// length, which only brings the next snapshot forward.
+	r.pending = replayed
+	log.Printf("[FileRepository] Loaded %d receipts and %d events (%d replayed from log)", len(r.mem.receipts), len(r.mem.events), replayed)
+	return r, nil
+}
+
+// Append logs the events before making them visible.
+func (r *FileRepository) Append(events ...*ticket.BookingEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.mem.mu.Lock()
+	defer r.mem.mu.Unlock()
+
+	r.mem.stamp(events)
+	if err := r.log.append(events); err != nil {
+		// Nothing was stored, so the sequence numbers are handed out again.
+		for _, event := range events {
+			event.Sequence = 0
+		}
+		return err
+	}
+	for _, event := range events {
+		r.mem.apply(event)
+	}
+
+	r.pending++
+	if r.pending >= r.snapshotEvery {
+		// The events are already durable in the log, so a failed snapshot is not
+		// reported to the caller; it is retried after the next append.
+		if err := r.snapshot(); err != nil {
+			log.Printf("[FileRepository] Snapshot failed: %v", err)
+		}
+	}
+	return nil
+}
+
+// GetReceipt looks up an active receipt by ticket ID.
+func (r *FileRepository) GetReceipt(ticketID string) (*ticket.Receipt, bool) {
+	return r.mem.GetReceipt(ticketID)
+}
+
+// GetWaitlistEntry looks up a waitlist entry, including promoted ones.
+func (r *FileRepository) GetWaitlistEntry(waitlistID string) (*ticket.WaitlistEntry, bool) {
+	return r.mem.GetWaitlistEntry(waitlistID)
+}
+
+// ListWaitlist returns the entries still waiting for a journey, in joining order.
+func (r *FileRepository) ListWaitlist(journeyID string) []*ticket.WaitlistEntry {
+	return r.mem.ListWaitlist(journeyID)
+}
+
+// GetRefund looks up a refund given on cancellation.
+func (r *FileRepository) GetRefund(refundID string) (*ticket.Refund, bool) {
+	return r.mem.GetRefund(refundID)
+}
+
+// GetReceiptsBySeat looks up the receipts holding a seat on a journey.
+func (r *FileRepository) GetReceiptsBySeat(journeyID, seatNumber string) []*ticket.Receipt {
+	return r.mem.GetReceiptsBySeat(journeyID, seatNumber)
+}
+
+// GetReceiptsByEmail looks up the active receipts of a passenger, oldest purchase first.
+func (r *FileRepository) GetReceiptsByEmail(email string) []*ticket.Receipt {
+	return r.mem.GetReceiptsByEmail(email)
+}
+
+// GetJourney looks up a scheduled journey.
+func (r *FileRepository) GetJourney(journeyID string) (*ticket.Journey, bool) {
+	return r.mem.GetJourney(journeyID)
+}
+
+// ListJourneys returns all scheduled journeys.
+func (r *FileRepository) ListJourneys() []*ticket.Journey {
+	return r.mem.ListJourneys()
+}
+
+// ListReceipts returns all active receipts.
+func (r *FileRepository) ListReceipts() []*ticket.Receipt {
+	return r.mem.ListReceipts()
+}
+
+// History returns the events recorded for a ticket, oldest first.
+func (r *FileRepository) History(ticketID string) []*ticket.BookingEvent {
+	return r.mem.History(ticketID)
+}
+
+// Events returns the whole ledger, oldest first.
+func (r *FileRepository) Events() []*ticket.BookingEvent {
+	return r.mem.Events()
+}
+
+// Snapshot archives the events in the log, checkpoints their derived state and empties the log.
+func (r *FileRepository) Snapshot() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.mem.mu.RLock()
+	defer r.mem.mu.RUnlock()
+	return r.snapshot()
+}
+
+// Close writes a final snapshot and closes the logs.
+func (r *FileRepository) Close() error {
+	err := r.Snapshot()
+	r.ledger.close()
+	if closeErr := r.log.close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// snapshot compacts the log. The events are archived and the snapshot written
+// before the log is emptied; a crash in between only means the log is replayed
+// over an archive and snapshot that already contain it, and events whose
+// sequence numbers are already there are skipped. The caller must hold r.mu and
+// r.mem.mu.
+func (r *FileRepository) snapshot() error {
+	if r.pending == 0 {
+		return nil
+	}
+	if events := r.mem.eventsAfter(r.archived); len(events) > 0 {
+		if err := r.ledger.append(events); err != nil {
+			return fmt.Errorf("archive ledger: %w", err)
+		}
+		r.archived = events[len(events)-1].GetSequence()
+	}
+	if err := writeSnapshot(r.snapshotPath(), r.mem.snapshotState()); err != nil {
+		return err
+	}
+	if err := r.log.reset(); err != nil {
+		return err
+	}
+	log.Printf("[FileRepository] Compacted %d log records into snapshot", r.pending)
+	r.pending = 0
+	return nil
+}
+
+func (r *FileRepository) snapshotPath() string {
+	return filepath.Join(r.dir, snapshotFileName)
+}
@@ -0,0 +1,385 @@
+package repository
+
+import (
+	"slices"
+	"sort"
+	"sync"
+
+	ticket "github.com/talk2sohail/train-ticket-api/internal/common/genproto/ticket"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/money"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/types"
+	"google.golang.org/protobuf/proto"
+)
+
+// MemoryRepository keeps the booking ledger in process memory. Nothing survives a restart.
+type MemoryRepository struct {
+	mu            sync.RWMutex
+	events        []*ticket.BookingEvent            // The ledger, ordered by sequence number.
+	applied       uint64                            // Sequence number of the last event folded into the state.
+	history       map[string][]*ticket.BookingEvent // Events per ticket, keyed by Ticket ID.
+	receipts      map[string]*ticket.Receipt        // Active receipts derived from the ledger, keyed by Ticket ID.
+	occupiedSeats map[seatKey][]*ticket.Receipt     // Stores which tickets hold each seat, keyed by journey and seat number.
+	byEmail       map[string][]*ticket.Receipt      // Active receipts of each passenger in purchase order, keyed by email.
+	journeys      map[string]*ticket.Journey        // Scheduled journeys, keyed by Journey ID.
+	waitlist      map[string]*ticket.WaitlistEntry  // Waitlist entries, keyed by Waitlist ID.
+	waitlistOrder []string                          // Waitlist IDs in the order passengers joined.
+	refunds       map[string]*ticket.Refund         // Refunds given on cancellation, keyed by Refund ID.
+}
+
+// seatKey identifies a seat on a specific journey.
+type seatKey struct {
+	journeyID  string
+	seatNumber string
+}
+
+func seatKeyOf(receipt *ticket.Receipt) seatKey {
+	return seatKey{journeyID: types.JourneyIDOf(receipt), seatNumber: receipt.GetAllocatedSeat().GetSeatNumber()}
+}
+
+// NewMemoryRepository creates an empty in-memory repository.
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{
+		history:       make(map[string][]*ticket.BookingEvent),
+		receipts:      make(map[string]*ticket.Receipt),
+		occupiedSeats: make(map[seatKey][]*ticket.Receipt),
+		byEmail:       make(map[string][]*ticket.Receipt),
+		journeys:      make(map[string]*ticket.Journey),
+		waitlist:      make(map[string]*ticket.WaitlistEntry),
+		refunds:       make(map[string]*ticket.Refund),
+	}
+}
+
+// Append numbers the events and applies them to the current state.
+func (r *MemoryRepository) Append(events ...*ticket.BookingEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stamp(events)
+	for _, event := range events {
+		r.apply(event)
+	}
+	return nil
+}
+
+// GetReceipt looks up an active receipt by ticket ID.
+func (r *MemoryRepository) GetReceipt(ticketID string) (*ticket.Receipt, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	receipt, ok := r.receipts[ticketID]
+	return receipt, ok
+}
+
+// GetReceiptsBySeat looks up the receipts holding a seat on a journey.
+func (r *MemoryRepository) GetReceiptsBySeat(journeyID, seatNumber string) []*ticket.Receipt {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]*ticket.Receipt(nil), r.occupiedSeats[seatKey{journeyID: journeyID, seatNumber: seatNumber}]...)
+}
+
+// GetReceiptsByEmail looks up the active receipts of a passenger, oldest purchase first.
+func (r *MemoryRepository) GetReceiptsByEmail(email string) []*ticket.Receipt {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]*ticket.Receipt(nil), r.byEmail[email]...)
+}
+
+// GetJourney looks up a scheduled journey.
+func (r *MemoryRepository) GetJourney(journeyID string) (*ticket.Journey, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	journey, ok := r.journeys[journeyID]
+	return journey, ok
+}
+
+// ListJourneys returns all scheduled journeys.
+func (r *MemoryRepository) ListJourneys() []*ticket.Journey {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	journeys := make([]*ticket.Journey, 0, len(r.journeys))
+	for _, journey := range r.journeys {
+		journeys = append(journeys, journey)
+	}
+	return journeys
+}
+
+// GetWaitlistEntry looks up a waitlist entry, including promoted ones.
+func (r *MemoryRepository) GetWaitlistEntry(waitlistID string) (*ticket.WaitlistEntry, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	entry, ok := r.waitlist[waitlistID]
+	return entry, ok
+}
+
+// ListWaitlist returns the entries still waiting for a journey, in joining order.
+func (r *MemoryRepository) ListWaitlist(journeyID string) []*ticket.WaitlistEntry {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var entries []*ticket.WaitlistEntry
+	for _, id := range r.waitlistOrder {
+		entry := r.waitlist[id]
+		if entry.GetJourneyId() == journeyID && entry.GetStatus() == ticket.WaitlistEntry_STATUS_WAITING {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// GetRefund looks up a refund given on cancellation.
+func (r *MemoryRepository) GetRefund(refundID string) (*ticket.Refund, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	refund, ok := r.refunds[refundID]
+	return refund, ok
+}
+
+// ListReceipts returns all active receipts.
+func (r *MemoryRepository) ListReceipts() []*ticket.Receipt {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	receipts := make([]*ticket.Receipt, 0, len(r.receipts))
+	for _, receipt := range r.receipts {
+		receipts = append(receipts, receipt)
+	}
+	return receipts
+}
+
+// History returns the events recorded for a ticket, oldest first.
+func (r *MemoryRepository) History(ticketID string) []*ticket.BookingEvent {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]*ticket.BookingEvent(nil), r.history[ticketID]...)
+}
+
+// Events returns the whole ledger, oldest first.
+func (r *MemoryRepository) Events() []*ticket.BookingEvent {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]*ticket.BookingEvent(nil), r.events...)
+}
+
+// Close is a no-op for the in-memory repository.
+func (r *MemoryRepository) Close() error {
+	return nil
+}
+
+// lastSequence returns the sequence number of the newest event. The caller must hold r.mu.
+func (r *MemoryRepository) lastSequence() uint64 {
+	if len(r.events) == 0 {
+		return 0
+	}
+	return r.events[len(r.events)-1].GetSequence()
+}
+
+// stamp assigns the next sequence numbers to events. The caller must hold r.mu.
+func (r *MemoryRepository) stamp(events []*ticket.BookingEvent) {
+	next := r.lastSequence()
+	for _, event := range events {
+		next++
+		event.Sequence = next
+	}
+}
+
+// apply records an event in the ledger and folds it into the current state.
+// Events already in the ledger are not recorded again, and events at or below
+// the last applied sequence number are not folded in again, which makes
+// replaying a log over a newer snapshot or archive harmless.
+// The caller must hold r.mu.
+func (r *MemoryRepository) apply(event *ticket.BookingEvent) {
+	if event.GetSequence() > r.lastSequence() {
+		r.record(event)
+	}
+	if event.GetSequence() <= r.applied {
+		return
+	}
+	r.applied = event.GetSequence()
+
+	switch e := event.GetEvent().(type) {
+	case *ticket.BookingEvent_TicketPurchased:
+		r.put(e.TicketPurchased.GetReceipt())
+	case *ticket.BookingEvent_SeatChanged:
+		current, ok := r.receipts[event.GetTicketId()]
+		if !ok {
+			return
+		}
+		// Stored receipts are never modified in place; earlier events and
+		// callers may still hold them.
+		updated := proto.Clone(current).(*ticket.Receipt)
+		updated.AllocatedSeat = e.SeatChanged.GetNewSeat()
+		r.put(updated)
+	case *ticket.BookingEvent_TicketCancelled:
+		r.delete(event.GetTicketId())
+		r.putRefund(e.TicketCancelled.GetRefund())
+	case *ticket.BookingEvent_RefundSettled:
+		r.settleRefund(e.RefundSettled)
+	case *ticket.BookingEvent_JourneyCreated:
+		r.putJourney(e.JourneyCreated.GetJourney())
+	case *ticket.BookingEvent_WaitlistJoined, *ticket.BookingEvent_WaitlistPromoted:
+		r.applyWaitlist(event)
+	}
+}
+
+// applyWaitlist folds a waitlist event into the queue. The caller must hold r.mu.
+func (r *MemoryRepository) applyWaitlist(event *ticket.BookingEvent) {
+	switch e := event.GetEvent().(type) {
+	case *ticket.BookingEvent_WaitlistJoined:
+		entry := e.WaitlistJoined.GetEntry()
+		r.waitlist[entry.GetWaitlistId()] = entry
+		r.waitlistOrder = append(r.waitlistOrder, entry.GetWaitlistId())
+	case *ticket.BookingEvent_WaitlistPromoted:
+		current, ok := r.waitlist[e.WaitlistPromoted.GetWaitlistId()]
+		if !ok {
+			return
+		}
+		promoted := proto.Clone(current).(*ticket.WaitlistEntry)
+		promoted.Status = ticket.WaitlistEntry_STATUS_PROMOTED
+		promoted.TicketId = event.GetTicketId()
+		promoted.PromotedAt = event.GetOccurredAt()
+		r.waitlist[promoted.GetWaitlistId()] = promoted
+	}
+}
+
+// record adds an event to the ledger and the per-ticket history without
+// folding it into the state. The caller must hold r.mu.
+func (r *MemoryRepository) record(event *ticket.BookingEvent) {
+	r.events = append(r.events, event)
+	if event.GetTicketId() != "" {
+		r.history[event.GetTicketId()] = append(r.history[event.GetTicketId()], event)
+	}
+}
+
+// eventsAfter returns the events of the ledger numbered after sequence. The caller must hold r.mu.
+func (r *MemoryRepository) eventsAfter(sequence uint64) []*ticket.BookingEvent {
+	i := sort.Search(len(r.events), func(i int) bool {
+		return r.events[i].GetSequence() > sequence
+	})
+	return r.events[i:]
+}
+
+// snapshotState returns the state derived from the ledger. The caller must hold r.mu.
+func (r *MemoryRepository) snapshotState() snapshotState {
+	state := snapshotState{
+		sequence: r.applied,
+		receipts: make([]*ticket.Receipt, 0, len(r.receipts)),
+		journeys: make([]*ticket.Journey, 0, len(r.journeys)),
+		waitlist: make([]*ticket.WaitlistEntry, 0, len(r.waitlistOrder)),
+		refunds:  make([]*ticket.Refund, 0, len(r.refunds)),
+	}
+	for _, receipt := range r.receipts {
+		state.receipts = append(state.receipts, receipt)
+	}
+	for _, journey := range r.journeys {
+		state.journeys = append(state.journeys, journey)
+	}
+	for _, id := range r.waitlistOrder {
+		state.waitlist = append(state.waitlist, r.waitlist[id])
+	}
+	for _, refund := range r.refunds {
+		state.refunds = append(state.refunds, refund)
+	}
+	return state
+}
+
+// restore replaces the state with that of a snapshot. The ledger is not part
+// of it and is recorded separately. The caller must hold r.mu.
+func (r *MemoryRepository) restore(state snapshotState) {
+	for _, journey := range state.journeys {
+		r.putJourney(journey)
+	}
+	for _, entry := range state.waitlist {
+		r.waitlist[entry.GetWaitlistId()] = entry
+		r.waitlistOrder = append(r.waitlistOrder, entry.GetWaitlistId())
+	}
+	for _, refund := range state.refunds {
+		r.putRefund(refund)
+	}
+	for _, receipt := range state.receipts {
+		r.put(receipt)
+	}
+	r.applied = state.sequence
+}
+
+// putJourney stores a scheduled journey. The caller must hold r.mu.
+func (r *MemoryRepository) putJourney(journey *ticket.Journey) {
+	r.journeys[journey.GetJourneyId()] = journey
+}
+
+// putRefund stores the refund of a cancellation, if it recorded one. The caller must hold r.mu.
+func (r *MemoryRepository) putRefund(refund *ticket.Refund) {
+	if refund != nil {
+		r.refunds[refund.GetRefundId()] = refund
+	}
+}
+
+// settleRefund records how a pending refund was settled. The caller must hold r.mu.
+func (r *MemoryRepository) settleRefund(settled *ticket.RefundSettled) {
+	current, ok := r.refunds[settled.GetRefundId()]
+	if !ok {
+		return
+	}
+	updated := proto.Clone(current).(*ticket.Refund)
+	updated.Status = settled.GetStatus()
+	r.refunds[updated.GetRefundId()] = updated
+}
+
+// put applies an insert or update. The caller must hold r.mu.
+func (r *MemoryRepository) put(receipt *ticket.Receipt) {
+	receipt = withPrice(receipt)
+	r.delete(receipt.GetTicketId())
+	r.receipts[receipt.GetTicketId()] = receipt
+	if key := seatKeyOf(receipt); key.seatNumber != "" {
+		r.occupiedSeats[key] = append(r.occupiedSeats[key], receipt)
+	}
+	if email := receipt.GetUser().GetEmail(); email != "" {
+		r.byEmail[email] = insertByPurchase(r.byEmail[email], receipt)
+	}
+}
+
+// withPrice fills in the price of a receipt stored before prices carried a currency, from its
+// price_paid in USD. Receipts that have a price are returned as they are.
+func withPrice(receipt *ticket.Receipt) *ticket.Receipt {
+	if receipt.GetPrice() != nil {
+		return receipt
+	}
+	price, _ := money.FromMajor(receipt.GetPricePaid(), money.USD) // USD is always supported.
+	upgraded := proto.Clone(receipt).(*ticket.Receipt)
+	upgraded.Price = price.Proto()
+	return upgraded
+}
+
+// insertByPurchase adds a receipt to a list kept in purchase order, breaking ties by ticket ID.
+func insertByPurchase(receipts []*ticket.Receipt, receipt *ticket.Receipt) []*ticket.Receipt {
+	i := sort.Search(len(receipts), func(i int) bool {
+		return !types.PurchasedBefore(receipts[i], receipt)
+	})
+	return slices.Insert(receipts, i, receipt)
+}
+
+// delete applies a removal. The caller must hold r.mu.
+func (r *MemoryRepository) delete(ticketID string) {
+	receipt, ok := r.receipts[ticketID]
+	if !ok {
+		return
+	}
+	delete(r.receipts, ticketID)
+	key := seatKeyOf(receipt)
+	if holders := withoutTicket(r.occupiedSeats[key], ticketID); len(holders) == 0 {
+		delete(r.occupiedSeats, key)
+	} else {
+		r.occupiedSeats[key] = holders
+	}
+	email := receipt.GetUser().GetEmail()
+	if owned := withoutTicket(r.byEmail[email], ticketID); len(owned) == 0 {
+		delete(r.byEmail, email)
+	} else {
+		r.byEmail[email] = owned
+	}
+}
+
+// withoutTicket returns receipts without the one for ticketID, leaving the original slice untouched.
+func withoutTicket(receipts []*ticket.Receipt, ticketID string) []*ticket.Receipt {
+	for i, receipt := range receipts {
+		if receipt.GetTicketId() == ticketID {
+			return append(receipts[:i:i], receipts[i+1:]...)
+		}
+	}
+	return receipts
+}
@@ -0,0 +1,660 @@
+package repository
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	ticket "github.com/talk2sohail/train-ticket-api/internal/common/genproto/ticket"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/types"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func newReceipt(ticketID, email, seatNumber string) *ticket.Receipt {
+	return &ticket.Receipt{
+		TicketId:      ticketID,
+		FromLocation:  "London",
+		ToLocation:    "Paris",
+		User:          &ticket.User{Email: email},
+		PricePaid:     20.0,
+		AllocatedSeat: &ticket.Seat{Section: ticket.Seat_SECTION_A, SeatNumber: seatNumber},
+	}
+}
+
+func purchased(receipt *ticket.Receipt) *ticket.BookingEvent {
+	return &ticket.BookingEvent{
+		TicketId:   receipt.GetTicketId(),
+		OccurredAt: timestamppb.Now(),
+		Event:      &ticket.BookingEvent_TicketPurchased{TicketPurchased: &ticket.TicketPurchased{Receipt: receipt}},
+	}
+}
+
+func seatChanged(ticketID, from, to string) *ticket.BookingEvent {
+	return &ticket.BookingEvent{
+		TicketId:   ticketID,
+		OccurredAt: timestamppb.Now(),
+		Event: &ticket.BookingEvent_SeatChanged{SeatChanged: &ticket.SeatChanged{
+			PreviousSeat: &ticket.Seat{Section: ticket.Seat_SECTION_A, SeatNumber: from},
+			NewSeat:      &ticket.Seat{Section: ticket.Seat_SECTION_A, SeatNumber: to},
+		}},
+	}
+}
+
+func cancelled(ticketID, seat string) *ticket.BookingEvent {
+	return &ticket.BookingEvent{
+		TicketId:   ticketID,
+		OccurredAt: timestamppb.Now(),
+		Event: &ticket.BookingEvent_TicketCancelled{TicketCancelled: &ticket.TicketCancelled{
+			ReleasedSeat: &ticket.Seat{Section: ticket.Seat_SECTION_A, SeatNumber: seat},
+		}},
+	}
+}
+
+func journeyCreated(journeyID string) *ticket.BookingEvent {
+	return &ticket.BookingEvent{
+		OccurredAt: timestamppb.Now(),
+		Event: &ticket.BookingEvent_JourneyCreated{JourneyCreated: &ticket.JourneyCreated{
+			Journey: &ticket.Journey{JourneyId: journeyID, ServiceDate: "2026-01-01", Origin: "London", Destination: "Paris", SeatsPerSection: 10},
+		}},
+	}
+}
+
+// holderOf returns the single ticket holding a seat on a journey, if any.
+func holderOf(repo types.TicketRepository, journeyID, seatNumber string) (*ticket.Receipt, bool) {
+	holders := repo.GetReceiptsBySeat(journeyID, seatNumber)
+	if len(holders) == 0 {
+		return nil, false
+	}
+	return holders[0], true
+}
+
+// exerciseRepository runs the behaviour every backend must share.
+func exerciseRepository(t *testing.T, repo types.TicketRepository) {
+	t.Helper()
+
+	if err := repo.Append(purchased(newReceipt("t1", "a@example.com", "A1"))); err != nil {
+		t.Fatalf("unexpected error appending purchase: %v", err)
+	}
+	if err := repo.Append(purchased(newReceipt("t2", "b@example.com", "A2"))); err != nil {
+		t.Fatalf("unexpected error appending purchase: %v", err)
+	}
+
+	if r, ok := repo.GetReceipt("t1"); !ok || r.GetUser().GetEmail() != "a@example.com" {
+		t.Errorf("expected receipt t1 for a@example.com, got %v", r)
+	}
+	if r, ok := holderOf(repo, types.DefaultJourneyID, "A2"); !ok || r.GetTicketId() != "t2" {
+		t.Errorf("expected seat A2 to be held by t2, got %v", r)
+	}
+	if owned := repo.GetReceiptsByEmail("b@example.com"); len(owned) != 1 || owned[0].GetTicketId() != "t2" {
+		t.Errorf("expected b@example.com to hold t2, got %v", owned)
+	}
+
+	// Moving t1 to A3 must release A1.
+	if err := repo.Append(seatChanged("t1", "A1", "A3")); err != nil {
+		t.Fatalf("unexpected error appending seat change: %v", err)
+	}
+	if _, ok := holderOf(repo, types.DefaultJourneyID, "A1"); ok {
+		t.Errorf("expected seat A1 to be released after seat change")
+	}
+	if r, ok := holderOf(repo, types.DefaultJourneyID, "A3"); !ok || r.GetTicketId() != "t1" {
+		t.Errorf("expected seat A3 to be held by t1, got %v", r)
+	}
+
+	if err := repo.Append(cancelled("t2", "A2")); err != nil {
+		t.Fatalf("unexpected error appending cancellation: %v", err)
+	}
+	if _, ok := repo.GetReceipt("t2"); ok {
+		t.Errorf("expected receipt t2 to be cancelled")
+	}
+	if _, ok := holderOf(repo, types.DefaultJourneyID, "A2"); ok {
+		t.Errorf("expected seat A2 to be released after cancellation")
+	}
+	if owned := repo.GetReceiptsByEmail("b@example.com"); len(owned) != 0 {
+		t.Errorf("expected b@example.com to hold no tickets after cancellation, got %v", owned)
+	}
+	if n := len(repo.ListReceipts()); n != 1 {
+		t.Errorf("expected 1 receipt, got %d", n)
+	}
+
+	// The ledger keeps every event, including those of cancelled tickets.
+	if n := len(repo.History("t2")); n != 2 {
+		t.Errorf("expected 2 events for t2, got %d", n)
+	}
+	events := repo.Events()
+	if len(events) != 4 {
+		t.Fatalf("expected 4 events in the ledger, got %d", len(events))
+	}
+	for i, event := range events {
+		if event.GetSequence() != uint64(i+1) {
+			t.Errorf("expected sequence %d, got %d", i+1, event.GetSequence())
+		}
+	}
+}
+
+func TestUnit_MemoryRepository(t *testing.T) {
+	exerciseRepository(t, NewMemoryRepository())
+}
+
+func TestUnit_MemoryRepositorySharedSeat(t *testing.T) {
+	repo := NewMemoryRepository()
+	// Two tickets travelling different legs hold the same seat.
+	if err := repo.Append(purchased(newReceipt("t1", "a@example.com", "A1")), purchased(newReceipt("t2", "b@example.com", "A1"))); err != nil {
+		t.Fatalf("unexpected error appending purchases: %v", err)
+	}
+	if n := len(repo.GetReceiptsBySeat(types.DefaultJourneyID, "A1")); n != 2 {
+		t.Fatalf("expected A1 to be held by 2 tickets, got %d", n)
+	}
+
+	if err := repo.Append(cancelled("t1", "A1")); err != nil {
+		t.Fatalf("unexpected error appending cancellation: %v", err)
+	}
+	if r, ok := holderOf(repo, types.DefaultJourneyID, "A1"); !ok || r.GetTicketId() != "t2" {
+		t.Errorf("expected A1 to stay held by t2, got %v", r)
+	}
+
+	if err := repo.Append(seatChanged("t2", "A1", "A2")); err != nil {
+		t.Fatalf("unexpected error appending seat change: %v", err)
+	}
+	if n := len(repo.GetReceiptsBySeat(types.DefaultJourneyID, "A1")); n != 0 {
+		t.Errorf("expected A1 to be free, got %d holders", n)
+	}
+}
+
+func TestUnit_MemoryRepositoryEmailIndex(t *testing.T) {
+	repo := NewMemoryRepository()
+	bought := func(ticketID string, at time.Time) *ticket.Receipt {
+		receipt := newReceipt(ticketID, "a@example.com", "A1")
+		receipt.PurchaseDate = timestamppb.New(at)
+		return receipt
+	}
+	day := time.Date(2025, 5, 1, 9, 0, 0, 0, time.UTC)
+	// Purchases are indexed in purchase order whatever order they are appended in.
+	if err := repo.Append(
+		purchased(bought("t3", day.Add(time.Hour))),
+		purchased(bought("t1", day)),
+		purchased(bought("t2", day)),
+	); err != nil {
+		t.Fatalf("unexpected error appending purchases: %v", err)
+	}
+	ticketIDs := func() []string {
+		var ids []string
+		for _, receipt := range repo.GetReceiptsByEmail("a@example.com") {
+			ids = append(ids, receipt.GetTicketId())
+		}
+		return ids
+	}
+	if got := ticketIDs(); !slices.Equal(got, []string{"t1", "t2", "t3"}) {
+		t.Errorf("expected tickets t1, t2, t3, got %v", got)
+	}
+
+	if err := repo.Append(cancelled("t2", "A1")); err != nil {
+		t.Fatalf("unexpected error appending cancellation: %v", err)
+	}
+	if got := ticketIDs(); !slices.Equal(got, []string{"t1", "t3"}) {
+		t.Errorf("expected tickets t1, t3 after cancelling t2, got %v", got)
+	}
+	if owned := repo.GetReceiptsByEmail("nobody@example.com"); len(owned) != 0 {
+		t.Errorf("expected no tickets for an unknown email, got %v", owned)
+	}
+}
+
+func TestUnit_MemoryRepositoryLegacyPrice(t *testing.T) {
+	repo := NewMemoryRepository()
+	legacy := newReceipt("t1", "alice@example.com", "A1")
+	priced := newReceipt("t2", "bob@example.com", "A2")
+	priced.PricePaid = 18.4
+	priced.Price = &ticket.Money{MinorUnits: 1840, CurrencyCode: "EUR"}
+	if err := repo.Append(purchased(legacy), purchased(priced)); err != nil {
+		t.Fatalf("unexpected error appending: %v", err)
+	}
+
+	if r, _ := repo.GetReceipt("t1"); r.GetPrice().GetMinorUnits() != 2000 || r.GetPrice().GetCurrencyCode() != "USD" {
+		t.Errorf("expected a receipt without a price to cost its price_paid in USD, got %v", r.GetPrice())
+	}
+	if legacy.GetPrice() != nil {
+		t.Errorf("expected the appended receipt not to be modified")
+	}
+	if r, _ := repo.GetReceipt("t2"); !proto.Equal(r, priced) {
+		t.Errorf("expected a priced receipt to be kept as it is, got %v", r)
+	}
+}
+
+func TestUnit_FileRepository(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("Shared behaviour", func(t *testing.T) {
+		repo, err := NewFileRepository(dir, 0)
+		if err != nil {
+			t.Fatalf("unexpected error opening repository: %v", err)
+		}
+		exerciseRepository(t, repo)
+		if err := repo.Close(); err != nil {
+			t.Fatalf("unexpected error closing repository: %v", err)
+		}
+	})
+
+	t.Run("State survives reopen", func(t *testing.T) {
+		repo, err := NewFileRepository(dir, 0)
+		if err != nil {
+			t.Fatalf("unexpected error reopening repository: %v", err)
+		}
+		receipts := repo.ListReceipts()
+		if len(receipts) != 1 || receipts[0].GetTicketId() != "t1" {
+			t.Fatalf("expected only t1 after reopen, got %v", receipts)
+		}
+		if r, ok := holderOf(repo, types.DefaultJourneyID, "A3"); !ok || r.GetTicketId() != "t1" {
+			t.Errorf("expected seat A3 to be held by t1 after reopen, got %v", r)
+		}
+	})
+
+	t.Run("Missing directory starts empty", func(t *testing.T) {
+		repo, err := NewFileRepository(filepath.Join(t.TempDir(), "nested"), 0)
+		if err != nil {
+			t.Fatalf("unexpected error opening repository: %v", err)
+		}
+		if n := len(repo.ListReceipts()); n != 0 {
+			t.Errorf("expected empty repository, got %d receipts", n)
+		}
+	})
+}
+
+func TestUnit_FileRepositoryReplay(t *testing.T) {
+	t.Run("Log is replayed without a clean shutdown", func(t *testing.T) {
+		dir := t.TempDir()
+		repo, err := NewFileRepository(dir, 0)
+		if err != nil {
+			t.Fatalf("unexpected error opening repository: %v", err)
+		}
+		exerciseRepository(t, repo)
+		// No Close: simulate a crash, leaving only the write-ahead log behind.
+		if _, err := os.Stat(filepath.Join(dir, snapshotFileName)); !os.IsNotExist(err) {
+			t.Fatalf("expected no snapshot before compaction, got %v", err)
+		}
+
+		reopened, err := NewFileRepository(dir, 0)
+		if err != nil {
+			t.Fatalf("unexpected error replaying log: %v", err)
+		}
+		assertSameState(t, repo, reopened)
+	})
+
+	t.Run("Torn final record is skipped", func(t *testing.T) {
+		dir := t.TempDir()
+		repo, err := NewFileRepository(dir, 0)
+		if err != nil {
+			t.Fatalf("unexpected error opening repository: %v", err)
+		}
+		if err := repo.Append(purchased(newReceipt("t1", "a@example.com", "A1"))); err != nil {
+			t.Fatalf("unexpected error appending purchase: %v", err)
+		}
+		if err := repo.Append(purchased(newReceipt("t2", "b@example.com", "A2")), seatChanged("t2", "A2", "A4")); err != nil {
+			t.Fatalf("unexpected error appending purchase: %v", err)
+		}
+
+		// Chop the last record in half, as a crash in the middle of an append would.
+		walPath := filepath.Join(dir, walFileName)
+		info, err := os.Stat(walPath)
+		if err != nil {
+			t.Fatalf("unexpected error reading log: %v", err)
+		}
+		if err := os.Truncate(walPath, info.Size()-5); err != nil {
+			t.Fatalf("unexpected error truncating log: %v", err)
+		}
+
+		reopened, err := NewFileRepository(dir, 0)
+		if err != nil {
+			t.Fatalf("expected torn record to be skipped, got error: %v", err)
+		}
+		if _, ok := reopened.GetReceipt("t1"); !ok {
+			t.Errorf("expected complete record t1 to be replayed")
+		}
+		if _, ok := reopened.GetReceipt("t2"); ok {
+			t.Errorf("expected torn record t2 to be skipped")
+		}
+		if n := len(reopened.History("t2")); n != 0 {
+			t.Errorf("expected no partial batch for t2, got %d events", n)
+		}
+
+		// New writes must land after the last good record and replay cleanly.
+		if err := reopened.Append(purchased(newReceipt("t3", "c@example.com", "A3"))); err != nil {
+			t.Fatalf("unexpected error appending purchase: %v", err)
+		}
+		again, err := NewFileRepository(dir, 0)
+		if err != nil {
+			t.Fatalf("unexpected error reopening repository: %v", err)
+		}
+		assertSameState(t, reopened, again)
+	})
+
+	t.Run("Corrupt final record is skipped", func(t *testing.T) {
+		dir := t.TempDir()
+		repo, err := NewFileRepository(dir, 0)
+		if err != nil {
+			t.Fatalf("unexpected error opening repository: %v", err)
+		}
+		if err := repo.Append(purchased(newReceipt("t1", "a@example.com", "A1"))); err != nil {
+			t.Fatalf("unexpected error appending purchase: %v", err)
+		}
+		if err := repo.Append(purchased(newReceipt("t2", "b@example.com", "A2"))); err != nil {
+			t.Fatalf("unexpected error appending purchase: %v", err)
+		}
+		walPath := filepath.Join(dir, walFileName)
+		data, err := os.ReadFile(walPath)
+		if err != nil {
+			t.Fatalf("unexpected error reading log: %v", err)
+		}
+		data[len(data)-1] ^= 0xff
+		if err := os.WriteFile(walPath, data, 0o644); err != nil {
+			t.Fatalf("unexpected error writing log: %v", err)
+		}
+
+		reopened, err := NewFileRepository(dir, 0)
+		if err != nil {
+			t.Fatalf("expected the corrupt final record to be skipped, got error: %v", err)
+		}
+		if _, ok := reopened.GetReceipt("t1"); !ok {
+			t.Errorf("expected complete record t1 to be replayed")
+		}
+		if _, ok := reopened.GetReceipt("t2"); ok {
+			t.Errorf("expected corrupt record t2 to be skipped")
+		}
+	})
+
+	t.Run("Corrupt record in the middle fails to open", func(t *testing.T) {
+		dir := t.TempDir()
+		repo, err := NewFileRepository(dir, 0)
+		if err != nil {
+			t.Fatalf("unexpected error opening repository: %v", err)
+		}
+		for i, seat := range []string{"A1", "A2", "A3"} {
+			id := fmt.Sprintf("t%d", i+1)
+			if err := repo.Append(purchased(newReceipt(id, id+"@example.com", seat))); err != nil {
+				t.Fatalf("unexpected error appending purchase: %v", err)
+			}
+		}
+		walPath := filepath.Join(dir, walFileName)
+		data, err := os.ReadFile(walPath)
+		if err != nil {
+			t.Fatalf("unexpected error reading log: %v", err)
+		}
+		// Flip a byte in the payload of the first record; two good records follow it.
+		data[frameHeaderSize+2] ^= 0xff
+		if err := os.WriteFile(walPath, data, 0o644); err != nil {
+			t.Fatalf("unexpected error writing log: %v", err)
+		}
+
+		if _, err := NewFileRepository(dir, 0); err == nil {
+			t.Fatalf("expected an error opening a log corrupt in the middle")
+		}
+		after, err := os.ReadFile(walPath)
+		if err != nil {
+			t.Fatalf("unexpected error reading log: %v", err)
+		}
+		if !bytes.Equal(after, data) {
+			t.Errorf("expected the log to be left untouched, got %d of %d bytes", len(after), len(data))
+		}
+	})
+
+	t.Run("Snapshot compacts the log", func(t *testing.T) {
+		dir := t.TempDir()
+		repo, err := NewFileRepository(dir, 2)
+		if err != nil {
+			t.Fatalf("unexpected error opening repository: %v", err)
+		}
+		exerciseRepository(t, repo) // Four writes: two snapshots.
+
+		info, err := os.Stat(filepath.Join(dir, walFileName))
+		if err != nil {
+			t.Fatalf("unexpected error reading log: %v", err)
+		}
+		if info.Size() != 0 {
+			t.Errorf("expected empty log after compaction, got %d bytes", info.Size())
+		}
+
+		reopened, err := NewFileRepository(dir, 2)
+		if err != nil {
+			t.Fatalf("unexpected error reopening repository: %v", err)
+		}
+		assertSameState(t, repo, reopened)
+	})
+
+	t.Run("Snapshot holds only derived state", func(t *testing.T) {
+		dir := t.TempDir()
+		repo, err := NewFileRepository(dir, 1)
+		if err != nil {
+			t.Fatalf("unexpected error opening repository: %v", err)
+		}
+		if err := repo.Append(purchased(newReceipt("t1", "a@example.com", "A1"))); err != nil {
+			t.Fatalf("unexpected error appending: %v", err)
+		}
+		info, err := os.Stat(filepath.Join(dir, snapshotFileName))
+		if err != nil {
+			t.Fatalf("unexpected error reading snapshot: %v", err)
+		}
+		size := info.Size()
+
+		// Buying and cancelling tickets adds to the ledger but not to the derived state.
+		for i := 0; i < 20; i++ {
+			id := fmt.Sprintf("t%d", i+2)
+			if err := repo.Append(purchased(newReceipt(id, "b@example.com", "B1"))); err != nil {
+				t.Fatalf("unexpected error appending: %v", err)
+			}
+			if err := repo.Append(cancelled(id, "B1")); err != nil {
+				t.Fatalf("unexpected error appending: %v", err)
+			}
+		}
+		if info, err = os.Stat(filepath.Join(dir, snapshotFileName)); err != nil {
+			t.Fatalf("unexpected error reading snapshot: %v", err)
+		}
+		// The checkpoint's varint may grow by a byte.
+		if info.Size() > size+1 {
+			t.Errorf("expected the snapshot to stay at %d bytes, got %d", size, info.Size())
+		}
+
+		reopened, err := NewFileRepository(dir, 1)
+		if err != nil {
+			t.Fatalf("unexpected error reopening repository: %v", err)
+		}
+		assertSameState(t, repo, reopened)
+	})
+
+	t.Run("Crash before the log is emptied keeps history once", func(t *testing.T) {
+		dir := t.TempDir()
+		repo, err := NewFileRepository(dir, 100)
+		if err != nil {
+			t.Fatalf("unexpected error opening repository: %v", err)
+		}
+		exerciseRepository(t, repo)
+		if err := repo.Append(journeyCreated("j1")); err != nil {
+			t.Fatalf("unexpected error appending journey: %v", err)
+		}
+		walBytes, err := os.ReadFile(filepath.Join(dir, walFileName))
+		if err != nil {
+			t.Fatalf("unexpected error reading log: %v", err)
+		}
+		if err := repo.Snapshot(); err != nil {
+			t.Fatalf("unexpected error taking snapshot: %v", err)
+		}
+		// Simulate a crash after the archive and snapshot were written but
+		// before the log was emptied.
+		if err := os.WriteFile(filepath.Join(dir, walFileName), walBytes, 0o644); err != nil {
+			t.Fatalf("unexpected error restoring log: %v", err)
+		}
+
+		reopened, err := NewFileRepository(dir, 100)
+		if err != nil {
+			t.Fatalf("unexpected error reopening repository: %v", err)
+		}
+		assertSameState(t, repo, reopened)
+	})
+}
+
+// assertSameState checks that two repositories hold identical ledgers, receipts, seat occupancy and email index.
+func assertSameState(t *testing.T, want, got types.TicketRepository) {
+	t.Helper()
+	wantEvents, gotEvents := want.Events(), got.Events()
+	if len(gotEvents) != len(wantEvents) {
+		t.Fatalf("expected %d events, got %d", len(wantEvents), len(gotEvents))
+	}
+	for i := range wantEvents {
+		if !proto.Equal(wantEvents[i], gotEvents[i]) {
+			t.Errorf("expected event %v, got %v", wantEvents[i], gotEvents[i])
+		}
+	}
+	wantReceipts := want.ListReceipts()
+	if len(got.ListReceipts()) != len(wantReceipts) {
+		t.Fatalf("expected %d receipts, got %d", len(wantReceipts), len(got.ListReceipts()))
+	}
+	for _, w := range wantReceipts {
+		g, ok := got.GetReceipt(w.GetTicketId())
+		if !ok || !proto.Equal(w, g) {
+			t.Errorf("expected receipt %v, got %v", w, g)
+		}
+		seat := w.GetAllocatedSeat().GetSeatNumber()
+		if occupant, ok := holderOf(got, types.JourneyIDOf(w), seat); !ok || occupant.GetTicketId() != w.GetTicketId() {
+			t.Errorf("expected seat %s to be held by %s, got %v", seat, w.GetTicketId(), occupant)
+		}
+		email := w.GetUser().GetEmail()
+		if owned := got.GetReceiptsByEmail(email); len(owned) != len(want.GetReceiptsByEmail(email)) {
+			t.Errorf("expected %s to hold %d tickets, got %d", email, len(want.GetReceiptsByEmail(email)), len(owned))
+		}
+	}
+	wantJourneys := want.ListJourneys()
+	if len(got.ListJourneys()) != len(wantJourneys) {
+		t.Fatalf("expected %d journeys, got %d", len(wantJourneys), len(got.ListJourneys()))
+	}
+	for _, w := range wantJourneys {
+		if g, ok := got.GetJourney(w.GetJourneyId()); !ok || !proto.Equal(w, g) {
+			t.Errorf("expected journey %v, got %v", w, g)
+		}
+	}
+}
+
+func waitlistJoined(waitlistID, email string) *ticket.BookingEvent {
+	return &ticket.BookingEvent{
+		OccurredAt: timestamppb.Now(),
+		Event: &ticket.BookingEvent_WaitlistJoined{WaitlistJoined: &ticket.WaitlistJoined{Entry: &ticket.WaitlistEntry{
+			WaitlistId:   waitlistID,
+			JourneyId:    types.DefaultJourneyID,
+			FromLocation: "London",
+			ToLocation:   "Paris",
+			User:         &ticket.User{Email: email},
+			Status:       ticket.WaitlistEntry_STATUS_WAITING,
+		}}},
+	}
+}
+
+func waitlistPromoted(waitlistID, ticketID string) *ticket.BookingEvent {
+	return &ticket.BookingEvent{
+		TicketId:   ticketID,
+		OccurredAt: timestamppb.Now(),
+		Event:      &ticket.BookingEvent_WaitlistPromoted{WaitlistPromoted: &ticket.WaitlistPromoted{WaitlistId: waitlistID}},
+	}
+}
+
+func TestUnit_FileRepositoryWaitlist(t *testing.T) {
+	assertWaitlist := func(t *testing.T, repo types.TicketRepository) {
+		t.Helper()
+		waiting := repo.ListWaitlist(types.DefaultJourneyID)
+		if len(waiting) != 1 || waiting[0].GetWaitlistId() != "w2" {
+			t.Errorf("expected only w2 to be waiting, got %v", waiting)
+		}
+		entry, ok := repo.GetWaitlistEntry("w1")
+		if !ok || entry.GetStatus() != ticket.WaitlistEntry_STATUS_PROMOTED || entry.GetTicketId() != "t2" {
+			t.Errorf("expected w1 to be promoted to t2, got %v", entry)
+		}
+		if n := len(repo.History("t2")); n != 2 {
+			t.Errorf("expected promotion and purchase in t2's history, got %d events", n)
+		}
+	}
+
+	for name, snapshotEvery := range map[string]int{"Replayed from the log": 0, "Restored from a snapshot": 1} {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			repo, err := NewFileRepository(dir, snapshotEvery)
+			if err != nil {
+				t.Fatalf("unexpected error opening repository: %v", err)
+			}
+			if err := repo.Append(purchased(newReceipt("t1", "a@example.com", "A1"))); err != nil {
+				t.Fatalf("unexpected error appending purchase: %v", err)
+			}
+			if err := repo.Append(waitlistJoined("w1", "b@example.com")); err != nil {
+				t.Fatalf("unexpected error joining waitlist: %v", err)
+			}
+			if err := repo.Append(waitlistJoined("w2", "c@example.com")); err != nil {
+				t.Fatalf("unexpected error joining waitlist: %v", err)
+			}
+			if err := repo.Append(cancelled("t1", "A1"), waitlistPromoted("w1", "t2"), purchased(newReceipt("t2", "b@example.com", "A1"))); err != nil {
+				t.Fatalf("unexpected error appending promotion: %v", err)
+			}
+			assertWaitlist(t, repo)
+
+			reopened, err := NewFileRepository(dir, snapshotEvery)
+			if err != nil {
+				t.Fatalf("unexpected error reopening repository: %v", err)
+			}
+			assertWaitlist(t, reopened)
+		})
+	}
+}
+
+func TestUnit_FileRepositoryRefunds(t *testing.T) {
+	refunded := cancelled("t1", "A1")
+	refunded.GetTicketCancelled().Refund = &ticket.Refund{
+		RefundId:      "r1",
+		TicketId:      "t1",
+		Amount:        &ticket.Money{MinorUnits: 1000, CurrencyCode: "USD"},
+		RefundPercent: 50,
+		Status:        ticket.Refund_STATUS_PENDING,
+	}
+	settled := &ticket.BookingEvent{
+		TicketId: "t1",
+		Event: &ticket.BookingEvent_RefundSettled{
+			RefundSettled: &ticket.RefundSettled{RefundId: "r1", Status: ticket.Refund_STATUS_COMPLETED},
+		},
+	}
+	assertRefund := func(t *testing.T, repo types.TicketRepository) {
+		t.Helper()
+		refund, ok := repo.GetRefund("r1")
+		if !ok || refund.GetTicketId() != "t1" || refund.GetAmount().GetMinorUnits() != 1000 {
+			t.Errorf("expected refund r1 of 10.00 for t1, got %v", refund)
+		}
+		if refund.GetStatus() != ticket.Refund_STATUS_COMPLETED {
+			t.Errorf("expected refund r1 to be settled, got %s", refund.GetStatus())
+		}
+		if _, ok := repo.GetRefund("r2"); ok {
+			t.Errorf("expected no refund for a cancellation that recorded none")
+		}
+	}
+
+	for name, snapshotEvery := range map[string]int{"Replayed from the log": 0, "Restored from a snapshot": 1} {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			repo, err := NewFileRepository(dir, snapshotEvery)
+			if err != nil {
+				t.Fatalf("unexpected error opening repository: %v", err)
+			}
+			if err := repo.Append(purchased(newReceipt("t1", "a@example.com", "A1")), purchased(newReceipt("t2", "b@example.com", "A2"))); err != nil {
+				t.Fatalf("unexpected error appending purchases: %v", err)
+			}
+			if err := repo.Append(proto.Clone(refunded).(*ticket.BookingEvent), cancelled("t2", "A2")); err != nil {
+				t.Fatalf("unexpected error appending cancellations: %v", err)
+			}
+			if err := repo.Append(proto.Clone(settled).(*ticket.BookingEvent)); err != nil {
+				t.Fatalf("unexpected error settling the refund: %v", err)
+			}
+			assertRefund(t, repo)
+
+			reopened, err := NewFileRepository(dir, snapshotEvery)
+			if err != nil {
+				t.Fatalf("unexpected error reopening repository: %v", err)
+			}
+			assertRefund(t, reopened)
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	ticket "github.com/talk2sohail/train-ticket-api/internal/common/genproto/ticket"
+	"google.golang.org/protobuf/proto"
+)
+
+// A snapshot is a checkpoint of the state derived from the ledger, so startup
+// does not have to replay every event: a checkpoint record with the sequence
+// number of the last event folded in, followed by the scheduled journeys, the
+// waitlist entries, the refunds given and the active receipts. Its size
+// follows the current state rather than the length of the ledger, whose events
+// are archived separately. It is written atomically so it is either complete or
+// absent.
+
+// snapshotState is the state a snapshot holds.
+type snapshotState struct {
+	sequence uint64 // Sequence number of the last event folded into the state.
+	journeys []*ticket.Journey
+	waitlist []*ticket.WaitlistEntry // In joining order.
+	refunds  []*ticket.Refund
+	receipts []*ticket.Receipt
+}
+
+// readSnapshot decodes the snapshot file at path. A missing file is treated as
+// an empty repository.
+func readSnapshot(path string) (snapshotState, error) {
+	var state snapshotState
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return state, fmt.Errorf("open snapshot: %w", err)
+	}
+	defer f.Close()
+
+	reader := bufio.NewReader(f)
+	for {
+		payload, err := readFrame(reader)
+		if err == io.EOF {
+			return state, nil
+		}
+		if err != nil {
+			return state, fmt.Errorf("read snapshot %s: %w", path, err)
+		}
+		if err := state.decode(payload); err != nil {
+			return state, fmt.Errorf("read snapshot %s: %w", path, err)
+		}
+	}
+}
+
+// decode adds a snapshot record to the state.
+func (state *snapshotState) decode(payload []byte) error {
+	kind, body, err := decodeRecord(payload)
+	if err != nil {
+		return err
+	}
+	switch kind {
+	case recordCheckpoint:
+		state.sequence, err = decodeCheckpoint(body)
+		return err
+	case recordReceipt:
+		receipt := &ticket.Receipt{}
+		if err := proto.Unmarshal(body, receipt); err != nil {
+			return fmt.Errorf("decode receipt: %w", err)
+		}
+		state.receipts = append(state.receipts, receipt)
+		return nil
+	case recordJourney:
+		journey := &ticket.Journey{}
+		if err := proto.Unmarshal(body, journey); err != nil {
+			return fmt.Errorf("decode journey: %w", err)
+		}
+		state.journeys = append(state.journeys, journey)
+		return nil
+	case recordWaitlistEntry:
+		entry := &ticket.WaitlistEntry{}
+		if err := proto.Unmarshal(body, entry); err != nil {
+			return fmt.Errorf("decode waitlist entry: %w", err)
+		}
+		state.waitlist = append(state.waitlist, entry)
+		return nil
+	case recordRefund:
+		refund := &ticket.Refund{}
+		if err := proto.Unmarshal(body, refund); err != nil {
+			return fmt.Errorf("decode refund: %w", err)
+		}
+		state.refunds = append(state.refunds, refund)
+		return nil
+	default:
+		return fmt.Errorf("unexpected record kind %d", kind)
+	}
+}
+
+// writeSnapshot atomically replaces the snapshot file at path with state.
+func writeSnapshot(path string, state snapshotState) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("create temporary snapshot: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	writer := bufio.NewWriter(tmp)
+	write := func(payload []byte, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := writeFrame(writer, payload); err != nil {
+			return fmt.Errorf("write snapshot: %w", err)
+		}
+		return nil
+	}
+	if err := write(encodeCheckpoint(state.sequence), nil); err != nil {
+		tmp.Close()
+		return err
+	}
+	for _, journey := range state.journeys {
+		if err := write(encodeMessage(recordJourney, journey)); err != nil {
+			tmp.Close()
+			return err
+		}
+	}
+	for _, entry := range state.waitlist {
+		if err := write(encodeMessage(recordWaitlistEntry, entry)); err != nil {
+			tmp.Close()
+			return err
+		}
+	}
+	for _, refund := range state.refunds {
+		if err := write(encodeMessage(recordRefund, refund)); err != nil {
+			tmp.Close()
+			return err
+		}
+	}
+	for _, receipt := range state.receipts {
+		if err := write(encodeMessage(recordReceipt, receipt)); err != nil {
+			tmp.Close()
+			return err
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write snapshot: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("sync snapshot: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close snapshot: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("replace snapshot: %w", err)
+	}
+	return syncDir(filepath.Dir(path))
+}
+
+// syncDir flushes directory metadata so a rename survives a crash.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return fmt.Errorf("open data directory: %w", err)
+	}
+	defer d.Close()
+	if err := d.Sync(); err != nil {
+		return fmt.Errorf("sync data directory: %w", err)
+	}
+	return nil
+}
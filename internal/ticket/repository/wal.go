@@ -0,0 +1,210 @@
+package repository
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	ticket "github.com/talk2sohail/train-ticket-api/internal/common/genproto/ticket"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+)
+
+// recordKind identifies what a framed record holds. The first byte of every
+// record payload holds the kind; the rest is kind specific.
+type recordKind byte
+
+const (
+	// recordEvents holds one or more booking events, each prefixed with its
+	// varint encoded length. Events appended together share a record so that
+	// they are replayed together or not at all.
+	recordEvents recordKind = 1
+	// recordReceipt holds a proto encoded Receipt. Only snapshots contain it.
+	recordReceipt recordKind = 2
+	// recordCheckpoint holds the varint encoded sequence number of the last
+	// event folded into a snapshot. Every snapshot starts with one.
+	recordCheckpoint recordKind = 3
+	// recordJourney holds a proto encoded Journey. Only snapshots contain it.
+	recordJourney recordKind = 4
+	// recordWaitlistEntry holds a proto encoded WaitlistEntry. Only snapshots
+	// contain it, in the order passengers joined.
+	recordWaitlistEntry recordKind = 5
+	// recordRefund holds a proto encoded Refund. Only snapshots contain it.
+	recordRefund recordKind = 6
+)
+
+func encodeEvents(events []*ticket.BookingEvent) ([]byte, error) {
+	payload := []byte{byte(recordEvents)}
+	for _, event := range events {
+		body, err := proto.Marshal(event)
+		if err != nil {
+			return nil, fmt.Errorf("encode event: %w", err)
+		}
+		payload = protowire.AppendBytes(payload, body)
+	}
+	return payload, nil
+}
+
+// encodeMessage encodes a record holding a single proto message.
+func encodeMessage(kind recordKind, m proto.Message) ([]byte, error) {
+	body, err := proto.Marshal(m)
+	if err != nil {
+		return nil, fmt.Errorf("encode record kind %d: %w", kind, err)
+	}
+	return append([]byte{byte(kind)}, body...), nil
+}
+
+func encodeCheckpoint(sequence uint64) []byte {
+	return protowire.AppendVarint([]byte{byte(recordCheckpoint)}, sequence)
+}
+
+// decodeRecord splits a record payload into its kind and kind specific body.
+func decodeRecord(payload []byte) (recordKind, []byte, error) {
+	if len(payload) == 0 {
+		return 0, nil, errors.New("empty record")
+	}
+	return recordKind(payload[0]), payload[1:], nil
+}
+
+// decodeEvents parses the body of a recordEvents record.
+func decodeEvents(body []byte) ([]*ticket.BookingEvent, error) {
+	var events []*ticket.BookingEvent
+	for len(body) > 0 {
+		raw, n := protowire.ConsumeBytes(body)
+		if n < 0 {
+			return nil, fmt.Errorf("decode event: %w", protowire.ParseError(n))
+		}
+		event := &ticket.BookingEvent{}
+		if err := proto.Unmarshal(raw, event); err != nil {
+			return nil, fmt.Errorf("decode event: %w", err)
+		}
+		events = append(events, event)
+		body = body[n:]
+	}
+	return events, nil
+}
+
+// decodeCheckpoint parses the body of a recordCheckpoint record.
+func decodeCheckpoint(body []byte) (uint64, error) {
+	sequence, n := protowire.ConsumeVarint(body)
+	if n < 0 || n != len(body) {
+		return 0, errors.New("decode checkpoint: malformed sequence number")
+	}
+	return sequence, nil
+}
+
+// wal is an append-only log of booking events. Each append is fsynced before
+// it returns, so an acknowledged change survives a crash.
+type wal struct {
+	f    *os.File
+	size int64 // Offset just past the last complete record.
+}
+
+// openWAL opens the log at path and calls apply for every event in it.
+// A bad final record, typically left by a crash in the middle of an append,
+// is logged and truncated so that new records are never written after garbage.
+// A bad record followed by more data cannot be the result of a crash: opening
+// fails rather than throwing away the records after it.
+func openWAL(path string, apply func(*ticket.BookingEvent)) (*wal, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("open wal: %w", err)
+	}
+
+	var offset int64
+	reader := bufio.NewReader(f)
+	for {
+		payload, err := readFrame(reader)
+		if err == io.EOF {
+			break
+		}
+		var events []*ticket.BookingEvent
+		if err == nil {
+			var kind recordKind
+			var body []byte
+			if kind, body, err = decodeRecord(payload); err == nil {
+				if kind == recordEvents {
+					events, err = decodeEvents(body)
+				} else {
+					err = fmt.Errorf("unexpected record kind %d", kind)
+				}
+			}
+		}
+		if err != nil {
+			if !errors.Is(err, errTornFrame) && !atEOF(reader) {
+				f.Close()
+				return nil, fmt.Errorf("wal %s: corrupt record at offset %d is followed by more records: %w", path, offset, err)
+			}
+			log.Printf("[WAL] Skipping torn record at offset %d in %s: %v", offset, path, err)
+			break
+		}
+		for _, event := range events {
+			apply(event)
+		}
+		offset += int64(frameHeaderSize + len(payload))
+	}
+
+	if err := f.Truncate(offset); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("truncate wal: %w", err)
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("seek wal: %w", err)
+	}
+	return &wal{f: f, size: offset}, nil
+}
+
+// atEOF reports whether nothing is left to read.
+func atEOF(r *bufio.Reader) bool {
+	_, err := r.Peek(1)
+	return err == io.EOF
+}
+
+// append durably writes events to the end of the log as a single record. If
+// the write fails the log is cut back to its previous length so a partial
+// record is never left behind.
+func (w *wal) append(events []*ticket.BookingEvent) error {
+	payload, err := encodeEvents(events)
+	if err != nil {
+		return err
+	}
+	if err := writeFrame(w.f, payload); err != nil {
+		w.rollback()
+		return fmt.Errorf("write wal: %w", err)
+	}
+	if err := w.f.Sync(); err != nil {
+		w.rollback()
+		return fmt.Errorf("sync wal: %w", err)
+	}
+	w.size += int64(frameHeaderSize + len(payload))
+	return nil
+}
+
+// reset empties the log once its records have been folded into a snapshot.
+func (w *wal) reset() error {
+	if err := w.f.Truncate(0); err != nil {
+		return fmt.Errorf("truncate wal: %w", err)
+	}
+	if _, err := w.f.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("seek wal: %w", err)
+	}
+	w.size = 0
+	return w.f.Sync()
+}
+
+func (w *wal) rollback() {
+	if err := w.f.Truncate(w.size); err != nil {
+		log.Printf("[WAL] Failed to roll back partial record: %v", err)
+	}
+	if _, err := w.f.Seek(w.size, io.SeekStart); err != nil {
+		log.Printf("[WAL] Failed to seek after rollback: %v", err)
+	}
+}
+
+func (w *wal) close() error {
+	return w.f.Close()
+}
@@ -1,20 +1,37 @@
 package server
 
 import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
 	"log"
 	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/auth"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/certs"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/config"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/gateway"
 	"github.com/talk2sohail/train-ticket-api/internal/ticket/handler"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/ratelimit"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/repository"
 	"github.com/talk2sohail/train-ticket-api/internal/ticket/service"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/types"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 type TicketGRPCServer struct {
 	addr string
+	cfg  config.Config
 }
 
-func NewTicketGRPCServer(addr string) *TicketGRPCServer {
-	return &TicketGRPCServer{addr: addr}
+func NewTicketGRPCServer(addr string, cfg config.Config) *TicketGRPCServer {
+	return &TicketGRPCServer{addr: addr, cfg: cfg}
 }
 
 func (s *TicketGRPCServer) Run() error {
@@ -23,13 +40,203 @@ func (s *TicketGRPCServer) Run() error {
 		log.Fatalf("failed to listen: %v", err)
 	}
 
-	grpcServer := grpc.NewServer()
+	layouts, err := s.cfg.TrainLayouts()
+	if err != nil {
+		return err
+	}
+	fares, err := s.cfg.Fares.Source()
+	if err != nil {
+		return err
+	}
+	rates, err := s.cfg.Currency.Rates()
+	if err != nil {
+		return err
+	}
+	payments, err := s.cfg.Payments.Processor()
+	if err != nil {
+		return err
+	}
+	policy, err := s.cfg.CancellationPolicy()
+	if err != nil {
+		return err
+	}
+
+	repo, err := openRepository(s.cfg.Storage)
+	if err != nil {
+		return err
+	}
+	defer repo.Close()
+
+	ticketService := service.NewTicketServiceWithRepository(repo,
+		service.WithLayouts(layouts, s.cfg.DefaultLayout),
+		service.WithHoldTTL(s.cfg.Holds.TTL()),
+		service.WithTicketQuota(s.cfg.Quotas.MaxActiveTickets),
+		service.WithFareSource(fares),
+		service.WithRates(rates),
+		service.WithPayments(payments),
+		service.WithCancellationPolicy(policy),
+	)
+
+	idempotency := service.NewIdempotencyStore(s.cfg.Idempotency.Window(), nil)
+	limiter := s.limiter()
+	authorizer, err := s.authorizer(ticketService)
+	if err != nil {
+		return err
+	}
+	opts := s.interceptors(authorizer, limiter, idempotency)
+	tlsConfig, err := s.tlsConfig()
+	if err != nil {
+		return err
+	}
+	if tlsConfig != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+	grpcServer := grpc.NewServer(opts...)
 
 	// register our grpc services
-	ticketService := service.NewTicketService()
 	handler.RegisterTicketServiceServer(grpcServer, ticketService)
 
+	stopReaper := ticketService.StartHoldReaper(s.cfg.Holds.ReapInterval())
+	defer stopReaper()
+
+	// Serve the REST gateway on its own port, backed by the same service.
+	var httpServer *http.Server
+	if s.cfg.HTTP.Addr != "" {
+		httpLis, err := net.Listen("tcp", s.cfg.HTTP.Addr)
+		if err != nil {
+			return fmt.Errorf("listen for REST gateway: %w", err)
+		}
+		httpServer = &http.Server{Handler: gateway.NewGateway(ticketService, s.gatewayOptions(authorizer, limiter, idempotency)...)}
+		if tlsConfig != nil {
+			httpLis = tls.NewListener(httpLis, tlsConfig)
+		}
+		go func() {
+			log.Println("Starting Ticketing REST gateway on", s.cfg.HTTP.Addr)
+			if err := httpServer.Serve(httpLis); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				log.Printf("REST gateway stopped: %v", err)
+			}
+		}()
+	}
+
+	// Stop gracefully on SIGINT/SIGTERM so the deferred repository Close runs
+	// and durable backends can flush a final snapshot.
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(stop)
+	go func() {
+		if _, ok := <-stop; ok {
+			if httpServer != nil {
+				log.Println("Shutting down Ticketing REST gateway")
+				if err := httpServer.Shutdown(context.Background()); err != nil {
+					log.Printf("REST gateway shutdown: %v", err)
+				}
+			}
+			log.Println("Shutting down Ticketing gRPC server")
+			grpcServer.GracefulStop()
+		}
+	}()
+
 	log.Println("Starting Ticketing gRPC server on", s.addr)
 
 	return grpcServer.Serve(lis)
 }
+
+// authorizer returns the authorizer of the configured signing keys, resolving
+// owners with owners, or nil when authentication is off.
+func (s *TicketGRPCServer) authorizer(owners auth.Owners) (*auth.Authorizer, error) {
+	if !s.cfg.Auth.Enabled() {
+		log.Println("Authentication is disabled; every caller is trusted")
+		return nil, nil
+	}
+	keys, err := s.cfg.Auth.SigningKeys()
+	if err != nil {
+		return nil, err
+	}
+	verifier, err := auth.NewVerifier(s.cfg.Auth.Issuer, s.cfg.Auth.Audience, keys...)
+	if err != nil {
+		return nil, fmt.Errorf("auth: %w", err)
+	}
+	log.Printf("Authenticating callers with %d signing keys", len(keys))
+	return auth.NewAuthorizer(verifier, owners), nil
+}
+
+// interceptors builds the interceptor chain of the gRPC server. With an
+// authorizer, every call must carry a bearer token allowing it; callers are
+// then throttled by limiter, if any, and retried mutating calls carrying an
+// idempotency key are answered from idempotency.
+func (s *TicketGRPCServer) interceptors(authorizer *auth.Authorizer, limiter *ratelimit.Limiter, idempotency *service.IdempotencyStore) []grpc.ServerOption {
+	var unary []grpc.UnaryServerInterceptor
+	var stream []grpc.StreamServerInterceptor
+	if authorizer != nil {
+		unary = append(unary, authorizer.UnaryServerInterceptor())
+		stream = append(stream, authorizer.StreamServerInterceptor())
+	}
+	if limiter != nil {
+		unary = append(unary, limiter.UnaryServerInterceptor())
+		stream = append(stream, limiter.StreamServerInterceptor())
+	}
+	unary = append(unary, handler.IdempotencyInterceptor(idempotency))
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+	}
+}
+
+// limiter returns the rate limiter of the configured limits, or nil when no RPC is limited.
+func (s *TicketGRPCServer) limiter() *ratelimit.Limiter {
+	if !s.cfg.RateLimits.Enabled() {
+		log.Println("Rate limiting is disabled")
+		return nil
+	}
+	fallback, limits := s.cfg.RateLimits.Limits()
+	log.Printf("Rate limiting %d RPCs individually", len(limits))
+	return ratelimit.NewLimiter(fallback, limits)
+}
+
+// gatewayOptions applies the gRPC server's authentication, rate limits and idempotency to the REST gateway.
+func (s *TicketGRPCServer) gatewayOptions(authorizer *auth.Authorizer, limiter *ratelimit.Limiter, idempotency *service.IdempotencyStore) []gateway.Option {
+	opts := []gateway.Option{gateway.WithIdempotency(idempotency)}
+	if authorizer != nil {
+		opts = append(opts, gateway.WithAuthorizer(authorizer))
+	}
+	if limiter != nil {
+		opts = append(opts, gateway.WithRateLimiter(limiter))
+	}
+	return opts
+}
+
+// tlsConfig returns the TLS config the servers are served with, or nil when TLS is off.
+func (s *TicketGRPCServer) tlsConfig() (*tls.Config, error) {
+	if !s.cfg.TLS.Enabled() {
+		log.Println("TLS is disabled; serving plaintext")
+		return nil, nil
+	}
+	r, err := certs.NewReloader(s.cfg.TLS.CertFile, s.cfg.TLS.KeyFile, s.cfg.TLS.ClientCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("tls: %w", err)
+	}
+	if s.cfg.TLS.ClientCAFile != "" {
+		log.Println("Serving mutual TLS; clients must present a certificate")
+	} else {
+		log.Println("Serving TLS")
+	}
+	return certs.ServerConfig(r), nil
+}
+
+// openRepository creates the storage backend selected in the config.
+func openRepository(cfg config.StorageConfig) (types.TicketRepository, error) {
+	switch cfg.Backend {
+	case config.StorageMemory, "":
+		log.Println("Using in-memory ticket storage")
+		return repository.NewMemoryRepository(), nil
+	case config.StorageFile:
+		log.Println("Using file ticket storage in", cfg.Dir)
+		repo, err := repository.NewFileRepository(cfg.Dir, cfg.SnapshotEvery)
+		if err != nil {
+			return nil, fmt.Errorf("open file storage: %w", err)
+		}
+		return repo, nil
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q", cfg.Backend)
+	}
+}
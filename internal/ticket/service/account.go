@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	ticket "github.com/talk2sohail/train-ticket-api/internal/common/genproto/ticket"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/types"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ListTicketsForUser lists a passenger's active tickets, oldest purchase first, a page at a time.
+// Pages are keyed on the last ticket returned rather than an offset, so a purchase or
+// cancellation between two calls neither repeats nor skips a ticket.
+func (s *TicketService) ListTicketsForUser(ctx context.Context, req *ticket.ListTicketsForUserRequest) (*ticket.ListTicketsForUserResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pageSize := int(req.GetPageSize())
+	if pageSize <= 0 {
+		pageSize = DefaultTicketPageSize
+	}
+	pageSize = min(pageSize, MaxTicketPageSize)
+
+	// The repository keeps each passenger's receipts in purchase order.
+	receipts := s.repo.GetReceiptsByEmail(req.GetEmail())
+	if req.GetPageToken() != "" {
+		after, err := decodePageToken(req.GetPageToken())
+		if err != nil {
+			log.Printf("[ListTicketsForUser] Invalid page token for email %s: %v", req.GetEmail(), err)
+			return nil, newError(ErrInvalidPageToken, req.GetPageToken())
+		}
+		for len(receipts) > 0 && !types.PurchasedBefore(after, receipts[0]) {
+			receipts = receipts[1:]
+		}
+	}
+
+	var nextPageToken string
+	if len(receipts) > pageSize {
+		receipts = receipts[:pageSize]
+		nextPageToken = encodePageToken(receipts[pageSize-1])
+	}
+	log.Printf("[ListTicketsForUser] Retrieved %d tickets for email %s", len(receipts), req.GetEmail())
+	return &ticket.ListTicketsForUserResponse{
+		Success:       true,
+		Message:       MsgTicketsListed,
+		Tickets:       receipts,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+// encodePageToken names the position of a receipt in a passenger's purchase order.
+func encodePageToken(receipt *ticket.Receipt) string {
+	position := strconv.FormatInt(receipt.GetPurchaseDate().AsTime().UnixNano(), 10) + ":" + receipt.GetTicketId()
+	return base64.RawURLEncoding.EncodeToString([]byte(position))
+}
+
+// decodePageToken returns a stand-in receipt at the position a page token names, to compare with types.PurchasedBefore.
+func decodePageToken(token string) (*ticket.Receipt, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, err
+	}
+	nanos, ticketID, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return nil, strconv.ErrSyntax
+	}
+	n, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &ticket.Receipt{TicketId: ticketID, PurchaseDate: timestamppb.New(time.Unix(0, n))}, nil
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	ticket "github.com/talk2sohail/train-ticket-api/internal/common/genproto/ticket"
+)
+
+func listTickets(t *testing.T, s *TicketService, email string, pageSize int32, pageToken string) *ticket.ListTicketsForUserResponse {
+	t.Helper()
+	resp, err := s.ListTicketsForUser(context.Background(), &ticket.ListTicketsForUserRequest{
+		Email:     email,
+		PageSize:  pageSize,
+		PageToken: pageToken,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error listing tickets: %v", err)
+	}
+	return resp
+}
+
+func ticketIDsOf(receipts []*ticket.Receipt) []string {
+	var ids []string
+	for _, receipt := range receipts {
+		ids = append(ids, receipt.GetTicketId())
+	}
+	return ids
+}
+
+func TestUnit_ListTicketsForUser(t *testing.T) {
+	clock := newFakeClock()
+	s := NewTicketService(WithClock(clock))
+
+	// Five tickets bought a minute apart, with one other passenger's ticket in between.
+	var bought []string
+	for i := 0; i < 5; i++ {
+		bought = append(bought, purchaseOn(t, s, "", "owner@example.com").GetReceipt().GetTicketId())
+		if i == 2 {
+			purchaseOn(t, s, "", "other@example.com")
+		}
+		clock.Advance(time.Minute)
+	}
+
+	t.Run("Pages follow purchase order", func(t *testing.T) {
+		var listed []string
+		token := ""
+		for pages := 0; ; pages++ {
+			if pages > 3 {
+				t.Fatalf("expected the listing to end after 3 pages")
+			}
+			resp := listTickets(t, s, "owner@example.com", 2, token)
+			if len(resp.GetTickets()) > 2 {
+				t.Fatalf("expected at most 2 tickets per page, got %d", len(resp.GetTickets()))
+			}
+			listed = append(listed, ticketIDsOf(resp.GetTickets())...)
+			if token = resp.GetNextPageToken(); token == "" {
+				break
+			}
+		}
+		if !slices.Equal(listed, bought) {
+			t.Errorf("expected tickets %v, got %v", bought, listed)
+		}
+	})
+
+	t.Run("Default page size", func(t *testing.T) {
+		resp := listTickets(t, s, "owner@example.com", 0, "")
+		if len(resp.GetTickets()) != 5 || resp.GetNextPageToken() != "" {
+			t.Errorf("expected all 5 tickets on one page, got %d with token %q", len(resp.GetTickets()), resp.GetNextPageToken())
+		}
+	})
+
+	t.Run("Cancellation between pages skips nothing", func(t *testing.T) {
+		first := listTickets(t, s, "owner@example.com", 2, "")
+		if _, err := s.CancelTicket(context.Background(), first.GetTickets()[1].GetTicketId()); err != nil {
+			t.Fatalf("unexpected error cancelling: %v", err)
+		}
+		second := listTickets(t, s, "owner@example.com", 2, first.GetNextPageToken())
+		if got := ticketIDsOf(second.GetTickets()); !slices.Equal(got, bought[2:4]) {
+			t.Errorf("expected tickets %v, got %v", bought[2:4], got)
+		}
+	})
+
+	t.Run("Unknown email has no tickets", func(t *testing.T) {
+		if resp := listTickets(t, s, "nobody@example.com", 0, ""); len(resp.GetTickets()) != 0 {
+			t.Errorf("expected no tickets, got %v", resp.GetTickets())
+		}
+	})
+
+	t.Run("Invalid page token", func(t *testing.T) {
+		_, err := s.ListTicketsForUser(context.Background(), &ticket.ListTicketsForUserRequest{
+			Email:     "owner@example.com",
+			PageToken: "not a token",
+		})
+		expectError(t, err, ErrInvalidPageToken)
+	})
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"log"
+
+	ticket "github.com/talk2sohail/train-ticket-api/internal/common/genproto/ticket"
+)
+
+// availabilityWatcher is one WatchAvailability stream's view of a journey's seats.
+// Updates are queued on a bounded channel so that a slow consumer never blocks a booking;
+// when the queue overflows the watcher is resynchronised with a fresh snapshot instead.
+type availabilityWatcher struct {
+	journey *ticket.Journey
+	seg     segment
+	free    map[string]bool // Last state sent for each seat, keyed by seat number.
+	updates chan *ticket.AvailabilityUpdate
+	resync  chan struct{} // Signalled when updates were dropped.
+}
+
+// seatStates reports whether each seat of a journey's layout is free over seg, in layout order.
+// This function assumes the caller has already acquired the server's mutex.
+func (s *TicketService) seatStates(journey *ticket.Journey, seg segment) ([]string, map[string]bool) {
+	var seats []string
+	free := make(map[string]bool)
+	for _, coach := range layoutOf(journey).GetCoaches() {
+		for _, seat := range coach.GetSeats() {
+			seats = append(seats, seat.GetSeatNumber())
+			free[seat.GetSeatNumber()] = s.isSeatFree(journey, seat.GetSeatNumber(), seg, "")
+		}
+	}
+	return seats, free
+}
+
+// snapshot resets the watcher's view to the current seat states and returns it as a snapshot update.
+// This function assumes the caller has already acquired the server's mutex.
+func (s *TicketService) snapshot(w *availabilityWatcher) *ticket.AvailabilityUpdate {
+	seats, free := s.seatStates(w.journey, w.seg)
+	w.free = free
+	update := &ticket.AvailabilityUpdate{
+		Kind:      ticket.AvailabilityUpdate_KIND_SNAPSHOT,
+		JourneyId: w.journey.GetJourneyId(),
+	}
+	for _, seat := range seats {
+		if free[seat] {
+			update.FreeSeats = append(update.FreeSeats, seat)
+		} else {
+			update.OccupiedSeats = append(update.OccupiedSeats, seat)
+		}
+	}
+	return update
+}
+
+// notifyAvailability queues a delta for every watcher of a journey whose seats changed state.
+// It never blocks: a watcher whose queue is full is flagged for a resync.
+// This function assumes the caller has already acquired the server's mutex.
+func (s *TicketService) notifyAvailability(journeyID string) {
+	for w := range s.watchers {
+		if w.journey.GetJourneyId() != journeyID {
+			continue
+		}
+		seats, free := s.seatStates(w.journey, w.seg)
+		update := &ticket.AvailabilityUpdate{
+			Kind:      ticket.AvailabilityUpdate_KIND_DELTA,
+			JourneyId: journeyID,
+		}
+		for _, seat := range seats {
+			if free[seat] == w.free[seat] {
+				continue
+			}
+			if free[seat] {
+				update.FreeSeats = append(update.FreeSeats, seat)
+			} else {
+				update.OccupiedSeats = append(update.OccupiedSeats, seat)
+			}
+		}
+		if len(update.FreeSeats) == 0 && len(update.OccupiedSeats) == 0 {
+			continue
+		}
+		w.free = free
+
+		select {
+		case w.updates <- update:
+		default:
+			select {
+			case w.resync <- struct{}{}:
+			default:
+			}
+		}
+	}
+}
+
+// WatchAvailability sends a snapshot of a journey's free and occupied seats, then a delta each time
+// occupancy changes, until ctx is cancelled or send fails. A consumer that falls more than
+// WatchBufferSize updates behind skips the backlog and is sent a fresh snapshot.
+func (s *TicketService) WatchAvailability(ctx context.Context, req *ticket.WatchAvailabilityRequest, send func(*ticket.AvailabilityUpdate) error) error {
+	s.mu.Lock()
+	journey, ok := s.lookupJourney(req.GetJourneyId())
+	if !ok {
+		s.mu.Unlock()
+		log.Printf("[WatchAvailability] %s %s", ErrJourneyNotFound, req.GetJourneyId())
+		return newError(ErrJourneyNotFound, req.GetJourneyId())
+	}
+	seg := wholeRoute(journey)
+	if req.GetFromLocation() != "" || req.GetToLocation() != "" {
+		var err error
+		if seg, err = segmentOf(journey, req.GetFromLocation(), req.GetToLocation()); err != nil {
+			s.mu.Unlock()
+			log.Printf("[WatchAvailability] Failed for journey %s: %v", journey.GetJourneyId(), err)
+			return err
+		}
+	}
+	w := &availabilityWatcher{
+		journey: journey,
+		seg:     seg,
+		updates: make(chan *ticket.AvailabilityUpdate, WatchBufferSize),
+		resync:  make(chan struct{}, 1),
+	}
+	initial := s.snapshot(w)
+	s.watchers[w] = struct{}{}
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		delete(s.watchers, w)
+		s.mu.Unlock()
+	}()
+
+	log.Printf("[WatchAvailability] Watching journey %s", journey.GetJourneyId())
+	if err := send(initial); err != nil {
+		return err
+	}
+	for {
+		var update *ticket.AvailabilityUpdate
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case update = <-w.updates:
+		case <-w.resync:
+			// Drop the stale backlog and start over from the current state.
+			s.mu.Lock()
+			for len(w.updates) > 0 {
+				<-w.updates
+			}
+			update = s.snapshot(w)
+			s.mu.Unlock()
+			log.Printf("[WatchAvailability] Resynchronised slow watcher of journey %s", journey.GetJourneyId())
+		}
+		if err := send(update); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	ticket "github.com/talk2sohail/train-ticket-api/internal/common/genproto/ticket"
+)
+
+// startWatch runs WatchAvailability in the background and delivers its updates on a channel.
+// The stream is cancelled when the test ends.
+func startWatch(t *testing.T, s *TicketService, req *ticket.WatchAvailabilityRequest) (<-chan *ticket.AvailabilityUpdate, <-chan error) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	updates := make(chan *ticket.AvailabilityUpdate, 100)
+	done := make(chan error, 1)
+	go func() {
+		done <- s.WatchAvailability(ctx, req, func(update *ticket.AvailabilityUpdate) error {
+			updates <- update
+			return nil
+		})
+	}()
+	t.Cleanup(cancel)
+	return updates, done
+}
+
+func nextUpdate(t *testing.T, updates <-chan *ticket.AvailabilityUpdate) *ticket.AvailabilityUpdate {
+	t.Helper()
+	select {
+	case update := <-updates:
+		return update
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for an availability update")
+		return nil
+	}
+}
+
+func TestUnit_WatchAvailability(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Snapshot then deltas", func(t *testing.T) {
+		s := NewTicketService()
+		journey := createJourney(t, s, "2025-05-01", time.Date(2025, 5, 1, 8, 0, 0, 0, time.UTC), 2)
+		purchaseOn(t, s, journey.JourneyId, "a@example.com")
+
+		updates, _ := startWatch(t, s, &ticket.WatchAvailabilityRequest{JourneyId: journey.JourneyId})
+		snapshot := nextUpdate(t, updates)
+		if snapshot.Kind != ticket.AvailabilityUpdate_KIND_SNAPSHOT {
+			t.Fatalf("expected a snapshot first, got %v", snapshot)
+		}
+		if !slices.Equal(snapshot.OccupiedSeats, []string{"A1"}) || !slices.Equal(snapshot.FreeSeats, []string{"A2", "B1", "B2"}) {
+			t.Errorf("expected A1 occupied and the rest free, got %v", snapshot)
+		}
+
+		bought := purchaseOn(t, s, journey.JourneyId, "b@example.com").Receipt
+		if delta := nextUpdate(t, updates); delta.Kind != ticket.AvailabilityUpdate_KIND_DELTA || !slices.Equal(delta.OccupiedSeats, []string{"A2"}) || len(delta.FreeSeats) != 0 {
+			t.Errorf("expected A2 to become occupied, got %v", delta)
+		}
+
+		if resp, err := s.ModifyUserSeat(ctx, bought, &ticket.Seat{SeatNumber: "B2"}); err != nil || !resp.Success {
+			t.Fatalf("expected seat change to succeed, got %v, %v", resp, err)
+		}
+		if delta := nextUpdate(t, updates); !slices.Equal(delta.FreeSeats, []string{"A2"}) || !slices.Equal(delta.OccupiedSeats, []string{"B2"}) {
+			t.Errorf("expected A2 freed and B2 occupied, got %v", delta)
+		}
+
+		if resp, err := s.RemoveUser(ctx, "b@example.com"); err != nil || !resp.Success {
+			t.Fatalf("expected removal to succeed, got %v, %v", resp, err)
+		}
+		if delta := nextUpdate(t, updates); !slices.Equal(delta.FreeSeats, []string{"B2"}) || len(delta.OccupiedSeats) != 0 {
+			t.Errorf("expected B2 to be freed, got %v", delta)
+		}
+	})
+
+	t.Run("Watching part of the route", func(t *testing.T) {
+		s := NewTicketService()
+		journey := createRoute(t, s, 1, "London", "Ashford", "Paris")
+		updates, _ := startWatch(t, s, &ticket.WatchAvailabilityRequest{JourneyId: journey.JourneyId, FromLocation: "Ashford", ToLocation: "Paris"})
+		nextUpdate(t, updates)
+
+		// A1 stays free from Ashford, so only the second purchase changes what this watcher sees.
+		purchaseLeg(t, s, journey.JourneyId, "London", "Ashford", "a@example.com")
+		purchaseLeg(t, s, journey.JourneyId, "Ashford", "Paris", "b@example.com")
+		if delta := nextUpdate(t, updates); !slices.Equal(delta.OccupiedSeats, []string{"A1"}) {
+			t.Errorf("expected only the Ashford boarding to occupy A1, got %v", delta)
+		}
+	})
+
+	t.Run("Slow consumer is resynchronised", func(t *testing.T) {
+		s := NewTicketService()
+		journey := createJourney(t, s, "2025-05-01", time.Date(2025, 5, 1, 8, 0, 0, 0, time.UTC), 20)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		gate := make(chan struct{})
+		received := make(chan *ticket.AvailabilityUpdate, 100)
+		go s.WatchAvailability(ctx, &ticket.WatchAvailabilityRequest{JourneyId: journey.JourneyId}, func(update *ticket.AvailabilityUpdate) error {
+			received <- update
+			<-gate // The consumer stalls after the snapshot until the test releases it.
+			return nil
+		})
+		nextUpdate(t, received)
+
+		// Bookings must not wait for the stalled consumer.
+		purchases := 2*WatchBufferSize + 2
+		for i := 0; i < purchases; i++ {
+			if res := purchaseOn(t, s, journey.JourneyId, fmt.Sprintf("user%d@example.com", i)); !res.Success {
+				t.Fatalf("expected purchase %d to succeed, got %v", i, res)
+			}
+		}
+		close(gate)
+
+		// The backlog is capped, and the consumer ends up with a snapshot of the final state.
+		for count := 0; ; count++ {
+			update := nextUpdate(t, received)
+			if update.Kind == ticket.AvailabilityUpdate_KIND_SNAPSHOT {
+				if len(update.OccupiedSeats) != purchases {
+					t.Errorf("expected %d occupied seats in the resync snapshot, got %d", purchases, len(update.OccupiedSeats))
+				}
+				break
+			}
+			if count > WatchBufferSize+1 {
+				t.Fatalf("expected a resync snapshot after at most %d deltas", WatchBufferSize)
+			}
+		}
+	})
+
+	t.Run("Unknown journey", func(t *testing.T) {
+		s := NewTicketService()
+		err := s.WatchAvailability(ctx, &ticket.WatchAvailabilityRequest{JourneyId: "missing"}, func(*ticket.AvailabilityUpdate) error { return nil })
+		if err == nil {
+			t.Errorf("expected an error for an unknown journey")
+		}
+	})
+
+	t.Run("Stream ends when cancelled or send fails", func(t *testing.T) {
+		s := NewTicketService()
+		sendErr := errors.New("client went away")
+		if err := s.WatchAvailability(ctx, &ticket.WatchAvailabilityRequest{}, func(*ticket.AvailabilityUpdate) error { return sendErr }); !errors.Is(err, sendErr) {
+			t.Errorf("expected %v, got %v", sendErr, err)
+		}
+
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+		if err := s.WatchAvailability(cancelled, &ticket.WatchAvailabilityRequest{}, func(*ticket.AvailabilityUpdate) error { return nil }); !errors.Is(err, context.Canceled) {
+			t.Errorf("expected %v, got %v", context.Canceled, err)
+		}
+		if n := len(s.watchers); n != 0 {
+			t.Errorf("expected watchers to be removed, got %d", n)
+		}
+	})
+}
@@ -0,0 +1,19 @@
+package service
+
+import "time"
+
+// Clock tells the service the current time. Tests substitute a fake clock to control hold expiry.
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+// WithClock makes the service read the current time from clock instead of the system clock.
+func WithClock(clock Clock) Option {
+	return func(s *TicketService) {
+		s.clock = clock
+	}
+}
@@ -1,18 +1,76 @@
 package service
 
+import "time"
+
 const (
 	// MaxSeatsPerSection defines the maximum number of seats in each section.
 	MaxSeatsPerSection = 5
 
+	// DefaultHoldTTL is how long HoldSeat reserves a seat when no TTL is configured.
+	DefaultHoldTTL = 10 * time.Minute
+
+	// DefaultTicketPageSize is how many tickets ListTicketsForUser returns when no page size is given.
+	DefaultTicketPageSize = 20
+
+	// MaxTicketPageSize caps the page size ListTicketsForUser accepts; larger requests are reduced to it.
+	MaxTicketPageSize = 100
+
+	// DefaultIdempotencyWindow is how long the response to an idempotency key is replayed when no window is configured.
+	DefaultIdempotencyWindow = 24 * time.Hour
+
+	// MaxIdempotencyKeyLength caps the length of an idempotency key.
+	MaxIdempotencyKeyLength = 255
+
+	// WatchBufferSize is how many availability updates a watcher may fall behind by before it is resynchronised.
+	WatchBufferSize = 16
+
 	// useful message
-	MsgTicketPurchaseSuccess = "Ticket purchased successfully"
-	MsgUsersRetrieved        = "Users retrieved successfully"
-	MsgUserRemovedSuccess    = "User removed successfully"
-	MsgSeatUpdatedSuccess    = "Seat updated successfully"
+	MsgTicketPurchaseSuccess  = "Ticket purchased successfully"
+	MsgGroupPurchaseSuccess   = "Group tickets purchased successfully"
+	MsgUsersRetrieved         = "Users retrieved successfully"
+	MsgUserRemovedSuccess     = "User removed successfully"
+	MsgTicketCancelled        = "Ticket cancelled successfully"
+	MsgTicketsListed          = "Tickets retrieved successfully"
+	MsgSeatUpdatedSuccess     = "Seat updated successfully"
+	MsgTicketHistoryRetrieved = "Ticket history retrieved successfully"
+	MsgSeatOccupantRetrieved  = "Seat occupant retrieved successfully"
+	MsgJourneyCreated         = "Journey created successfully"
+	MsgJourneysRetrieved      = "Journeys retrieved successfully"
+	MsgSeatHeld               = "Seat held successfully"
+	MsgHoldConfirmed          = "Hold confirmed successfully"
+	MsgWaitlistJoined         = "Joined the waitlist successfully"
+	MsgWaitlistStatus         = "Waitlist status retrieved successfully"
+	MsgFareQuoted             = "Fare quoted successfully"
+	MsgRefundRetrieved        = "Refund retrieved successfully"
 
 	// Define named errors
-	ErrNoAvailableSeats = "no available seats on the train"
-	ErrReceiptNotFound  = "receipt not found"
-	ErrUserNotFound     = "user not found"
-	ErrSeatOccupied     = "requested seat is already occupied"
+	ErrNoAvailableSeats       = "no available seats on the train"
+	ErrGroupNotSeatedTogether = "not enough free seats in one coach to seat the group together"
+	ErrReceiptNotFound        = "receipt not found"
+	ErrUserNotFound           = "user not found"
+	ErrSeatOccupied           = "requested seat is already occupied"
+	ErrTicketHistoryNotFound  = "no history found"
+	ErrSeatNotOccupied        = "seat was not occupied at the requested time"
+	ErrStopNotOnRoute         = "stop is not on the journey's route"
+	ErrInvalidSegment         = "destination must come after origin on the journey's route"
+	ErrJourneyNotFound        = "journey not found"
+	ErrLayoutNotFound         = "train layout not found"
+	ErrCoachNotFound          = "coach not found in the train layout"
+	ErrSeatNotFound           = "seat not found in the train layout"
+	ErrHoldNotFound           = "hold not found"
+	ErrHoldExpired            = "hold has expired"
+	ErrSeatsAvailable         = "seats are still available; purchase a ticket instead"
+	ErrWaitlistNotFound       = "waitlist entry not found"
+	ErrEmailAmbiguous         = "email holds more than one ticket; identify the ticket by its ID"
+	ErrInvalidPageToken       = "page token is invalid"
+	ErrIdempotencyKeyReused   = "idempotency key was already used for a different request"
+	ErrIdempotencyKeyInvalid  = "idempotency key is too long"
+	ErrTicketQuotaExceeded    = "passenger already holds the maximum number of active tickets"
+	ErrFareMismatch           = "price paid does not match the fare"
+	ErrFaresNotConfigured     = "fares are not configured on this server"
+	ErrCurrencyNotSupported   = "currency cannot be converted"
+	ErrPaymentDeclined        = "payment was declined"
+	ErrPaymentFailed          = "payment could not be processed"
+	ErrPriceTooLarge          = "total price is too large to charge"
+	ErrRefundNotFound         = "refund not found"
 )
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"errors"
+	"time"
+)
+
+// Kind classifies why a request failed, so that each transport can report it in its own terms.
+type Kind int
+
+const (
+	// KindInternal is a failure of the service itself, such as the ledger failing to store an event.
+	KindInternal Kind = iota
+	// KindInvalidArgument is a request that cannot be served as asked, such as a stop not on the route.
+	KindInvalidArgument
+	// KindNotFound is a request for a ticket, journey, seat or other resource that does not exist.
+	KindNotFound
+	// KindFailedPrecondition is a request the current state does not allow, such as taking an occupied seat.
+	KindFailedPrecondition
+	// KindResourceExhausted is a request for seats the journey has run out of, or for more
+	// tickets than the passenger's quota allows.
+	KindResourceExhausted
+	// KindUnavailable is a request a dependency, such as the payment processor, failed to serve; it may succeed when retried.
+	KindUnavailable
+)
+
+// Reasons identify each named error with a stable value clients can branch on.
+const (
+	ReasonNoAvailableSeats       = "NO_AVAILABLE_SEATS"
+	ReasonGroupNotSeatedTogether = "GROUP_NOT_SEATED_TOGETHER"
+	ReasonReceiptNotFound        = "RECEIPT_NOT_FOUND"
+	ReasonUserNotFound           = "USER_NOT_FOUND"
+	ReasonSeatOccupied           = "SEAT_OCCUPIED"
+	ReasonTicketHistoryNotFound  = "TICKET_HISTORY_NOT_FOUND"
+	ReasonSeatNotOccupied        = "SEAT_NOT_OCCUPIED"
+	ReasonStopNotOnRoute         = "STOP_NOT_ON_ROUTE"
+	ReasonInvalidSegment         = "INVALID_SEGMENT"
+	ReasonJourneyNotFound        = "JOURNEY_NOT_FOUND"
+	ReasonLayoutNotFound         = "LAYOUT_NOT_FOUND"
+	ReasonCoachNotFound          = "COACH_NOT_FOUND"
+	ReasonSeatNotFound           = "SEAT_NOT_FOUND"
+	ReasonHoldNotFound           = "HOLD_NOT_FOUND"
+	ReasonHoldExpired            = "HOLD_EXPIRED"
+	ReasonSeatsAvailable         = "SEATS_AVAILABLE"
+	ReasonWaitlistNotFound       = "WAITLIST_NOT_FOUND"
+	ReasonEmailAmbiguous         = "EMAIL_AMBIGUOUS"
+	ReasonInvalidPageToken       = "INVALID_PAGE_TOKEN"
+	ReasonIdempotencyKeyReused   = "IDEMPOTENCY_KEY_REUSED"
+	ReasonIdempotencyKeyInvalid  = "INVALID_IDEMPOTENCY_KEY"
+	ReasonTicketQuotaExceeded    = "TICKET_QUOTA_EXCEEDED"
+	ReasonFareMismatch           = "FARE_MISMATCH"
+	ReasonFaresNotConfigured     = "FARES_NOT_CONFIGURED"
+	ReasonCurrencyNotSupported   = "CURRENCY_NOT_SUPPORTED"
+	ReasonPaymentDeclined        = "PAYMENT_DECLINED"
+	ReasonPaymentFailed          = "PAYMENT_FAILED"
+	ReasonPriceTooLarge          = "PRICE_TOO_LARGE"
+	ReasonRefundNotFound         = "REFUND_NOT_FOUND"
+)
+
+// causes classifies each named error.
+var causes = map[string]struct {
+	kind   Kind
+	reason string
+}{
+	ErrNoAvailableSeats:       {KindResourceExhausted, ReasonNoAvailableSeats},
+	ErrGroupNotSeatedTogether: {KindResourceExhausted, ReasonGroupNotSeatedTogether},
+	ErrReceiptNotFound:        {KindNotFound, ReasonReceiptNotFound},
+	ErrUserNotFound:           {KindNotFound, ReasonUserNotFound},
+	ErrSeatOccupied:           {KindFailedPrecondition, ReasonSeatOccupied},
+	ErrTicketHistoryNotFound:  {KindNotFound, ReasonTicketHistoryNotFound},
+	ErrSeatNotOccupied:        {KindNotFound, ReasonSeatNotOccupied},
+	ErrStopNotOnRoute:         {KindInvalidArgument, ReasonStopNotOnRoute},
+	ErrInvalidSegment:         {KindInvalidArgument, ReasonInvalidSegment},
+	ErrJourneyNotFound:        {KindNotFound, ReasonJourneyNotFound},
+	ErrLayoutNotFound:         {KindNotFound, ReasonLayoutNotFound},
+	ErrCoachNotFound:          {KindNotFound, ReasonCoachNotFound},
+	ErrSeatNotFound:           {KindNotFound, ReasonSeatNotFound},
+	ErrHoldNotFound:           {KindNotFound, ReasonHoldNotFound},
+	ErrHoldExpired:            {KindFailedPrecondition, ReasonHoldExpired},
+	ErrSeatsAvailable:         {KindFailedPrecondition, ReasonSeatsAvailable},
+	ErrWaitlistNotFound:       {KindNotFound, ReasonWaitlistNotFound},
+	ErrEmailAmbiguous:         {KindFailedPrecondition, ReasonEmailAmbiguous},
+	ErrInvalidPageToken:       {KindInvalidArgument, ReasonInvalidPageToken},
+	ErrIdempotencyKeyReused:   {KindFailedPrecondition, ReasonIdempotencyKeyReused},
+	ErrIdempotencyKeyInvalid:  {KindInvalidArgument, ReasonIdempotencyKeyInvalid},
+	ErrTicketQuotaExceeded:    {KindResourceExhausted, ReasonTicketQuotaExceeded},
+	ErrFareMismatch:           {KindFailedPrecondition, ReasonFareMismatch},
+	ErrFaresNotConfigured:     {KindFailedPrecondition, ReasonFaresNotConfigured},
+	ErrCurrencyNotSupported:   {KindInvalidArgument, ReasonCurrencyNotSupported},
+	ErrPaymentDeclined:        {KindFailedPrecondition, ReasonPaymentDeclined},
+	ErrPaymentFailed:          {KindUnavailable, ReasonPaymentFailed},
+	ErrPriceTooLarge:          {KindInvalidArgument, ReasonPriceTooLarge},
+	ErrRefundNotFound:         {KindNotFound, ReasonRefundNotFound},
+}
+
+// Error is a failure the service reports to its caller in place of a response.
+type Error struct {
+	Kind    Kind
+	Reason  string // One of the Reason values.
+	Message string // One of the named errors.
+	Subject string // The identifier the failure concerns, such as a ticket ID or stop, the fare due for ErrFareMismatch in the currency paid, a currency code, or the decline code for ErrPaymentDeclined; may be empty.
+	Seat    string // The seat in conflict, for ErrSeatOccupied.
+	// RetryAfter is how long until the request may succeed when retried, for ErrTicketQuotaExceeded
+	// while one of the passenger's holds is about to expire; zero when unknown.
+	RetryAfter time.Duration
+}
+
+// newError returns the Error for a named error about subject.
+func newError(message, subject string) *Error {
+	cause := causes[message]
+	return &Error{Kind: cause.kind, Reason: cause.reason, Message: message, Subject: subject}
+}
+
+func (e *Error) Error() string {
+	if e.Subject == "" {
+		return e.Message
+	}
+	return e.Message + ": " + e.Subject
+}
+
+// AsError returns the Error in err's chain, if there is one.
+func AsError(err error) (*Error, bool) {
+	var e *Error
+	ok := errors.As(err, &e)
+	return e, ok
+}
+
+// KindOf returns the Kind of err; errors the service does not name are internal.
+func KindOf(err error) Kind {
+	if e, ok := AsError(err); ok {
+		return e.Kind
+	}
+	return KindInternal
+}
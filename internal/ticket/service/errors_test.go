@@ -0,0 +1,49 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+)
+
+// expectError fails the test unless err is the service Error for the named error message.
+func expectError(t *testing.T, err error, message string) *Error {
+	t.Helper()
+	e, ok := AsError(err)
+	if !ok || e.Message != message {
+		t.Fatalf("expected error %q, got %v", message, err)
+	}
+	return e
+}
+
+func TestUnit_Error(t *testing.T) {
+	t.Run("Every named error is classified", func(t *testing.T) {
+		for message, cause := range causes {
+			e := newError(message, "")
+			if e.Reason == "" || e.Reason != cause.reason || e.Kind == KindInternal {
+				t.Errorf("expected %q to have a reason and a kind, got %+v", message, e)
+			}
+		}
+	})
+
+	t.Run("Message carries the subject", func(t *testing.T) {
+		if got := newError(ErrReceiptNotFound, "t1").Error(); got != ErrReceiptNotFound+": t1" {
+			t.Errorf("expected the subject after the message, got %q", got)
+		}
+		if got := newError(ErrInvalidSegment, "").Error(); got != ErrInvalidSegment {
+			t.Errorf("expected the bare message, got %q", got)
+		}
+	})
+
+	t.Run("Found through wrapping", func(t *testing.T) {
+		err := fmt.Errorf("purchase: %w", newError(ErrNoAvailableSeats, ""))
+		if e, ok := AsError(err); !ok || e.Reason != ReasonNoAvailableSeats {
+			t.Errorf("expected the wrapped Error, got %v", err)
+		}
+		if KindOf(err) != KindResourceExhausted {
+			t.Errorf("expected %v, got %v", KindResourceExhausted, KindOf(err))
+		}
+		if KindOf(fmt.Errorf("disk full")) != KindInternal {
+			t.Errorf("expected unnamed errors to be internal")
+		}
+	})
+}
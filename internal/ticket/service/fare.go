@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"log"
+
+	ticket "github.com/talk2sohail/train-ticket-api/internal/common/genproto/ticket"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/fare"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/layout"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/money"
+)
+
+// WithFareTable prices tickets with table. Purchases must then pay the quoted fare;
+// without a fare table the price_paid of each request is taken as given.
+func WithFareTable(table *fare.Table) Option {
+	return WithFareSource(func() *fare.Table { return table })
+}
+
+// WithFareSource prices each ticket with the table source returns at the time,
+// so the fare table can be swapped while the service runs.
+func WithFareSource(source func() *fare.Table) Option {
+	return func(s *TicketService) {
+		s.fares = source
+	}
+}
+
+// quoteFare prices a trip over seg of a journey booked now, or returns nil without a fare table.
+// seat is the seat being sold, or nil when none was allocated yet.
+// This function assumes the caller has already acquired the server's mutex.
+func (s *TicketService) quoteFare(journey *ticket.Journey, seg segment, from, to string, class ticket.TravelClass, passenger ticket.PassengerType, seat *ticket.Seat) *ticket.Fare {
+	if s.fares == nil {
+		return nil
+	}
+	return s.fares().Quote(fare.Trip{
+		From:        from,
+		To:          to,
+		ServiceDate: journey.GetServiceDate(),
+		Location:    journeyLocation(journey),
+		Class:       class,
+		Passenger:   passenger,
+		Demand:      s.demandFor(journey, seg, seat),
+	}, s.clock.Now())
+}
+
+// demandFor describes how full a journey is over seg, when it leaves and the coach of seat, if any.
+// This function assumes the caller has already acquired the server's mutex.
+func (s *TicketService) demandFor(journey *ticket.Journey, seg segment, seat *ticket.Seat) fare.Demand {
+	seats, free := s.seatStates(journey, seg)
+	d := fare.Demand{SeatsTotal: len(seats)}
+	for _, number := range seats {
+		if !free[number] {
+			d.SeatsSold++
+		}
+	}
+	if journey.GetDepartureTime() != nil {
+		d.Departure = journey.GetDepartureTime().AsTime()
+	}
+	if seat != nil {
+		d.Coach = layout.CoachOf(seat)
+	}
+	return d
+}
+
+// WithRates converts prices paid in other currencies than the fare table's at rates.
+// Without rates every price must be paid in the fare table's currency.
+func WithRates(rates *money.Rates) Option {
+	return func(s *TicketService) {
+		s.rates = rates
+	}
+}
+
+// legacyPrice reads a price_paid field, which holds USD.
+func legacyPrice(pricePaid float64) money.Money {
+	m, _ := money.FromMajor(pricePaid, money.USD) // USD is always supported.
+	return m
+}
+
+// priceOf reads the price of a request: price when it is set, or else the legacy price_paid.
+func priceOf(price *ticket.Money, pricePaid float64) (money.Money, error) {
+	if price == nil {
+		return legacyPrice(pricePaid), nil
+	}
+	m, err := money.FromProto(price)
+	if err != nil {
+		return money.Money{}, newError(ErrCurrencyNotSupported, price.GetCurrencyCode())
+	}
+	return m, nil
+}
+
+// checkPrice refuses a price paid that is not the quoted fare in the currency it was paid in;
+// without a quote every price is accepted. who names the passengers paying in the log.
+func (s *TicketService) checkPrice(method, who string, quoted *ticket.Fare, paid money.Money) error {
+	if quoted == nil {
+		return nil
+	}
+	due, err := s.fareIn(quoted, paid.Currency)
+	if err != nil {
+		log.Printf("[%s] Rejected %s: %v", method, who, err)
+		return err
+	}
+	if paid != due {
+		log.Printf("[%s] Rejected %s: %s (paid %s, fare %s)", method, who, ErrFareMismatch, paid, due)
+		return newError(ErrFareMismatch, due.Decimal())
+	}
+	return nil
+}
+
+// fareIn converts the price of a fare to currency, or fails with ErrCurrencyNotSupported
+// when there is no rate for it.
+func (s *TicketService) fareIn(quoted *ticket.Fare, currency string) (money.Money, error) {
+	price, err := money.FromProto(quoted.GetPrice())
+	if err != nil {
+		return money.Money{}, err
+	}
+	converted, err := s.rates.Convert(price, currency)
+	if err != nil {
+		return money.Money{}, newError(ErrCurrencyNotSupported, currency)
+	}
+	return converted, nil
+}
+
+// QuoteFare prices a trip without booking it. The fare is what PurchaseTicket charges for the same trip today,
+// in the seat it would allocate; on a sold-out journey it is what the waitlist charges.
+func (s *TicketService) QuoteFare(ctx context.Context, req *ticket.QuoteFareRequest) (*ticket.QuoteFareResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.fares == nil {
+		log.Printf("[QuoteFare] %s", ErrFaresNotConfigured)
+		return nil, newError(ErrFaresNotConfigured, "")
+	}
+	journey, ok := s.lookupJourney(req.GetJourneyId())
+	if !ok {
+		log.Printf("[QuoteFare] %s %s", ErrJourneyNotFound, req.GetJourneyId())
+		return nil, newError(ErrJourneyNotFound, req.GetJourneyId())
+	}
+	seg, err := segmentOf(journey, req.GetFromLocation(), req.GetToLocation())
+	if err != nil {
+		log.Printf("[QuoteFare] %v", err)
+		return nil, err
+	}
+	// A sold-out journey has no seat to offer and is quoted without one.
+	seat, _, _, _ := s.findPreferredSeat(journey, seg, req.GetPreferences())
+
+	quoted := s.quoteFare(journey, seg, req.GetFromLocation(), req.GetToLocation(), req.GetTravelClass(), req.GetPassengerType(), seat)
+	currency := req.GetCurrencyCode()
+	if currency == "" {
+		currency = quoted.GetPrice().GetCurrencyCode()
+	}
+	price, err := s.fareIn(quoted, currency)
+	if err != nil {
+		log.Printf("[QuoteFare] %v %s", err, currency)
+		return nil, err
+	}
+	log.Printf("[QuoteFare] Quoted %s for %s -> %s on Journey=%s", price, req.GetFromLocation(), req.GetToLocation(), journey.GetJourneyId())
+	return &ticket.QuoteFareResponse{
+		Success: true,
+		Message: MsgFareQuoted,
+		Fare:    quoted,
+		Price:   price.Proto(),
+	}, nil
+}
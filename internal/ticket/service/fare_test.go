@@ -0,0 +1,456 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	ticket "github.com/talk2sohail/train-ticket-api/internal/common/genproto/ticket"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/fare"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/money"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// newFareService returns a service pricing London to Lille at 15, any other trip at 20,
+// first class at 1.5 times, children at half price and bookings a week ahead at 20% off.
+func newFareService(t *testing.T, opts ...Option) *TicketService {
+	t.Helper()
+	table, err := fare.Build(fare.Config{
+		BaseFare:   "20",
+		Routes:     []fare.RouteConfig{{From: "London", To: "Lille", Fare: "15"}},
+		Classes:    map[string]json.Number{fare.ClassFirst: "1.5"},
+		Passengers: map[string]json.Number{fare.PassengerChild: "0.5"},
+		Advance:    []fare.AdvanceConfig{{MinDays: 7, Multiplier: "0.8"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error building the fare table: %v", err)
+	}
+	return NewTicketService(append(opts, WithFareTable(table))...)
+}
+
+func TestUnit_QuoteFare(t *testing.T) {
+	ctx := context.Background()
+	s := newFareService(t, WithClock(newFakeClock()))
+	route := createRoute(t, s, 2, "London", "Lille", "Paris")
+	ahead, err := s.CreateJourney(ctx, &ticket.CreateJourneyRequest{
+		ServiceDate:   "2025-05-10",
+		DepartureTime: timestamppb.New(time.Date(2025, 5, 10, 8, 0, 0, 0, time.UTC)),
+		Origin:        "London",
+		Destination:   "Paris",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error creating a journey: %v", err)
+	}
+	// At 9:00 UTC on May 1 it is still April 30 in Honolulu, a week before the journey.
+	honolulu, err := s.CreateJourney(ctx, &ticket.CreateJourneyRequest{
+		ServiceDate:   "2025-05-07",
+		DepartureTime: timestamppb.New(time.Date(2025, 5, 7, 18, 0, 0, 0, time.UTC)),
+		Origin:        "London",
+		Destination:   "Paris",
+		TimeZone:      "Pacific/Honolulu",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error creating a journey: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		req  *ticket.QuoteFareRequest
+		want float64
+	}{
+		{"Default journey", &ticket.QuoteFareRequest{FromLocation: "London", ToLocation: "Paris"}, 20},
+		{"Route fare", &ticket.QuoteFareRequest{FromLocation: "London", ToLocation: "Lille", JourneyId: route.GetJourneyId()}, 15},
+		{"First class child", &ticket.QuoteFareRequest{
+			FromLocation:  "London",
+			ToLocation:    "Lille",
+			JourneyId:     route.GetJourneyId(),
+			TravelClass:   ticket.TravelClass_TRAVEL_CLASS_FIRST,
+			PassengerType: ticket.PassengerType_PASSENGER_TYPE_CHILD,
+		}, 11.25},
+		{"Booked ahead", &ticket.QuoteFareRequest{FromLocation: "London", ToLocation: "Paris", JourneyId: ahead.GetJourney().GetJourneyId()}, 16},
+		{"Booked ahead in the journey's time zone", &ticket.QuoteFareRequest{FromLocation: "London", ToLocation: "Paris", JourneyId: honolulu.GetJourney().GetJourneyId()}, 16},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := s.QuoteFare(ctx, tt.req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !resp.GetSuccess() || resp.GetFare().GetAmount() != tt.want {
+				t.Errorf("expected a fare of %.2f, got %v", tt.want, resp.GetFare())
+			}
+		})
+	}
+
+	t.Run("Stop not on the route", func(t *testing.T) {
+		_, err := s.QuoteFare(ctx, &ticket.QuoteFareRequest{FromLocation: "London", ToLocation: "Brussels", JourneyId: route.GetJourneyId()})
+		expectError(t, err, ErrStopNotOnRoute)
+	})
+
+	t.Run("Unknown journey", func(t *testing.T) {
+		_, err := s.QuoteFare(ctx, &ticket.QuoteFareRequest{FromLocation: "London", ToLocation: "Paris", JourneyId: "missing"})
+		expectError(t, err, ErrJourneyNotFound)
+	})
+
+	t.Run("No fare table", func(t *testing.T) {
+		_, err := NewTicketService().QuoteFare(ctx, &ticket.QuoteFareRequest{FromLocation: "London", ToLocation: "Paris"})
+		expectError(t, err, ErrFaresNotConfigured)
+	})
+}
+
+func TestUnit_FareCharged(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Purchase", func(t *testing.T) {
+		s := newFareService(t)
+		resp := purchaseOn(t, s, "", "alice@example.com")
+		if resp.GetReceipt().GetFare().GetAmount() != 20 {
+			t.Errorf("expected the receipt to record the fare, got %v", resp.GetReceipt().GetFare())
+		}
+
+		_, err := s.PurchaseTicket(ctx, &ticket.PurchaseTicketRequest{
+			FromLocation: "London",
+			ToLocation:   "Paris",
+			User:         &ticket.User{Email: "bob@example.com"},
+			PricePaid:    20,
+			TravelClass:  ticket.TravelClass_TRAVEL_CLASS_FIRST,
+		})
+		e := expectError(t, err, ErrFareMismatch)
+		if e.Kind != KindFailedPrecondition || e.Subject != "30.00" {
+			t.Errorf("expected a failed precondition naming the fare of 30.00, got %+v", e)
+		}
+		if tickets := s.repo.GetReceiptsByEmail("bob@example.com"); len(tickets) != 0 {
+			t.Errorf("expected no ticket at the wrong price, got %d", len(tickets))
+		}
+	})
+
+	t.Run("Hold", func(t *testing.T) {
+		s := newFareService(t, WithClock(newFakeClock()))
+		resp, err := s.HoldSeat(ctx, &ticket.HoldSeatRequest{
+			FromLocation:  "London",
+			ToLocation:    "Paris",
+			User:          &ticket.User{Email: "alice@example.com"},
+			PassengerType: ticket.PassengerType_PASSENGER_TYPE_CHILD,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error holding a seat: %v", err)
+		}
+		if resp.GetFare().GetAmount() != 10 {
+			t.Fatalf("expected the hold to quote 10.00, got %v", resp.GetFare())
+		}
+		_, err = s.ConfirmHold(ctx, &ticket.ConfirmHoldRequest{HoldId: resp.GetHoldId(), PricePaid: 20})
+		expectError(t, err, ErrFareMismatch)
+		confirmed, err := s.ConfirmHold(ctx, &ticket.ConfirmHoldRequest{HoldId: resp.GetHoldId(), PricePaid: 10})
+		if err != nil {
+			t.Fatalf("unexpected error confirming at the quoted fare: %v", err)
+		}
+		if confirmed.GetReceipt().GetFare().GetPassengerType() != ticket.PassengerType_PASSENGER_TYPE_CHILD {
+			t.Errorf("expected the receipt to record the child fare, got %v", confirmed.GetReceipt().GetFare())
+		}
+	})
+
+	t.Run("Group", func(t *testing.T) {
+		s := newFareService(t)
+		req := groupRequest(2, false)
+		req.PassengerType = ticket.PassengerType_PASSENGER_TYPE_CHILD
+		_, err := s.PurchaseGroupTicket(ctx, req)
+		expectError(t, err, ErrFareMismatch)
+
+		req.PricePaid = 10
+		resp, err := s.PurchaseGroupTicket(ctx, req)
+		if err != nil {
+			t.Fatalf("unexpected error booking the group: %v", err)
+		}
+		for _, receipt := range resp.GetReceipts() {
+			if receipt.GetFare().GetAmount() != 10 {
+				t.Errorf("expected every receipt to record the fare, got %v", receipt.GetFare())
+			}
+		}
+	})
+
+	t.Run("Waitlist", func(t *testing.T) {
+		s := newFareService(t)
+		journey := createRoute(t, s, 1, "London", "Paris")
+		first := purchaseOn(t, s, journey.GetJourneyId(), "alice@example.com").GetReceipt()
+		purchaseOn(t, s, journey.GetJourneyId(), "bob@example.com")
+
+		_, err := s.JoinWaitlist(ctx, &ticket.JoinWaitlistRequest{
+			FromLocation: "London",
+			ToLocation:   "Paris",
+			User:         &ticket.User{Email: "carol@example.com"},
+			PricePaid:    25,
+			JourneyId:    journey.GetJourneyId(),
+		})
+		expectError(t, err, ErrFareMismatch)
+		entry := joinWaitlist(t, s, journey.GetJourneyId(), "London", "Paris", "carol@example.com")
+		if entry.GetEntry().GetFare().GetAmount() != 20 {
+			t.Fatalf("expected the entry to record the fare, got %v", entry.GetEntry().GetFare())
+		}
+
+		if _, err := s.CancelTicket(ctx, first.GetTicketId()); err != nil {
+			t.Fatalf("unexpected error cancelling: %v", err)
+		}
+		status := waitlistStatus(t, s, entry.GetEntry().GetWaitlistId())
+		if status.GetReceipt().GetFare().GetAmount() != 20 {
+			t.Errorf("expected the promoted ticket to carry the fare, got %v", status.GetReceipt().GetFare())
+		}
+	})
+
+	t.Run("Prices taken as given without a fare table", func(t *testing.T) {
+		s := NewTicketService()
+		resp, err := s.PurchaseTicket(ctx, &ticket.PurchaseTicketRequest{
+			FromLocation: "London",
+			ToLocation:   "Paris",
+			User:         &ticket.User{Email: "alice@example.com"},
+			PricePaid:    42,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.GetReceipt().GetPricePaid() != 42 || resp.GetReceipt().GetFare() != nil {
+			t.Errorf("expected the price to be taken as given, got %v", resp.GetReceipt())
+		}
+	})
+}
+
+func TestUnit_DynamicPricing(t *testing.T) {
+	ctx := context.Background()
+	build := func(pricing fare.PricingConfig) *fare.Table {
+		t.Helper()
+		table, err := fare.Build(fare.Config{BaseFare: "20", Pricing: pricing})
+		if err != nil {
+			t.Fatalf("unexpected error building the fare table: %v", err)
+		}
+		return table
+	}
+	purchase := func(s *TicketService, journeyID, email string, price float64, prefs *ticket.SeatPreferences) (*ticket.PurchaseTicketResponse, error) {
+		return s.PurchaseTicket(ctx, &ticket.PurchaseTicketRequest{
+			FromLocation: "London",
+			ToLocation:   "Paris",
+			User:         &ticket.User{Email: email},
+			PricePaid:    price,
+			JourneyId:    journeyID,
+			Preferences:  prefs,
+		})
+	}
+
+	t.Run("Occupancy tiers", func(t *testing.T) {
+		s := NewTicketService(WithFareTable(build(fare.PricingConfig{
+			Strategy:  fare.StrategyOccupancy,
+			Occupancy: []fare.OccupancyTier{{MinPercentSold: 50, Multiplier: "1.25"}},
+		})))
+		journey := createRoute(t, s, 2, "London", "Paris")
+		first := purchaseOn(t, s, journey.GetJourneyId(), "alice@example.com").GetReceipt()
+		if first.GetFare().GetPricingStrategy() != fare.StrategyOccupancy || first.GetFare().GetPricingTier() != "" {
+			t.Errorf("expected the first ticket to be priced by the occupancy strategy below every tier, got %v", first.GetFare())
+		}
+		purchaseOn(t, s, journey.GetJourneyId(), "bob@example.com")
+
+		// Half of the four seats are sold.
+		_, err := purchase(s, journey.GetJourneyId(), "carol@example.com", 20, nil)
+		expectError(t, err, ErrFareMismatch)
+		resp, err := purchase(s, journey.GetJourneyId(), "carol@example.com", 25, nil)
+		if err != nil {
+			t.Fatalf("unexpected error purchasing at the tier's fare: %v", err)
+		}
+		if f := resp.GetReceipt().GetFare(); f.GetPricingTier() != "50%" || f.GetDemandMultiplier() != 1.25 {
+			t.Errorf("expected the receipt to record the 50%% tier, got %v", f)
+		}
+	})
+
+	t.Run("Section multipliers", func(t *testing.T) {
+		s := NewTicketService(WithFareTable(build(fare.PricingConfig{
+			Strategy: fare.StrategySection,
+			Sections: map[string]json.Number{"B": "1.5"},
+		})))
+		quote, err := s.QuoteFare(ctx, &ticket.QuoteFareRequest{FromLocation: "London", ToLocation: "Paris", Preferences: &ticket.SeatPreferences{Coach: "B"}})
+		if err != nil {
+			t.Fatalf("unexpected error quoting: %v", err)
+		}
+		if quote.GetFare().GetAmount() != 30 || quote.GetFare().GetCoach() != "B" {
+			t.Errorf("expected a seat in coach B to be quoted 30.00, got %v", quote.GetFare())
+		}
+
+		resp, err := purchase(s, "", "alice@example.com", 30, &ticket.SeatPreferences{Coach: "B"})
+		if err != nil {
+			t.Fatalf("unexpected error purchasing in coach B: %v", err)
+		}
+		if f := resp.GetReceipt().GetFare(); f.GetPricingStrategy() != fare.StrategySection || f.GetPricingTier() != "B" {
+			t.Errorf("expected the receipt to record the section strategy and coach B, got %v", f)
+		}
+		if _, err := purchase(s, "", "bob@example.com", 20, nil); err != nil {
+			t.Errorf("unexpected error purchasing in coach A at the base fare: %v", err)
+		}
+	})
+
+	t.Run("Departure tiers keep a quote until a threshold", func(t *testing.T) {
+		clock := newFakeClock()
+		s := NewTicketService(WithClock(clock), WithFareTable(build(fare.PricingConfig{
+			Strategy:  fare.StrategyDeparture,
+			Departure: []fare.DepartureTier{{WithinHours: 24, Multiplier: "1.2"}, {WithinHours: 2, Multiplier: "1.5"}},
+		})))
+		journey := createJourney(t, s, "2025-05-02", clock.Now().Add(23*time.Hour), 2)
+		quote, err := s.QuoteFare(ctx, &ticket.QuoteFareRequest{FromLocation: "London", ToLocation: "Paris", JourneyId: journey.GetJourneyId()})
+		if err != nil {
+			t.Fatalf("unexpected error quoting: %v", err)
+		}
+		if quote.GetFare().GetAmount() != 24 {
+			t.Fatalf("expected a fare of 24.00 within a day of departure, got %v", quote.GetFare())
+		}
+
+		clock.Advance(20 * time.Hour)
+		if _, err := purchase(s, journey.GetJourneyId(), "alice@example.com", 24, nil); err != nil {
+			t.Errorf("unexpected error paying the quote hours later in the same tier: %v", err)
+		}
+		clock.Advance(2 * time.Hour)
+		_, err = purchase(s, journey.GetJourneyId(), "bob@example.com", 24, nil)
+		expectError(t, err, ErrFareMismatch)
+	})
+
+	t.Run("Strategies swapped while running", func(t *testing.T) {
+		table := build(fare.PricingConfig{})
+		s := NewTicketService(WithFareSource(func() *fare.Table { return table }))
+		purchaseOn(t, s, "", "alice@example.com")
+
+		table = build(fare.PricingConfig{Strategy: fare.StrategySection, Sections: map[string]json.Number{"A": "2"}})
+		_, err := purchase(s, "", "bob@example.com", 20, nil)
+		expectError(t, err, ErrFareMismatch)
+		if _, err := purchase(s, "", "bob@example.com", 40, nil); err != nil {
+			t.Errorf("unexpected error purchasing at the new fare: %v", err)
+		}
+	})
+}
+
+func TestUnit_PriceCurrency(t *testing.T) {
+	ctx := context.Background()
+	rates, err := money.BuildRates(money.RatesConfig{Base: "USD", Rates: map[string]json.Number{"EUR": "0.92"}})
+	if err != nil {
+		t.Fatalf("unexpected error building rates: %v", err)
+	}
+	s := newFareService(t, WithRates(rates))
+	purchase := func(email string, price *ticket.Money) (*ticket.PurchaseTicketResponse, error) {
+		return s.PurchaseTicket(ctx, &ticket.PurchaseTicketRequest{
+			FromLocation: "London",
+			ToLocation:   "Paris",
+			User:         &ticket.User{Email: email},
+			Price:        price,
+		})
+	}
+
+	quote, err := s.QuoteFare(ctx, &ticket.QuoteFareRequest{FromLocation: "London", ToLocation: "Paris", CurrencyCode: "EUR"})
+	if err != nil {
+		t.Fatalf("unexpected error quoting in EUR: %v", err)
+	}
+	if p := quote.GetPrice(); p.GetMinorUnits() != 1840 || p.GetCurrencyCode() != "EUR" {
+		t.Errorf("expected a quote of 18.40 EUR, got %v", p)
+	}
+	if p := quote.GetFare().GetPrice(); p.GetMinorUnits() != 2000 || p.GetCurrencyCode() != "USD" {
+		t.Errorf("expected the fare to stay in USD, got %v", p)
+	}
+
+	t.Run("Paid in the fare's currency", func(t *testing.T) {
+		resp, err := purchase("alice@example.com", &ticket.Money{MinorUnits: 2000, CurrencyCode: "USD"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if r := resp.GetReceipt(); r.GetPrice().GetMinorUnits() != 2000 || r.GetPrice().GetCurrencyCode() != "USD" || r.GetPricePaid() != 20 {
+			t.Errorf("expected the receipt to record 20.00 USD, got %v and %v", r.GetPrice(), r.GetPricePaid())
+		}
+	})
+
+	t.Run("Paid in another currency", func(t *testing.T) {
+		_, err := purchase("bob@example.com", &ticket.Money{MinorUnits: 1839, CurrencyCode: "EUR"})
+		if e := expectError(t, err, ErrFareMismatch); e.Subject != "18.40" {
+			t.Errorf("expected the fare due in EUR, got %q", e.Subject)
+		}
+		resp, err := purchase("bob@example.com", quote.GetPrice())
+		if err != nil {
+			t.Fatalf("unexpected error paying the quoted price: %v", err)
+		}
+		if r := resp.GetReceipt(); r.GetPrice().GetCurrencyCode() != "EUR" || r.GetPricePaid() != 18.4 {
+			t.Errorf("expected the receipt to record 18.40 EUR, got %v and %v", r.GetPrice(), r.GetPricePaid())
+		}
+	})
+
+	t.Run("Legacy price_paid is read as USD", func(t *testing.T) {
+		resp, err := s.PurchaseTicket(ctx, &ticket.PurchaseTicketRequest{
+			FromLocation: "London",
+			ToLocation:   "Paris",
+			User:         &ticket.User{Email: "carol@example.com"},
+			PricePaid:    20,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if p := resp.GetReceipt().GetPrice(); p.GetMinorUnits() != 2000 || p.GetCurrencyCode() != "USD" {
+			t.Errorf("expected the receipt to record 20.00 USD, got %v", p)
+		}
+	})
+
+	t.Run("Group, hold and waitlist paid in another currency", func(t *testing.T) {
+		inEUR := func(name string, price *ticket.Money) {
+			t.Helper()
+			if price.GetMinorUnits() != 1840 || price.GetCurrencyCode() != "EUR" {
+				t.Errorf("expected the %s to record 18.40 EUR, got %v", name, price)
+			}
+		}
+
+		_, err := s.PurchaseGroupTicket(ctx, &ticket.PurchaseGroupTicketRequest{
+			FromLocation: "London", ToLocation: "Paris", Passengers: []*ticket.User{{Email: "erin@example.com"}, {Email: "frank@example.com"}},
+			Price: &ticket.Money{MinorUnits: 1839, CurrencyCode: "EUR"},
+		})
+		if e := expectError(t, err, ErrFareMismatch); e.Subject != "18.40" {
+			t.Errorf("expected the group fare due in EUR, got %q", e.Subject)
+		}
+		group, err := s.PurchaseGroupTicket(ctx, &ticket.PurchaseGroupTicketRequest{
+			FromLocation: "London", ToLocation: "Paris", Passengers: []*ticket.User{{Email: "erin@example.com"}, {Email: "frank@example.com"}},
+			Price: quote.GetPrice(),
+		})
+		if err != nil {
+			t.Fatalf("unexpected error purchasing for the group: %v", err)
+		}
+		for _, r := range group.GetReceipts() {
+			inEUR("group receipt", r.GetPrice())
+		}
+
+		hold, err := s.HoldSeat(ctx, &ticket.HoldSeatRequest{FromLocation: "London", ToLocation: "Paris", User: &ticket.User{Email: "gina@example.com"}})
+		if err != nil {
+			t.Fatalf("unexpected error holding: %v", err)
+		}
+		confirmed, err := s.ConfirmHold(ctx, &ticket.ConfirmHoldRequest{HoldId: hold.GetHoldId(), Price: quote.GetPrice()})
+		if err != nil {
+			t.Fatalf("unexpected error confirming the hold: %v", err)
+		}
+		inEUR("confirmed hold", confirmed.GetReceipt().GetPrice())
+
+		journey := createJourney(t, s, "2025-05-01", time.Date(2025, 5, 1, 8, 0, 0, 0, time.UTC), 1)
+		first := purchaseOn(t, s, journey.GetJourneyId(), "b@example.com")
+		purchaseOn(t, s, journey.GetJourneyId(), "c@example.com")
+		joined, err := s.JoinWaitlist(ctx, &ticket.JoinWaitlistRequest{
+			FromLocation: "London", ToLocation: "Paris", User: &ticket.User{Email: "hana@example.com"}, JourneyId: journey.GetJourneyId(),
+			Price: quote.GetPrice(),
+		})
+		if err != nil {
+			t.Fatalf("unexpected error joining the waitlist: %v", err)
+		}
+		inEUR("waitlist entry", joined.GetEntry().GetPrice())
+		if _, err := s.CancelTicket(ctx, first.GetReceipt().GetTicketId()); err != nil {
+			t.Fatalf("unexpected error cancelling: %v", err)
+		}
+		status, err := s.GetWaitlistStatus(ctx, joined.GetEntry().GetWaitlistId())
+		if err != nil {
+			t.Fatalf("unexpected error checking the waitlist: %v", err)
+		}
+		inEUR("promoted ticket", status.GetReceipt().GetPrice())
+	})
+
+	t.Run("Currency without a rate", func(t *testing.T) {
+		_, err := purchase("dave@example.com", &ticket.Money{MinorUnits: 2000, CurrencyCode: "GBP"})
+		expectError(t, err, ErrCurrencyNotSupported)
+		_, err = s.QuoteFare(ctx, &ticket.QuoteFareRequest{FromLocation: "London", ToLocation: "Paris", CurrencyCode: "GBP"})
+		expectError(t, err, ErrCurrencyNotSupported)
+		_, err = newFareService(t).QuoteFare(ctx, &ticket.QuoteFareRequest{FromLocation: "London", ToLocation: "Paris", CurrencyCode: "EUR"})
+		expectError(t, err, ErrCurrencyNotSupported)
+	})
+}
@@ -0,0 +1,225 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	ticket "github.com/talk2sohail/train-ticket-api/internal/common/genproto/ticket"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/money"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// findGroupSeats picks size seats that are free over the segment being travelled, keeping the party together.
+// It prefers a run of adjacent seats, then any free seats within one coach, and only spreads the party over
+// several coaches when allowSplit is set. See adjacent for which seats count as a run.
+// This function assumes the caller has already acquired the server's mutex.
+func (s *TicketService) findGroupSeats(journey *ticket.Journey, seg segment, size int, allowSplit bool) ([]*ticket.Seat, error) {
+	var sameCoach, anywhere []*ticket.Seat
+	for _, coach := range layoutOf(journey).GetCoaches() {
+		var free, run []*ticket.Seat
+		for _, seat := range coach.GetSeats() {
+			if !s.isSeatFree(journey, seat.GetSeatNumber(), seg, "") {
+				run = run[:0]
+				continue
+			}
+			if len(run) > 0 && !adjacent(run[len(run)-1], seat) {
+				run = run[:0]
+			}
+			free = append(free, seat)
+			run = append(run, seat)
+			if len(run) == size {
+				return cloneSeats(run), nil
+			}
+		}
+		if sameCoach == nil && len(free) >= size {
+			sameCoach = free[:size]
+		}
+		anywhere = append(anywhere, free...)
+	}
+
+	if sameCoach != nil {
+		return cloneSeats(sameCoach), nil
+	}
+	if allowSplit && len(anywhere) >= size {
+		return cloneSeats(anywhere[:size]), nil
+	}
+	if len(anywhere) >= size {
+		return nil, newError(ErrGroupNotSeatedTogether, "")
+	}
+	return nil, newError(ErrNoAvailableSeats, "")
+}
+
+// adjacent reports whether seat sits right next to prev, which comes just before it in layout order:
+// the next column of the same row or, in a coach with a single line of seats, the next row.
+func adjacent(prev, seat *ticket.Seat) bool {
+	if prev.GetColumn() == "" && seat.GetColumn() == "" {
+		return seat.GetRow() == prev.GetRow()+1
+	}
+	return seat.GetRow() == prev.GetRow()
+}
+
+func cloneSeats(seats []*ticket.Seat) []*ticket.Seat {
+	clones := make([]*ticket.Seat, len(seats))
+	for i, seat := range seats {
+		clones[i] = proto.Clone(seat).(*ticket.Seat)
+	}
+	return clones
+}
+
+// groupPlan is the seats and fare a party would be booked at.
+type groupPlan struct {
+	journey *ticket.Journey
+	seg     segment
+	seats   []*ticket.Seat
+	quoted  *ticket.Fare
+}
+
+// planGroup finds the seats a party would be given and checks the price each passenger pays for them.
+// This function assumes the caller has already acquired the server's mutex.
+func (s *TicketService) planGroup(req *ticket.PurchaseGroupTicketRequest, paid money.Money) (*groupPlan, error) {
+	passengers := req.GetPassengers()
+	journey, ok := s.lookupJourney(req.GetJourneyId())
+	if !ok {
+		log.Printf("[PurchaseGroupTicket] Failed for %d passengers: %s %s", len(passengers), ErrJourneyNotFound, req.GetJourneyId())
+		return nil, newError(ErrJourneyNotFound, req.GetJourneyId())
+	}
+
+	seg, err := segmentOf(journey, req.GetFromLocation(), req.GetToLocation())
+	if err != nil {
+		log.Printf("[PurchaseGroupTicket] Failed for %d passengers: %v", len(passengers), err)
+		return nil, err
+	}
+
+	perEmail := make(map[string]int)
+	for _, p := range passengers {
+		perEmail[p.GetEmail()]++
+	}
+	for email, n := range perEmail {
+		if err := s.checkTicketQuota("PurchaseGroupTicket", email, n); err != nil {
+			return nil, err
+		}
+	}
+
+	seats, err := s.findGroupSeats(journey, seg, len(passengers), req.GetAllowSplit())
+	if err != nil {
+		log.Printf("[PurchaseGroupTicket] Failed for %d passengers: %v", len(passengers), err)
+		return nil, err
+	}
+
+	// Every passenger pays the same fare: that of the dearest seat when a split party's coaches are priced differently.
+	var quoted *ticket.Fare
+	for _, seat := range seats {
+		if f := s.quoteFare(journey, seg, req.GetFromLocation(), req.GetToLocation(), req.GetTravelClass(), req.GetPassengerType(), seat); f.GetPrice().GetMinorUnits() > quoted.GetPrice().GetMinorUnits() {
+			quoted = f
+		}
+	}
+	if err := s.checkPrice("PurchaseGroupTicket", fmt.Sprintf("%d passengers", len(passengers)), quoted, paid); err != nil {
+		return nil, err
+	}
+	return &groupPlan{journey: journey, seg: seg, seats: seats, quoted: quoted}, nil
+}
+
+// PurchaseGroupTicket books a seat for every passenger of a party under one booking reference.
+// Either every passenger is booked or, when the party cannot be seated, nobody is.
+// The whole party pays with one payment, taken while its seats are kept with the mutex released.
+func (s *TicketService) PurchaseGroupTicket(ctx context.Context, req *ticket.PurchaseGroupTicketRequest) (*ticket.PurchaseGroupTicketResponse, error) {
+
+	// Acquire a lock so that finding the seats and storing every receipt happen atomically.
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	passengers := req.GetPassengers()
+	paid, err := priceOf(req.GetPrice(), req.GetPricePaid())
+	if err != nil {
+		log.Printf("[PurchaseGroupTicket] Failed for %d passengers: %v", len(passengers), err)
+		return nil, err
+	}
+	total, err := paid.Mul(int64(len(passengers)))
+	if err != nil {
+		log.Printf("[PurchaseGroupTicket] Failed for %d passengers: %v", len(passengers), err)
+		return nil, newError(ErrPriceTooLarge, paid.Decimal())
+	}
+
+	// Check that the party can be seated before reserving its price.
+	plan, err := s.planGroup(req, paid)
+	if err != nil {
+		return nil, err
+	}
+	who := fmt.Sprintf("%d passengers", len(passengers))
+	paymentID, err := s.authorize(ctx, "PurchaseGroupTicket", who, req.GetPaymentMethod(), total)
+	if err != nil {
+		return nil, err
+	}
+	if paymentID != "" {
+		// Seats may have been taken while the price was reserved, so seat the party again.
+		if plan, err = s.planGroup(req, paid); err != nil {
+			s.void(ctx, "PurchaseGroupTicket", paymentID)
+			return nil, err
+		}
+	}
+
+	reservations := make([]string, len(plan.seats))
+	for i, seat := range plan.seats {
+		reservations[i] = s.reserve(plan.journey.GetJourneyId(), seat, plan.seg, passengers[i], "")
+	}
+	err = s.capture(ctx, "PurchaseGroupTicket", paymentID)
+	s.release(reservations...)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.bookGroup(ctx, req, plan, paid, paymentID)
+}
+
+// bookGroup issues a receipt per passenger at the quoted fare, each paying paid, and records them in a single ledger append.
+// Each receipt records its passenger's share of the party's payment, which is what cancelling the ticket refunds.
+func (s *TicketService) bookGroup(ctx context.Context, req *ticket.PurchaseGroupTicketRequest, plan *groupPlan, paid money.Money, paymentID string) (*ticket.PurchaseGroupTicketResponse, error) {
+	bookingReference := uuid.New().String()
+	now := s.clock.Now()
+
+	receipts := make([]*ticket.Receipt, len(plan.seats))
+	events := make([]*ticket.BookingEvent, len(plan.seats))
+	for i, passenger := range req.GetPassengers() {
+		receipts[i] = &ticket.Receipt{
+			TicketId:         uuid.New().String(),
+			FromLocation:     req.GetFromLocation(),
+			ToLocation:       req.GetToLocation(),
+			User:             passenger,
+			PricePaid:        paid.Major(),
+			Price:            paid.Proto(),
+			AllocatedSeat:    plan.seats[i],
+			PurchaseDate:     timestamppb.New(now),
+			JourneyId:        plan.journey.GetJourneyId(),
+			BookingReference: bookingReference,
+			Payment:          s.paymentOf(paymentID, paid),
+		}
+		if plan.quoted != nil {
+			receipts[i].Fare = proto.Clone(plan.quoted).(*ticket.Fare)
+		}
+		events[i] = ticketPurchasedEvent(receipts[i], now)
+	}
+
+	// The repository stores a batch of events all or nothing.
+	if err := s.repo.Append(events...); err != nil {
+		log.Printf("[PurchaseGroupTicket] Failed to store booking %s: %v", bookingReference, err)
+		for _, receipt := range receipts {
+			if err := s.refund(ctx, "PurchaseGroupTicket", receipt); err != nil {
+				log.Printf("[PurchaseGroupTicket] Payment %s was taken for a booking that was not stored", paymentID)
+			}
+		}
+		return nil, fmt.Errorf("failed to store group booking: %w", err)
+	}
+	s.notifyAvailability(plan.journey.GetJourneyId())
+
+	log.Printf("[PurchaseGroupTicket] Success: BookingReference=%s, Journey=%s, Passengers=%d", bookingReference, plan.journey.GetJourneyId(), len(receipts))
+	return &ticket.PurchaseGroupTicketResponse{
+		Success:          true,
+		Message:          MsgGroupPurchaseSuccess,
+		BookingReference: bookingReference,
+		Receipts:         receipts,
+	}, nil
+}
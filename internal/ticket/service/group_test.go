@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	ticket "github.com/talk2sohail/train-ticket-api/internal/common/genproto/ticket"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/layout"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/types"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func groupRequest(size int, allowSplit bool) *ticket.PurchaseGroupTicketRequest {
+	req := &ticket.PurchaseGroupTicketRequest{
+		FromLocation: "London",
+		ToLocation:   "Paris",
+		PricePaid:    20.0,
+		AllowSplit:   allowSplit,
+	}
+	for i := 0; i < size; i++ {
+		req.Passengers = append(req.Passengers, &ticket.User{FirstName: "Pupil", LastName: fmt.Sprint(i), Email: fmt.Sprintf("pupil%d@example.com", i)})
+	}
+	return req
+}
+
+func seatNumbersOf(receipts []*ticket.Receipt) []string {
+	var seats []string
+	for _, receipt := range receipts {
+		seats = append(seats, receipt.GetAllocatedSeat().GetSeatNumber())
+	}
+	return seats
+}
+
+func TestUnit_PurchaseGroupTicket(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Seats the group in adjacent seats", func(t *testing.T) {
+		s := NewTicketService()
+		occupySeat(t, s, ticket.Seat_SECTION_A, "A2")
+
+		resp, err := s.PurchaseGroupTicket(ctx, groupRequest(3, false))
+		if err != nil || !resp.Success {
+			t.Fatalf("expected group purchase to succeed, got %v, %v", resp, err)
+		}
+		if got := fmt.Sprint(seatNumbersOf(resp.Receipts)); got != "[A3 A4 A5]" {
+			t.Errorf("expected adjacent seats [A3 A4 A5], got %s", got)
+		}
+		for i, receipt := range resp.Receipts {
+			if receipt.BookingReference != resp.BookingReference || resp.BookingReference == "" {
+				t.Errorf("expected receipt %d to carry booking reference %s, got %q", i, resp.BookingReference, receipt.BookingReference)
+			}
+			if receipt.User.Email != fmt.Sprintf("pupil%d@example.com", i) {
+				t.Errorf("expected receipts in request order, got %s at %d", receipt.User.Email, i)
+			}
+			if _, ok := s.repo.GetReceipt(receipt.TicketId); !ok {
+				t.Errorf("expected receipt %s to be stored", receipt.TicketId)
+			}
+		}
+	})
+
+	t.Run("Falls back to the same coach", func(t *testing.T) {
+		s := NewTicketService()
+		occupySeat(t, s, ticket.Seat_SECTION_A, "A2")
+		occupySeat(t, s, ticket.Seat_SECTION_A, "A4")
+		occupySeat(t, s, ticket.Seat_SECTION_B, "B2")
+		occupySeat(t, s, ticket.Seat_SECTION_B, "B4")
+
+		resp, err := s.PurchaseGroupTicket(ctx, groupRequest(3, false))
+		if err != nil || !resp.Success {
+			t.Fatalf("expected group purchase to succeed, got %v, %v", resp, err)
+		}
+		if got := fmt.Sprint(seatNumbersOf(resp.Receipts)); got != "[A1 A3 A5]" {
+			t.Errorf("expected seats [A1 A3 A5] in one coach, got %s", got)
+		}
+	})
+
+	t.Run("Splits only when allowed", func(t *testing.T) {
+		s := NewTicketService()
+		for _, seat := range []string{"A1", "A2", "B1", "B2"} {
+			occupySeat(t, s, ticket.Seat_SECTION_A, seat)
+		}
+
+		_, err := s.PurchaseGroupTicket(ctx, groupRequest(4, false))
+		expectError(t, err, ErrGroupNotSeatedTogether)
+		if n := len(s.repo.ListReceipts()); n != 4 {
+			t.Errorf("expected nothing to be booked, got %d receipts", n)
+		}
+
+		resp, err := s.PurchaseGroupTicket(ctx, groupRequest(4, true))
+		if err != nil || !resp.Success {
+			t.Fatalf("expected split group purchase to succeed, got %v, %v", resp, err)
+		}
+		if got := fmt.Sprint(seatNumbersOf(resp.Receipts)); got != "[A3 A4 A5 B3]" {
+			t.Errorf("expected seats [A3 A4 A5 B3], got %s", got)
+		}
+	})
+
+	t.Run("Books nobody when the group does not fit", func(t *testing.T) {
+		s := NewTicketService()
+		_, err := s.PurchaseGroupTicket(ctx, groupRequest(2*MaxSeatsPerSection+1, true))
+		expectError(t, err, ErrNoAvailableSeats)
+		if n := len(s.repo.Events()); n != 0 {
+			t.Errorf("expected no events, got %d", n)
+		}
+	})
+
+	t.Run("Adjacent seats stay within a row", func(t *testing.T) {
+		rows, err := layout.Build("rows", layout.Config{Coaches: []layout.CoachConfig{
+			{ID: "C", Rows: 2, Columns: []layout.ColumnConfig{
+				{Letter: "A", Position: layout.PositionWindow},
+				{Letter: "B", Position: layout.PositionMiddle},
+				{Letter: "C", Position: layout.PositionAisle},
+			}},
+		}})
+		if err != nil {
+			t.Fatalf("unexpected error building layout: %v", err)
+		}
+		s := NewTicketService(WithLayouts(map[string]*ticket.TrainLayout{"rows": rows}, ""))
+		created, err := s.CreateJourney(ctx, &ticket.CreateJourneyRequest{
+			ServiceDate:   "2025-05-01",
+			DepartureTime: timestamppb.New(time.Date(2025, 5, 1, 8, 0, 0, 0, time.UTC)),
+			Origin:        "London",
+			Destination:   "Paris",
+			Layout:        "rows",
+		})
+		if err != nil || !created.Success {
+			t.Fatalf("failed to create journey: %v, %v", created, err)
+		}
+		purchaseOn(t, s, created.Journey.JourneyId, "first@example.com") // Takes C1A.
+
+		// C1B, C1C and C2A follow each other in layout order but span a row break.
+		req := groupRequest(3, false)
+		req.JourneyId = created.Journey.JourneyId
+		resp, err := s.PurchaseGroupTicket(ctx, req)
+		if err != nil || !resp.Success {
+			t.Fatalf("expected group purchase to succeed, got %v, %v", resp, err)
+		}
+		if got := fmt.Sprint(seatNumbersOf(resp.Receipts)); got != "[C2A C2B C2C]" {
+			t.Errorf("expected the party in row 2 [C2A C2B C2C], got %s", got)
+		}
+	})
+
+	t.Run("Unknown journey", func(t *testing.T) {
+		s := NewTicketService()
+		req := groupRequest(2, false)
+		req.JourneyId = "no-such-journey"
+		_, err := s.PurchaseGroupTicket(ctx, req)
+		expectError(t, err, ErrJourneyNotFound)
+	})
+}
+
+func TestUnit_ConcurrentGroupPurchases(t *testing.T) {
+	s := NewTicketService()
+
+	// Four groups of three compete for two coaches of five seats: at most one group fits in each coach.
+	var wg sync.WaitGroup
+	responses := make([]*ticket.PurchaseGroupTicketResponse, 4)
+	for i := range responses {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			resp, err := s.PurchaseGroupTicket(context.Background(), groupRequest(3, false))
+			if e, ok := AsError(err); ok && e.Message == ErrGroupNotSeatedTogether {
+				return
+			}
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			responses[i] = resp
+		}(i)
+	}
+	wg.Wait()
+
+	booked := 0
+	for _, resp := range responses {
+		if resp.GetSuccess() {
+			booked++
+			coach := resp.Receipts[0].AllocatedSeat.Section
+			for _, receipt := range resp.Receipts {
+				if receipt.AllocatedSeat.Section != coach {
+					t.Errorf("expected booking %s to stay in one coach", resp.BookingReference)
+				}
+			}
+		}
+	}
+	if booked != 2 {
+		t.Errorf("expected 2 groups to be booked, got %d", booked)
+	}
+	if n := len(s.repo.ListReceipts()); n != 6 {
+		t.Errorf("expected 6 receipts, got %d", n)
+	}
+	seen := make(map[string]bool)
+	for _, receipt := range s.repo.ListReceipts() {
+		key := types.JourneyIDOf(receipt) + "/" + receipt.AllocatedSeat.SeatNumber
+		if seen[key] {
+			t.Errorf("seat %s sold twice", key)
+		}
+		seen[key] = true
+	}
+}
@@ -0,0 +1,224 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	ticket "github.com/talk2sohail/train-ticket-api/internal/common/genproto/ticket"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// seatHold is a seat reserved for a passenger until it is confirmed or expires, or while their
+// payment is taken. Holds live only in memory: a restart releases every unconfirmed seat.
+type seatHold struct {
+	id         string
+	journeyID  string
+	seat       *ticket.Seat
+	seg        segment
+	from, to   string
+	user       *ticket.User
+	fare       *ticket.Fare // Price to pay on confirmation; nil without a fare table.
+	expiresAt  time.Time
+	pending    bool   // Payment for the seat is being taken; a pending hold does not expire and cannot be confirmed.
+	waitlistID string // Waitlist entry being promoted into the seat, if any.
+}
+
+func (h *seatHold) expired(now time.Time) bool {
+	return !h.pending && !now.Before(h.expiresAt)
+}
+
+// WithHoldTTL sets how long HoldSeat reserves a seat for.
+func WithHoldTTL(ttl time.Duration) Option {
+	return func(s *TicketService) {
+		if ttl > 0 {
+			s.holdTTL = ttl
+		}
+	}
+}
+
+// isSeatHeld reports whether an unexpired hold reserves a seat on a journey over any part of seg.
+// This function assumes the caller has already acquired the server's mutex.
+func (s *TicketService) isSeatHeld(journeyID, seatNumber string, seg segment) bool {
+	now := s.clock.Now()
+	for _, hold := range s.holds {
+		if hold.journeyID == journeyID && hold.seat.GetSeatNumber() == seatNumber && !hold.expired(now) && hold.seg.overlaps(seg) {
+			return true
+		}
+	}
+	return false
+}
+
+// HoldSeat reserves the free seat that best matches the passenger's preferences for the hold TTL.
+// The seat is not handed out to anyone else until the hold is confirmed or expires.
+func (s *TicketService) HoldSeat(ctx context.Context, req *ticket.HoldSeatRequest) (*ticket.HoldSeatResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	journey, ok := s.lookupJourney(req.GetJourneyId())
+	if !ok {
+		log.Printf("[HoldSeat] Failed for user %s: %s %s", req.GetUser().GetEmail(), ErrJourneyNotFound, req.GetJourneyId())
+		return nil, newError(ErrJourneyNotFound, req.GetJourneyId())
+	}
+
+	seg, err := segmentOf(journey, req.GetFromLocation(), req.GetToLocation())
+	if err != nil {
+		log.Printf("[HoldSeat] Failed for user %s: %v", req.GetUser().GetEmail(), err)
+		return nil, err
+	}
+
+	// A hold counts against the quota until it expires, so confirming it needs no second check.
+	if err := s.checkTicketQuota("HoldSeat", req.GetUser().GetEmail(), 1); err != nil {
+		return nil, err
+	}
+
+	seat, met, unmet, err := s.findPreferredSeat(journey, seg, req.GetPreferences())
+	if err != nil {
+		log.Printf("[HoldSeat] Failed for user %s: %v", req.GetUser().GetEmail(), err)
+		return nil, err
+	}
+
+	hold := &seatHold{
+		id:        uuid.New().String(),
+		journeyID: journey.GetJourneyId(),
+		seat:      seat,
+		seg:       seg,
+		from:      req.GetFromLocation(),
+		to:        req.GetToLocation(),
+		user:      req.GetUser(),
+		fare:      s.quoteFare(journey, seg, req.GetFromLocation(), req.GetToLocation(), req.GetTravelClass(), req.GetPassengerType(), seat),
+		expiresAt: s.clock.Now().Add(s.holdTTL),
+	}
+	s.holds[hold.id] = hold
+	s.notifyAvailability(hold.journeyID)
+
+	log.Printf("[HoldSeat] Held Seat=%s on Journey=%s for HoldID=%s until %s", seat.GetSeatNumber(), hold.journeyID, hold.id, hold.expiresAt.Format(time.RFC3339))
+	return &ticket.HoldSeatResponse{
+		Success:          true,
+		Message:          MsgSeatHeld,
+		HoldId:           hold.id,
+		Seat:             seat,
+		ExpiresAt:        timestamppb.New(hold.expiresAt),
+		PreferencesMet:   met,
+		PreferencesUnmet: unmet,
+		Fare:             hold.fare,
+	}, nil
+}
+
+// ConfirmHold issues a ticket for a held seat at the fare quoted when it was held.
+// Holds that have expired cannot be confirmed, even if the reaper has not released them yet.
+func (s *TicketService) ConfirmHold(ctx context.Context, req *ticket.ConfirmHoldRequest) (*ticket.ConfirmHoldResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	hold, ok := s.holds[req.GetHoldId()]
+	if !ok || hold.pending {
+		log.Printf("[ConfirmHold] %s for HoldID %s", ErrHoldNotFound, req.GetHoldId())
+		return nil, newError(ErrHoldNotFound, req.GetHoldId())
+	}
+	now := s.clock.Now()
+	if hold.expired(now) {
+		delete(s.holds, hold.id)
+		s.notifyAvailability(hold.journeyID)
+		log.Printf("[ConfirmHold] %s for HoldID %s", ErrHoldExpired, hold.id)
+		return nil, newError(ErrHoldExpired, hold.id)
+	}
+	paid, err := priceOf(req.GetPrice(), req.GetPricePaid())
+	if err != nil {
+		log.Printf("[ConfirmHold] Failed for HoldID %s: %v", hold.id, err)
+		return nil, err
+	}
+	if err := s.checkPrice("ConfirmHold", "user "+hold.user.GetEmail(), hold.fare, paid); err != nil {
+		return nil, err
+	}
+
+	// The hold neither expires nor can be confirmed again while it is paid for. A payment
+	// that is refused leaves the seat held, so another method can be tried before it expires.
+	hold.pending = true
+	paymentID, err := s.charge(ctx, "ConfirmHold", "HoldID "+hold.id, req.GetPaymentMethod(), paid)
+	hold.pending = false
+	if err != nil {
+		return nil, err
+	}
+
+	now = s.clock.Now()
+	receipt := &ticket.Receipt{
+		TicketId:      uuid.New().String(),
+		FromLocation:  hold.from,
+		ToLocation:    hold.to,
+		User:          hold.user,
+		PricePaid:     paid.Major(),
+		Price:         paid.Proto(),
+		AllocatedSeat: hold.seat,
+		PurchaseDate:  timestamppb.New(now),
+		JourneyId:     hold.journeyID,
+		Fare:          hold.fare,
+		Payment:       s.paymentOf(paymentID, paid),
+	}
+	if err := s.repo.Append(ticketPurchasedEvent(receipt, now)); err != nil {
+		log.Printf("[ConfirmHold] Failed to store receipt for HoldID %s: %v", hold.id, err)
+		if err := s.refund(ctx, "ConfirmHold", receipt); err != nil {
+			log.Printf("[ConfirmHold] Payment %s was taken for a ticket that was not booked", paymentID)
+		}
+		return nil, fmt.Errorf("failed to store receipt: %w", err)
+	}
+	delete(s.holds, hold.id)
+
+	log.Printf("[ConfirmHold] Success: HoldID=%s, TicketID=%s, Seat=%s", hold.id, receipt.GetTicketId(), hold.seat.GetSeatNumber())
+	return &ticket.ConfirmHoldResponse{
+		Success: true,
+		Message: MsgHoldConfirmed,
+		Receipt: receipt,
+	}, nil
+}
+
+// releaseExpiredHolds drops every hold that has expired, offers the released seats to the waitlist
+// and returns how many holds were released.
+func (s *TicketService) releaseExpiredHolds() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.clock.Now()
+	var released []*seatHold
+	for id, hold := range s.holds {
+		if !hold.expired(now) {
+			continue
+		}
+		delete(s.holds, id)
+		released = append(released, hold)
+		log.Printf("[HoldReaper] Released Seat=%s on Journey=%s from expired HoldID=%s", hold.seat.GetSeatNumber(), hold.journeyID, id)
+	}
+
+	// Seats are offered once every expired hold is dropped, as taking payment for a promotion releases the mutex.
+	for _, hold := range released {
+		if journey, ok := s.lookupJourney(hold.journeyID); ok {
+			s.offerSeat(context.Background(), "HoldReaper", journey, hold.seat)
+		}
+		s.notifyAvailability(hold.journeyID)
+	}
+	return len(released)
+}
+
+// StartHoldReaper releases expired holds every interval until the returned stop function is called.
+// Expired holds never block allocation even before the reaper runs; the reaper only frees their memory.
+func (s *TicketService) StartHoldReaper(interval time.Duration) (stop func()) {
+	ticker := time.NewTicker(interval)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				s.releaseExpiredHolds()
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() {
+		ticker.Stop()
+		close(done)
+	}
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	ticket "github.com/talk2sohail/train-ticket-api/internal/common/genproto/ticket"
+)
+
+// fakeClock is a Clock that only moves when the test advances it.
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2025, 5, 1, 9, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func holdSeat(t *testing.T, s *TicketService, email string) *ticket.HoldSeatResponse {
+	t.Helper()
+	resp, err := s.HoldSeat(context.Background(), &ticket.HoldSeatRequest{
+		FromLocation: "London",
+		ToLocation:   "Paris",
+		User:         &ticket.User{FirstName: "Test", LastName: "User", Email: email},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error holding a seat: %v", err)
+	}
+	return resp
+}
+
+func TestUnit_HoldSeat(t *testing.T) {
+	ctx := context.Background()
+	clock := newFakeClock()
+	s := NewTicketService(WithClock(clock), WithHoldTTL(5*time.Minute))
+
+	hold := holdSeat(t, s, "holder@example.com")
+	if !hold.Success || hold.Seat.SeatNumber != "A1" {
+		t.Fatalf("expected A1 to be held, got %v", hold)
+	}
+	if want := clock.Now().Add(5 * time.Minute); !hold.ExpiresAt.AsTime().Equal(want) {
+		t.Errorf("expected hold to expire at %s, got %s", want, hold.ExpiresAt.AsTime())
+	}
+
+	t.Run("Held seat is not handed out", func(t *testing.T) {
+		res := purchaseOn(t, s, "", "buyer@example.com")
+		if !res.Success || res.Receipt.AllocatedSeat.SeatNumber != "A2" {
+			t.Errorf("expected the buyer to get A2, got %v", res)
+		}
+		buyer := res.Receipt
+		_, err := s.ModifyUserSeat(ctx, buyer, &ticket.Seat{SeatNumber: "A1"})
+		expectError(t, err, ErrSeatOccupied)
+	})
+
+	t.Run("Confirming issues a ticket for the held seat", func(t *testing.T) {
+		clock.Advance(4 * time.Minute)
+		resp, err := s.ConfirmHold(ctx, &ticket.ConfirmHoldRequest{HoldId: hold.HoldId, PricePaid: 20.0})
+		if err != nil || !resp.Success {
+			t.Fatalf("expected confirmation to succeed, got %v, %v", resp, err)
+		}
+		if resp.Receipt.AllocatedSeat.SeatNumber != "A1" || resp.Receipt.User.Email != "holder@example.com" {
+			t.Errorf("expected a ticket on A1 for holder@example.com, got %v", resp.Receipt)
+		}
+		if _, ok := s.repo.GetReceipt(resp.Receipt.TicketId); !ok {
+			t.Errorf("expected the receipt to be stored")
+		}
+
+		_, err = s.ConfirmHold(ctx, &ticket.ConfirmHoldRequest{HoldId: hold.HoldId, PricePaid: 20.0})
+		expectError(t, err, ErrHoldNotFound)
+	})
+}
+
+func TestUnit_HoldExpiry(t *testing.T) {
+	ctx := context.Background()
+	clock := newFakeClock()
+	s := NewTicketService(WithClock(clock), WithHoldTTL(time.Minute))
+
+	hold := holdSeat(t, s, "slow@example.com")
+	clock.Advance(time.Minute)
+
+	t.Run("Expired hold no longer blocks the seat", func(t *testing.T) {
+		res := purchaseOn(t, s, "", "fast@example.com")
+		if !res.Success || res.Receipt.AllocatedSeat.SeatNumber != "A1" {
+			t.Errorf("expected the expired hold's seat A1 to be sold, got %v", res)
+		}
+	})
+
+	t.Run("Expired hold cannot be confirmed", func(t *testing.T) {
+		_, err := s.ConfirmHold(ctx, &ticket.ConfirmHoldRequest{HoldId: hold.HoldId, PricePaid: 20.0})
+		expectError(t, err, ErrHoldExpired)
+	})
+
+	t.Run("Reaper releases expired holds", func(t *testing.T) {
+		holdSeat(t, s, "first@example.com")
+		clock.Advance(30 * time.Second)
+		holdSeat(t, s, "second@example.com")
+		clock.Advance(30 * time.Second)
+
+		if n := s.releaseExpiredHolds(); n != 1 {
+			t.Errorf("expected 1 hold to be released, got %d", n)
+		}
+		if n := len(s.holds); n != 1 {
+			t.Errorf("expected 1 hold to remain, got %d", n)
+		}
+	})
+
+	t.Run("Background reaper", func(t *testing.T) {
+		clock.Advance(time.Minute)
+		stop := s.StartHoldReaper(time.Millisecond)
+		defer stop()
+
+		deadline := time.Now().Add(time.Second)
+		for {
+			s.mu.Lock()
+			remaining := len(s.holds)
+			s.mu.Unlock()
+			if remaining == 0 {
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("expected the reaper to release every hold, %d remain", remaining)
+			}
+			time.Sleep(time.Millisecond)
+		}
+	})
+}
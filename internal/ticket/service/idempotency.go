@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"log"
+	"sync"
+	"time"
+)
+
+// IdempotencyStore remembers the first successful response to each idempotency
+// key so that a retried request is answered with it instead of being served again.
+// The transports fingerprint each request; reusing a key for a request with another
+// fingerprint is refused. Failed requests are not remembered and may be retried
+// under the same key. Responses are forgotten once the window has passed.
+type IdempotencyStore struct {
+	mu      sync.Mutex
+	window  time.Duration
+	clock   Clock
+	entries map[string]*replay
+	expiry  []*replay // Remembered responses, oldest first.
+}
+
+// replay is the request an idempotency key was first used for and, once it succeeded, its response.
+type replay struct {
+	key         string
+	fingerprint [sha256.Size]byte
+	done        chan struct{} // Closed when the first request has finished.
+	kept        bool          // Whether the first request succeeded and its response is replayed.
+	response    any
+	expires     time.Time
+}
+
+// NewIdempotencyStore creates a store replaying responses for window, or for
+// DefaultIdempotencyWindow when window is not positive. A nil clock is the system clock.
+func NewIdempotencyStore(window time.Duration, clock Clock) *IdempotencyStore {
+	if window <= 0 {
+		window = DefaultIdempotencyWindow
+	}
+	if clock == nil {
+		clock = systemClock{}
+	}
+	return &IdempotencyStore{window: window, clock: clock, entries: make(map[string]*replay)}
+}
+
+// Do serves a request made with an idempotency key. The first request under key runs
+// call, whose keep result tells whether its response is remembered. A later request
+// under key with the same fingerprint gets that response back with replayed set, after
+// waiting for the first to finish if it is still being served; one with another
+// fingerprint fails with ErrIdempotencyKeyReused. key should be scoped by the caller
+// to the operation, so that one client key may be used with several operations.
+func (st *IdempotencyStore) Do(ctx context.Context, key string, fingerprint []byte, call func() (response any, keep bool)) (response any, replayed bool, err error) {
+	sum := sha256.Sum256(fingerprint)
+	for {
+		st.mu.Lock()
+		st.expire()
+		r, ok := st.entries[key]
+		if !ok {
+			r = &replay{key: key, fingerprint: sum, done: make(chan struct{})}
+			st.entries[key] = r
+			st.mu.Unlock()
+			return st.serve(r, call), false, nil
+		}
+		st.mu.Unlock()
+
+		if r.fingerprint != sum {
+			log.Printf("[Idempotency] Key %s reused for a different request", key)
+			return nil, false, newError(ErrIdempotencyKeyReused, "")
+		}
+		select {
+		case <-r.done:
+		case <-ctx.Done():
+			return nil, false, ctx.Err()
+		}
+		st.mu.Lock()
+		kept, response := r.kept, r.response
+		st.mu.Unlock()
+		if kept {
+			log.Printf("[Idempotency] Replaying response for key %s", key)
+			return response, true, nil
+		}
+		// The first request failed and released the key; serve this one in its place.
+	}
+}
+
+// serve runs the first request under a key and remembers its response if it is kept.
+func (st *IdempotencyStore) serve(r *replay, call func() (any, bool)) (response any) {
+	keep := false
+	defer func() {
+		st.mu.Lock()
+		defer st.mu.Unlock()
+		if keep {
+			r.kept, r.response, r.expires = true, response, st.clock.Now().Add(st.window)
+			st.expiry = append(st.expiry, r)
+		} else {
+			delete(st.entries, r.key)
+		}
+		close(r.done)
+	}()
+	response, keep = call()
+	return response
+}
+
+// expire forgets responses whose window has passed; the caller holds st.mu.
+func (st *IdempotencyStore) expire() {
+	now := st.clock.Now()
+	for len(st.expiry) > 0 && !now.Before(st.expiry[0].expires) {
+		r := st.expiry[0]
+		st.expiry[0] = nil
+		st.expiry = st.expiry[1:]
+		if st.entries[r.key] == r {
+			delete(st.entries, r.key)
+		}
+	}
+}
+
+// ValidateIdempotencyKey checks a key supplied by a client.
+func ValidateIdempotencyKey(key string) error {
+	if len(key) > MaxIdempotencyKeyLength {
+		return newError(ErrIdempotencyKeyInvalid, "")
+	}
+	return nil
+}
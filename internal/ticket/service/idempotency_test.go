@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestUnit_IdempotencyStore(t *testing.T) {
+	ctx := context.Background()
+
+	// counter returns a call that counts its runs and answers with the run number.
+	counter := func(keep bool) (func() (any, bool), *atomic.Int32) {
+		var runs atomic.Int32
+		return func() (any, bool) { return runs.Add(1), keep }, &runs
+	}
+
+	t.Run("Replays the first response", func(t *testing.T) {
+		st := NewIdempotencyStore(time.Minute, newFakeClock())
+		call, runs := counter(true)
+		first, replayed, err := st.Do(ctx, "k", []byte("req"), call)
+		if err != nil || replayed {
+			t.Fatalf("expected a fresh call, got replayed=%v err=%v", replayed, err)
+		}
+		second, replayed, err := st.Do(ctx, "k", []byte("req"), call)
+		if err != nil || !replayed {
+			t.Fatalf("expected a replay, got replayed=%v err=%v", replayed, err)
+		}
+		if runs.Load() != 1 || first != second {
+			t.Errorf("expected one run answered twice, got %d runs answering %v and %v", runs.Load(), first, second)
+		}
+	})
+
+	t.Run("Different request under the same key", func(t *testing.T) {
+		st := NewIdempotencyStore(time.Minute, newFakeClock())
+		call, runs := counter(true)
+		if _, _, err := st.Do(ctx, "k", []byte("req"), call); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		_, _, err := st.Do(ctx, "k", []byte("other"), call)
+		expectError(t, err, ErrIdempotencyKeyReused)
+		if runs.Load() != 1 {
+			t.Errorf("expected the mismatched request not to run, got %d runs", runs.Load())
+		}
+	})
+
+	t.Run("Keys are independent", func(t *testing.T) {
+		st := NewIdempotencyStore(time.Minute, newFakeClock())
+		call, runs := counter(true)
+		for _, key := range []string{"a", "b"} {
+			if _, replayed, err := st.Do(ctx, key, []byte("req"), call); err != nil || replayed {
+				t.Fatalf("expected a fresh call for %s, got replayed=%v err=%v", key, replayed, err)
+			}
+		}
+		if runs.Load() != 2 {
+			t.Errorf("expected 2 runs, got %d", runs.Load())
+		}
+	})
+
+	t.Run("Failures are not remembered", func(t *testing.T) {
+		st := NewIdempotencyStore(time.Minute, newFakeClock())
+		call, runs := counter(false)
+		for range 2 {
+			if _, replayed, err := st.Do(ctx, "k", []byte("req"), call); err != nil || replayed {
+				t.Fatalf("expected a fresh call, got replayed=%v err=%v", replayed, err)
+			}
+		}
+		if runs.Load() != 2 {
+			t.Errorf("expected the failed request to run again, got %d runs", runs.Load())
+		}
+		// A failed request frees its key for a different one.
+		if _, _, err := st.Do(ctx, "k", []byte("other"), call); err != nil {
+			t.Errorf("unexpected error reusing a failed key: %v", err)
+		}
+	})
+
+	t.Run("Responses expire after the window", func(t *testing.T) {
+		clock := newFakeClock()
+		st := NewIdempotencyStore(time.Minute, clock)
+		call, runs := counter(true)
+		st.Do(ctx, "k", []byte("req"), call)
+
+		clock.Advance(59 * time.Second)
+		if _, replayed, _ := st.Do(ctx, "k", []byte("req"), call); !replayed {
+			t.Errorf("expected a replay within the window")
+		}
+		clock.Advance(time.Second)
+		if _, replayed, err := st.Do(ctx, "k", []byte("other"), call); err != nil || replayed {
+			t.Errorf("expected the key to be free after the window, got replayed=%v err=%v", replayed, err)
+		}
+		if runs.Load() != 2 {
+			t.Errorf("expected 2 runs, got %d", runs.Load())
+		}
+	})
+
+	t.Run("Concurrent retry waits for the first request", func(t *testing.T) {
+		st := NewIdempotencyStore(time.Minute, newFakeClock())
+		started, release := make(chan struct{}), make(chan struct{})
+		var runs atomic.Int32
+		call := func() (any, bool) {
+			runs.Add(1)
+			close(started)
+			<-release
+			return "ticket", true
+		}
+		go st.Do(ctx, "k", []byte("req"), call)
+		<-started
+
+		done := make(chan any)
+		go func() {
+			resp, _, _ := st.Do(ctx, "k", []byte("req"), call)
+			done <- resp
+		}()
+		select {
+		case <-done:
+			t.Fatalf("expected the retry to wait for the first request")
+		case <-time.After(10 * time.Millisecond):
+		}
+		close(release)
+		if resp := <-done; resp != "ticket" || runs.Load() != 1 {
+			t.Errorf("expected the retry to get the first response, got %v after %d runs", resp, runs.Load())
+		}
+	})
+
+	t.Run("Retry gives up with its context", func(t *testing.T) {
+		st := NewIdempotencyStore(time.Minute, newFakeClock())
+		started, release := make(chan struct{}), make(chan struct{})
+		defer close(release)
+		go st.Do(ctx, "k", []byte("req"), func() (any, bool) {
+			close(started)
+			<-release
+			return nil, true
+		})
+		<-started
+
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+		if _, _, err := st.Do(cancelled, "k", []byte("req"), nil); err != context.Canceled {
+			t.Errorf("expected %v, got %v", context.Canceled, err)
+		}
+	})
+}
+
+func TestUnit_ValidateIdempotencyKey(t *testing.T) {
+	if err := ValidateIdempotencyKey(strings.Repeat("k", MaxIdempotencyKeyLength)); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	expectError(t, ValidateIdempotencyKey(strings.Repeat("k", MaxIdempotencyKeyLength+1)), ErrIdempotencyKeyInvalid)
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	ticket "github.com/talk2sohail/train-ticket-api/internal/common/genproto/ticket"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/layout"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/types"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// defaultJourney describes the undated train that requests without a journey ID book.
+// It is not stored in the ledger; its seat inventory comes from s.defaultLayout.
+func (s *TicketService) defaultJourney() *ticket.Journey {
+	return &ticket.Journey{
+		JourneyId:       types.DefaultJourneyID,
+		SeatsPerSection: standardSeatsPerSection(s.defaultLayout),
+		Layout:          s.defaultLayout,
+	}
+}
+
+// lookupJourney resolves a journey ID, treating an empty ID as the default journey.
+func (s *TicketService) lookupJourney(journeyID string) (*ticket.Journey, bool) {
+	if journeyID == "" || journeyID == types.DefaultJourneyID {
+		return s.defaultJourney(), true
+	}
+	return s.repo.GetJourney(journeyID)
+}
+
+// layoutOf returns the seating plan of a journey. Journeys scheduled before
+// layouts existed have the standard A/B layout of their recorded size.
+func layoutOf(journey *ticket.Journey) *ticket.TrainLayout {
+	if l := journey.GetLayout(); l != nil {
+		return l
+	}
+	return layout.Standard(int(journey.GetSeatsPerSection()))
+}
+
+// journeyCreatedEvent records a newly scheduled journey.
+func journeyCreatedEvent(journey *ticket.Journey, at time.Time) *ticket.BookingEvent {
+	return &ticket.BookingEvent{
+		OccurredAt: timestamppb.New(at),
+		Event: &ticket.BookingEvent_JourneyCreated{
+			JourneyCreated: &ticket.JourneyCreated{Journey: journey},
+		},
+	}
+}
+
+// journeyLocation returns the time zone of a journey's service date. Zones are checked when
+// journeys are created, so one that cannot be loaded any more falls back to UTC.
+func journeyLocation(journey *ticket.Journey) *time.Location {
+	loc, err := time.LoadLocation(journey.GetTimeZone())
+	if err != nil {
+		log.Printf("Failed to load time zone %s of journey %s: %v", journey.GetTimeZone(), journey.GetJourneyId(), err)
+		return time.UTC
+	}
+	return loc
+}
+
+// CreateJourney schedules a new journey with its own seat inventory.
+func (s *TicketService) CreateJourney(ctx context.Context, req *ticket.CreateJourneyRequest) (*ticket.CreateJourneyResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	trainLayout := s.defaultLayout
+	switch {
+	case req.GetLayout() != "":
+		named, ok := s.layouts[req.GetLayout()]
+		if !ok {
+			log.Printf("[CreateJourney] Failed: %s %s", ErrLayoutNotFound, req.GetLayout())
+			return nil, newError(ErrLayoutNotFound, req.GetLayout())
+		}
+		trainLayout = named
+	case req.GetSeatsPerSection() > 0:
+		trainLayout = layout.Standard(int(req.GetSeatsPerSection()))
+	}
+	stops := req.GetStops()
+	if len(stops) == 0 {
+		stops = []string{req.GetOrigin(), req.GetDestination()}
+	}
+	journey := &ticket.Journey{
+		JourneyId:       uuid.New().String(),
+		TrainNumber:     req.GetTrainNumber(),
+		ServiceDate:     req.GetServiceDate(),
+		DepartureTime:   req.GetDepartureTime(),
+		Origin:          req.GetOrigin(),
+		Destination:     req.GetDestination(),
+		SeatsPerSection: standardSeatsPerSection(trainLayout),
+		Stops:           stops,
+		Layout:          trainLayout,
+		TimeZone:        req.GetTimeZone(),
+	}
+
+	if err := s.repo.Append(journeyCreatedEvent(journey, s.clock.Now())); err != nil {
+		log.Printf("[CreateJourney] Failed to store journey %s -> %s: %v", journey.GetOrigin(), journey.GetDestination(), err)
+		return nil, fmt.Errorf("failed to store journey: %w", err)
+	}
+
+	log.Printf("[CreateJourney] Created JourneyID=%s: %s -> %s on %s", journey.GetJourneyId(), journey.GetOrigin(), journey.GetDestination(), journey.GetServiceDate())
+	return &ticket.CreateJourneyResponse{
+		Success: true,
+		Message: MsgJourneyCreated,
+		Journey: journey,
+	}, nil
+}
+
+// ListJourneys returns the scheduled journeys ordered by departure time.
+// When serviceDate is set only journeys running on that date are returned;
+// otherwise the undated default journey is listed first.
+func (s *TicketService) ListJourneys(ctx context.Context, serviceDate string) (*ticket.ListJourneysResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var journeys []*ticket.Journey
+	for _, journey := range s.repo.ListJourneys() {
+		if serviceDate == "" || journey.GetServiceDate() == serviceDate {
+			journeys = append(journeys, journey)
+		}
+	}
+	sort.Slice(journeys, func(i, j int) bool {
+		di, dj := journeys[i].GetDepartureTime().AsTime(), journeys[j].GetDepartureTime().AsTime()
+		if !di.Equal(dj) {
+			return di.Before(dj)
+		}
+		return journeys[i].GetJourneyId() < journeys[j].GetJourneyId()
+	})
+	if serviceDate == "" {
+		journeys = append([]*ticket.Journey{s.defaultJourney()}, journeys...)
+	}
+
+	log.Printf("[ListJourneys] Retrieved %d journeys", len(journeys))
+	return &ticket.ListJourneysResponse{
+		Success:  true,
+		Message:  MsgJourneysRetrieved,
+		Journeys: journeys,
+	}, nil
+}
@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	ticket "github.com/talk2sohail/train-ticket-api/internal/common/genproto/ticket"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/repository"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/types"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// createJourney schedules a journey and fails the test if that is not possible.
+func createJourney(t *testing.T, s *TicketService, date string, departure time.Time, seatsPerSection int32) *ticket.Journey {
+	t.Helper()
+	resp, err := s.CreateJourney(context.Background(), &ticket.CreateJourneyRequest{
+		TrainNumber:     "ES9014",
+		ServiceDate:     date,
+		DepartureTime:   timestamppb.New(departure),
+		Origin:          "London",
+		Destination:     "Paris",
+		SeatsPerSection: seatsPerSection,
+	})
+	if err != nil || !resp.Success {
+		t.Fatalf("failed to create journey: %v, %v", resp, err)
+	}
+	return resp.Journey
+}
+
+func purchaseOn(t *testing.T, s *TicketService, journeyID, email string) *ticket.PurchaseTicketResponse {
+	t.Helper()
+	res, err := tryPurchaseLeg(s, journeyID, "London", "Paris", email)
+	if err != nil {
+		t.Fatalf("unexpected error purchasing on journey %s: %v", journeyID, err)
+	}
+	return res
+}
+
+func TestUnit_CreateAndListJourneys(t *testing.T) {
+	ctx := context.Background()
+	s := NewTicketService()
+
+	morning := time.Date(2025, 5, 1, 8, 0, 0, 0, time.UTC)
+	late := createJourney(t, s, "2025-05-01", morning.Add(10*time.Hour), 0)
+	early := createJourney(t, s, "2025-05-01", morning, 2)
+	nextDay := createJourney(t, s, "2025-05-02", morning.Add(24*time.Hour), 3)
+
+	t.Run("Default seat inventory", func(t *testing.T) {
+		if late.SeatsPerSection != MaxSeatsPerSection {
+			t.Errorf("expected %d seats per section, got %d", MaxSeatsPerSection, late.SeatsPerSection)
+		}
+	})
+
+	t.Run("Lists all journeys with the default first", func(t *testing.T) {
+		resp, err := s.ListJourneys(ctx, "")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := []string{types.DefaultJourneyID, early.JourneyId, late.JourneyId, nextDay.JourneyId}
+		if len(resp.Journeys) != len(want) {
+			t.Fatalf("expected %d journeys, got %d", len(want), len(resp.Journeys))
+		}
+		for i, id := range want {
+			if resp.Journeys[i].JourneyId != id {
+				t.Errorf("expected journey %d to be %s, got %s", i, id, resp.Journeys[i].JourneyId)
+			}
+		}
+	})
+
+	t.Run("Filters by service date", func(t *testing.T) {
+		resp, err := s.ListJourneys(ctx, "2025-05-02")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(resp.Journeys) != 1 || resp.Journeys[0].JourneyId != nextDay.JourneyId {
+			t.Errorf("expected only the 2025-05-02 journey, got %v", resp.Journeys)
+		}
+	})
+}
+
+func TestUnit_PurchaseTicketPerJourney(t *testing.T) {
+	ctx := context.Background()
+	s := NewTicketService()
+	journey := createJourney(t, s, "2025-05-01", time.Date(2025, 5, 1, 8, 0, 0, 0, time.UTC), 1)
+
+	t.Run("Journeys have independent seat inventories", func(t *testing.T) {
+		first := purchaseOn(t, s, "", "default@example.com")
+		second := purchaseOn(t, s, journey.JourneyId, "journey@example.com")
+		if !first.Success || !second.Success {
+			t.Fatalf("expected both purchases to succeed, got %q and %q", first.Message, second.Message)
+		}
+		if first.Receipt.AllocatedSeat.SeatNumber != "A1" || second.Receipt.AllocatedSeat.SeatNumber != "A1" {
+			t.Errorf("expected A1 on both journeys, got %s and %s", first.Receipt.AllocatedSeat.SeatNumber, second.Receipt.AllocatedSeat.SeatNumber)
+		}
+		if first.Receipt.JourneyId != types.DefaultJourneyID || second.Receipt.JourneyId != journey.JourneyId {
+			t.Errorf("expected receipts to record their journeys, got %s and %s", first.Receipt.JourneyId, second.Receipt.JourneyId)
+		}
+	})
+
+	t.Run("Journey sells out on its own capacity", func(t *testing.T) {
+		if res := purchaseOn(t, s, journey.JourneyId, "journey2@example.com"); !res.Success || res.Receipt.AllocatedSeat.SeatNumber != "B1" {
+			t.Fatalf("expected B1 on the journey, got %v", res)
+		}
+		_, err := tryPurchaseLeg(s, journey.JourneyId, "London", "Paris", "journey3@example.com")
+		expectError(t, err, ErrNoAvailableSeats)
+		if res := purchaseOn(t, s, "", "default2@example.com"); !res.Success {
+			t.Errorf("expected the default journey to still have seats, got %q", res.Message)
+		}
+	})
+
+	t.Run("Unknown journey", func(t *testing.T) {
+		_, err := tryPurchaseLeg(s, "no-such-journey", "London", "Paris", "lost@example.com")
+		expectError(t, err, ErrJourneyNotFound)
+	})
+
+	t.Run("Users by section are scoped per journey", func(t *testing.T) {
+		resp, err := s.GetUsersBySection(ctx, journey.JourneyId, "A")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(resp.UsersInSection) != 1 || resp.UsersInSection[0].User.GetEmail() != "journey@example.com" {
+			t.Errorf("expected only journey@example.com in section A, got %v", resp.UsersInSection)
+		}
+		if _, err := s.GetUsersBySection(ctx, "no-such-journey", "A"); err == nil {
+			t.Errorf("expected error for unknown journey")
+		}
+	})
+
+	t.Run("Seat changes stay within the journey", func(t *testing.T) {
+		receipt := purchaseOn(t, s, "", "mover@example.com").Receipt
+		// A1 is taken on the default journey even though it is also taken on the other journey.
+		_, err := s.ModifyUserSeat(ctx, receipt, &ticket.Seat{Section: ticket.Seat_SECTION_A, SeatNumber: "A1"})
+		if conflict := expectError(t, err, ErrSeatOccupied); conflict.Seat != "A1" || conflict.Kind != KindFailedPrecondition {
+			t.Errorf("expected the conflicting seat A1 to be reported, got %+v", conflict)
+		}
+		resp, err := s.ModifyUserSeat(ctx, receipt, &ticket.Seat{Section: ticket.Seat_SECTION_B, SeatNumber: "B5"})
+		if err != nil || !resp.Success {
+			t.Fatalf("expected seat change to succeed, got %v, %v", resp, err)
+		}
+		if occupant, _ := holderOf(s.repo, types.DefaultJourneyID, "B5"); occupant.GetTicketId() != receipt.TicketId {
+			t.Errorf("expected B5 on the default journey to be held by %s", receipt.TicketId)
+		}
+		if _, occupied := holderOf(s.repo, journey.JourneyId, "B5"); occupied {
+			t.Errorf("expected B5 on the other journey to stay free")
+		}
+	})
+}
+
+func TestUnit_JourneysSurviveRestart(t *testing.T) {
+	dir := t.TempDir()
+	repo, err := repository.NewFileRepository(dir, 0)
+	if err != nil {
+		t.Fatalf("unexpected error opening repository: %v", err)
+	}
+	s := NewTicketServiceWithRepository(repo)
+	journey := createJourney(t, s, "2025-05-01", time.Date(2025, 5, 1, 8, 0, 0, 0, time.UTC), 2)
+	for i := 0; i < 3; i++ {
+		purchaseOn(t, s, journey.JourneyId, fmt.Sprintf("user%d@example.com", i))
+	}
+	if err := repo.Close(); err != nil {
+		t.Fatalf("unexpected error closing repository: %v", err)
+	}
+
+	reopened, err := repository.NewFileRepository(dir, 0)
+	if err != nil {
+		t.Fatalf("unexpected error reopening repository: %v", err)
+	}
+	s = NewTicketServiceWithRepository(reopened)
+	restored, ok := s.lookupJourney(journey.JourneyId)
+	if !ok || restored.SeatsPerSection != 2 {
+		t.Fatalf("expected journey to be restored, got %v", restored)
+	}
+	seat, err := s.findNextAvailableSeat(restored, segment{from: 0, to: 1})
+	if err != nil || seat.SeatNumber != "B2" {
+		t.Errorf("expected next free seat B2 after restart, got %v, %v", seat, err)
+	}
+}
@@ -0,0 +1,33 @@
+package service
+
+import (
+	"log"
+
+	ticket "github.com/talk2sohail/train-ticket-api/internal/common/genproto/ticket"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/layout"
+)
+
+// WithLayouts makes named train layouts available to CreateJourney. The layout named
+// defaultLayout seats the default journey and journeys created without a layout;
+// an empty name keeps the standard A/B layout.
+func WithLayouts(layouts map[string]*ticket.TrainLayout, defaultLayout string) Option {
+	return func(s *TicketService) {
+		s.layouts = layouts
+		if defaultLayout == "" {
+			return
+		}
+		if l, ok := layouts[defaultLayout]; ok {
+			s.defaultLayout = l
+		} else {
+			log.Printf("[WithLayouts] Unknown default layout %s, keeping %s", defaultLayout, layout.StandardName)
+		}
+	}
+}
+
+// standardSeatsPerSection returns the section size of a standard A/B layout, or 0 for any other layout.
+func standardSeatsPerSection(l *ticket.TrainLayout) int32 {
+	if l.GetName() != layout.StandardName || len(l.GetCoaches()) == 0 {
+		return 0
+	}
+	return int32(len(l.GetCoaches()[0].GetSeats()))
+}
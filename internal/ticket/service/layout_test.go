@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ticket "github.com/talk2sohail/train-ticket-api/internal/common/genproto/ticket"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/layout"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// newLayoutService returns a service with a two-coach layout named "e320".
+func newLayoutService(t *testing.T, defaultLayout string) *TicketService {
+	t.Helper()
+	e320, err := layout.Build("e320", layout.Config{Coaches: []layout.CoachConfig{
+		{ID: "C", Rows: 1, Columns: []layout.ColumnConfig{{Letter: "A", Position: layout.PositionWindow}, {Letter: "B", Position: layout.PositionAisle}}},
+		{ID: "D", Rows: 1, Columns: []layout.ColumnConfig{{Letter: "A", Position: layout.PositionWindow}}, Attributes: layout.Attributes{QuietZone: true}},
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error building layout: %v", err)
+	}
+	return NewTicketService(WithLayouts(map[string]*ticket.TrainLayout{"e320": e320}, defaultLayout))
+}
+
+func TestUnit_CreateJourneyWithLayout(t *testing.T) {
+	ctx := context.Background()
+	s := newLayoutService(t, "")
+	req := &ticket.CreateJourneyRequest{
+		ServiceDate:   "2025-05-01",
+		DepartureTime: timestamppb.New(time.Date(2025, 5, 1, 8, 0, 0, 0, time.UTC)),
+		Origin:        "London",
+		Destination:   "Paris",
+		Layout:        "e320",
+	}
+
+	t.Run("Unknown layout", func(t *testing.T) {
+		bad := &ticket.CreateJourneyRequest{Origin: "London", Destination: "Paris", Layout: "tgv"}
+		_, err := s.CreateJourney(ctx, bad)
+		expectError(t, err, ErrLayoutNotFound)
+	})
+
+	resp, err := s.CreateJourney(ctx, req)
+	if err != nil || !resp.Success {
+		t.Fatalf("failed to create journey: %v, %v", resp, err)
+	}
+	journey := resp.Journey
+	if journey.GetLayout().GetName() != "e320" || journey.SeatsPerSection != 0 {
+		t.Errorf("expected the e320 layout, got %v", journey.GetLayout())
+	}
+
+	t.Run("Allocates seats in layout order", func(t *testing.T) {
+		want := []string{"C1A", "C1B", "D1A"}
+		for i, seatNumber := range want {
+			res := purchaseOn(t, s, journey.JourneyId, "passenger"+seatNumber+"@example.com")
+			if !res.Success || res.Receipt.AllocatedSeat.SeatNumber != seatNumber {
+				t.Fatalf("expected purchase %d to get %s, got %v", i, seatNumber, res)
+			}
+		}
+		_, err := tryPurchaseLeg(s, journey.JourneyId, "London", "Paris", "late@example.com")
+		expectError(t, err, ErrNoAvailableSeats)
+		quiet, _ := holderOf(s.repo, journey.JourneyId, "D1A")
+		if seat := quiet.GetAllocatedSeat(); seat.GetCoach() != "D" || !seat.GetAttributes().GetQuietZone() || !seat.GetAttributes().GetWindow() {
+			t.Errorf("expected the receipt to carry the seat's coach and attributes, got %v", seat)
+		}
+	})
+
+	t.Run("Lists users by coach", func(t *testing.T) {
+		resp, err := s.GetUsersBySection(ctx, journey.JourneyId, "C")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(resp.UsersInSection) != 2 {
+			t.Errorf("expected 2 users in coach C, got %d", len(resp.UsersInSection))
+		}
+		if _, err := s.GetUsersBySection(ctx, journey.JourneyId, "A"); err == nil {
+			t.Errorf("expected an error for a coach outside the layout")
+		}
+	})
+
+	t.Run("Seat change must stay within the layout", func(t *testing.T) {
+		receipt, _ := holderOf(s.repo, journey.JourneyId, "C1A")
+		_, err := s.ModifyUserSeat(ctx, receipt, &ticket.Seat{SeatNumber: "A1"})
+		expectError(t, err, ErrSeatNotFound)
+	})
+}
+
+func TestUnit_DefaultLayoutOption(t *testing.T) {
+	s := newLayoutService(t, "e320")
+	res := purchaseOn(t, s, "", "default@example.com")
+	if !res.Success || res.Receipt.AllocatedSeat.SeatNumber != "C1A" {
+		t.Errorf("expected the default journey to use the e320 layout, got %v", res)
+	}
+}
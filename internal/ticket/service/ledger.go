@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	ticket "github.com/talk2sohail/train-ticket-api/internal/common/genproto/ticket"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/types"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ticketPurchasedEvent records the issue of a new receipt.
+func ticketPurchasedEvent(receipt *ticket.Receipt, at time.Time) *ticket.BookingEvent {
+	return &ticket.BookingEvent{
+		TicketId:   receipt.GetTicketId(),
+		OccurredAt: timestamppb.New(at),
+		Event: &ticket.BookingEvent_TicketPurchased{
+			TicketPurchased: &ticket.TicketPurchased{Receipt: receipt},
+		},
+	}
+}
+
+// seatChangedEvent records a ticket moving from its current seat to newSeat.
+func seatChangedEvent(receipt *ticket.Receipt, newSeat *ticket.Seat, at time.Time) *ticket.BookingEvent {
+	return &ticket.BookingEvent{
+		TicketId:   receipt.GetTicketId(),
+		OccurredAt: timestamppb.New(at),
+		Event: &ticket.BookingEvent_SeatChanged{
+			SeatChanged: &ticket.SeatChanged{
+				PreviousSeat: receipt.GetAllocatedSeat(),
+				NewSeat:      newSeat,
+			},
+		},
+	}
+}
+
+// ticketCancelledEvent records a ticket being cancelled, its seat released and the refund given.
+func ticketCancelledEvent(receipt *ticket.Receipt, refund *ticket.Refund, at time.Time) *ticket.BookingEvent {
+	return &ticket.BookingEvent{
+		TicketId:   receipt.GetTicketId(),
+		OccurredAt: timestamppb.New(at),
+		Event: &ticket.BookingEvent_TicketCancelled{
+			TicketCancelled: &ticket.TicketCancelled{ReleasedSeat: receipt.GetAllocatedSeat(), Refund: refund},
+		},
+	}
+}
+
+// refundSettledEvent records whether the payment of a pending refund was returned.
+func refundSettledEvent(refund *ticket.Refund, status ticket.Refund_Status, at time.Time) *ticket.BookingEvent {
+	return &ticket.BookingEvent{
+		TicketId:   refund.GetTicketId(),
+		OccurredAt: timestamppb.New(at),
+		Event: &ticket.BookingEvent_RefundSettled{
+			RefundSettled: &ticket.RefundSettled{RefundId: refund.GetRefundId(), Status: status},
+		},
+	}
+}
+
+// GetTicketHistory returns every event recorded for a ticket, including cancelled tickets.
+func (s *TicketService) GetTicketHistory(ctx context.Context, ticketID string) (*ticket.GetTicketHistoryResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	events := s.repo.History(ticketID)
+	if len(events) == 0 {
+		err := newError(ErrTicketHistoryNotFound, ticketID)
+		log.Printf("[GetTicketHistory] %v", err)
+		return nil, err
+	}
+	log.Printf("[GetTicketHistory] Retrieved %d events for ticketID %s", len(events), ticketID)
+	return &ticket.GetTicketHistoryResponse{
+		Success: true,
+		Message: MsgTicketHistoryRetrieved,
+		Events:  events,
+	}, nil
+}
+
+// GetSeatOccupant replays the ledger up to the given time to find which ticket held a seat on a journey.
+// The returned receipt reflects the ticket as it was at that moment.
+func (s *TicketService) GetSeatOccupant(ctx context.Context, journeyID, seatNumber string, at time.Time) (*ticket.GetSeatOccupantResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if journeyID == "" {
+		journeyID = types.DefaultJourneyID
+	}
+
+	// Receipts as they were at the time being replayed, keyed by Ticket ID.
+	receipts := make(map[string]*ticket.Receipt)
+	for _, event := range s.repo.Events() {
+		if event.GetOccurredAt().AsTime().After(at) {
+			break
+		}
+		switch e := event.GetEvent().(type) {
+		case *ticket.BookingEvent_TicketPurchased:
+			receipts[event.GetTicketId()] = e.TicketPurchased.GetReceipt()
+		case *ticket.BookingEvent_SeatChanged:
+			if current, ok := receipts[event.GetTicketId()]; ok {
+				updated := proto.Clone(current).(*ticket.Receipt)
+				updated.AllocatedSeat = e.SeatChanged.GetNewSeat()
+				receipts[event.GetTicketId()] = updated
+			}
+		case *ticket.BookingEvent_TicketCancelled:
+			delete(receipts, event.GetTicketId())
+		}
+	}
+
+	// Tickets travelling non-overlapping legs can share a seat; report the earliest purchase.
+	var occupant *ticket.Receipt
+	for _, receipt := range receipts {
+		if types.JourneyIDOf(receipt) != journeyID || receipt.GetAllocatedSeat().GetSeatNumber() != seatNumber {
+			continue
+		}
+		if occupant == nil || types.PurchasedBefore(receipt, occupant) {
+			occupant = receipt
+		}
+	}
+	if occupant != nil {
+		log.Printf("[GetSeatOccupant] Seat %s was held by TicketID %s at %s", seatNumber, occupant.GetTicketId(), at.Format(time.RFC3339))
+		return &ticket.GetSeatOccupantResponse{
+			Success: true,
+			Message: MsgSeatOccupantRetrieved,
+			Receipt: occupant,
+		}, nil
+	}
+	log.Printf("[GetSeatOccupant] Seat %s was free at %s", seatNumber, at.Format(time.RFC3339))
+	return nil, newError(ErrSeatNotOccupied, seatNumber)
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ticket "github.com/talk2sohail/train-ticket-api/internal/common/genproto/ticket"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/types"
+)
+
+func TestUnit_GetTicketHistory(t *testing.T) {
+	ctx := context.Background()
+	s := NewTicketService()
+
+	res, err := s.PurchaseTicket(ctx, &ticket.PurchaseTicketRequest{
+		FromLocation: "London",
+		ToLocation:   "Paris",
+		User:         &ticket.User{FirstName: "Test", LastName: "User", Email: "history@example.com"},
+		PricePaid:    20.0,
+	})
+	if err != nil || !res.Success {
+		t.Fatalf("expected successful purchase, got %v, %v", res, err)
+	}
+	ticketID := res.Receipt.TicketId
+
+	if _, err := s.ModifyUserSeat(ctx, res.Receipt, &ticket.Seat{Section: ticket.Seat_SECTION_B, SeatNumber: "B2"}); err != nil {
+		t.Fatalf("unexpected error modifying seat: %v", err)
+	}
+	if _, err := s.RemoveUser(ctx, "history@example.com"); err != nil {
+		t.Fatalf("unexpected error removing user: %v", err)
+	}
+
+	t.Run("History of a cancelled ticket is kept", func(t *testing.T) {
+		resp, err := s.GetTicketHistory(ctx, ticketID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(resp.Events) != 3 {
+			t.Fatalf("expected 3 events, got %d", len(resp.Events))
+		}
+		if resp.Events[0].GetTicketPurchased().GetReceipt().GetAllocatedSeat().GetSeatNumber() != "A1" {
+			t.Errorf("expected purchase of A1 first, got %v", resp.Events[0])
+		}
+		changed := resp.Events[1].GetSeatChanged()
+		if changed.GetPreviousSeat().GetSeatNumber() != "A1" || changed.GetNewSeat().GetSeatNumber() != "B2" {
+			t.Errorf("expected seat change A1 -> B2, got %v", resp.Events[1])
+		}
+		if resp.Events[2].GetTicketCancelled().GetReleasedSeat().GetSeatNumber() != "B2" {
+			t.Errorf("expected cancellation releasing B2, got %v", resp.Events[2])
+		}
+		for i, event := range resp.Events {
+			if event.Sequence != uint64(i+1) {
+				t.Errorf("expected sequence %d, got %d", i+1, event.Sequence)
+			}
+		}
+	})
+
+	t.Run("Current state is derived from the ledger", func(t *testing.T) {
+		if _, err := s.GetReceiptDetails(ctx, ticketID); err == nil {
+			t.Errorf("expected cancelled ticket to have no active receipt")
+		}
+		if _, occupied := holderOf(s.repo, types.DefaultJourneyID, "B2"); occupied {
+			t.Errorf("expected seat B2 to be free after cancellation")
+		}
+	})
+
+	t.Run("Unknown ticket returns error", func(t *testing.T) {
+		if _, err := s.GetTicketHistory(ctx, "unknown"); err == nil {
+			t.Errorf("expected error for unknown ticket, got nil")
+		}
+	})
+}
+
+func TestUnit_GetSeatOccupant(t *testing.T) {
+	ctx := context.Background()
+	s := NewTicketService()
+
+	nine := time.Date(2025, 5, 1, 9, 0, 0, 0, time.UTC)
+	receipt := &ticket.Receipt{
+		TicketId:      "ticket1",
+		User:          &ticket.User{Email: "first@example.com"},
+		AllocatedSeat: &ticket.Seat{Section: ticket.Seat_SECTION_B, SeatNumber: "B2"},
+	}
+	second := &ticket.Receipt{
+		TicketId:      "ticket2",
+		User:          &ticket.User{Email: "second@example.com"},
+		AllocatedSeat: &ticket.Seat{Section: ticket.Seat_SECTION_A, SeatNumber: "A1"},
+	}
+	// ticket1 sits in B2 from 09:00 and moves to B3 at 10:30; ticket2 moves into B2 at 11:00.
+	err := s.repo.Append(
+		ticketPurchasedEvent(receipt, nine),
+		ticketPurchasedEvent(second, nine.Add(5*time.Minute)),
+		seatChangedEvent(receipt, &ticket.Seat{Section: ticket.Seat_SECTION_B, SeatNumber: "B3"}, nine.Add(90*time.Minute)),
+		seatChangedEvent(second, &ticket.Seat{Section: ticket.Seat_SECTION_B, SeatNumber: "B2"}, nine.Add(2*time.Hour)),
+	)
+	if err != nil {
+		t.Fatalf("failed to seed ledger: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		at       time.Time
+		ticketID string
+	}{
+		{"Before any booking", nine.Add(-time.Minute), ""},
+		{"First passenger at 10:00", nine.Add(time.Hour), "ticket1"},
+		{"Free between passengers", nine.Add(100 * time.Minute), ""},
+		{"Second passenger at 12:00", nine.Add(3 * time.Hour), "ticket2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := s.GetSeatOccupant(ctx, "", "B2", tt.at)
+			if tt.ticketID == "" {
+				expectError(t, err, ErrSeatNotOccupied)
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !resp.Success || resp.Receipt.GetTicketId() != tt.ticketID {
+				t.Errorf("expected %s in B2, got %v", tt.ticketID, resp.Receipt)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package service
+
+// TicketOwner returns the email of the passenger a ticket was issued to, including a cancelled ticket.
+func (s *TicketService) TicketOwner(ticketID string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if receipt, ok := s.repo.GetReceipt(ticketID); ok {
+		return receipt.GetUser().GetEmail(), true
+	}
+	// A cancelled ticket is only found in the ledger.
+	for _, event := range s.repo.History(ticketID) {
+		if purchased := event.GetTicketPurchased(); purchased != nil {
+			return purchased.GetReceipt().GetUser().GetEmail(), true
+		}
+	}
+	return "", false
+}
+
+// HoldOwner returns the email of the passenger a seat hold was taken for.
+func (s *TicketService) HoldOwner(holdID string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	hold, ok := s.holds[holdID]
+	if !ok {
+		return "", false
+	}
+	return hold.user.GetEmail(), true
+}
+
+// RefundOwner returns the email of the passenger whose cancelled ticket a refund was given for.
+func (s *TicketService) RefundOwner(refundID string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	refund, ok := s.repo.GetRefund(refundID)
+	if !ok {
+		return "", false
+	}
+	return refund.GetUser().GetEmail(), true
+}
+
+// WaitlistOwner returns the email of the passenger a waitlist entry queues.
+func (s *TicketService) WaitlistOwner(waitlistID string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.repo.GetWaitlistEntry(waitlistID)
+	if !ok {
+		return "", false
+	}
+	return entry.GetUser().GetEmail(), true
+}
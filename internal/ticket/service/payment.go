@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"log"
+
+	ticket "github.com/talk2sohail/train-ticket-api/internal/common/genproto/ticket"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/money"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/payment"
+
+	"github.com/google/uuid"
+)
+
+// WithPayments takes payment for purchased tickets through provider and refunds it
+// when they are cancelled. Without a provider tickets are booked without payment.
+func WithPayments(provider payment.Provider) Option {
+	return func(s *TicketService) {
+		s.payments = provider
+	}
+}
+
+// unlocked calls f with the server's mutex released, so that a slow payment processor holds
+// up no other booking. Whatever the caller found under the mutex may have changed when f returns.
+// This function assumes the caller has already acquired the server's mutex.
+func (s *TicketService) unlocked(f func()) {
+	s.mu.Unlock()
+	defer s.mu.Lock()
+	f()
+}
+
+// reserve keeps a seat over seg for user while their payment is taken, and returns the ID of the
+// reservation. A reservation blocks the seat and counts against the passenger's quota like a hold,
+// but it never expires and cannot be confirmed. waitlistID names the waitlist entry being promoted
+// into the seat, if any.
+// This function assumes the caller has already acquired the server's mutex.
+func (s *TicketService) reserve(journeyID string, seat *ticket.Seat, seg segment, user *ticket.User, waitlistID string) string {
+	id := uuid.New().String()
+	s.holds[id] = &seatHold{id: id, journeyID: journeyID, seat: seat, seg: seg, user: user, pending: true, waitlistID: waitlistID}
+	return id
+}
+
+// release drops reservations once their payments are settled.
+// This function assumes the caller has already acquired the server's mutex.
+func (s *TicketService) release(reservations ...string) {
+	for _, id := range reservations {
+		delete(s.holds, id)
+	}
+}
+
+// authorize reserves the price of a purchase on the passenger's payment method and
+// returns the payment's ID, or "" when the service takes no payment.
+// The processor is called with the server's mutex released.
+// This function assumes the caller has already acquired the server's mutex.
+func (s *TicketService) authorize(ctx context.Context, method, who, paymentMethod string, paid money.Money) (string, error) {
+	if s.payments == nil {
+		return "", nil
+	}
+	var paymentID string
+	var err error
+	s.unlocked(func() {
+		paymentID, err = s.payments.Authorize(ctx, paymentMethod, paid)
+	})
+	if err != nil {
+		log.Printf("[%s] Payment of %s for %s was not authorized: %v", method, paid, who, err)
+		return "", paymentError(err, "")
+	}
+	return paymentID, nil
+}
+
+// capture takes an authorized payment once its seat is allocated, voiding it if it cannot be taken.
+// The processor is called with the server's mutex released, so the seat must be reserved.
+// This function assumes the caller has already acquired the server's mutex.
+func (s *TicketService) capture(ctx context.Context, method, paymentID string) error {
+	if paymentID == "" {
+		return nil
+	}
+	var err error
+	s.unlocked(func() {
+		err = s.payments.Capture(ctx, paymentID)
+	})
+	if err != nil {
+		log.Printf("[%s] Failed to capture payment %s: %v", method, paymentID, err)
+		s.void(ctx, method, paymentID)
+		return paymentError(err, "")
+	}
+	return nil
+}
+
+// charge authorizes and captures the price of a seat that is already kept for the passenger,
+// and returns the payment's ID, or "" when the service takes no payment.
+// This function assumes the caller has already acquired the server's mutex.
+func (s *TicketService) charge(ctx context.Context, method, who, paymentMethod string, paid money.Money) (string, error) {
+	paymentID, err := s.authorize(ctx, method, who, paymentMethod, paid)
+	if err != nil {
+		return "", err
+	}
+	if err := s.capture(ctx, method, paymentID); err != nil {
+		return "", err
+	}
+	return paymentID, nil
+}
+
+// void releases an authorized payment whose booking failed. One that cannot be
+// voided is logged and left to lapse at the processor.
+// This function assumes the caller has already acquired the server's mutex.
+func (s *TicketService) void(ctx context.Context, method, paymentID string) {
+	if paymentID == "" {
+		return
+	}
+	var err error
+	s.unlocked(func() {
+		err = s.payments.Void(ctx, paymentID)
+	})
+	if err != nil {
+		log.Printf("[%s] Failed to void payment %s: %v", method, paymentID, err)
+	}
+}
+
+// paymentOf describes a captured payment on its receipt, or returns nil when none was taken.
+func (s *TicketService) paymentOf(paymentID string, paid money.Money) *ticket.Payment {
+	if paymentID == "" {
+		return nil
+	}
+	return &ticket.Payment{PaymentId: paymentID, Provider: s.payments.Name(), Amount: paid.Proto()}
+}
+
+// refund returns the payment taken for a ticket in full. Tickets booked without
+// payment have nothing to refund.
+// This function assumes the caller has already acquired the server's mutex.
+func (s *TicketService) refund(ctx context.Context, method string, receipt *ticket.Receipt) error {
+	if receipt.GetPayment() == nil {
+		return nil
+	}
+	amount, err := money.FromProto(receipt.GetPayment().GetAmount())
+	if err != nil {
+		log.Printf("[%s] Cannot refund payment %s of TicketID %s: %v", method, receipt.GetPayment().GetPaymentId(), receipt.GetTicketId(), err)
+		return newError(ErrPaymentFailed, receipt.GetTicketId())
+	}
+	return s.refundPayment(ctx, method, receipt, amount)
+}
+
+// refundable checks that amount of the payment taken for a ticket can be returned:
+// the provider that took it must still be configured.
+func (s *TicketService) refundable(method string, receipt *ticket.Receipt, amount money.Money) error {
+	p := receipt.GetPayment()
+	if p == nil || amount.Minor == 0 {
+		return nil
+	}
+	if s.payments == nil || s.payments.Name() != p.GetProvider() {
+		log.Printf("[%s] Cannot refund payment %s of TicketID %s taken through %q", method, p.GetPaymentId(), receipt.GetTicketId(), p.GetProvider())
+		return newError(ErrPaymentFailed, receipt.GetTicketId())
+	}
+	return nil
+}
+
+// refundPayment returns amount of the payment taken for a ticket. Tickets booked
+// without payment have nothing to return. The processor is called with the server's mutex released.
+// This function assumes the caller has already acquired the server's mutex.
+func (s *TicketService) refundPayment(ctx context.Context, method string, receipt *ticket.Receipt, amount money.Money) error {
+	p := receipt.GetPayment()
+	if p == nil || amount.Minor == 0 {
+		return nil
+	}
+	if err := s.refundable(method, receipt, amount); err != nil {
+		return err
+	}
+	var err error
+	s.unlocked(func() {
+		err = s.payments.Refund(ctx, p.GetPaymentId(), amount)
+	})
+	if err != nil {
+		log.Printf("[%s] Failed to refund payment %s of TicketID %s: %v", method, p.GetPaymentId(), receipt.GetTicketId(), err)
+		return paymentError(err, receipt.GetTicketId())
+	}
+	log.Printf("[%s] Refunded %s of payment %s for TicketID %s", method, amount, p.GetPaymentId(), receipt.GetTicketId())
+	return nil
+}
+
+// paymentError reports a failed call to the payment provider: a decline with its
+// code, or anything else as a payment that could not be processed about subject.
+func paymentError(err error, subject string) error {
+	if d, ok := payment.AsDeclined(err); ok {
+		return newError(ErrPaymentDeclined, d.Code)
+	}
+	return newError(ErrPaymentFailed, subject)
+}
@@ -0,0 +1,468 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	ticket "github.com/talk2sohail/train-ticket-api/internal/common/genproto/ticket"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/money"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/payment"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/types"
+)
+
+// hookedProvider is a payment.Fake that runs a hook before the next call of an operation,
+// e.g. to act on the service while it waits for the processor.
+type hookedProvider struct {
+	*payment.Fake
+	hooks map[payment.Op]func()
+}
+
+func newHookedProvider() *hookedProvider {
+	return &hookedProvider{Fake: payment.NewFake(), hooks: make(map[payment.Op]func())}
+}
+
+// before runs hook before the next call of op.
+func (p *hookedProvider) before(op payment.Op, hook func()) {
+	p.hooks[op] = hook
+}
+
+func (p *hookedProvider) run(op payment.Op) {
+	if hook, ok := p.hooks[op]; ok {
+		delete(p.hooks, op)
+		hook()
+	}
+}
+
+func (p *hookedProvider) Authorize(ctx context.Context, method string, amount money.Money) (string, error) {
+	p.run(payment.OpAuthorize)
+	return p.Fake.Authorize(ctx, method, amount)
+}
+
+func (p *hookedProvider) Capture(ctx context.Context, paymentID string) error {
+	p.run(payment.OpCapture)
+	return p.Fake.Capture(ctx, paymentID)
+}
+
+func (p *hookedProvider) Refund(ctx context.Context, paymentID string, amount money.Money) error {
+	p.run(payment.OpRefund)
+	return p.Fake.Refund(ctx, paymentID, amount)
+}
+
+// promotionFailingRepository fails to store promotions from the waitlist.
+type promotionFailingRepository struct {
+	types.TicketRepository
+}
+
+func (r promotionFailingRepository) Append(events ...*ticket.BookingEvent) error {
+	for _, event := range events {
+		if event.GetWaitlistPromoted() != nil {
+			return errors.New("disk full")
+		}
+	}
+	return r.TicketRepository.Append(events...)
+}
+
+// expectPayment fails the test unless the fake holds a payment in state.
+func expectPayment(t *testing.T, fake *payment.Fake, paymentID string, state payment.State) {
+	t.Helper()
+	if p, ok := fake.Payment(paymentID); !ok || p.State != state {
+		t.Errorf("expected payment %s to be %s, got %+v", paymentID, state, p)
+	}
+}
+
+func TestUnit_PurchasePayment(t *testing.T) {
+	ctx := context.Background()
+	outage := errors.New("processor unavailable")
+
+	t.Run("Captured once the seat is allocated", func(t *testing.T) {
+		fake := payment.NewFake()
+		s := NewTicketService(WithPayments(fake))
+		receipt := purchaseOn(t, s, "", "alice@example.com").GetReceipt()
+		p := receipt.GetPayment()
+		if p.GetProvider() != payment.FakeName || p.GetAmount().GetMinorUnits() != 2000 || p.GetAmount().GetCurrencyCode() != money.USD {
+			t.Fatalf("expected a payment of 20.00 USD through the fake, got %v", p)
+		}
+		expectPayment(t, fake, p.GetPaymentId(), payment.StateCaptured)
+	})
+
+	t.Run("Booked without payment when no provider is configured", func(t *testing.T) {
+		receipt := purchaseOn(t, NewTicketService(), "", "alice@example.com").GetReceipt()
+		if receipt.GetPayment() != nil {
+			t.Errorf("expected no payment, got %v", receipt.GetPayment())
+		}
+	})
+
+	t.Run("Declined payment method", func(t *testing.T) {
+		fake := payment.NewFake()
+		fake.DeclineMethod("tok_broke", "insufficient_funds")
+		s := NewTicketService(WithPayments(fake))
+		_, err := s.PurchaseTicket(ctx, &ticket.PurchaseTicketRequest{
+			FromLocation:  "London",
+			ToLocation:    "Paris",
+			User:          &ticket.User{Email: "alice@example.com"},
+			PricePaid:     20,
+			PaymentMethod: "tok_broke",
+		})
+		e := expectError(t, err, ErrPaymentDeclined)
+		if e.Kind != KindFailedPrecondition || e.Subject != "insufficient_funds" {
+			t.Errorf("expected a failed precondition about insufficient_funds, got %+v", e)
+		}
+		if n := len(s.repo.ListReceipts()); n != 0 {
+			t.Errorf("expected no ticket to be booked, got %d", n)
+		}
+	})
+
+	t.Run("Processor failing to authorize", func(t *testing.T) {
+		fake := payment.NewFake()
+		fake.Script(payment.OpAuthorize, payment.Outcome{Err: outage})
+		s := NewTicketService(WithPayments(fake))
+		_, err := tryPurchaseLeg(s, "", "London", "Paris", "alice@example.com")
+		if e := expectError(t, err, ErrPaymentFailed); e.Kind != KindUnavailable {
+			t.Errorf("expected %v, got %v", KindUnavailable, e.Kind)
+		}
+		// The processor recovers and the retry is served.
+		purchaseOn(t, s, "", "alice@example.com")
+	})
+
+	t.Run("Not authorized when no seat is free", func(t *testing.T) {
+		fake := payment.NewFake()
+		s := NewTicketService(WithPayments(fake))
+		journey := createJourney(t, s, "2024-05-10", time.Date(2024, 5, 10, 8, 0, 0, 0, time.UTC), 1)
+		purchaseOn(t, s, journey.GetJourneyId(), "alice@example.com")
+		purchaseOn(t, s, journey.GetJourneyId(), "bob@example.com")
+		_, err := tryPurchaseLeg(s, journey.GetJourneyId(), "London", "Paris", "carol@example.com")
+		expectError(t, err, ErrNoAvailableSeats)
+		if _, ok := fake.Payment("fake_3"); ok {
+			t.Errorf("expected no payment to be authorized")
+		}
+	})
+
+	t.Run("Not authorized when the fare does not match", func(t *testing.T) {
+		fake := payment.NewFake()
+		s := newFareService(t, WithPayments(fake))
+		_, err := s.PurchaseTicket(ctx, &ticket.PurchaseTicketRequest{
+			FromLocation: "London",
+			ToLocation:   "Paris",
+			User:         &ticket.User{Email: "alice@example.com"},
+			PricePaid:    25,
+		})
+		expectError(t, err, ErrFareMismatch)
+		if _, ok := fake.Payment("fake_1"); ok {
+			t.Errorf("expected no payment to be authorized")
+		}
+	})
+
+	t.Run("Voided when the last seat is sold during authorization", func(t *testing.T) {
+		provider := newHookedProvider()
+		s := NewTicketService(WithPayments(provider))
+		journey := createJourney(t, s, "2024-05-10", time.Date(2024, 5, 10, 8, 0, 0, 0, time.UTC), 1)
+		purchaseOn(t, s, journey.GetJourneyId(), "alice@example.com")
+		// The service is not locked while the processor is called, so another passenger can buy the seat.
+		provider.before(payment.OpAuthorize, func() {
+			purchaseOn(t, s, journey.GetJourneyId(), "bob@example.com")
+		})
+		_, err := tryPurchaseLeg(s, journey.GetJourneyId(), "London", "Paris", "carol@example.com")
+		expectError(t, err, ErrNoAvailableSeats)
+		// Bob's payment is authorized during Carol's, so hers comes third.
+		expectPayment(t, provider.Fake, "fake_3", payment.StateVoided)
+	})
+
+	t.Run("Seat kept while the payment is captured", func(t *testing.T) {
+		provider := newHookedProvider()
+		s := NewTicketService(WithPayments(provider))
+		journey := createJourney(t, s, "2024-05-10", time.Date(2024, 5, 10, 8, 0, 0, 0, time.UTC), 1)
+		purchaseOn(t, s, journey.GetJourneyId(), "alice@example.com")
+		provider.before(payment.OpCapture, func() {
+			_, err := tryPurchaseLeg(s, journey.GetJourneyId(), "London", "Paris", "bob@example.com")
+			expectError(t, err, ErrNoAvailableSeats)
+		})
+		receipt := purchaseOn(t, s, journey.GetJourneyId(), "carol@example.com").GetReceipt()
+		expectPayment(t, provider.Fake, receipt.GetPayment().GetPaymentId(), payment.StateCaptured)
+	})
+
+	t.Run("Voided when capture fails", func(t *testing.T) {
+		fake := payment.NewFake()
+		fake.Script(payment.OpCapture, payment.Outcome{Err: outage})
+		s := NewTicketService(WithPayments(fake))
+		_, err := tryPurchaseLeg(s, "", "London", "Paris", "alice@example.com")
+		expectError(t, err, ErrPaymentFailed)
+		expectPayment(t, fake, "fake_1", payment.StateVoided)
+		if n := len(s.repo.ListReceipts()); n != 0 {
+			t.Errorf("expected no ticket to be booked, got %d", n)
+		}
+	})
+
+	t.Run("Processor too slow", func(t *testing.T) {
+		fake := payment.NewFake()
+		fake.Script(payment.OpAuthorize, payment.Outcome{Latency: time.Hour})
+		s := NewTicketService(WithPayments(fake))
+		ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+		_, err := s.PurchaseTicket(ctx, &ticket.PurchaseTicketRequest{
+			FromLocation: "London",
+			ToLocation:   "Paris",
+			User:         &ticket.User{Email: "alice@example.com"},
+			PricePaid:    20,
+		})
+		expectError(t, err, ErrPaymentFailed)
+	})
+}
+
+func TestUnit_CancelRefund(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Refunded in full", func(t *testing.T) {
+		fake := payment.NewFake()
+		s := NewTicketService(WithPayments(fake))
+		receipt := purchaseOn(t, s, "", "alice@example.com").GetReceipt()
+		if _, err := s.CancelTicket(ctx, receipt.GetTicketId()); err != nil {
+			t.Fatalf("unexpected error cancelling: %v", err)
+		}
+		expectPayment(t, fake, receipt.GetPayment().GetPaymentId(), payment.StateRefunded)
+	})
+
+	t.Run("Failed refund recorded on the cancelled ticket", func(t *testing.T) {
+		fake := payment.NewFake()
+		s := NewTicketService(WithPayments(fake))
+		receipt := purchaseOn(t, s, "", "alice@example.com").GetReceipt()
+		fake.Script(payment.OpRefund, payment.Outcome{Decline: "account_closed"})
+		resp, err := s.RemoveUser(ctx, "alice@example.com")
+		if err != nil {
+			t.Fatalf("unexpected error removing: %v", err)
+		}
+		if status := resp.GetRefund().GetStatus(); status != ticket.Refund_STATUS_FAILED {
+			t.Errorf("expected the refund to be %v, got %v", ticket.Refund_STATUS_FAILED, status)
+		}
+		if _, err := s.GetReceiptDetails(ctx, receipt.GetTicketId()); err == nil {
+			t.Errorf("expected the ticket to be cancelled")
+		}
+		got, _ := s.GetRefund(ctx, resp.GetRefund().GetRefundId())
+		if got.GetRefund().GetStatus() != ticket.Refund_STATUS_FAILED {
+			t.Errorf("expected the failure to be recorded, got %v", got.GetRefund())
+		}
+		expectPayment(t, fake, receipt.GetPayment().GetPaymentId(), payment.StateCaptured)
+	})
+
+	t.Run("Refunded once when cancelled twice at the same time", func(t *testing.T) {
+		provider := newHookedProvider()
+		s := NewTicketService(WithPayments(provider))
+		receipt := purchaseOn(t, s, "", "alice@example.com").GetReceipt()
+		provider.before(payment.OpRefund, func() {
+			_, err := s.CancelTicket(ctx, receipt.GetTicketId())
+			expectError(t, err, ErrReceiptNotFound)
+		})
+		if _, err := s.CancelTicket(ctx, receipt.GetTicketId()); err != nil {
+			t.Fatalf("unexpected error cancelling: %v", err)
+		}
+		expectPayment(t, provider.Fake, receipt.GetPayment().GetPaymentId(), payment.StateRefunded)
+	})
+
+	t.Run("Provider that took the payment is gone", func(t *testing.T) {
+		s := NewTicketService(WithPayments(payment.NewFake()))
+		receipt := purchaseOn(t, s, "", "alice@example.com").GetReceipt()
+		s.payments = nil
+		_, err := s.CancelTicket(ctx, receipt.GetTicketId())
+		expectError(t, err, ErrPaymentFailed)
+	})
+}
+
+func TestUnit_GroupPayment(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Party charged once and refunded by the passenger", func(t *testing.T) {
+		fake := payment.NewFake()
+		s := NewTicketService(WithPayments(fake))
+		resp, err := s.PurchaseGroupTicket(ctx, groupRequest(3, false))
+		if err != nil {
+			t.Fatalf("unexpected error purchasing: %v", err)
+		}
+		for _, receipt := range resp.GetReceipts() {
+			if p := receipt.GetPayment(); p.GetPaymentId() != "fake_1" || p.GetAmount().GetMinorUnits() != 2000 {
+				t.Errorf("expected a 20.00 share of fake_1, got %v", p)
+			}
+		}
+		if p, _ := fake.Payment("fake_1"); p.State != payment.StateCaptured || p.Amount.Minor != 6000 {
+			t.Fatalf("expected 60.00 to be captured, got %+v", p)
+		}
+		if _, err := s.CancelTicket(ctx, resp.GetReceipts()[0].GetTicketId()); err != nil {
+			t.Fatalf("unexpected error cancelling: %v", err)
+		}
+		if p, _ := fake.Payment("fake_1"); p.State != payment.StateCaptured || p.Refunded.Minor != 2000 {
+			t.Errorf("expected one share to be refunded, got %+v", p)
+		}
+	})
+
+	t.Run("Declined payment method", func(t *testing.T) {
+		fake := payment.NewFake()
+		fake.DeclineMethod("tok_broke", "insufficient_funds")
+		s := NewTicketService(WithPayments(fake))
+		req := groupRequest(3, false)
+		req.PaymentMethod = "tok_broke"
+		_, err := s.PurchaseGroupTicket(ctx, req)
+		if e := expectError(t, err, ErrPaymentDeclined); e.Subject != "insufficient_funds" {
+			t.Errorf("expected the decline code as subject, got %q", e.Subject)
+		}
+		if n := len(s.repo.ListReceipts()); n != 0 {
+			t.Errorf("expected no ticket to be booked, got %d", n)
+		}
+	})
+
+	t.Run("Voided when the party can no longer be seated", func(t *testing.T) {
+		provider := newHookedProvider()
+		s := NewTicketService(WithPayments(provider))
+		journey := createJourney(t, s, "2024-05-10", time.Date(2024, 5, 10, 8, 0, 0, 0, time.UTC), 1)
+		req := groupRequest(2, true)
+		req.JourneyId = journey.GetJourneyId()
+		provider.before(payment.OpAuthorize, func() {
+			purchaseOn(t, s, journey.GetJourneyId(), "alice@example.com")
+		})
+		_, err := s.PurchaseGroupTicket(ctx, req)
+		expectError(t, err, ErrNoAvailableSeats)
+		expectPayment(t, provider.Fake, "fake_2", payment.StateVoided)
+	})
+
+	t.Run("Total too large to charge", func(t *testing.T) {
+		fake := payment.NewFake()
+		s := NewTicketService(WithPayments(fake))
+		req := groupRequest(3, false)
+		req.Price = &ticket.Money{MinorUnits: math.MaxInt64 / 2, CurrencyCode: money.USD}
+		_, err := s.PurchaseGroupTicket(ctx, req)
+		if e := expectError(t, err, ErrPriceTooLarge); e.Kind != KindInvalidArgument {
+			t.Errorf("expected %v, got %v", KindInvalidArgument, e.Kind)
+		}
+		if _, ok := fake.Payment("fake_1"); ok {
+			t.Errorf("expected no payment to be authorized")
+		}
+	})
+}
+
+func TestUnit_HoldPayment(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Captured on confirmation", func(t *testing.T) {
+		fake := payment.NewFake()
+		s := NewTicketService(WithPayments(fake))
+		hold := holdSeat(t, s, "alice@example.com")
+		resp, err := s.ConfirmHold(ctx, &ticket.ConfirmHoldRequest{HoldId: hold.GetHoldId(), PricePaid: 20})
+		if err != nil {
+			t.Fatalf("unexpected error confirming: %v", err)
+		}
+		expectPayment(t, fake, resp.GetReceipt().GetPayment().GetPaymentId(), payment.StateCaptured)
+	})
+
+	t.Run("Declined payment method keeps the hold", func(t *testing.T) {
+		fake := payment.NewFake()
+		fake.DeclineMethod("tok_broke", "insufficient_funds")
+		s := NewTicketService(WithPayments(fake))
+		hold := holdSeat(t, s, "alice@example.com")
+		_, err := s.ConfirmHold(ctx, &ticket.ConfirmHoldRequest{HoldId: hold.GetHoldId(), PricePaid: 20, PaymentMethod: "tok_broke"})
+		expectError(t, err, ErrPaymentDeclined)
+		if n := len(s.repo.ListReceipts()); n != 0 {
+			t.Errorf("expected no ticket to be booked, got %d", n)
+		}
+		// The passenger can pay another way while the seat is still held.
+		if _, err := s.ConfirmHold(ctx, &ticket.ConfirmHoldRequest{HoldId: hold.GetHoldId(), PricePaid: 20, PaymentMethod: "tok_visa"}); err != nil {
+			t.Errorf("unexpected error confirming with another method: %v", err)
+		}
+	})
+
+	t.Run("Confirmed once while it is paid for", func(t *testing.T) {
+		provider := newHookedProvider()
+		s := NewTicketService(WithPayments(provider))
+		hold := holdSeat(t, s, "alice@example.com")
+		provider.before(payment.OpAuthorize, func() {
+			_, err := s.ConfirmHold(ctx, &ticket.ConfirmHoldRequest{HoldId: hold.GetHoldId(), PricePaid: 20})
+			expectError(t, err, ErrHoldNotFound)
+		})
+		if _, err := s.ConfirmHold(ctx, &ticket.ConfirmHoldRequest{HoldId: hold.GetHoldId(), PricePaid: 20}); err != nil {
+			t.Fatalf("unexpected error confirming: %v", err)
+		}
+		if _, ok := provider.Payment("fake_2"); ok {
+			t.Errorf("expected a single payment")
+		}
+	})
+}
+
+func TestUnit_WaitlistPayment(t *testing.T) {
+	ctx := context.Background()
+	// soldOut returns a service taking payment through provider with a journey of two seats, both sold.
+	soldOut := func(t *testing.T, provider payment.Provider) (*TicketService, *ticket.Journey, []*ticket.Receipt) {
+		t.Helper()
+		s := NewTicketService(WithPayments(provider))
+		journey := createJourney(t, s, "2024-05-10", time.Date(2024, 5, 10, 8, 0, 0, 0, time.UTC), 1)
+		return s, journey, []*ticket.Receipt{
+			purchaseOn(t, s, journey.GetJourneyId(), "alice@example.com").GetReceipt(),
+			purchaseOn(t, s, journey.GetJourneyId(), "bob@example.com").GetReceipt(),
+		}
+	}
+
+	t.Run("Charged on promotion, not on joining", func(t *testing.T) {
+		fake := payment.NewFake()
+		s, journey, sold := soldOut(t, fake)
+		entry := joinWaitlist(t, s, journey.GetJourneyId(), "London", "Paris", "carol@example.com").GetEntry()
+		if _, ok := fake.Payment("fake_3"); ok {
+			t.Fatalf("expected no payment to be authorized on joining")
+		}
+
+		if _, err := s.CancelTicket(ctx, sold[0].GetTicketId()); err != nil {
+			t.Fatalf("unexpected error cancelling: %v", err)
+		}
+		status := waitlistStatus(t, s, entry.GetWaitlistId())
+		if status.GetEntry().GetStatus() != ticket.WaitlistEntry_STATUS_PROMOTED {
+			t.Fatalf("expected the passenger to be promoted, got %v", status.GetEntry().GetStatus())
+		}
+		expectPayment(t, fake, status.GetReceipt().GetPayment().GetPaymentId(), payment.StateCaptured)
+	})
+
+	t.Run("Passed over when the payment is declined", func(t *testing.T) {
+		fake := payment.NewFake()
+		s, journey, sold := soldOut(t, fake)
+		first := joinWaitlist(t, s, journey.GetJourneyId(), "London", "Paris", "carol@example.com").GetEntry()
+		second := joinWaitlist(t, s, journey.GetJourneyId(), "London", "Paris", "dave@example.com").GetEntry()
+		fake.Script(payment.OpAuthorize, payment.Outcome{Decline: "expired_card"})
+
+		if _, err := s.CancelTicket(ctx, sold[0].GetTicketId()); err != nil {
+			t.Fatalf("unexpected error cancelling: %v", err)
+		}
+		if status := waitlistStatus(t, s, first.GetWaitlistId()); status.GetEntry().GetStatus() != ticket.WaitlistEntry_STATUS_WAITING || status.GetPosition() != 1 {
+			t.Errorf("expected the declined passenger to keep their place, got %v", status)
+		}
+		if status := waitlistStatus(t, s, second.GetWaitlistId()); status.GetEntry().GetStatus() != ticket.WaitlistEntry_STATUS_PROMOTED {
+			t.Errorf("expected the next passenger to be promoted, got %v", status.GetEntry().GetStatus())
+		}
+	})
+
+	t.Run("Seat kept while the promotion is paid for", func(t *testing.T) {
+		provider := newHookedProvider()
+		s, journey, sold := soldOut(t, provider)
+		entry := joinWaitlist(t, s, journey.GetJourneyId(), "London", "Paris", "carol@example.com").GetEntry()
+		provider.before(payment.OpCapture, func() {
+			_, err := tryPurchaseLeg(s, journey.GetJourneyId(), "London", "Paris", "dave@example.com")
+			expectError(t, err, ErrNoAvailableSeats)
+		})
+		if _, err := s.CancelTicket(ctx, sold[0].GetTicketId()); err != nil {
+			t.Fatalf("unexpected error cancelling: %v", err)
+		}
+		if status := waitlistStatus(t, s, entry.GetWaitlistId()); status.GetEntry().GetStatus() != ticket.WaitlistEntry_STATUS_PROMOTED {
+			t.Errorf("expected the passenger to be promoted, got %v", status.GetEntry().GetStatus())
+		}
+	})
+
+	t.Run("Refunded when the promotion cannot be stored", func(t *testing.T) {
+		fake := payment.NewFake()
+		s, journey, sold := soldOut(t, fake)
+		entry := joinWaitlist(t, s, journey.GetJourneyId(), "London", "Paris", "carol@example.com").GetEntry()
+		s.repo = promotionFailingRepository{s.repo}
+
+		if _, err := s.CancelTicket(ctx, sold[0].GetTicketId()); err != nil {
+			t.Fatalf("unexpected error cancelling: %v", err)
+		}
+		expectPayment(t, fake, "fake_3", payment.StateRefunded)
+		if status := waitlistStatus(t, s, entry.GetWaitlistId()); status.GetEntry().GetStatus() != ticket.WaitlistEntry_STATUS_WAITING {
+			t.Errorf("expected the passenger to keep waiting, got %v", status.GetEntry().GetStatus())
+		}
+	})
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	ticket "github.com/talk2sohail/train-ticket-api/internal/common/genproto/ticket"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/layout"
+	"google.golang.org/protobuf/proto"
+)
+
+// Names of the seat preferences reported back in PurchaseTicketResponse.
+const (
+	PreferenceSeatNumber = "seat_number"
+	PreferenceCoach      = "coach"
+	PreferencePosition   = "position"
+	PreferenceFacing     = "facing"
+	PreferenceNearDoor   = "near_door"
+)
+
+// preference is a single requested seat feature. Each weight is larger than the
+// sum of all weights below it, so a seat meeting a higher-ranked preference always
+// beats one meeting any number of lower-ranked preferences.
+type preference struct {
+	name   string
+	weight int
+	meets  func(*ticket.Seat) bool
+}
+
+// preferencesOf lists the preferences a request actually sets, highest ranked first.
+func preferencesOf(p *ticket.SeatPreferences) []preference {
+	var prefs []preference
+	if seatNumber := p.GetSeatNumber(); seatNumber != "" {
+		prefs = append(prefs, preference{PreferenceSeatNumber, 16, func(seat *ticket.Seat) bool {
+			return seat.GetSeatNumber() == seatNumber
+		}})
+	}
+	if coach := p.GetCoach(); coach != "" {
+		prefs = append(prefs, preference{PreferenceCoach, 8, func(seat *ticket.Seat) bool {
+			return layout.CoachOf(seat) == coach
+		}})
+	}
+	switch p.GetPosition() {
+	case ticket.SeatPreferences_POSITION_WINDOW:
+		prefs = append(prefs, preference{PreferencePosition, 4, func(seat *ticket.Seat) bool {
+			return seat.GetAttributes().GetWindow()
+		}})
+	case ticket.SeatPreferences_POSITION_AISLE:
+		prefs = append(prefs, preference{PreferencePosition, 4, func(seat *ticket.Seat) bool {
+			return seat.GetAttributes().GetAisle()
+		}})
+	}
+	if facing := p.GetFacing(); facing != ticket.SeatAttributes_FACING_UNKNOWN {
+		prefs = append(prefs, preference{PreferenceFacing, 2, func(seat *ticket.Seat) bool {
+			return seat.GetAttributes().GetFacing() == facing
+		}})
+	}
+	if p.GetNearDoor() {
+		prefs = append(prefs, preference{PreferenceNearDoor, 1, func(seat *ticket.Seat) bool {
+			return seat.GetAttributes().GetNearDoor()
+		}})
+	}
+	return prefs
+}
+
+// findPreferredSeat scores every seat of a journey that is free over the segment being travelled
+// against the requested preferences and returns the best one, along with the preferences it meets
+// and those it does not. Ties go to the seat findNextAvailableSeat would pick.
+// This function assumes the caller has already acquired the server's mutex.
+func (s *TicketService) findPreferredSeat(journey *ticket.Journey, seg segment, p *ticket.SeatPreferences) (*ticket.Seat, []string, []string, error) {
+	prefs := preferencesOf(p)
+	if len(prefs) == 0 {
+		seat, err := s.findNextAvailableSeat(journey, seg)
+		return seat, nil, nil, err
+	}
+
+	var best *ticket.Seat
+	bestScore := -1
+	for _, coach := range layoutOf(journey).GetCoaches() {
+		for _, seat := range coach.GetSeats() {
+			if !s.isSeatFree(journey, seat.GetSeatNumber(), seg, "") {
+				continue
+			}
+			score := 0
+			for _, pref := range prefs {
+				if pref.meets(seat) {
+					score += pref.weight
+				}
+			}
+			if score > bestScore {
+				best, bestScore = seat, score
+			}
+		}
+	}
+	if best == nil {
+		return nil, nil, nil, newError(ErrNoAvailableSeats, "")
+	}
+
+	var met, unmet []string
+	for _, pref := range prefs {
+		if pref.meets(best) {
+			met = append(met, pref.name)
+		} else {
+			unmet = append(unmet, pref.name)
+		}
+	}
+	return proto.Clone(best).(*ticket.Seat), met, unmet, nil
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	ticket "github.com/talk2sohail/train-ticket-api/internal/common/genproto/ticket"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/layout"
+)
+
+// newPreferenceService returns a service whose default journey has one coach of
+// two rows: row 1 faces backward next to the door, row 2 faces forward.
+func newPreferenceService(t *testing.T) *TicketService {
+	t.Helper()
+	coach, err := layout.Build("twin", layout.Config{Coaches: []layout.CoachConfig{
+		{
+			ID:   "C",
+			Rows: 2,
+			Columns: []layout.ColumnConfig{
+				{Letter: "A", Position: layout.PositionWindow},
+				{Letter: "B", Position: layout.PositionAisle},
+			},
+			RowAttributes: map[int]layout.Attributes{
+				1: {Facing: layout.FacingBackward, NearDoor: true},
+				2: {Facing: layout.FacingForward},
+			},
+		},
+		{ID: "D", Rows: 1, Columns: []layout.ColumnConfig{{Letter: "A", Position: layout.PositionWindow}}},
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error building layout: %v", err)
+	}
+	return NewTicketService(WithLayouts(map[string]*ticket.TrainLayout{"twin": coach}, "twin"))
+}
+
+func purchaseWith(t *testing.T, s *TicketService, prefs *ticket.SeatPreferences) *ticket.PurchaseTicketResponse {
+	t.Helper()
+	res, err := s.PurchaseTicket(context.Background(), &ticket.PurchaseTicketRequest{
+		FromLocation: "London",
+		ToLocation:   "Paris",
+		User:         &ticket.User{FirstName: "Test", LastName: "User", Email: "prefs@example.com"},
+		PricePaid:    20.0,
+		Preferences:  prefs,
+	})
+	if err != nil || !res.Success {
+		t.Fatalf("expected purchase to succeed, got %v, %v", res, err)
+	}
+	return res
+}
+
+func TestUnit_PurchaseTicketWithPreferences(t *testing.T) {
+	tests := []struct {
+		name      string
+		prefs     *ticket.SeatPreferences
+		wantSeat  string
+		wantMet   []string
+		wantUnmet []string
+	}{
+		{
+			name:     "No preferences takes the first free seat",
+			wantSeat: "C1A",
+		},
+		{
+			name:     "Aisle",
+			prefs:    &ticket.SeatPreferences{Position: ticket.SeatPreferences_POSITION_AISLE},
+			wantSeat: "C1B",
+			wantMet:  []string{PreferencePosition},
+		},
+		{
+			name:     "Forward-facing window",
+			prefs:    &ticket.SeatPreferences{Position: ticket.SeatPreferences_POSITION_WINDOW, Facing: ticket.SeatAttributes_FACING_FORWARD},
+			wantSeat: "C2A",
+			wantMet:  []string{PreferencePosition, PreferenceFacing},
+		},
+		{
+			name:      "Higher-ranked preference wins a conflict",
+			prefs:     &ticket.SeatPreferences{Facing: ticket.SeatAttributes_FACING_FORWARD, NearDoor: true},
+			wantSeat:  "C2A",
+			wantMet:   []string{PreferenceFacing},
+			wantUnmet: []string{PreferenceNearDoor},
+		},
+		{
+			name:      "Specific seat outranks everything else",
+			prefs:     &ticket.SeatPreferences{SeatNumber: "D1A", Coach: "C", Position: ticket.SeatPreferences_POSITION_AISLE},
+			wantSeat:  "D1A",
+			wantMet:   []string{PreferenceSeatNumber},
+			wantUnmet: []string{PreferenceCoach, PreferencePosition},
+		},
+		{
+			name:      "Legacy section names a coach",
+			prefs:     &ticket.SeatPreferences{Coach: "B"},
+			wantSeat:  "C1A",
+			wantUnmet: []string{PreferenceCoach},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newPreferenceService(t)
+			res := purchaseWith(t, s, tt.prefs)
+			if got := res.Receipt.AllocatedSeat.SeatNumber; got != tt.wantSeat {
+				t.Errorf("expected seat %s, got %s", tt.wantSeat, got)
+			}
+			if !reflect.DeepEqual(res.PreferencesMet, tt.wantMet) {
+				t.Errorf("expected met preferences %v, got %v", tt.wantMet, res.PreferencesMet)
+			}
+			if !reflect.DeepEqual(res.PreferencesUnmet, tt.wantUnmet) {
+				t.Errorf("expected unmet preferences %v, got %v", tt.wantUnmet, res.PreferencesUnmet)
+			}
+		})
+	}
+
+	t.Run("Requested seat already taken", func(t *testing.T) {
+		s := newPreferenceService(t)
+		purchaseWith(t, s, &ticket.SeatPreferences{SeatNumber: "C2B"})
+		res := purchaseWith(t, s, &ticket.SeatPreferences{SeatNumber: "C2B", Position: ticket.SeatPreferences_POSITION_AISLE})
+		if res.Receipt.AllocatedSeat.SeatNumber != "C1B" {
+			t.Errorf("expected the next best aisle seat C1B, got %s", res.Receipt.AllocatedSeat.SeatNumber)
+		}
+		if !reflect.DeepEqual(res.PreferencesUnmet, []string{PreferenceSeatNumber}) {
+			t.Errorf("expected seat_number to be unmet, got %v", res.PreferencesUnmet)
+		}
+	})
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"log"
+	"time"
+)
+
+// WithTicketQuota caps how many active tickets one email may hold at a time, counting seats
+// it holds that are not confirmed yet. A quota that is not positive leaves passengers unlimited.
+func WithTicketQuota(quota int) Option {
+	return func(s *TicketService) {
+		s.ticketQuota = quota
+	}
+}
+
+// checkTicketQuota refuses to issue more tickets or holds to email when that would take it past the quota.
+// While email holds seats, the error says to retry once the first hold expires.
+// This function assumes the caller has already acquired the server's mutex.
+func (s *TicketService) checkTicketQuota(method, email string, more int) error {
+	if s.ticketQuota <= 0 {
+		return nil
+	}
+	if active, nextExpiry := s.activeTickets(email); active+more > s.ticketQuota {
+		log.Printf("[%s] Rejected user %s: %s (%d active, quota %d)", method, email, ErrTicketQuotaExceeded, active, s.ticketQuota)
+		err := newError(ErrTicketQuotaExceeded, email)
+		if !nextExpiry.IsZero() {
+			err.RetryAfter = nextExpiry.Sub(s.clock.Now())
+		}
+		return err
+	}
+	return nil
+}
+
+// activeTickets counts the tickets and unexpired holds of email, including seats it is paying
+// for, and returns when the first of those holds expires, or the zero time without any that expire.
+// This function assumes the caller has already acquired the server's mutex.
+func (s *TicketService) activeTickets(email string) (int, time.Time) {
+	active := len(s.repo.GetReceiptsByEmail(email))
+	var nextExpiry time.Time
+	now := s.clock.Now()
+	for _, hold := range s.holds {
+		if hold.user.GetEmail() == email && !hold.expired(now) {
+			active++
+			if !hold.pending && (nextExpiry.IsZero() || hold.expiresAt.Before(nextExpiry)) {
+				nextExpiry = hold.expiresAt
+			}
+		}
+	}
+	return active, nextExpiry
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ticket "github.com/talk2sohail/train-ticket-api/internal/common/genproto/ticket"
+)
+
+func TestUnit_TicketQuota(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Purchases up to the quota", func(t *testing.T) {
+		s := NewTicketService(WithTicketQuota(2))
+		purchaseOn(t, s, "", "alice@example.com")
+		purchaseOn(t, s, "", "alice@example.com")
+		_, err := tryPurchaseLeg(s, "", "London", "Paris", "alice@example.com")
+		e := expectError(t, err, ErrTicketQuotaExceeded)
+		if e.Kind != KindResourceExhausted || e.Subject != "alice@example.com" || e.RetryAfter != 0 {
+			t.Errorf("expected a resource exhausted error about alice without a retry time, got %+v", e)
+		}
+		// Other passengers are not affected.
+		purchaseOn(t, s, "", "bob@example.com")
+	})
+
+	t.Run("Cancelling frees a place", func(t *testing.T) {
+		s := NewTicketService(WithTicketQuota(1))
+		receipt := purchaseOn(t, s, "", "alice@example.com").GetReceipt()
+		if _, err := tryPurchaseLeg(s, "", "London", "Paris", "alice@example.com"); err == nil {
+			t.Fatalf("expected the quota to be reached")
+		}
+		if _, err := s.CancelTicket(ctx, receipt.GetTicketId()); err != nil {
+			t.Fatalf("unexpected error cancelling: %v", err)
+		}
+		purchaseOn(t, s, "", "alice@example.com")
+	})
+
+	t.Run("Holds count until they expire", func(t *testing.T) {
+		clock := newFakeClock()
+		s := NewTicketService(WithTicketQuota(1), WithClock(clock), WithHoldTTL(time.Minute))
+		hold := holdSeat(t, s, "alice@example.com")
+		if _, err := tryPurchaseLeg(s, "", "London", "Paris", "alice@example.com"); err == nil {
+			t.Fatalf("expected the hold to count against the quota")
+		}
+		clock.Advance(20 * time.Second)
+		_, err := s.HoldSeat(ctx, &ticket.HoldSeatRequest{FromLocation: "London", ToLocation: "Paris", User: &ticket.User{Email: "alice@example.com"}})
+		if e := expectError(t, err, ErrTicketQuotaExceeded); e.RetryAfter != 40*time.Second {
+			t.Errorf("expected to retry when the hold expires in 40s, got %s", e.RetryAfter)
+		}
+
+		// Confirming the hold turns it into the one ticket allowed.
+		if _, err := s.ConfirmHold(ctx, &ticket.ConfirmHoldRequest{HoldId: hold.GetHoldId(), PricePaid: 20}); err != nil {
+			t.Fatalf("unexpected error confirming within the quota: %v", err)
+		}
+
+		s = NewTicketService(WithTicketQuota(1), WithClock(clock), WithHoldTTL(time.Minute))
+		holdSeat(t, s, "alice@example.com")
+		clock.Advance(time.Minute)
+		purchaseOn(t, s, "", "alice@example.com")
+	})
+
+	t.Run("Group purchases count every seat", func(t *testing.T) {
+		s := NewTicketService(WithTicketQuota(2))
+		purchaseOn(t, s, "", "pupil0@example.com")
+		req := groupRequest(2, false)
+		req.Passengers[1].Email = req.Passengers[0].GetEmail()
+		_, err := s.PurchaseGroupTicket(ctx, req)
+		expectError(t, err, ErrTicketQuotaExceeded)
+		if tickets := s.repo.GetReceiptsByEmail("pupil0@example.com"); len(tickets) != 1 {
+			t.Errorf("expected nobody in the group to be booked, got %d tickets", len(tickets))
+		}
+		if _, err := s.PurchaseGroupTicket(ctx, groupRequest(2, false)); err != nil {
+			t.Errorf("unexpected error booking a group within the quota: %v", err)
+		}
+	})
+
+	t.Run("Waitlist", func(t *testing.T) {
+		s := NewTicketService(WithTicketQuota(1))
+		journey := createRoute(t, s, 1, "London", "Paris")
+		first := purchaseOn(t, s, journey.GetJourneyId(), "alice@example.com").GetReceipt()
+		purchaseOn(t, s, journey.GetJourneyId(), "bob@example.com")
+
+		_, err := tryJoinWaitlist(s, journey.GetJourneyId(), "London", "Paris", "alice@example.com")
+		expectError(t, err, ErrTicketQuotaExceeded)
+
+		// A passenger who reached the quota after joining is passed over and keeps their place.
+		carol := joinWaitlist(t, s, journey.GetJourneyId(), "London", "Paris", "carol@example.com")
+		dave := joinWaitlist(t, s, journey.GetJourneyId(), "London", "Paris", "dave@example.com")
+		purchaseOn(t, s, "", "carol@example.com")
+		if _, err := s.CancelTicket(ctx, first.GetTicketId()); err != nil {
+			t.Fatalf("unexpected error cancelling: %v", err)
+		}
+		if status := waitlistStatus(t, s, dave.GetEntry().GetWaitlistId()); status.GetEntry().GetStatus() != ticket.WaitlistEntry_STATUS_PROMOTED {
+			t.Errorf("expected dave to be promoted, got %v", status.GetEntry().GetStatus())
+		}
+		if status := waitlistStatus(t, s, carol.GetEntry().GetWaitlistId()); status.GetPosition() != 1 {
+			t.Errorf("expected carol to keep first place, got %v at %d", status.GetEntry().GetStatus(), status.GetPosition())
+		}
+	})
+
+	t.Run("Unlimited by default", func(t *testing.T) {
+		s := NewTicketService()
+		for range MaxSeatsPerSection + 1 {
+			purchaseOn(t, s, "", "alice@example.com")
+		}
+	})
+}
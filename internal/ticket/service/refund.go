@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	ticket "github.com/talk2sohail/train-ticket-api/internal/common/genproto/ticket"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/cancellation"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/money"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// WithCancellationPolicy refunds cancelled tickets under policy. Without a policy
+// tickets cancelled before departure are refunded in full.
+func WithCancellationPolicy(policy *cancellation.Policy) Option {
+	return func(s *TicketService) {
+		s.cancellation = policy
+	}
+}
+
+// refundFor decides the refund of a ticket cancelled at now from how long before the
+// journey's departure that is, and returns it with the amount due. Tickets booked without
+// payment, which is every ticket when the service takes none, have no refund.
+func (s *TicketService) refundFor(receipt *ticket.Receipt, journey *ticket.Journey, now time.Time) (*ticket.Refund, money.Money, error) {
+	if receipt.GetPayment() == nil {
+		return nil, money.Money{}, nil
+	}
+	paid, err := money.FromProto(receipt.GetPayment().GetAmount())
+	if err != nil {
+		return nil, money.Money{}, fmt.Errorf("payment of the ticket: %w", err)
+	}
+	var departure time.Time
+	if journey.GetDepartureTime() != nil {
+		departure = journey.GetDepartureTime().AsTime()
+	}
+	decision := s.cancellation.Decide(departure, now)
+	amount := decision.Refund(paid)
+	return &ticket.Refund{
+		RefundId:      uuid.New().String(),
+		TicketId:      receipt.GetTicketId(),
+		User:          receipt.GetUser(),
+		Amount:        amount.Proto(),
+		RefundPercent: decision.Percent(),
+		Rule:          decision.Rule,
+		PaymentId:     receipt.GetPayment().GetPaymentId(),
+		RefundedAt:    timestamppb.New(now),
+		Status:        refundStatus(amount),
+	}, amount, nil
+}
+
+// refundStatus is the status a refund of amount is recorded with: pending until the
+// payment is refunded, or completed when there is nothing to return.
+func refundStatus(amount money.Money) ticket.Refund_Status {
+	if amount.Minor == 0 {
+		return ticket.Refund_STATUS_COMPLETED
+	}
+	return ticket.Refund_STATUS_PENDING
+}
+
+// settleRefund returns the amount of a pending refund to the ticket's payment once its
+// cancellation is recorded, and records whether that worked. A refund the provider fails
+// is recorded as failed, to be settled by hand: the ticket is cancelled either way.
+// This function assumes the caller has already acquired the server's mutex.
+func (s *TicketService) settleRefund(ctx context.Context, method string, receipt *ticket.Receipt, refund *ticket.Refund, amount money.Money) *ticket.Refund {
+	if refund.GetStatus() != ticket.Refund_STATUS_PENDING {
+		return refund
+	}
+	status := ticket.Refund_STATUS_COMPLETED
+	if err := s.refundPayment(ctx, method, receipt, amount); err != nil {
+		log.Printf("[%s] Refund %s of TicketID %s failed and must be settled by hand: %v", method, refund.GetRefundId(), receipt.GetTicketId(), err)
+		status = ticket.Refund_STATUS_FAILED
+	}
+	if err := s.repo.Append(refundSettledEvent(refund, status, s.clock.Now())); err != nil {
+		log.Printf("[%s] Failed to record refund %s as %s: %v", method, refund.GetRefundId(), status, err)
+	}
+	settled := proto.Clone(refund).(*ticket.Refund)
+	settled.Status = status
+	return settled
+}
+
+// GetRefund retrieves the refund given when a ticket was cancelled.
+func (s *TicketService) GetRefund(ctx context.Context, refundID string) (*ticket.GetRefundResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	refund, ok := s.repo.GetRefund(refundID)
+	if !ok {
+		err := newError(ErrRefundNotFound, refundID)
+		log.Printf("[GetRefund] %v", err)
+		return nil, err
+	}
+	log.Printf("[GetRefund] Retrieved refund %s of TicketID %s", refundID, refund.GetTicketId())
+	return &ticket.GetRefundResponse{
+		Success: true,
+		Message: MsgRefundRetrieved,
+		Refund:  refund,
+	}, nil
+}
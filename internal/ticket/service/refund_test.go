@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	ticket "github.com/talk2sohail/train-ticket-api/internal/common/genproto/ticket"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/cancellation"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/money"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/payment"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/repository"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/types"
+)
+
+// failingRepository fails every Append while err is set.
+type failingRepository struct {
+	types.TicketRepository
+	err error
+}
+
+func (r *failingRepository) Append(events ...*ticket.BookingEvent) error {
+	if r.err != nil {
+		return r.err
+	}
+	return r.TicketRepository.Append(events...)
+}
+
+// newPolicy builds a policy refunding in full up to a day before departure and half up to two hours before.
+func newPolicy(t *testing.T) *cancellation.Policy {
+	t.Helper()
+	policy, err := cancellation.Build(cancellation.Config{Rules: []cancellation.Rule{
+		{MinHoursBefore: 24, RefundPercent: "100"},
+		{MinHoursBefore: 2, RefundPercent: "50"},
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error building the policy: %v", err)
+	}
+	return policy
+}
+
+func TestUnit_CancellationRefund(t *testing.T) {
+	ctx := context.Background()
+	// The fake clock starts 47 hours before the train departs.
+	departure := time.Date(2025, 5, 3, 8, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		advance time.Duration
+		percent float64
+		rule    string
+		minor   int64
+		status  ticket.Refund_Status
+	}{
+		{"Full refund a day ahead", 0, 100, ">=24h", 2000, ticket.Refund_STATUS_COMPLETED},
+		{"Partial refund on the day", 30 * time.Hour, 50, ">=2h", 1000, ticket.Refund_STATUS_COMPLETED},
+		{"Too late for a refund", 46 * time.Hour, 0, cancellation.RuleNone, 0, ticket.Refund_STATUS_COMPLETED},
+		{"Departed", 48 * time.Hour, 0, cancellation.RuleDeparted, 0, ticket.Refund_STATUS_COMPLETED},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := newFakeClock()
+			s := NewTicketService(WithClock(clock), WithPayments(payment.NewFake()), WithCancellationPolicy(newPolicy(t)))
+			journey := createJourney(t, s, "2025-05-03", departure, 2)
+			receipt := purchaseOn(t, s, journey.GetJourneyId(), "alice@example.com").GetReceipt()
+			clock.Advance(tt.advance)
+
+			resp, err := s.CancelTicket(ctx, receipt.GetTicketId())
+			if err != nil {
+				t.Fatalf("unexpected error cancelling: %v", err)
+			}
+			refund := resp.GetRefund()
+			if refund.GetRefundPercent() != tt.percent || refund.GetRule() != tt.rule || refund.GetAmount().GetMinorUnits() != tt.minor {
+				t.Errorf("expected %d cents (%g%%) under %q, got %v", tt.minor, tt.percent, tt.rule, refund)
+			}
+			if refund.GetStatus() != tt.status {
+				t.Errorf("expected the refund to be %v, got %v", tt.status, refund.GetStatus())
+			}
+			if refund.GetTicketId() != receipt.GetTicketId() || !refund.GetRefundedAt().AsTime().Equal(clock.Now()) {
+				t.Errorf("expected the refund of %s at %v, got %v", receipt.GetTicketId(), clock.Now(), refund)
+			}
+
+			got, err := s.GetRefund(ctx, refund.GetRefundId())
+			if err != nil || got.GetRefund().GetAmount().GetMinorUnits() != tt.minor || got.GetRefund().GetStatus() != tt.status {
+				t.Errorf("expected the refund to be recorded, got %v, %v", got, err)
+			}
+		})
+	}
+
+	t.Run("Undated journey refunded in full", func(t *testing.T) {
+		s := NewTicketService(WithPayments(payment.NewFake()), WithCancellationPolicy(newPolicy(t)))
+		receipt := purchaseOn(t, s, "", "alice@example.com").GetReceipt()
+		resp, err := s.RemoveUser(ctx, "alice@example.com")
+		if err != nil {
+			t.Fatalf("unexpected error removing: %v", err)
+		}
+		if r := resp.GetRefund(); r.GetRule() != cancellation.RuleUndated || r.GetAmount().GetMinorUnits() != 2000 || r.GetTicketId() != receipt.GetTicketId() {
+			t.Errorf("expected a full refund of %s, got %v", receipt.GetTicketId(), r)
+		}
+	})
+
+	t.Run("Nothing recorded without payment", func(t *testing.T) {
+		s := NewTicketService(WithCancellationPolicy(newPolicy(t)))
+		receipt := purchaseOn(t, s, "", "alice@example.com").GetReceipt()
+		resp, err := s.CancelTicket(ctx, receipt.GetTicketId())
+		if err != nil {
+			t.Fatalf("unexpected error cancelling: %v", err)
+		}
+		if resp.GetRefund() != nil {
+			t.Errorf("expected no refund for a ticket booked without payment, got %v", resp.GetRefund())
+		}
+		history, err := s.GetTicketHistory(ctx, receipt.GetTicketId())
+		if err != nil {
+			t.Fatalf("unexpected error reading the history: %v", err)
+		}
+		for _, event := range history.GetEvents() {
+			if c := event.GetTicketCancelled(); c != nil && c.GetRefund() != nil {
+				t.Errorf("expected the cancellation to record no refund, got %v", c.GetRefund())
+			}
+		}
+	})
+
+	t.Run("Partial refund of the payment", func(t *testing.T) {
+		clock := newFakeClock()
+		fake := payment.NewFake()
+		s := NewTicketService(WithClock(clock), WithPayments(fake), WithCancellationPolicy(newPolicy(t)))
+		journey := createJourney(t, s, "2025-05-03", departure, 2)
+		receipt := purchaseOn(t, s, journey.GetJourneyId(), "alice@example.com").GetReceipt()
+		clock.Advance(30 * time.Hour)
+
+		resp, err := s.CancelTicket(ctx, receipt.GetTicketId())
+		if err != nil {
+			t.Fatalf("unexpected error cancelling: %v", err)
+		}
+		paymentID := receipt.GetPayment().GetPaymentId()
+		if resp.GetRefund().GetPaymentId() != paymentID {
+			t.Errorf("expected the refund to name payment %s, got %v", paymentID, resp.GetRefund())
+		}
+		p, _ := fake.Payment(paymentID)
+		if p.Refunded != (money.Money{Minor: 1000, Currency: money.USD}) {
+			t.Errorf("expected 10.00 USD to be refunded, got %v", p.Refunded)
+		}
+	})
+
+	t.Run("Nothing refunded after departure", func(t *testing.T) {
+		clock := newFakeClock()
+		fake := payment.NewFake()
+		s := NewTicketService(WithClock(clock), WithPayments(fake), WithCancellationPolicy(newPolicy(t)))
+		journey := createJourney(t, s, "2025-05-03", departure, 2)
+		receipt := purchaseOn(t, s, journey.GetJourneyId(), "alice@example.com").GetReceipt()
+		clock.Advance(48 * time.Hour)
+
+		if _, err := s.CancelTicket(ctx, receipt.GetTicketId()); err != nil {
+			t.Fatalf("unexpected error cancelling: %v", err)
+		}
+		expectPayment(t, fake, receipt.GetPayment().GetPaymentId(), payment.StateCaptured)
+	})
+}
+
+func TestUnit_CancellationNotStored(t *testing.T) {
+	ctx := context.Background()
+	fake := payment.NewFake()
+	repo := &failingRepository{TicketRepository: repository.NewMemoryRepository()}
+	s := NewTicketServiceWithRepository(repo, WithPayments(fake))
+	receipt := purchaseOn(t, s, "", "alice@example.com").GetReceipt()
+	paymentID := receipt.GetPayment().GetPaymentId()
+
+	repo.err = errors.New("disk full")
+	if _, err := s.CancelTicket(ctx, receipt.GetTicketId()); err == nil {
+		t.Fatalf("expected an error while the ledger cannot be written")
+	}
+	expectPayment(t, fake, paymentID, payment.StateCaptured)
+	if _, err := s.GetReceiptDetails(ctx, receipt.GetTicketId()); err != nil {
+		t.Errorf("expected the ticket to stay booked, got %v", err)
+	}
+
+	// Retrying once the ledger recovers refunds the payment once.
+	repo.err = nil
+	resp, err := s.CancelTicket(ctx, receipt.GetTicketId())
+	if err != nil {
+		t.Fatalf("unexpected error retrying the cancellation: %v", err)
+	}
+	if status := resp.GetRefund().GetStatus(); status != ticket.Refund_STATUS_COMPLETED {
+		t.Errorf("expected the refund to be %v, got %v", ticket.Refund_STATUS_COMPLETED, status)
+	}
+	if p, _ := fake.Payment(paymentID); p.State != payment.StateRefunded || p.Refunded.Minor != 2000 {
+		t.Errorf("expected 20.00 USD to be refunded once, got %+v", p)
+	}
+}
+
+func TestUnit_GetRefund(t *testing.T) {
+	s := NewTicketService()
+	_, err := s.GetRefund(context.Background(), "missing")
+	if e := expectError(t, err, ErrRefundNotFound); e.Kind != KindNotFound || e.Subject != "missing" {
+		t.Errorf("expected a not found error about missing, got %+v", e)
+	}
+}
@@ -0,0 +1,89 @@
+package service
+
+import ticket "github.com/talk2sohail/train-ticket-api/internal/common/genproto/ticket"
+
+// segment is the half-open range of stop indexes [from, to) a ticket travels on a journey's route.
+type segment struct {
+	from, to int
+}
+
+// overlaps reports whether two tickets on the same seat would be on the train at the same time.
+// A passenger alighting at a stop frees the seat for one boarding there.
+func (a segment) overlaps(b segment) bool {
+	return a.from < b.to && b.from < a.to
+}
+
+// routeOf returns the ordered stops of a journey. The default journey has no route,
+// and journeys scheduled before routes existed run non-stop from origin to destination.
+func routeOf(journey *ticket.Journey) []string {
+	if stops := journey.GetStops(); len(stops) >= 2 {
+		return stops
+	}
+	if journey.GetOrigin() == "" || journey.GetDestination() == "" {
+		return nil
+	}
+	return []string{journey.GetOrigin(), journey.GetDestination()}
+}
+
+// segmentOf resolves the stops a passenger travels between to a segment of the journey's route.
+// On a journey without a route every ticket holds its seat for the whole train.
+func segmentOf(journey *ticket.Journey, from, to string) (segment, error) {
+	route := routeOf(journey)
+	if route == nil {
+		return segment{from: 0, to: 1}, nil
+	}
+	fromIndex, toIndex := indexOf(route, from), indexOf(route, to)
+	if fromIndex < 0 {
+		return segment{}, newError(ErrStopNotOnRoute, from)
+	}
+	if toIndex < 0 {
+		return segment{}, newError(ErrStopNotOnRoute, to)
+	}
+	if toIndex <= fromIndex {
+		return segment{}, newError(ErrInvalidSegment, "")
+	}
+	return segment{from: fromIndex, to: toIndex}, nil
+}
+
+// segmentOfReceipt returns the segment a stored receipt holds its seat over.
+// Receipts whose stops are not on the route, such as those issued before routes
+// existed, are treated as travelling the whole route.
+func segmentOfReceipt(journey *ticket.Journey, receipt *ticket.Receipt) segment {
+	seg, err := segmentOf(journey, receipt.GetFromLocation(), receipt.GetToLocation())
+	if err != nil {
+		return wholeRoute(journey)
+	}
+	return seg
+}
+
+// wholeRoute returns the segment covering a journey's entire route.
+func wholeRoute(journey *ticket.Journey) segment {
+	return segment{from: 0, to: max(len(routeOf(journey))-1, 1)}
+}
+
+// isSeatFree reports whether a seat on a journey is neither sold nor held over seg, ignoring the ticket
+// identified by ignoreTicketID so that a ticket can be moved within its own seat.
+// This function assumes the caller has already acquired the server's mutex.
+func (s *TicketService) isSeatFree(journey *ticket.Journey, seatNumber string, seg segment, ignoreTicketID string) bool {
+	if s.isSeatHeld(journey.GetJourneyId(), seatNumber, seg) {
+		return false
+	}
+	for _, holder := range s.repo.GetReceiptsBySeat(journey.GetJourneyId(), seatNumber) {
+		if holder.GetTicketId() == ignoreTicketID {
+			continue
+		}
+		if segmentOfReceipt(journey, holder).overlaps(seg) {
+			return false
+		}
+	}
+	return true
+}
+
+func indexOf(stops []string, stop string) int {
+	for i, s := range stops {
+		if s == stop {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,219 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	ticket "github.com/talk2sohail/train-ticket-api/internal/common/genproto/ticket"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// createRoute schedules a journey calling at the given stops.
+func createRoute(t *testing.T, s *TicketService, seatsPerSection int32, stops ...string) *ticket.Journey {
+	t.Helper()
+	resp, err := s.CreateJourney(context.Background(), &ticket.CreateJourneyRequest{
+		TrainNumber:     "ES9014",
+		ServiceDate:     "2025-05-01",
+		DepartureTime:   timestamppb.New(time.Date(2025, 5, 1, 8, 0, 0, 0, time.UTC)),
+		Origin:          stops[0],
+		Destination:     stops[len(stops)-1],
+		SeatsPerSection: seatsPerSection,
+		Stops:           stops,
+	})
+	if err != nil || !resp.Success {
+		t.Fatalf("failed to create journey: %v, %v", resp, err)
+	}
+	return resp.Journey
+}
+
+// tryPurchaseLeg buys a ticket between two stops, leaving any failure to the caller.
+func tryPurchaseLeg(s *TicketService, journeyID, from, to, email string) (*ticket.PurchaseTicketResponse, error) {
+	return s.PurchaseTicket(context.Background(), &ticket.PurchaseTicketRequest{
+		FromLocation: from,
+		ToLocation:   to,
+		User:         &ticket.User{FirstName: "Test", LastName: "User", Email: email},
+		PricePaid:    20.0,
+		JourneyId:    journeyID,
+	})
+}
+
+func purchaseLeg(t *testing.T, s *TicketService, journeyID, from, to, email string) *ticket.PurchaseTicketResponse {
+	t.Helper()
+	res, err := tryPurchaseLeg(s, journeyID, from, to, email)
+	if err != nil {
+		t.Fatalf("unexpected error purchasing %s -> %s: %v", from, to, err)
+	}
+	return res
+}
+
+func TestUnit_SegmentOf(t *testing.T) {
+	journey := &ticket.Journey{JourneyId: "j1", Stops: []string{"London", "Ashford", "Lille", "Paris"}}
+
+	tests := []struct {
+		name     string
+		from, to string
+		want     segment
+		wantErr  bool
+	}{
+		{name: "Whole route", from: "London", to: "Paris", want: segment{from: 0, to: 3}},
+		{name: "Intermediate leg", from: "Ashford", to: "Lille", want: segment{from: 1, to: 2}},
+		{name: "Unknown stop", from: "London", to: "Brussels", wantErr: true},
+		{name: "Backwards", from: "Paris", to: "London", wantErr: true},
+		{name: "Same stop", from: "Lille", to: "Lille", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := segmentOf(journey, tt.from, tt.to)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+
+	t.Run("Default journey holds the whole train", func(t *testing.T) {
+		got, err := segmentOf(&ticket.Journey{JourneyId: "default"}, "anywhere", "elsewhere")
+		if err != nil || got != (segment{from: 0, to: 1}) {
+			t.Errorf("expected whole-train segment, got %v, %v", got, err)
+		}
+	})
+
+	t.Run("Alighting and boarding at the same stop do not overlap", func(t *testing.T) {
+		if (segment{from: 0, to: 2}).overlaps(segment{from: 2, to: 3}) {
+			t.Errorf("expected adjacent segments not to overlap")
+		}
+		if !(segment{from: 0, to: 2}).overlaps(segment{from: 1, to: 3}) {
+			t.Errorf("expected crossing segments to overlap")
+		}
+	})
+}
+
+func TestUnit_PurchaseTicketReusesSeatForLaterLeg(t *testing.T) {
+	ctx := context.Background()
+	s := NewTicketService()
+	journey := createRoute(t, s, 1, "London", "Ashford", "Lille", "Paris")
+
+	first := purchaseLeg(t, s, journey.JourneyId, "London", "Lille", "first@example.com")
+	if !first.Success || first.Receipt.AllocatedSeat.SeatNumber != "A1" {
+		t.Fatalf("expected A1, got %v", first)
+	}
+
+	t.Run("Overlapping leg gets another seat", func(t *testing.T) {
+		res := purchaseLeg(t, s, journey.JourneyId, "Ashford", "Paris", "overlap@example.com")
+		if !res.Success || res.Receipt.AllocatedSeat.SeatNumber != "B1" {
+			t.Fatalf("expected B1, got %v", res)
+		}
+	})
+
+	t.Run("Later leg reuses the seat", func(t *testing.T) {
+		res := purchaseLeg(t, s, journey.JourneyId, "Lille", "Paris", "later@example.com")
+		if !res.Success || res.Receipt.AllocatedSeat.SeatNumber != "A1" {
+			t.Fatalf("expected A1 to be resold from Lille, got %v", res)
+		}
+		if n := len(s.repo.GetReceiptsBySeat(journey.JourneyId, "A1")); n != 2 {
+			t.Errorf("expected A1 to be held by 2 tickets, got %d", n)
+		}
+	})
+
+	t.Run("Earlier leg fills the gap before an overlapping ticket", func(t *testing.T) {
+		res := purchaseLeg(t, s, journey.JourneyId, "London", "Ashford", "gap@example.com")
+		if !res.Success || res.Receipt.AllocatedSeat.SeatNumber != "B1" {
+			t.Fatalf("expected B1 to be sold up to Ashford, got %v", res)
+		}
+	})
+
+	t.Run("Sold out once every leg is taken", func(t *testing.T) {
+		_, err := tryPurchaseLeg(s, journey.JourneyId, "London", "Lille", "late@example.com")
+		expectError(t, err, ErrNoAvailableSeats)
+	})
+
+	t.Run("Stops must be on the route", func(t *testing.T) {
+		_, err := tryPurchaseLeg(s, journey.JourneyId, "London", "Brussels", "lost@example.com")
+		if e := expectError(t, err, ErrStopNotOnRoute); e.Subject != "Brussels" || e.Kind != KindInvalidArgument {
+			t.Errorf("expected Brussels to be reported as an invalid argument, got %+v", e)
+		}
+		_, err = tryPurchaseLeg(s, journey.JourneyId, "Paris", "Lille", "backwards@example.com")
+		expectError(t, err, ErrInvalidSegment)
+	})
+
+	t.Run("Seat change only conflicts with overlapping legs", func(t *testing.T) {
+		journey := createRoute(t, s, 2, "London", "Ashford", "Lille", "Paris")
+		early := purchaseLeg(t, s, journey.JourneyId, "London", "Ashford", "early@example.com").Receipt
+		late := purchaseLeg(t, s, journey.JourneyId, "Ashford", "Paris", "late@example.com").Receipt
+		if early.AllocatedSeat.SeatNumber != "A1" || late.AllocatedSeat.SeatNumber != "A1" {
+			t.Fatalf("expected both legs on A1, got %s and %s", early.AllocatedSeat.SeatNumber, late.AllocatedSeat.SeatNumber)
+		}
+		whole := purchaseLeg(t, s, journey.JourneyId, "London", "Paris", "whole@example.com").Receipt
+
+		_, err := s.ModifyUserSeat(ctx, whole, &ticket.Seat{Section: ticket.Seat_SECTION_A, SeatNumber: "A1"})
+		expectError(t, err, ErrSeatOccupied)
+
+		resp, err := s.ModifyUserSeat(ctx, early, &ticket.Seat{Section: ticket.Seat_SECTION_B, SeatNumber: "B2"})
+		if err != nil || !resp.Success {
+			t.Fatalf("expected seat change to succeed, got %v, %v", resp, err)
+		}
+		// A1 stays taken from Ashford.
+		_, err = s.ModifyUserSeat(ctx, whole, &ticket.Seat{Section: ticket.Seat_SECTION_A, SeatNumber: "A1"})
+		expectError(t, err, ErrSeatOccupied)
+	})
+}
+
+func TestUnit_ConcurrentPurchasesOnSegments(t *testing.T) {
+	s := NewTicketService()
+	journey := createRoute(t, s, 2, "London", "Lille", "Paris")
+	legs := [][2]string{{"London", "Lille"}, {"Lille", "Paris"}, {"London", "Paris"}}
+
+	// Ten buyers per leg compete for four seats.
+	var wg sync.WaitGroup
+	results := make(chan *ticket.PurchaseTicketResponse, 10*len(legs))
+	for i := 0; i < 10; i++ {
+		for _, leg := range legs {
+			wg.Add(1)
+			go func(i int, from, to string) {
+				defer wg.Done()
+				res, err := tryPurchaseLeg(s, journey.JourneyId, from, to, fmt.Sprintf("%s-%s-%d@example.com", from, to, i))
+				if e, ok := AsError(err); ok && e.Message == ErrNoAvailableSeats {
+					return
+				}
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+					return
+				}
+				results <- res
+			}(i, leg[0], leg[1])
+		}
+	}
+	wg.Wait()
+	close(results)
+
+	// No two confirmed tickets may share a seat over an overlapping segment.
+	var sold []*ticket.Receipt
+	for res := range results {
+		sold = append(sold, res.Receipt)
+	}
+	for i, a := range sold {
+		for _, b := range sold[i+1:] {
+			if a.AllocatedSeat.SeatNumber != b.AllocatedSeat.SeatNumber {
+				continue
+			}
+			if segmentOfReceipt(journey, a).overlaps(segmentOfReceipt(journey, b)) {
+				t.Errorf("seat %s double-booked: %s -> %s and %s -> %s", a.AllocatedSeat.SeatNumber,
+					a.FromLocation, a.ToLocation, b.FromLocation, b.ToLocation)
+			}
+		}
+	}
+
+	// Every seat must be full over both segments once the dust settles.
+	for _, seatNumber := range []string{"A1", "A2", "B1", "B2"} {
+		for _, leg := range []segment{{from: 0, to: 1}, {from: 1, to: 2}} {
+			if s.isSeatFree(journey, seatNumber, leg, "") {
+				t.Errorf("expected seat %s to be sold over %v", seatNumber, leg)
+			}
+		}
+	}
+}
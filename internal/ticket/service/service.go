@@ -8,80 +8,161 @@ import (
 	"time"
 
 	ticket "github.com/talk2sohail/train-ticket-api/internal/common/genproto/ticket"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/cancellation"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/fare"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/layout"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/money"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/payment"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/repository"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/types"
 
 	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type TicketService struct {
-	mu                sync.Mutex                  // Mutex to protect concurrent access to in-memory data structures.
-	receipts          map[string]*ticket.Receipt  // Stores all purchased receipts, keyed by Ticket ID.
-	occupiedSeats     map[string]*ticket.Receipt  // Stores which seats are occupied, keyed by seat number (e.g., "A1").
-	sectionCapacities map[ticket.Seat_Section]int // Defines the maximum number of seats for each section.
+	mu            sync.Mutex                        // Mutex to serialise read-modify-write sequences (e.g. seat allocation) against the repository.
+	repo          types.TicketRepository            // Stores receipts and the seats they occupy.
+	layouts       map[string]*ticket.TrainLayout    // Train layouts journeys can be created with, keyed by name.
+	defaultLayout *ticket.TrainLayout               // Seating plan of the default journey and of journeys created without a layout.
+	clock         Clock                             // Source of the current time.
+	holdTTL       time.Duration                     // How long a seat hold lasts before it expires.
+	holds         map[string]*seatHold              // Seats reserved by HoldSeat and not yet confirmed, or kept while they are paid for, keyed by Hold ID.
+	watchers      map[*availabilityWatcher]struct{} // Open WatchAvailability streams.
+	ticketQuota   int                               // Most active tickets and holds one email may have; unlimited when not positive.
+	fares         func() *fare.Table                // Gives the fare table in force; the price_paid of requests is taken as given when nil.
+	rates         *money.Rates                      // Converts prices paid in other currencies than the fare table's; nil when there are none.
+	payments      payment.Provider                  // Takes payment for purchased tickets; tickets are booked without payment when nil.
+	cancellation  *cancellation.Policy              // Decides how much of a cancelled ticket's price is refunded; all of it before departure when nil.
 }
 
-// NewTicketService creates a new instance of TicketService
-func NewTicketService() *TicketService {
-	return &TicketService{
-		// Initialize necessary fields here
-		receipts:      make(map[string]*ticket.Receipt),
-		occupiedSeats: make(map[string]*ticket.Receipt),
-		sectionCapacities: map[ticket.Seat_Section]int{
-			ticket.Seat_SECTION_A: MaxSeatsPerSection,
-			ticket.Seat_SECTION_B: MaxSeatsPerSection,
-		},
+// Option configures optional behaviour of a TicketService.
+type Option func(*TicketService)
+
+// NewTicketService creates a new instance of TicketService backed by in-memory storage.
+func NewTicketService(opts ...Option) *TicketService {
+	return NewTicketServiceWithRepository(repository.NewMemoryRepository(), opts...)
+}
+
+// NewTicketServiceWithRepository creates a new instance of TicketService that stores bookings in repo.
+func NewTicketServiceWithRepository(repo types.TicketRepository, opts ...Option) *TicketService {
+	s := &TicketService{
+		repo:          repo,
+		defaultLayout: layout.Standard(MaxSeatsPerSection),
+		clock:         systemClock{},
+		holdTTL:       DefaultHoldTTL,
+		holds:         make(map[string]*seatHold),
+		watchers:      make(map[*availabilityWatcher]struct{}),
+	}
+	for _, opt := range opts {
+		opt(s)
 	}
+	return s
 }
 
-// findNextAvailableSeat iterates through sections and seat numbers to find the first unoccupied seat.
+// findNextAvailableSeat walks the seats of a journey's layout in coach, row and column order to find the first
+// seat that is free over the segment being travelled.
 // This function assumes the caller has already acquired the server's mutex to ensure thread safety
-// when accessing `s.occupiedSeats` and `s.sectionCapacities`.
-func (s *TicketService) findNextAvailableSeat() (*ticket.Seat, error) {
-	// First, attempt to find an available seat in Section A.
-	for i := 1; i <= s.sectionCapacities[ticket.Seat_SECTION_A]; i++ {
-		seatNumber := fmt.Sprintf("A%d", i) // Construct seat string, e.g., "A1", "A2"
-		if _, isOccupied := s.occupiedSeats[seatNumber]; !isOccupied {
-			return &ticket.Seat{
-				Section:    ticket.Seat_SECTION_A,
-				SeatNumber: seatNumber,
-			}, nil
+// when reading seat occupancy from `s.repo`.
+func (s *TicketService) findNextAvailableSeat(journey *ticket.Journey, seg segment) (*ticket.Seat, error) {
+	for _, coach := range layoutOf(journey).GetCoaches() {
+		for _, seat := range coach.GetSeats() {
+			if s.isSeatFree(journey, seat.GetSeatNumber(), seg, "") {
+				return proto.Clone(seat).(*ticket.Seat), nil
+			}
 		}
 	}
 
-	// If Section A is full, attempt to find an available seat in Section B.
-	for i := 1; i <= s.sectionCapacities[ticket.Seat_SECTION_B]; i++ {
-		seatNumber := fmt.Sprintf("B%d", i) // Construct seat string, e.g., "B1", "B2"
-		if _, isOccupied := s.occupiedSeats[seatNumber]; !isOccupied {
-			return &ticket.Seat{
-				Section:    ticket.Seat_SECTION_B,
-				SeatNumber: seatNumber,
-			}, nil
-		}
+	return nil, newError(ErrNoAvailableSeats, "")
+}
+
+// purchasePlan is the seat and fare a ticket would be purchased at.
+type purchasePlan struct {
+	journey    *ticket.Journey
+	seg        segment
+	seat       *ticket.Seat
+	met, unmet []string
+	quoted     *ticket.Fare
+}
+
+// planPurchase finds the seat a purchase would be given and checks the price paid for it.
+// This function assumes the caller has already acquired the server's mutex.
+func (s *TicketService) planPurchase(req *ticket.PurchaseTicketRequest, paid money.Money) (*purchasePlan, error) {
+	journey, ok := s.lookupJourney(req.GetJourneyId())
+	if !ok {
+		log.Printf("[PurchaseTicket] Failed for user %s: %s %s", req.GetUser().GetEmail(), ErrJourneyNotFound, req.GetJourneyId())
+		return nil, newError(ErrJourneyNotFound, req.GetJourneyId())
 	}
 
-	return nil, fmt.Errorf("%s", ErrNoAvailableSeats)
+	// Work out which part of the route the passenger travels; the seat is only held over it.
+	seg, err := segmentOf(journey, req.GetFromLocation(), req.GetToLocation())
+	if err != nil {
+		log.Printf("[PurchaseTicket] Failed for user %s: %v", req.GetUser().GetEmail(), err)
+		return nil, err
+	}
+
+	if err := s.checkTicketQuota("PurchaseTicket", req.GetUser().GetEmail(), 1); err != nil {
+		return nil, err
+	}
+
+	// find the free seat that best matches the passenger's preferences.
+	allocatedSeat, met, unmet, err := s.findPreferredSeat(journey, seg, req.GetPreferences())
+	if err != nil {
+		log.Printf("[PurchaseTicket] Failed for user %s: %v", req.GetUser().GetEmail(), err)
+		return nil, err
+	}
+
+	// Charge the fare of the seat; the client must have been quoted the same price.
+	quoted := s.quoteFare(journey, seg, req.GetFromLocation(), req.GetToLocation(), req.GetTravelClass(), req.GetPassengerType(), allocatedSeat)
+	if err := s.checkPrice("PurchaseTicket", "user "+req.GetUser().GetEmail(), quoted, paid); err != nil {
+		return nil, err
+	}
+	return &purchasePlan{journey: journey, seg: seg, seat: allocatedSeat, met: met, unmet: unmet, quoted: quoted}, nil
 }
 
 // PurchaseTicket handles the purchase of a train ticket
-func (s *TicketService) PurchaseTicket(ctx context.Context, req *ticket.PurchaseTicketRequest) (ticket.PurchaseTicketResponse, error) {
+func (s *TicketService) PurchaseTicket(ctx context.Context, req *ticket.PurchaseTicketRequest) (*ticket.PurchaseTicketResponse, error) {
 
-	// Acquire a lock to protect shared server state (receipts, occupiedSeats) during concurrent access.
+	// Acquire a lock so that finding a free seat and storing the receipt happen atomically.
+	// It is only released while the payment processor is called, when the seat is checked again or kept.
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	// find the next available seat using our allocation logic.
-	allocatedSeat, err := s.findNextAvailableSeat()
+	paid, err := priceOf(req.GetPrice(), req.GetPricePaid())
 	if err != nil {
 		log.Printf("[PurchaseTicket] Failed for user %s: %v", req.GetUser().GetEmail(), err)
-		return ticket.PurchaseTicketResponse{
-			Success: false,
-			Message: err.Error(),
-			Receipt: nil,
-		}, nil
+		return nil, err
+	}
+
+	// Check that a seat can be sold before reserving its price.
+	plan, err := s.planPurchase(req, paid)
+	if err != nil {
+		return nil, err
+	}
+	paymentID, err := s.authorize(ctx, "PurchaseTicket", "user "+req.GetUser().GetEmail(), req.GetPaymentMethod(), paid)
+	if err != nil {
+		return nil, err
+	}
+	if paymentID != "" {
+		// The seat may have been taken while the price was reserved, so find one again.
+		if plan, err = s.planPurchase(req, paid); err != nil {
+			s.void(ctx, "PurchaseTicket", paymentID)
+			return nil, err
+		}
+	}
+
+	// The price is only taken once the seat is found, and the seat is kept meanwhile.
+	reservation := s.reserve(plan.journey.GetJourneyId(), plan.seat, plan.seg, req.GetUser(), "")
+	err = s.capture(ctx, "PurchaseTicket", paymentID)
+	s.release(reservation)
+	if err != nil {
+		return nil, err
 	}
 
 	// Generate a unique ticket ID for the new purchase.
 	ticketID := uuid.New().String()
+	now := s.clock.Now()
 
 	// Construct the Receipt object using the request details and the allocated seat.
 	receipt := &ticket.Receipt{
@@ -89,22 +170,38 @@ func (s *TicketService) PurchaseTicket(ctx context.Context, req *ticket.Purchase
 		FromLocation:  req.GetFromLocation(),
 		ToLocation:    req.GetToLocation(),
 		User:          req.GetUser(),
-		PricePaid:     req.GetPricePaid(),
-		AllocatedSeat: allocatedSeat,
-		PurchaseDate:  timestamppb.New(time.Now()),
+		PricePaid:     paid.Major(),
+		Price:         paid.Proto(),
+		AllocatedSeat: plan.seat,
+		PurchaseDate:  timestamppb.New(now),
+		JourneyId:     plan.journey.GetJourneyId(),
+		Fare:          plan.quoted,
+		Payment:       s.paymentOf(paymentID, paid),
 	}
 
-	// Store the new receipt in our in-memory data structures.
-	s.receipts[ticketID] = receipt
-	s.occupiedSeats[allocatedSeat.GetSeatNumber()] = receipt
+	// Record the purchase in the ledger, which also marks the seat as occupied.
+	if err := s.repo.Append(ticketPurchasedEvent(receipt, now)); err != nil {
+		log.Printf("[PurchaseTicket] Failed to store receipt for user %s: %v", req.GetUser().GetEmail(), err)
+		if err := s.refund(ctx, "PurchaseTicket", receipt); err != nil {
+			log.Printf("[PurchaseTicket] Payment %s was taken for a ticket that was not booked", paymentID)
+		}
+		return nil, fmt.Errorf("failed to store receipt: %w", err)
+	}
+	s.notifyAvailability(plan.journey.GetJourneyId())
 
-	log.Printf("[PurchaseTicket] Success: TicketID=%s, Seat=%s, Section=%s", ticketID, allocatedSeat.GetSeatNumber(), allocatedSeat.GetSection().String())
+	log.Printf("[PurchaseTicket] Success: TicketID=%s, Journey=%s, Seat=%s, Section=%s", ticketID, plan.journey.GetJourneyId(), plan.seat.GetSeatNumber(), plan.seat.GetSection().String())
+
+	if len(plan.unmet) > 0 {
+		log.Printf("[PurchaseTicket] TicketID=%s could not meet preferences %v", ticketID, plan.unmet)
+	}
 
 	// Return a successful response with the generated receipt.
-	return ticket.PurchaseTicketResponse{
-		Success: true,
-		Message: MsgTicketPurchaseSuccess,
-		Receipt: receipt,
+	return &ticket.PurchaseTicketResponse{
+		Success:          true,
+		Message:          MsgTicketPurchaseSuccess,
+		Receipt:          receipt,
+		PreferencesMet:   plan.met,
+		PreferencesUnmet: plan.unmet,
 	}, nil
 }
 
@@ -113,9 +210,9 @@ func (s *TicketService) GetReceiptDetails(ctx context.Context, ticketID string)
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	receipt, exists := s.receipts[ticketID]
+	receipt, exists := s.repo.GetReceipt(ticketID)
 	if !exists {
-		err := fmt.Errorf("%s for ticketID %s", ErrReceiptNotFound, ticketID)
+		err := newError(ErrReceiptNotFound, ticketID)
 		log.Printf("[GetReceiptDetails] %v", err)
 		return nil, err
 	}
@@ -123,95 +220,209 @@ func (s *TicketService) GetReceiptDetails(ctx context.Context, ticketID string)
 	return receipt, nil
 }
 
-// GetUsersBySection retrieves all users with their seats in a specified section.
-func (s *TicketService) GetUsersBySection(ctx context.Context, section ticket.Seat_Section) (ticket.GetUsersBySectionResponse, error) {
+// GetReceiptByEmail retrieves the receipt of the only active ticket held under an email.
+// A passenger with several tickets must identify the one they mean by its ticket ID.
+func (s *TicketService) GetReceiptByEmail(ctx context.Context, email string) (*ticket.Receipt, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	receipt, err := s.receiptByEmail(email)
+	if err != nil {
+		log.Printf("[GetReceiptByEmail] %v", err)
+		return nil, err
+	}
+	log.Printf("[GetReceiptByEmail] Retrieved receipt %s for email %s", receipt.GetTicketId(), email)
+	return receipt, nil
+}
+
+// receiptByEmail looks up the only active ticket held under an email using the repository's email index.
+// This function assumes the caller has already acquired the server's mutex.
+func (s *TicketService) receiptByEmail(email string) (*ticket.Receipt, error) {
+	receipts := s.repo.GetReceiptsByEmail(email)
+	switch len(receipts) {
+	case 0:
+		return nil, newError(ErrUserNotFound, email)
+	case 1:
+		return receipts[0], nil
+	default:
+		return nil, newError(ErrEmailAmbiguous, email)
+	}
+}
+
+// GetUsersBySection retrieves all users with their seats in a specified coach of a journey.
+// Coaches "A" and "B" are the sections of the standard layout.
+func (s *TicketService) GetUsersBySection(ctx context.Context, journeyID string, coach string) (*ticket.GetUsersBySectionResponse, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+
+	journey, ok := s.lookupJourney(journeyID)
+	if !ok {
+		err := newError(ErrJourneyNotFound, journeyID)
+		log.Printf("[GetUsersBySection] %v", err)
+		return nil, err
+	}
+
+	if !layout.HasCoach(layoutOf(journey), coach) {
+		err := newError(ErrCoachNotFound, coach)
+		log.Printf("[GetUsersBySection] %v", err)
+		return nil, err
+	}
+
 	var users []*ticket.UserSeat
-	// Iterate through all receipts to find users in the specified section.
-	for _, receipt := range s.receipts {
-		if receipt.AllocatedSeat.Section == section {
+	// Iterate through all receipts to find users in the specified coach of the journey.
+	for _, receipt := range s.repo.ListReceipts() {
+		if types.JourneyIDOf(receipt) == journey.GetJourneyId() && layout.CoachOf(receipt.AllocatedSeat) == coach {
 			users = append(users, &ticket.UserSeat{
 				User: receipt.User,
 				Seat: receipt.AllocatedSeat,
 			})
 		}
 	}
-	log.Printf("[GetUsersBySection] Retrieved %d users in section %s", len(users), section.String())
-	return ticket.GetUsersBySectionResponse{
+	log.Printf("[GetUsersBySection] Retrieved %d users in coach %s of journey %s", len(users), coach, journey.GetJourneyId())
+	return &ticket.GetUsersBySectionResponse{
 		Success:        true,
 		Message:        MsgUsersRetrieved,
 		UsersInSection: users,
 	}, nil
 }
 
-// RemoveUser removes a user identified by their email.
-func (s *TicketService) RemoveUser(ctx context.Context, email string) (ticket.RemoveUserResponse, error) {
+// RemoveUser removes a user identified by their email, cancelling their ticket.
+// A passenger with several tickets must cancel the one they mean with CancelTicket.
+func (s *TicketService) RemoveUser(ctx context.Context, email string) (*ticket.RemoveUserResponse, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	var ticketIdToRemove string
-	for id, receipt := range s.receipts {
-		if receipt.User.GetEmail() == email {
-			ticketIdToRemove = id
-			break
-		}
+	receiptToRemove, err := s.receiptByEmail(email)
+	if err != nil {
+		log.Printf("[RemoveUser] Failed for email %s: %v", email, err)
+		return nil, err
 	}
-
-	if ticketIdToRemove == "" {
-		log.Printf("[RemoveUser] No user found with email: %s", email)
-		return ticket.RemoveUserResponse{
-			Success: false,
-			Message: ErrUserNotFound,
-		}, nil
+	refund, err := s.cancel(ctx, "RemoveUser", receiptToRemove)
+	if err != nil {
+		return nil, err
 	}
-
-	receipt := s.receipts[ticketIdToRemove]
-	delete(s.receipts, ticketIdToRemove)
-	delete(s.occupiedSeats, receipt.AllocatedSeat.SeatNumber)
-	log.Printf("[RemoveUser] Removed user with email: %s, TicketID: %s", email, ticketIdToRemove)
-	return ticket.RemoveUserResponse{
+	return &ticket.RemoveUserResponse{
 		Success: true,
 		Message: MsgUserRemovedSuccess,
+		Refund:  refund,
+	}, nil
+}
+
+// CancelTicket cancels a ticket identified by its ID, leaving the passenger's other tickets untouched.
+func (s *TicketService) CancelTicket(ctx context.Context, ticketID string) (*ticket.CancelTicketResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	receiptToRemove, ok := s.repo.GetReceipt(ticketID)
+	if !ok {
+		log.Printf("[CancelTicket] Receipt not found for TicketID: %s", ticketID)
+		return nil, newError(ErrReceiptNotFound, ticketID)
+	}
+	refund, err := s.cancel(ctx, "CancelTicket", receiptToRemove)
+	if err != nil {
+		return nil, err
+	}
+	return &ticket.CancelTicketResponse{
+		Success: true,
+		Message: MsgTicketCancelled,
+		Receipt: receiptToRemove,
+		Refund:  refund,
 	}, nil
 }
 
+// cancel records a ticket's cancellation with the refund due under the cancellation policy,
+// hands its seat to the waitlist and then returns the refund to the ticket's payment.
+// This function assumes the caller has already acquired the server's mutex.
+func (s *TicketService) cancel(ctx context.Context, method string, receiptToRemove *ticket.Receipt) (*ticket.Refund, error) {
+	ticketIdToRemove := receiptToRemove.GetTicketId()
+	now := s.clock.Now()
+	journey, hasJourney := s.lookupJourney(types.JourneyIDOf(receiptToRemove))
+
+	refund, amount, err := s.refundFor(receiptToRemove, journey, now)
+	if err != nil {
+		log.Printf("[%s] Failed to work out the refund of TicketID %s: %v", method, ticketIdToRemove, err)
+		return nil, err
+	}
+	// Money is only returned once the cancellation is recorded, so a cancellation that
+	// cannot be stored moves none and can be retried without refunding twice.
+	if err := s.refundable(method, receiptToRemove, amount); err != nil {
+		return nil, err
+	}
+
+	// Without payments, hand the freed seat to the waitlist in the same batch, so a crash
+	// cannot cancel the ticket without promoting the passengers waiting for it.
+	events := []*ticket.BookingEvent{ticketCancelledEvent(receiptToRemove, refund, now)}
+	if hasJourney && s.payments == nil {
+		events = append(events, promotionEvents(s.promoteWaitlist(journey, receiptToRemove.GetAllocatedSeat(), ticketIdToRemove, nil, now), now)...)
+	}
+	if err := s.repo.Append(events...); err != nil {
+		log.Printf("[%s] Failed to remove TicketID %s: %v", method, ticketIdToRemove, err)
+		return nil, fmt.Errorf("failed to remove receipt: %w", err)
+	}
+	if refund == nil {
+		log.Printf("[%s] Removed user with email: %s, TicketID: %s", method, receiptToRemove.GetUser().GetEmail(), ticketIdToRemove)
+	} else {
+		log.Printf("[%s] Removed user with email: %s, TicketID: %s, Refund: %s (%s)", method, receiptToRemove.GetUser().GetEmail(), ticketIdToRemove, amount, refund.GetRefundId())
+	}
+	logPromotions(method, events)
+	if refund != nil {
+		refund = s.settleRefund(ctx, method, receiptToRemove, refund, amount)
+	}
+
+	// With payments, promoted passengers pay for the seat once the cancellation is stored. A crash
+	// in between leaves the seat free for anyone to buy.
+	if hasJourney && s.payments != nil {
+		s.offerSeat(ctx, method, journey, receiptToRemove.GetAllocatedSeat())
+	}
+	s.notifyAvailability(types.JourneyIDOf(receiptToRemove))
+	return refund, nil
+}
+
 // ModifyUserSeat updates a user's seat given an existing receipt and the new seat.
-func (s *TicketService) ModifyUserSeat(ctx context.Context, receipt *ticket.Receipt, newSeat *ticket.Seat) (ticket.ModifyUserSeatResponse, error) {
+func (s *TicketService) ModifyUserSeat(ctx context.Context, receipt *ticket.Receipt, newSeat *ticket.Seat) (*ticket.ModifyUserSeatResponse, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	// Ensure the receipt exists.
-	existingUserReceipt, ok := s.receipts[receipt.TicketId]
+	existingUserReceipt, ok := s.repo.GetReceipt(receipt.TicketId)
 	if !ok {
 		log.Printf("[ModifyUserSeat] Receipt not found for TicketID: %s", receipt.TicketId)
-		return ticket.ModifyUserSeatResponse{
-			Success: false,
-			Message: ErrReceiptNotFound,
-		}, nil
-	}
-
-	// Check if new seat is occupied by another ticket.
-	if occupied, exists := s.occupiedSeats[newSeat.SeatNumber]; exists {
-		if occupied.TicketId != receipt.TicketId {
-			log.Printf("[ModifyUserSeat] Seat %s is already occupied", newSeat.SeatNumber)
-			return ticket.ModifyUserSeatResponse{
-				Success: false,
-				Message: ErrSeatOccupied,
-			}, nil
-		}
+		return nil, newError(ErrReceiptNotFound, receipt.TicketId)
 	}
 
-	// Free the old seat.
-	delete(s.occupiedSeats, existingUserReceipt.AllocatedSeat.SeatNumber)
-	// Update receipt with the new seat.
-	existingUserReceipt.AllocatedSeat = newSeat
-	s.occupiedSeats[newSeat.SeatNumber] = existingUserReceipt
+	// Check if new seat is occupied by another ticket over the part of the route this ticket travels.
+	journey, ok := s.lookupJourney(types.JourneyIDOf(existingUserReceipt))
+	if !ok {
+		log.Printf("[ModifyUserSeat] Journey %s not found for TicketID: %s", types.JourneyIDOf(existingUserReceipt), receipt.TicketId)
+		return nil, newError(ErrJourneyNotFound, types.JourneyIDOf(existingUserReceipt))
+	}
+	// The new seat must exist in the journey's layout; the stored seat carries the layout's coach and attributes.
+	layoutSeat, ok := layout.FindSeat(layoutOf(journey), newSeat.GetSeatNumber())
+	if !ok {
+		log.Printf("[ModifyUserSeat] Seat %s is not in the layout of journey %s", newSeat.GetSeatNumber(), journey.GetJourneyId())
+		return nil, newError(ErrSeatNotFound, newSeat.GetSeatNumber())
+	}
+	newSeat = proto.Clone(layoutSeat).(*ticket.Seat)
+
+	if !s.isSeatFree(journey, newSeat.SeatNumber, segmentOfReceipt(journey, existingUserReceipt), receipt.TicketId) {
+		log.Printf("[ModifyUserSeat] Seat %s is already occupied", newSeat.SeatNumber)
+		err := newError(ErrSeatOccupied, receipt.TicketId)
+		err.Seat = newSeat.SeatNumber
+		return nil, err
+	}
+
+	// Record the seat change in the ledger; applying it frees the old seat.
+	if err := s.repo.Append(seatChangedEvent(existingUserReceipt, newSeat, s.clock.Now())); err != nil {
+		log.Printf("[ModifyUserSeat] Failed to store seat change for TicketID %s: %v", receipt.TicketId, err)
+		return nil, fmt.Errorf("failed to store seat change: %w", err)
+	}
+	updatedReceipt, _ := s.repo.GetReceipt(receipt.TicketId)
+	s.notifyAvailability(journey.GetJourneyId())
 
 	log.Printf("[ModifyUserSeat] Updated seat for TicketID: %s to Seat: %s", receipt.TicketId, newSeat.SeatNumber)
-	return ticket.ModifyUserSeatResponse{
+	return &ticket.ModifyUserSeatResponse{
 		Success:        true,
 		Message:        MsgSeatUpdatedSuccess,
-		UpdatedReceipt: existingUserReceipt,
+		UpdatedReceipt: updatedReceipt,
 	}, nil
 }
@@ -5,15 +5,45 @@ import (
 	"fmt"
 	"sync"
 	"testing"
+	"time"
 
 	ticket "github.com/talk2sohail/train-ticket-api/internal/common/genproto/ticket"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/repository"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/types"
 )
 
+// saveReceipt records a purchase of receipt directly in the service's repository.
+func saveReceipt(t *testing.T, s *TicketService, receipt *ticket.Receipt) {
+	t.Helper()
+	if err := s.repo.Append(ticketPurchasedEvent(receipt, time.Now())); err != nil {
+		t.Fatalf("failed to seed receipt %s: %v", receipt.GetTicketId(), err)
+	}
+}
+
+// occupySeat marks a seat as taken by storing a placeholder receipt for it.
+func occupySeat(t *testing.T, s *TicketService, section ticket.Seat_Section, seatNumber string) {
+	t.Helper()
+	saveReceipt(t, s, &ticket.Receipt{
+		TicketId:      "occupied-" + seatNumber,
+		User:          &ticket.User{Email: seatNumber + "@example.com"},
+		AllocatedSeat: &ticket.Seat{Section: section, SeatNumber: seatNumber},
+	})
+}
+
+// holderOf returns the single ticket holding a seat on a journey, if any.
+func holderOf(repo types.TicketRepository, journeyID, seatNumber string) (*ticket.Receipt, bool) {
+	holders := repo.GetReceiptsBySeat(journeyID, seatNumber)
+	if len(holders) == 0 {
+		return nil, false
+	}
+	return holders[0], true
+}
+
 func TestUnit_FindNextAvailableSeat(t *testing.T) {
 	s := NewTicketService()
 
 	t.Run("Section A available", func(t *testing.T) {
-		seat, err := s.findNextAvailableSeat()
+		seat, err := s.findNextAvailableSeat(s.defaultJourney(), segment{from: 0, to: 1})
 		if err != nil {
 			t.Fatalf("expected seat, got error: %v", err)
 		}
@@ -23,11 +53,10 @@ func TestUnit_FindNextAvailableSeat(t *testing.T) {
 	})
 
 	t.Run("Section A full, Section B available", func(t *testing.T) {
-		for i := 1; i <= s.sectionCapacities[ticket.Seat_SECTION_A]; i++ {
-			seatNum := fmt.Sprintf("A%d", i)
-			s.occupiedSeats[seatNum] = &ticket.Receipt{}
+		for i := 1; i <= MaxSeatsPerSection; i++ {
+			occupySeat(t, s, ticket.Seat_SECTION_A, fmt.Sprintf("A%d", i))
 		}
-		seat, err := s.findNextAvailableSeat()
+		seat, err := s.findNextAvailableSeat(s.defaultJourney(), segment{from: 0, to: 1})
 		if err != nil {
 			t.Fatalf("expected seat in Section B, got error: %v", err)
 		}
@@ -37,11 +66,10 @@ func TestUnit_FindNextAvailableSeat(t *testing.T) {
 	})
 
 	t.Run("No available seats", func(t *testing.T) {
-		for i := 1; i <= s.sectionCapacities[ticket.Seat_SECTION_B]; i++ {
-			seatNum := fmt.Sprintf("B%d", i)
-			s.occupiedSeats[seatNum] = &ticket.Receipt{}
+		for i := 1; i <= MaxSeatsPerSection; i++ {
+			occupySeat(t, s, ticket.Seat_SECTION_B, fmt.Sprintf("B%d", i))
 		}
-		seat, err := s.findNextAvailableSeat()
+		seat, err := s.findNextAvailableSeat(s.defaultJourney(), segment{from: 0, to: 1})
 		if err == nil {
 			t.Fatalf("expected error %s, got seat: %v", ErrNoAvailableSeats, seat)
 		}
@@ -84,14 +112,14 @@ func TestUnit_PurchaseTicket(t *testing.T) {
 	t.Run("Purchase fails when no seats available", func(t *testing.T) {
 		s := NewTicketService()
 		for _, section := range []ticket.Seat_Section{ticket.Seat_SECTION_A, ticket.Seat_SECTION_B} {
-			for i := 1; i <= s.sectionCapacities[section]; i++ {
+			for i := 1; i <= MaxSeatsPerSection; i++ {
 				seatID := ""
 				if section == ticket.Seat_SECTION_A {
 					seatID = fmt.Sprintf("A%d", i)
 				} else {
 					seatID = fmt.Sprintf("B%d", i)
 				}
-				s.occupiedSeats[seatID] = &ticket.Receipt{}
+				occupySeat(t, s, section, seatID)
 			}
 		}
 
@@ -106,17 +134,11 @@ func TestUnit_PurchaseTicket(t *testing.T) {
 			PricePaid: 50.0,
 		}
 		res, err := s.PurchaseTicket(ctx, req)
-		if err != nil {
-			t.Fatalf("expected no error returned, got: %v", err)
+		if e := expectError(t, err, ErrNoAvailableSeats); e.Kind != KindResourceExhausted {
+			t.Errorf("expected %v, got %v", KindResourceExhausted, e.Kind)
 		}
-		if res.Success {
-			t.Fatalf("expected purchase failure, but got success")
-		}
-		if res.Receipt != nil {
-			t.Errorf("expected no receipt on failure, got one")
-		}
-		if res.Message != ErrNoAvailableSeats {
-			t.Errorf("expected message %q, got: %q", ErrNoAvailableSeats, res.Message)
+		if res != nil {
+			t.Errorf("expected no response on failure, got %v", res)
 		}
 	})
 
@@ -153,10 +175,10 @@ func TestUnit_PurchaseTicketConcurrent(t *testing.T) {
 	ctx := context.Background()
 	s := NewTicketService()
 
-	totalSeats := s.sectionCapacities[ticket.Seat_SECTION_A] + s.sectionCapacities[ticket.Seat_SECTION_B]
+	totalSeats := 2 * MaxSeatsPerSection
 
 	type result struct {
-		response ticket.PurchaseTicketResponse
+		response *ticket.PurchaseTicketResponse
 		err      error
 	}
 	var wg sync.WaitGroup
@@ -189,21 +211,17 @@ func TestUnit_PurchaseTicketConcurrent(t *testing.T) {
 	var successCount int
 	for res := range resChan {
 		if res.err != nil {
-			t.Errorf("Unexpected error: %v", res.err)
+			if e, ok := AsError(res.err); !ok || e.Message != ErrNoAvailableSeats {
+				t.Errorf("Expected error %q, got %v", ErrNoAvailableSeats, res.err)
+			}
 			continue
 		}
-		if res.response.Success {
-			successCount++
-			seat := res.response.Receipt.AllocatedSeat.SeatNumber
-			if successMap[seat] {
-				t.Errorf("Duplicate allocation for seat %s", seat)
-			}
-			successMap[seat] = true
-		} else {
-			if res.response.Message != ErrNoAvailableSeats {
-				t.Errorf("Expected error message %q, got %q", ErrNoAvailableSeats, res.response.Message)
-			}
+		successCount++
+		seat := res.response.Receipt.AllocatedSeat.SeatNumber
+		if successMap[seat] {
+			t.Errorf("Duplicate allocation for seat %s", seat)
 		}
+		successMap[seat] = true
 	}
 
 	if successCount != totalSeats {
@@ -223,7 +241,7 @@ func TestUnit_GetReceiptDetails(t *testing.T) {
 			ToLocation:   "CityB",
 			PricePaid:    100.0,
 		}
-		s.receipts[ticketID] = expectedReceipt
+		saveReceipt(t, s, expectedReceipt)
 
 		receipt, err := s.GetReceiptDetails(ctx, ticketID)
 		if err != nil {
@@ -241,25 +259,15 @@ func TestUnit_GetReceiptDetails(t *testing.T) {
 	t.Run("Receipt does not exist", func(t *testing.T) {
 		unknownTicketID := "non-existent-ticket"
 		_, err := s.GetReceiptDetails(ctx, unknownTicketID)
-		if err == nil {
-			t.Fatalf("expected error for ticketID %s, got nil", unknownTicketID)
-		}
-		expectedError := fmt.Sprintf("%s for ticketID %s", ErrReceiptNotFound, unknownTicketID)
-		if err.Error() != expectedError {
-			t.Errorf("expected error %q, got %q", expectedError, err.Error())
+		if e := expectError(t, err, ErrReceiptNotFound); e.Subject != unknownTicketID || e.Kind != KindNotFound {
+			t.Errorf("expected %s to be reported not found, got %+v", unknownTicketID, e)
 		}
 	})
 
 	t.Run("Empty TicketID returns error", func(t *testing.T) {
 		emptyID := ""
 		_, err := s.GetReceiptDetails(ctx, emptyID)
-		if err == nil {
-			t.Fatalf("expected error for empty ticketID, got nil")
-		}
-		expected := fmt.Sprintf("%s for ticketID %s", ErrReceiptNotFound, emptyID)
-		if err.Error() != expected {
-			t.Errorf("expected error %q, got %q", expected, err.Error())
-		}
+		expectError(t, err, ErrReceiptNotFound)
 	})
 
 	t.Run("Concurrent retrieval of a receipt", func(t *testing.T) {
@@ -270,7 +278,7 @@ func TestUnit_GetReceiptDetails(t *testing.T) {
 			ToLocation:   "CityY",
 			PricePaid:    75.0,
 		}
-		s.receipts[ticketID] = expectedReceipt
+		saveReceipt(t, s, expectedReceipt)
 
 		numGoroutines := 20
 		var wg sync.WaitGroup
@@ -306,7 +314,7 @@ func TestUnit_GetUsersBySection(t *testing.T) {
 	s := NewTicketService()
 
 	t.Run("Empty section returns no users", func(t *testing.T) {
-		resp, err := s.GetUsersBySection(ctx, ticket.Seat_SECTION_A)
+		resp, err := s.GetUsersBySection(ctx, "", "A")
 		if err != nil {
 			t.Fatalf("expected no error, got: %v", err)
 		}
@@ -341,15 +349,11 @@ func TestUnit_GetUsersBySection(t *testing.T) {
 			User: &ticket.User{Email: "user3@example.com"},
 		}
 
-		s.receipts[r1.TicketId] = r1
-		s.receipts[r2.TicketId] = r2
-		s.receipts[r3.TicketId] = r3
+		saveReceipt(t, s, r1)
+		saveReceipt(t, s, r2)
+		saveReceipt(t, s, r3)
 
-		s.occupiedSeats[r1.AllocatedSeat.SeatNumber] = r1
-		s.occupiedSeats[r2.AllocatedSeat.SeatNumber] = r2
-		s.occupiedSeats[r3.AllocatedSeat.SeatNumber] = r3
-
-		resp, err := s.GetUsersBySection(ctx, ticket.Seat_SECTION_A)
+		resp, err := s.GetUsersBySection(ctx, "", "A")
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -390,8 +394,7 @@ func TestUnit_RemoveUser(t *testing.T) {
 				SeatNumber: "A1",
 			},
 		}
-		s.receipts["ticket1"] = receipt
-		s.occupiedSeats["A1"] = receipt
+		saveReceipt(t, s, receipt)
 
 		resp, err := s.RemoveUser(ctx, "user@example.com")
 		if err != nil {
@@ -402,26 +405,117 @@ func TestUnit_RemoveUser(t *testing.T) {
 			t.Errorf("expected removal success, got failure with message: %s", resp.Message)
 		}
 
-		if _, exists := s.receipts["ticket1"]; exists {
+		if _, exists := s.repo.GetReceipt("ticket1"); exists {
 			t.Errorf("expected receipt to be removed")
 		}
-		if _, exists := s.occupiedSeats["A1"]; exists {
+		if _, exists := holderOf(s.repo, types.DefaultJourneyID, "A1"); exists {
 			t.Errorf("expected seat to be unoccupied")
 		}
 	})
 
 	t.Run("User not found", func(t *testing.T) {
 
-		resp, err := s.RemoveUser(ctx, "nonexistent@example.com")
+		_, err := s.RemoveUser(ctx, "nonexistent@example.com")
+		expectError(t, err, ErrUserNotFound)
+	})
+
+	t.Run("Email holding several tickets is ambiguous", func(t *testing.T) {
+		saveReceipt(t, s, &ticket.Receipt{
+			TicketId:      "ticket2",
+			User:          &ticket.User{Email: "twice@example.com"},
+			AllocatedSeat: &ticket.Seat{Section: ticket.Seat_SECTION_A, SeatNumber: "A2"},
+		})
+		saveReceipt(t, s, &ticket.Receipt{
+			TicketId:      "ticket3",
+			User:          &ticket.User{Email: "twice@example.com"},
+			AllocatedSeat: &ticket.Seat{Section: ticket.Seat_SECTION_A, SeatNumber: "A3"},
+		})
+
+		_, err := s.RemoveUser(ctx, "twice@example.com")
+		expectError(t, err, ErrEmailAmbiguous)
+		if n := len(s.repo.GetReceiptsByEmail("twice@example.com")); n != 2 {
+			t.Errorf("expected both tickets to remain, got %d", n)
+		}
+	})
+}
+
+func TestUnit_CancelTicket(t *testing.T) {
+	ctx := context.Background()
+	s := NewTicketService()
+	for i, seatNumber := range []string{"A1", "A2"} {
+		saveReceipt(t, s, &ticket.Receipt{
+			TicketId:      fmt.Sprintf("ticket%d", i+1),
+			User:          &ticket.User{Email: "user@example.com"},
+			AllocatedSeat: &ticket.Seat{Section: ticket.Seat_SECTION_A, SeatNumber: seatNumber},
+		})
+	}
+
+	t.Run("Removes only the identified ticket", func(t *testing.T) {
+		resp, err := s.CancelTicket(ctx, "ticket1")
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
+		if !resp.Success || resp.GetReceipt().GetTicketId() != "ticket1" {
+			t.Errorf("expected ticket1 to be cancelled, got %v", resp)
+		}
+		if _, exists := holderOf(s.repo, types.DefaultJourneyID, "A1"); exists {
+			t.Errorf("expected seat A1 to be unoccupied")
+		}
+		if _, exists := s.repo.GetReceipt("ticket2"); !exists {
+			t.Errorf("expected the passenger's other ticket to remain")
+		}
+	})
 
-		if resp.Success {
-			t.Errorf("expected failure when removing nonexistent user")
+	t.Run("Email is unambiguous once one ticket remains", func(t *testing.T) {
+		receipt, err := s.GetReceiptByEmail(ctx, "user@example.com")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
 		}
-		if resp.Message != ErrUserNotFound {
-			t.Errorf("expected error message %q, got: %q", ErrUserNotFound, resp.Message)
+		if receipt.GetTicketId() != "ticket2" {
+			t.Errorf("expected ticket2, got %s", receipt.GetTicketId())
+		}
+	})
+
+	t.Run("Ticket not found", func(t *testing.T) {
+		_, err := s.CancelTicket(ctx, "ticket1")
+		expectError(t, err, ErrReceiptNotFound)
+	})
+}
+
+func TestUnit_GetReceiptByEmail(t *testing.T) {
+	ctx := context.Background()
+	s := NewTicketService()
+
+	t.Run("Receipt exists", func(t *testing.T) {
+		saveReceipt(t, s, &ticket.Receipt{
+			TicketId:      "ticket1",
+			User:          &ticket.User{Email: "user@example.com"},
+			AllocatedSeat: &ticket.Seat{Section: ticket.Seat_SECTION_A, SeatNumber: "A1"},
+		})
+		receipt, err := s.GetReceiptByEmail(ctx, "user@example.com")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if receipt.GetTicketId() != "ticket1" {
+			t.Errorf("expected ticket1, got %s", receipt.GetTicketId())
+		}
+	})
+
+	t.Run("Unknown email", func(t *testing.T) {
+		_, err := s.GetReceiptByEmail(ctx, "nobody@example.com")
+		expectError(t, err, ErrUserNotFound)
+	})
+
+	t.Run("Email holding several tickets", func(t *testing.T) {
+		saveReceipt(t, s, &ticket.Receipt{
+			TicketId:      "ticket2",
+			User:          &ticket.User{Email: "user@example.com"},
+			AllocatedSeat: &ticket.Seat{Section: ticket.Seat_SECTION_A, SeatNumber: "A2"},
+		})
+		_, err := s.GetReceiptByEmail(ctx, "user@example.com")
+		e := expectError(t, err, ErrEmailAmbiguous)
+		if e.Kind != KindFailedPrecondition || e.Subject != "user@example.com" {
+			t.Errorf("expected a failed precondition about user@example.com, got %+v", e)
 		}
 	})
 }
@@ -441,8 +535,7 @@ func TestUnit_ModifyUserSeat(t *testing.T) {
 				SeatNumber: "A1",
 			},
 		}
-		s.receipts[receipt.TicketId] = receipt
-		s.occupiedSeats["A1"] = receipt
+		saveReceipt(t, s, receipt)
 
 		newSeat := &ticket.Seat{
 			Section:    ticket.Seat_SECTION_A,
@@ -455,10 +548,13 @@ func TestUnit_ModifyUserSeat(t *testing.T) {
 		if !resp.Success {
 			t.Fatalf("expected success, got failure with message: %s", resp.Message)
 		}
-		if receipt.AllocatedSeat.SeatNumber != "A2" {
-			t.Errorf("expected seat to be updated to A2, got %s", receipt.AllocatedSeat.SeatNumber)
+		if resp.UpdatedReceipt.AllocatedSeat.SeatNumber != "A2" {
+			t.Errorf("expected seat to be updated to A2, got %s", resp.UpdatedReceipt.AllocatedSeat.SeatNumber)
 		}
-		if _, exists := s.occupiedSeats["A1"]; exists {
+		if stored, _ := s.repo.GetReceipt(receipt.TicketId); stored.AllocatedSeat.SeatNumber != "A2" {
+			t.Errorf("expected stored seat to be updated to A2, got %s", stored.AllocatedSeat.SeatNumber)
+		}
+		if _, exists := holderOf(s.repo, types.DefaultJourneyID, "A1"); exists {
 			t.Errorf("expected seat A1 to be freed")
 		}
 	})
@@ -484,25 +580,17 @@ func TestUnit_ModifyUserSeat(t *testing.T) {
 				SeatNumber: "B2",
 			},
 		}
-		s.receipts[receipt1.TicketId] = receipt1
-		s.occupiedSeats["B1"] = receipt1
+		saveReceipt(t, s, receipt1)
 
-		s.receipts[receipt2.TicketId] = receipt2
-		s.occupiedSeats["B2"] = receipt2
+		saveReceipt(t, s, receipt2)
 
 		newSeat := &ticket.Seat{
 			Section:    ticket.Seat_SECTION_B,
 			SeatNumber: "B2",
 		}
-		resp, err := s.ModifyUserSeat(ctx, receipt1, newSeat)
-		if err != nil {
-			t.Fatalf("unexpected error: %v", err)
-		}
-		if resp.Success {
-			t.Fatalf("expected failure due to seat being occupied")
-		}
-		if resp.Message != ErrSeatOccupied {
-			t.Errorf("expected error message %q, got %q", ErrSeatOccupied, resp.Message)
+		_, err := s.ModifyUserSeat(ctx, receipt1, newSeat)
+		if conflict := expectError(t, err, ErrSeatOccupied); conflict.Seat != "B2" {
+			t.Errorf("expected the conflicting seat B2 to be reported, got %q", conflict.Seat)
 		}
 	})
 
@@ -521,16 +609,8 @@ func TestUnit_ModifyUserSeat(t *testing.T) {
 			Section:    ticket.Seat_SECTION_A,
 			SeatNumber: "A4",
 		}
-		resp, err := s.ModifyUserSeat(ctx, receipt, newSeat)
-		if err != nil {
-			t.Fatalf("unexpected error: %v", err)
-		}
-		if resp.Success {
-			t.Fatalf("expected failure due to non-existent receipt")
-		}
-		if resp.Message != ErrReceiptNotFound {
-			t.Errorf("expected error message %q, got %q", ErrReceiptNotFound, resp.Message)
-		}
+		_, err := s.ModifyUserSeat(ctx, receipt, newSeat)
+		expectError(t, err, ErrReceiptNotFound)
 	})
 
 	t.Run("Modify seat to same seat returns success", func(t *testing.T) {
@@ -544,8 +624,7 @@ func TestUnit_ModifyUserSeat(t *testing.T) {
 				SeatNumber: "A1",
 			},
 		}
-		s.receipts[receipt.TicketId] = receipt
-		s.occupiedSeats["A1"] = receipt
+		saveReceipt(t, s, receipt)
 
 		sameSeat := &ticket.Seat{
 			Section:    ticket.Seat_SECTION_A,
@@ -558,8 +637,49 @@ func TestUnit_ModifyUserSeat(t *testing.T) {
 		if !resp.Success {
 			t.Fatalf("expected success when modifying to the same seat, got failure with message: %s", resp.Message)
 		}
-		if receipt.AllocatedSeat.SeatNumber != "A1" {
-			t.Errorf("expected seat to remain A1, got %s", receipt.AllocatedSeat.SeatNumber)
+		if resp.UpdatedReceipt.AllocatedSeat.SeatNumber != "A1" {
+			t.Errorf("expected seat to remain A1, got %s", resp.UpdatedReceipt.AllocatedSeat.SeatNumber)
 		}
 	})
 }
+
+func TestUnit_PurchaseTicketWithFileRepository(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	repo, err := repository.NewFileRepository(dir, 0)
+	if err != nil {
+		t.Fatalf("unexpected error opening repository: %v", err)
+	}
+	s := NewTicketServiceWithRepository(repo)
+	res, err := s.PurchaseTicket(ctx, &ticket.PurchaseTicketRequest{
+		FromLocation: "London",
+		ToLocation:   "Paris",
+		User:         &ticket.User{FirstName: "Test", LastName: "User", Email: "durable@example.com"},
+		PricePaid:    20.0,
+	})
+	if err != nil || !res.Success {
+		t.Fatalf("expected successful purchase, got %v, %v", res, err)
+	}
+
+	// A new service on the same directory must see the booking and skip its seat.
+	reopened, err := repository.NewFileRepository(dir, 0)
+	if err != nil {
+		t.Fatalf("unexpected error reopening repository: %v", err)
+	}
+	s = NewTicketServiceWithRepository(reopened)
+	receipt, err := s.GetReceiptDetails(ctx, res.Receipt.TicketId)
+	if err != nil {
+		t.Fatalf("expected receipt to survive restart, got: %v", err)
+	}
+	if receipt.AllocatedSeat.SeatNumber != "A1" {
+		t.Errorf("expected seat A1 after restart, got %s", receipt.AllocatedSeat.SeatNumber)
+	}
+	seat, err := s.findNextAvailableSeat(s.defaultJourney(), segment{from: 0, to: 1})
+	if err != nil {
+		t.Fatalf("expected a free seat, got error: %v", err)
+	}
+	if seat.SeatNumber != "A2" {
+		t.Errorf("expected next free seat A2 after restart, got %s", seat.SeatNumber)
+	}
+}
@@ -0,0 +1,277 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	ticket "github.com/talk2sohail/train-ticket-api/internal/common/genproto/ticket"
+	"github.com/talk2sohail/train-ticket-api/internal/ticket/money"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// waitlistJoinedEvent records a passenger joining the waitlist. It belongs to no ticket yet.
+func waitlistJoinedEvent(entry *ticket.WaitlistEntry, at time.Time) *ticket.BookingEvent {
+	return &ticket.BookingEvent{
+		OccurredAt: timestamppb.New(at),
+		Event: &ticket.BookingEvent_WaitlistJoined{
+			WaitlistJoined: &ticket.WaitlistJoined{Entry: entry},
+		},
+	}
+}
+
+// waitlistPromotedEvent records a waiting passenger being issued the ticket in receipt.
+func waitlistPromotedEvent(entry *ticket.WaitlistEntry, receipt *ticket.Receipt, at time.Time) *ticket.BookingEvent {
+	return &ticket.BookingEvent{
+		TicketId:   receipt.GetTicketId(),
+		OccurredAt: timestamppb.New(at),
+		Event: &ticket.BookingEvent_WaitlistPromoted{
+			WaitlistPromoted: &ticket.WaitlistPromoted{WaitlistId: entry.GetWaitlistId()},
+		},
+	}
+}
+
+// JoinWaitlist queues a passenger for a seat on a journey that has none free over their segment.
+// Passengers are served in the order they joined.
+func (s *TicketService) JoinWaitlist(ctx context.Context, req *ticket.JoinWaitlistRequest) (*ticket.JoinWaitlistResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	journey, ok := s.lookupJourney(req.GetJourneyId())
+	if !ok {
+		log.Printf("[JoinWaitlist] Failed for user %s: %s %s", req.GetUser().GetEmail(), ErrJourneyNotFound, req.GetJourneyId())
+		return nil, newError(ErrJourneyNotFound, req.GetJourneyId())
+	}
+
+	seg, err := segmentOf(journey, req.GetFromLocation(), req.GetToLocation())
+	if err != nil {
+		log.Printf("[JoinWaitlist] Failed for user %s: %v", req.GetUser().GetEmail(), err)
+		return nil, err
+	}
+
+	// The waitlist is only for sold-out trains; anyone who could buy a seat now should.
+	if _, err := s.findNextAvailableSeat(journey, seg); err == nil {
+		log.Printf("[JoinWaitlist] Rejected user %s: %s", req.GetUser().GetEmail(), ErrSeatsAvailable)
+		return nil, newError(ErrSeatsAvailable, journey.GetJourneyId())
+	}
+	if err := s.checkTicketQuota("JoinWaitlist", req.GetUser().GetEmail(), 1); err != nil {
+		return nil, err
+	}
+	// The fare is fixed when joining and charged to the payment method on promotion.
+	quoted := s.quoteFare(journey, seg, req.GetFromLocation(), req.GetToLocation(), req.GetTravelClass(), req.GetPassengerType(), nil)
+	paid, err := priceOf(req.GetPrice(), req.GetPricePaid())
+	if err != nil {
+		log.Printf("[JoinWaitlist] Failed for user %s: %v", req.GetUser().GetEmail(), err)
+		return nil, err
+	}
+	if err := s.checkPrice("JoinWaitlist", "user "+req.GetUser().GetEmail(), quoted, paid); err != nil {
+		return nil, err
+	}
+
+	now := s.clock.Now()
+	entry := &ticket.WaitlistEntry{
+		WaitlistId:    uuid.New().String(),
+		JourneyId:     journey.GetJourneyId(),
+		FromLocation:  req.GetFromLocation(),
+		ToLocation:    req.GetToLocation(),
+		User:          req.GetUser(),
+		PricePaid:     paid.Major(),
+		Price:         paid.Proto(),
+		JoinedAt:      timestamppb.New(now),
+		Status:        ticket.WaitlistEntry_STATUS_WAITING,
+		Fare:          quoted,
+		PaymentMethod: req.GetPaymentMethod(),
+	}
+	if err := s.repo.Append(waitlistJoinedEvent(entry, now)); err != nil {
+		log.Printf("[JoinWaitlist] Failed to store entry for user %s: %v", req.GetUser().GetEmail(), err)
+		return nil, fmt.Errorf("failed to store waitlist entry: %w", err)
+	}
+
+	position := s.waitlistPosition(entry)
+	log.Printf("[JoinWaitlist] Success: WaitlistID=%s, Journey=%s, Position=%d", entry.GetWaitlistId(), entry.GetJourneyId(), position)
+	return &ticket.JoinWaitlistResponse{
+		Success:  true,
+		Message:  MsgWaitlistJoined,
+		Entry:    entry,
+		Position: position,
+	}, nil
+}
+
+// GetWaitlistStatus reports where a waitlist entry stands. Promoted entries carry the ticket
+// they were given, and the receipt is returned for as long as that ticket is active.
+func (s *TicketService) GetWaitlistStatus(ctx context.Context, waitlistID string) (*ticket.GetWaitlistStatusResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.repo.GetWaitlistEntry(waitlistID)
+	if !ok {
+		log.Printf("[GetWaitlistStatus] %s for WaitlistID %s", ErrWaitlistNotFound, waitlistID)
+		return nil, newError(ErrWaitlistNotFound, waitlistID)
+	}
+
+	resp := &ticket.GetWaitlistStatusResponse{
+		Success: true,
+		Message: MsgWaitlistStatus,
+		Entry:   entry,
+	}
+	if entry.GetStatus() == ticket.WaitlistEntry_STATUS_PROMOTED {
+		if receipt, ok := s.repo.GetReceipt(entry.GetTicketId()); ok {
+			resp.Receipt = receipt
+		}
+	} else {
+		resp.Position = s.waitlistPosition(entry)
+	}
+	return resp, nil
+}
+
+// waitlistPosition returns an entry's 1-based place in its journey's queue, or 0 if it is no longer waiting.
+// This function assumes the caller has already acquired the server's mutex.
+func (s *TicketService) waitlistPosition(entry *ticket.WaitlistEntry) int32 {
+	for i, waiting := range s.repo.ListWaitlist(entry.GetJourneyId()) {
+		if waiting.GetWaitlistId() == entry.GetWaitlistId() {
+			return int32(i + 1)
+		}
+	}
+	return 0
+}
+
+// promotion is a waiting passenger chosen for a freed seat.
+type promotion struct {
+	entry   *ticket.WaitlistEntry
+	seg     segment
+	paid    money.Money
+	receipt *ticket.Receipt // Ticket to issue; its payment is recorded once taken.
+}
+
+// promoteWaitlist picks the journey's waiting passengers to hand a freed seat to, in joining order. The first
+// passenger whose segment fits gets the seat; on a route with stops, later passengers travelling other parts
+// of the route can share it. ignoreTicketID names the ticket giving the seat up, which has not been removed
+// from the repository yet. Passengers who have reached their ticket quota since joining, or are in passedOver,
+// are passed over and keep their place, as are those being promoted into another seat.
+// This function assumes the caller has already acquired the server's mutex.
+func (s *TicketService) promoteWaitlist(journey *ticket.Journey, seat *ticket.Seat, ignoreTicketID string, passedOver map[string]bool, now time.Time) []*promotion {
+	var promotions []*promotion
+	var taken []segment
+	promoted := make(map[string]int)
+	for _, entry := range s.repo.ListWaitlist(journey.GetJourneyId()) {
+		if passedOver[entry.GetWaitlistId()] || s.isBeingPromoted(entry.GetWaitlistId()) {
+			continue
+		}
+		seg, err := segmentOf(journey, entry.GetFromLocation(), entry.GetToLocation())
+		if err != nil || !s.isSeatFree(journey, seat.GetSeatNumber(), seg, ignoreTicketID) || overlapsAny(seg, taken) {
+			continue
+		}
+		email := entry.GetUser().GetEmail()
+		if s.checkTicketQuota("promoteWaitlist", email, promoted[email]+1) != nil {
+			continue
+		}
+		promoted[email]++
+		// Entries stored before their price was recorded hold it in price_paid; the currency was checked on joining.
+		paid, _ := priceOf(entry.GetPrice(), entry.GetPricePaid())
+		receipt := &ticket.Receipt{
+			TicketId:      uuid.New().String(),
+			FromLocation:  entry.GetFromLocation(),
+			ToLocation:    entry.GetToLocation(),
+			User:          entry.GetUser(),
+			PricePaid:     paid.Major(),
+			Price:         paid.Proto(),
+			AllocatedSeat: proto.Clone(seat).(*ticket.Seat),
+			PurchaseDate:  timestamppb.New(now),
+			JourneyId:     journey.GetJourneyId(),
+			Fare:          entry.GetFare(),
+		}
+		promotions = append(promotions, &promotion{entry: entry, seg: seg, paid: paid, receipt: receipt})
+		taken = append(taken, seg)
+	}
+	return promotions
+}
+
+// isBeingPromoted reports whether a waitlist entry is paying for a seat it is being promoted into.
+// This function assumes the caller has already acquired the server's mutex.
+func (s *TicketService) isBeingPromoted(waitlistID string) bool {
+	for _, hold := range s.holds {
+		if hold.waitlistID == waitlistID {
+			return true
+		}
+	}
+	return false
+}
+
+// promotionEvents returns the events recording each promotion.
+func promotionEvents(promotions []*promotion, now time.Time) []*ticket.BookingEvent {
+	var events []*ticket.BookingEvent
+	for _, p := range promotions {
+		events = append(events, waitlistPromotedEvent(p.entry, p.receipt, now), ticketPurchasedEvent(p.receipt, now))
+	}
+	return events
+}
+
+// offerSeat hands a seat that has been freed to the journey's waitlist and records the promotions. Each
+// promoted passenger pays from the payment method they joined with while the seat is kept for them; one
+// whose payment cannot be taken is passed over but keeps their place, and the seat is offered to the
+// next in line. Payments are taken even if ctx is cancelled, as the caller has already freed the seat.
+// This function assumes the caller has already acquired the server's mutex.
+func (s *TicketService) offerSeat(ctx context.Context, method string, journey *ticket.Journey, seat *ticket.Seat) {
+	ctx = context.WithoutCancel(ctx)
+	passedOver := make(map[string]bool)
+	for {
+		promotions := s.promoteWaitlist(journey, seat, "", passedOver, s.clock.Now())
+		if len(promotions) == 0 {
+			return
+		}
+
+		reservations := make([]string, len(promotions))
+		for i, p := range promotions {
+			reservations[i] = s.reserve(journey.GetJourneyId(), p.receipt.GetAllocatedSeat(), p.seg, p.entry.GetUser(), p.entry.GetWaitlistId())
+		}
+		var paid []*promotion
+		for _, p := range promotions {
+			paymentID, err := s.charge(ctx, method, "WaitlistID "+p.entry.GetWaitlistId(), p.entry.GetPaymentMethod(), p.paid)
+			if err != nil {
+				log.Printf("[%s] Passed over WaitlistID=%s for Seat=%s on Journey=%s: %v", method, p.entry.GetWaitlistId(), seat.GetSeatNumber(), journey.GetJourneyId(), err)
+				passedOver[p.entry.GetWaitlistId()] = true
+				continue
+			}
+			p.receipt.Payment = s.paymentOf(paymentID, p.paid)
+			paid = append(paid, p)
+		}
+		s.release(reservations...)
+		if len(paid) == 0 {
+			continue
+		}
+
+		events := promotionEvents(paid, s.clock.Now())
+		if err := s.repo.Append(events...); err != nil {
+			log.Printf("[%s] Failed to promote waitlist for Seat=%s on Journey=%s: %v", method, seat.GetSeatNumber(), journey.GetJourneyId(), err)
+			for _, p := range paid {
+				if err := s.refund(ctx, method, p.receipt); err != nil {
+					log.Printf("[%s] Payment %s was taken for a promotion that was not stored", method, p.receipt.GetPayment().GetPaymentId())
+				}
+			}
+			return
+		}
+		logPromotions(method, events)
+	}
+}
+
+// logPromotions logs the promotions among events once they have been stored.
+func logPromotions(method string, events []*ticket.BookingEvent) {
+	for _, event := range events {
+		if promoted := event.GetWaitlistPromoted(); promoted != nil {
+			log.Printf("[%s] Promoted WaitlistID=%s to TicketID=%s", method, promoted.GetWaitlistId(), event.GetTicketId())
+		}
+	}
+}
+
+func overlapsAny(seg segment, others []segment) bool {
+	for _, other := range others {
+		if seg.overlaps(other) {
+			return true
+		}
+	}
+	return false
+}
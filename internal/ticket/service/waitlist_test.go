@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ticket "github.com/talk2sohail/train-ticket-api/internal/common/genproto/ticket"
+)
+
+// tryJoinWaitlist queues a passenger between two stops, leaving any failure to the caller.
+func tryJoinWaitlist(s *TicketService, journeyID, from, to, email string) (*ticket.JoinWaitlistResponse, error) {
+	return s.JoinWaitlist(context.Background(), &ticket.JoinWaitlistRequest{
+		FromLocation: from,
+		ToLocation:   to,
+		User:         &ticket.User{FirstName: "Test", LastName: "User", Email: email},
+		PricePaid:    20.0,
+		JourneyId:    journeyID,
+	})
+}
+
+func joinWaitlist(t *testing.T, s *TicketService, journeyID, from, to, email string) *ticket.JoinWaitlistResponse {
+	t.Helper()
+	resp, err := tryJoinWaitlist(s, journeyID, from, to, email)
+	if err != nil {
+		t.Fatalf("unexpected error joining waitlist: %v", err)
+	}
+	return resp
+}
+
+func waitlistStatus(t *testing.T, s *TicketService, waitlistID string) *ticket.GetWaitlistStatusResponse {
+	t.Helper()
+	resp, err := s.GetWaitlistStatus(context.Background(), waitlistID)
+	if err != nil {
+		t.Fatalf("unexpected error getting waitlist status: %v", err)
+	}
+	return resp
+}
+
+func TestUnit_JoinWaitlist(t *testing.T) {
+	s := NewTicketService()
+	journey := createRoute(t, s, 1, "London", "Paris")
+
+	t.Run("Rejected while seats are free", func(t *testing.T) {
+		_, err := tryJoinWaitlist(s, journey.JourneyId, "London", "Paris", "early@example.com")
+		expectError(t, err, ErrSeatsAvailable)
+	})
+
+	purchaseLeg(t, s, journey.JourneyId, "London", "Paris", "a@example.com")
+	purchaseLeg(t, s, journey.JourneyId, "London", "Paris", "b@example.com")
+
+	t.Run("Queued in joining order once sold out", func(t *testing.T) {
+		first := joinWaitlist(t, s, journey.JourneyId, "London", "Paris", "first@example.com")
+		second := joinWaitlist(t, s, journey.JourneyId, "London", "Paris", "second@example.com")
+		if !first.Success || first.Position != 1 {
+			t.Errorf("expected first passenger at position 1, got %v", first)
+		}
+		if !second.Success || second.Position != 2 {
+			t.Errorf("expected second passenger at position 2, got %v", second)
+		}
+		if status := waitlistStatus(t, s, second.Entry.WaitlistId); status.Position != 2 || status.Entry.Status != ticket.WaitlistEntry_STATUS_WAITING {
+			t.Errorf("expected second passenger waiting at position 2, got %v", status)
+		}
+	})
+
+	t.Run("Unknown stop", func(t *testing.T) {
+		_, err := tryJoinWaitlist(s, journey.JourneyId, "London", "Brussels", "lost@example.com")
+		expectError(t, err, ErrStopNotOnRoute)
+	})
+
+	t.Run("Unknown entry", func(t *testing.T) {
+		_, err := s.GetWaitlistStatus(context.Background(), "missing")
+		expectError(t, err, ErrWaitlistNotFound)
+	})
+}
+
+func TestUnit_WaitlistPromotion(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("RemoveUser gives the seat to the first waiting passenger", func(t *testing.T) {
+		s := NewTicketService()
+		journey := createRoute(t, s, 1, "London", "Paris")
+		purchaseLeg(t, s, journey.JourneyId, "London", "Paris", "a@example.com")
+		leaving := purchaseLeg(t, s, journey.JourneyId, "London", "Paris", "b@example.com").Receipt
+		first := joinWaitlist(t, s, journey.JourneyId, "London", "Paris", "first@example.com").Entry
+		second := joinWaitlist(t, s, journey.JourneyId, "London", "Paris", "second@example.com").Entry
+
+		if resp, err := s.RemoveUser(ctx, "b@example.com"); err != nil || !resp.Success {
+			t.Fatalf("expected removal to succeed, got %v, %v", resp, err)
+		}
+
+		status := waitlistStatus(t, s, first.WaitlistId)
+		if status.Entry.Status != ticket.WaitlistEntry_STATUS_PROMOTED || status.Position != 0 {
+			t.Fatalf("expected first passenger to be promoted, got %v", status)
+		}
+		if status.Receipt == nil || status.Receipt.AllocatedSeat.SeatNumber != leaving.AllocatedSeat.SeatNumber {
+			t.Errorf("expected the freed seat %s, got %v", leaving.AllocatedSeat.SeatNumber, status.Receipt)
+		}
+		if status.Receipt.User.Email != "first@example.com" || status.Receipt.TicketId != status.Entry.TicketId {
+			t.Errorf("expected a ticket for first@example.com, got %v", status.Receipt)
+		}
+
+		// The promotion is part of the new ticket's history, ahead of the purchase.
+		history, err := s.GetTicketHistory(ctx, status.Entry.TicketId)
+		if err != nil || len(history.Events) != 2 || history.Events[0].GetWaitlistPromoted() == nil || history.Events[1].GetTicketPurchased() == nil {
+			t.Errorf("expected promotion then purchase in history, got %v, %v", history, err)
+		}
+
+		if status := waitlistStatus(t, s, second.WaitlistId); status.Entry.Status != ticket.WaitlistEntry_STATUS_WAITING || status.Position != 1 {
+			t.Errorf("expected second passenger to move up to position 1, got %v", status)
+		}
+	})
+
+	t.Run("Passengers on other legs share the freed seat", func(t *testing.T) {
+		s := NewTicketService()
+		journey := createRoute(t, s, 1, "London", "Lille", "Paris")
+		purchaseLeg(t, s, journey.JourneyId, "London", "Paris", "a@example.com")
+		purchaseLeg(t, s, journey.JourneyId, "London", "Paris", "b@example.com")
+		whole := joinWaitlist(t, s, journey.JourneyId, "London", "Paris", "whole@example.com").Entry
+		firstLeg := joinWaitlist(t, s, journey.JourneyId, "London", "Lille", "first-leg@example.com").Entry
+		secondLeg := joinWaitlist(t, s, journey.JourneyId, "Lille", "Paris", "second-leg@example.com").Entry
+
+		if resp, err := s.RemoveUser(ctx, "b@example.com"); err != nil || !resp.Success {
+			t.Fatalf("expected removal to succeed, got %v, %v", resp, err)
+		}
+
+		if status := waitlistStatus(t, s, whole.WaitlistId); status.Entry.Status != ticket.WaitlistEntry_STATUS_PROMOTED {
+			t.Errorf("expected the passenger at the front to be promoted, got %v", status)
+		}
+		for _, entry := range []*ticket.WaitlistEntry{firstLeg, secondLeg} {
+			if status := waitlistStatus(t, s, entry.WaitlistId); status.Entry.Status != ticket.WaitlistEntry_STATUS_WAITING {
+				t.Errorf("expected %s to keep waiting behind the whole-route passenger, got %v", entry.User.Email, status)
+			}
+		}
+
+		if resp, err := s.RemoveUser(ctx, "whole@example.com"); err != nil || !resp.Success {
+			t.Fatalf("expected removal to succeed, got %v, %v", resp, err)
+		}
+		for _, entry := range []*ticket.WaitlistEntry{firstLeg, secondLeg} {
+			status := waitlistStatus(t, s, entry.WaitlistId)
+			if status.Entry.Status != ticket.WaitlistEntry_STATUS_PROMOTED || status.Receipt == nil {
+				t.Errorf("expected %s to be promoted, got %v", entry.User.Email, status)
+			}
+		}
+	})
+
+	t.Run("Expired holds are offered to the waitlist", func(t *testing.T) {
+		clock := newFakeClock()
+		s := NewTicketService(WithClock(clock), WithHoldTTL(time.Minute))
+		journey := createRoute(t, s, 1, "London", "Paris")
+		purchaseLeg(t, s, journey.JourneyId, "London", "Paris", "a@example.com")
+		hold, err := s.HoldSeat(ctx, &ticket.HoldSeatRequest{
+			FromLocation: "London",
+			ToLocation:   "Paris",
+			User:         &ticket.User{Email: "holder@example.com"},
+			JourneyId:    journey.JourneyId,
+		})
+		if err != nil || !hold.Success {
+			t.Fatalf("expected hold to succeed, got %v, %v", hold, err)
+		}
+		entry := joinWaitlist(t, s, journey.JourneyId, "London", "Paris", "waiting@example.com").Entry
+		if entry == nil {
+			t.Fatalf("expected to join the waitlist while the last seat is held")
+		}
+
+		clock.Advance(time.Minute)
+		if released := s.releaseExpiredHolds(); released != 1 {
+			t.Fatalf("expected 1 hold to be released, got %d", released)
+		}
+		status := waitlistStatus(t, s, entry.WaitlistId)
+		if status.Entry.Status != ticket.WaitlistEntry_STATUS_PROMOTED || status.Receipt.AllocatedSeat.SeatNumber != hold.Seat.SeatNumber {
+			t.Errorf("expected the waiting passenger to get the held seat %s, got %v", hold.Seat.SeatNumber, status)
+		}
+	})
+}
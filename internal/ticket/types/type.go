@@ -2,14 +2,88 @@ package types
 
 import (
 	"context"
+	"time"
 
 	ticket "github.com/talk2sohail/train-ticket-api/internal/common/genproto/ticket"
 )
 
+// DefaultJourneyID identifies the journey used when a request does not name one.
+const DefaultJourneyID = "default"
+
+// JourneyIDOf returns the journey a receipt belongs to. Receipts stored before
+// journeys existed carry no journey ID and belong to the default journey.
+func JourneyIDOf(receipt *ticket.Receipt) string {
+	if id := receipt.GetJourneyId(); id != "" {
+		return id
+	}
+	return DefaultJourneyID
+}
+
+// PurchasedBefore orders receipts by purchase time, breaking ties by ticket ID.
+func PurchasedBefore(a, b *ticket.Receipt) bool {
+	ta, tb := a.GetPurchaseDate().AsTime(), b.GetPurchaseDate().AsTime()
+	if !ta.Equal(tb) {
+		return ta.Before(tb)
+	}
+	return a.GetTicketId() < b.GetTicketId()
+}
+
 type TicketService interface {
-	PurchaseTicket(context.Context, *ticket.PurchaseTicketRequest) (ticket.PurchaseTicketResponse, error)
+	PurchaseTicket(context.Context, *ticket.PurchaseTicketRequest) (*ticket.PurchaseTicketResponse, error)
+	PurchaseGroupTicket(context.Context, *ticket.PurchaseGroupTicketRequest) (*ticket.PurchaseGroupTicketResponse, error)
+	HoldSeat(context.Context, *ticket.HoldSeatRequest) (*ticket.HoldSeatResponse, error)
+	ConfirmHold(context.Context, *ticket.ConfirmHoldRequest) (*ticket.ConfirmHoldResponse, error)
+	JoinWaitlist(context.Context, *ticket.JoinWaitlistRequest) (*ticket.JoinWaitlistResponse, error)
+	GetWaitlistStatus(context.Context, string) (*ticket.GetWaitlistStatusResponse, error)
+	WatchAvailability(context.Context, *ticket.WatchAvailabilityRequest, func(*ticket.AvailabilityUpdate) error) error
 	GetReceiptDetails(context.Context, string) (*ticket.Receipt, error)
-	GetUsersBySection(context.Context, ticket.Seat_Section) (ticket.GetUsersBySectionResponse, error)
-	RemoveUser(context.Context, string) (ticket.RemoveUserResponse, error)
-	ModifyUserSeat(context.Context, *ticket.Receipt, *ticket.Seat) (ticket.ModifyUserSeatResponse, error)
+	GetReceiptByEmail(context.Context, string) (*ticket.Receipt, error)
+	GetUsersBySection(context.Context, string, string) (*ticket.GetUsersBySectionResponse, error)
+	RemoveUser(context.Context, string) (*ticket.RemoveUserResponse, error)
+	CancelTicket(context.Context, string) (*ticket.CancelTicketResponse, error)
+	ListTicketsForUser(context.Context, *ticket.ListTicketsForUserRequest) (*ticket.ListTicketsForUserResponse, error)
+	ModifyUserSeat(context.Context, *ticket.Receipt, *ticket.Seat) (*ticket.ModifyUserSeatResponse, error)
+	GetTicketHistory(context.Context, string) (*ticket.GetTicketHistoryResponse, error)
+	GetSeatOccupant(context.Context, string, string, time.Time) (*ticket.GetSeatOccupantResponse, error)
+	CreateJourney(context.Context, *ticket.CreateJourneyRequest) (*ticket.CreateJourneyResponse, error)
+	ListJourneys(context.Context, string) (*ticket.ListJourneysResponse, error)
+	QuoteFare(context.Context, *ticket.QuoteFareRequest) (*ticket.QuoteFareResponse, error)
+	GetRefund(context.Context, string) (*ticket.GetRefundResponse, error)
+}
+
+// TicketRepository is the storage backend used by the ticket service.
+// Bookings are stored as an append-only ledger of events; the receipts and
+// seat occupancy it serves are derived by applying those events in order.
+// Implementations must be safe for concurrent use. They only guarantee that a
+// single call is atomic; the service serialises read-modify-write sequences
+// such as seat allocation with its own lock.
+type TicketRepository interface {
+	// Append assigns the next sequence numbers to the events, stores them and
+	// applies them to the current state. Either all events are stored or none.
+	Append(...*ticket.BookingEvent) error
+	// GetReceipt returns the current receipt of an active ticket.
+	GetReceipt(ticketID string) (*ticket.Receipt, bool)
+	// GetReceiptsBySeat returns the receipts currently holding a seat on a journey.
+	// A seat can be held by several tickets travelling non-overlapping legs.
+	GetReceiptsBySeat(journeyID, seatNumber string) []*ticket.Receipt
+	// GetReceiptsByEmail returns the active receipts of a passenger, oldest purchase first.
+	GetReceiptsByEmail(email string) []*ticket.Receipt
+	// ListReceipts returns every active receipt in no particular order.
+	ListReceipts() []*ticket.Receipt
+	// GetJourney returns a journey scheduled through a JourneyCreated event.
+	GetJourney(journeyID string) (*ticket.Journey, bool)
+	// ListJourneys returns every scheduled journey in no particular order.
+	ListJourneys() []*ticket.Journey
+	// GetWaitlistEntry returns a waitlist entry, whether still waiting or promoted.
+	GetWaitlistEntry(waitlistID string) (*ticket.WaitlistEntry, bool)
+	// ListWaitlist returns the entries still waiting for a journey, in joining order.
+	ListWaitlist(journeyID string) []*ticket.WaitlistEntry
+	// GetRefund returns a refund recorded by a TicketCancelled event.
+	GetRefund(refundID string) (*ticket.Refund, bool)
+	// History returns the events recorded for a ticket, oldest first.
+	History(ticketID string) []*ticket.BookingEvent
+	// Events returns the whole ledger, oldest first.
+	Events() []*ticket.BookingEvent
+	// Close releases any resources held by the repository.
+	Close() error
 }
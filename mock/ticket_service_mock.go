@@ -7,6 +7,7 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	proto "github.com/talk2sohail/train-ticket-api/internal/common/genproto/ticket"
@@ -35,6 +36,66 @@ func (m *MockTicketService) EXPECT() *MockTicketServiceMockRecorder {
 	return m.recorder
 }
 
+// CancelTicket mocks base method.
+func (m *MockTicketService) CancelTicket(arg0 context.Context, arg1 string) (*proto.CancelTicketResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelTicket", arg0, arg1)
+	ret0, _ := ret[0].(*proto.CancelTicketResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelTicket indicates an expected call of CancelTicket.
+func (mr *MockTicketServiceMockRecorder) CancelTicket(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelTicket", reflect.TypeOf((*MockTicketService)(nil).CancelTicket), arg0, arg1)
+}
+
+// ConfirmHold mocks base method.
+func (m *MockTicketService) ConfirmHold(arg0 context.Context, arg1 *proto.ConfirmHoldRequest) (*proto.ConfirmHoldResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmHold", arg0, arg1)
+	ret0, _ := ret[0].(*proto.ConfirmHoldResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmHold indicates an expected call of ConfirmHold.
+func (mr *MockTicketServiceMockRecorder) ConfirmHold(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmHold", reflect.TypeOf((*MockTicketService)(nil).ConfirmHold), arg0, arg1)
+}
+
+// CreateJourney mocks base method.
+func (m *MockTicketService) CreateJourney(arg0 context.Context, arg1 *proto.CreateJourneyRequest) (*proto.CreateJourneyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateJourney", arg0, arg1)
+	ret0, _ := ret[0].(*proto.CreateJourneyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateJourney indicates an expected call of CreateJourney.
+func (mr *MockTicketServiceMockRecorder) CreateJourney(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateJourney", reflect.TypeOf((*MockTicketService)(nil).CreateJourney), arg0, arg1)
+}
+
+// GetReceiptByEmail mocks base method.
+func (m *MockTicketService) GetReceiptByEmail(arg0 context.Context, arg1 string) (*proto.Receipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReceiptByEmail", arg0, arg1)
+	ret0, _ := ret[0].(*proto.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReceiptByEmail indicates an expected call of GetReceiptByEmail.
+func (mr *MockTicketServiceMockRecorder) GetReceiptByEmail(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReceiptByEmail", reflect.TypeOf((*MockTicketService)(nil).GetReceiptByEmail), arg0, arg1)
+}
+
 // GetReceiptDetails mocks base method.
 func (m *MockTicketService) GetReceiptDetails(arg0 context.Context, arg1 string) (*proto.Receipt, error) {
 	m.ctrl.T.Helper()
@@ -50,26 +111,146 @@ func (mr *MockTicketServiceMockRecorder) GetReceiptDetails(arg0, arg1 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReceiptDetails", reflect.TypeOf((*MockTicketService)(nil).GetReceiptDetails), arg0, arg1)
 }
 
+// GetRefund mocks base method.
+func (m *MockTicketService) GetRefund(arg0 context.Context, arg1 string) (*proto.GetRefundResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRefund", arg0, arg1)
+	ret0, _ := ret[0].(*proto.GetRefundResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRefund indicates an expected call of GetRefund.
+func (mr *MockTicketServiceMockRecorder) GetRefund(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRefund", reflect.TypeOf((*MockTicketService)(nil).GetRefund), arg0, arg1)
+}
+
+// GetSeatOccupant mocks base method.
+func (m *MockTicketService) GetSeatOccupant(arg0 context.Context, arg1, arg2 string, arg3 time.Time) (*proto.GetSeatOccupantResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSeatOccupant", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*proto.GetSeatOccupantResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSeatOccupant indicates an expected call of GetSeatOccupant.
+func (mr *MockTicketServiceMockRecorder) GetSeatOccupant(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSeatOccupant", reflect.TypeOf((*MockTicketService)(nil).GetSeatOccupant), arg0, arg1, arg2, arg3)
+}
+
+// GetTicketHistory mocks base method.
+func (m *MockTicketService) GetTicketHistory(arg0 context.Context, arg1 string) (*proto.GetTicketHistoryResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTicketHistory", arg0, arg1)
+	ret0, _ := ret[0].(*proto.GetTicketHistoryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTicketHistory indicates an expected call of GetTicketHistory.
+func (mr *MockTicketServiceMockRecorder) GetTicketHistory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTicketHistory", reflect.TypeOf((*MockTicketService)(nil).GetTicketHistory), arg0, arg1)
+}
+
 // GetUsersBySection mocks base method.
-func (m *MockTicketService) GetUsersBySection(arg0 context.Context, arg1 proto.Seat_Section) (proto.GetUsersBySectionResponse, error) {
+func (m *MockTicketService) GetUsersBySection(arg0 context.Context, arg1, arg2 string) (*proto.GetUsersBySectionResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUsersBySection", arg0, arg1)
-	ret0, _ := ret[0].(proto.GetUsersBySectionResponse)
+	ret := m.ctrl.Call(m, "GetUsersBySection", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*proto.GetUsersBySectionResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetUsersBySection indicates an expected call of GetUsersBySection.
-func (mr *MockTicketServiceMockRecorder) GetUsersBySection(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockTicketServiceMockRecorder) GetUsersBySection(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersBySection", reflect.TypeOf((*MockTicketService)(nil).GetUsersBySection), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersBySection", reflect.TypeOf((*MockTicketService)(nil).GetUsersBySection), arg0, arg1, arg2)
+}
+
+// GetWaitlistStatus mocks base method.
+func (m *MockTicketService) GetWaitlistStatus(arg0 context.Context, arg1 string) (*proto.GetWaitlistStatusResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWaitlistStatus", arg0, arg1)
+	ret0, _ := ret[0].(*proto.GetWaitlistStatusResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWaitlistStatus indicates an expected call of GetWaitlistStatus.
+func (mr *MockTicketServiceMockRecorder) GetWaitlistStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWaitlistStatus", reflect.TypeOf((*MockTicketService)(nil).GetWaitlistStatus), arg0, arg1)
+}
+
+// HoldSeat mocks base method.
+func (m *MockTicketService) HoldSeat(arg0 context.Context, arg1 *proto.HoldSeatRequest) (*proto.HoldSeatResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HoldSeat", arg0, arg1)
+	ret0, _ := ret[0].(*proto.HoldSeatResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HoldSeat indicates an expected call of HoldSeat.
+func (mr *MockTicketServiceMockRecorder) HoldSeat(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HoldSeat", reflect.TypeOf((*MockTicketService)(nil).HoldSeat), arg0, arg1)
+}
+
+// JoinWaitlist mocks base method.
+func (m *MockTicketService) JoinWaitlist(arg0 context.Context, arg1 *proto.JoinWaitlistRequest) (*proto.JoinWaitlistResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "JoinWaitlist", arg0, arg1)
+	ret0, _ := ret[0].(*proto.JoinWaitlistResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// JoinWaitlist indicates an expected call of JoinWaitlist.
+func (mr *MockTicketServiceMockRecorder) JoinWaitlist(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "JoinWaitlist", reflect.TypeOf((*MockTicketService)(nil).JoinWaitlist), arg0, arg1)
+}
+
+// ListJourneys mocks base method.
+func (m *MockTicketService) ListJourneys(arg0 context.Context, arg1 string) (*proto.ListJourneysResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListJourneys", arg0, arg1)
+	ret0, _ := ret[0].(*proto.ListJourneysResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListJourneys indicates an expected call of ListJourneys.
+func (mr *MockTicketServiceMockRecorder) ListJourneys(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListJourneys", reflect.TypeOf((*MockTicketService)(nil).ListJourneys), arg0, arg1)
+}
+
+// ListTicketsForUser mocks base method.
+func (m *MockTicketService) ListTicketsForUser(arg0 context.Context, arg1 *proto.ListTicketsForUserRequest) (*proto.ListTicketsForUserResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTicketsForUser", arg0, arg1)
+	ret0, _ := ret[0].(*proto.ListTicketsForUserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTicketsForUser indicates an expected call of ListTicketsForUser.
+func (mr *MockTicketServiceMockRecorder) ListTicketsForUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTicketsForUser", reflect.TypeOf((*MockTicketService)(nil).ListTicketsForUser), arg0, arg1)
 }
 
 // ModifyUserSeat mocks base method.
-func (m *MockTicketService) ModifyUserSeat(arg0 context.Context, arg1 *proto.Receipt, arg2 *proto.Seat) (proto.ModifyUserSeatResponse, error) {
+func (m *MockTicketService) ModifyUserSeat(arg0 context.Context, arg1 *proto.Receipt, arg2 *proto.Seat) (*proto.ModifyUserSeatResponse, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ModifyUserSeat", arg0, arg1, arg2)
-	ret0, _ := ret[0].(proto.ModifyUserSeatResponse)
+	ret0, _ := ret[0].(*proto.ModifyUserSeatResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -80,11 +261,26 @@ func (mr *MockTicketServiceMockRecorder) ModifyUserSeat(arg0, arg1, arg2 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModifyUserSeat", reflect.TypeOf((*MockTicketService)(nil).ModifyUserSeat), arg0, arg1, arg2)
 }
 
+// PurchaseGroupTicket mocks base method.
+func (m *MockTicketService) PurchaseGroupTicket(arg0 context.Context, arg1 *proto.PurchaseGroupTicketRequest) (*proto.PurchaseGroupTicketResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurchaseGroupTicket", arg0, arg1)
+	ret0, _ := ret[0].(*proto.PurchaseGroupTicketResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurchaseGroupTicket indicates an expected call of PurchaseGroupTicket.
+func (mr *MockTicketServiceMockRecorder) PurchaseGroupTicket(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurchaseGroupTicket", reflect.TypeOf((*MockTicketService)(nil).PurchaseGroupTicket), arg0, arg1)
+}
+
 // PurchaseTicket mocks base method.
-func (m *MockTicketService) PurchaseTicket(arg0 context.Context, arg1 *proto.PurchaseTicketRequest) (proto.PurchaseTicketResponse, error) {
+func (m *MockTicketService) PurchaseTicket(arg0 context.Context, arg1 *proto.PurchaseTicketRequest) (*proto.PurchaseTicketResponse, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "PurchaseTicket", arg0, arg1)
-	ret0, _ := ret[0].(proto.PurchaseTicketResponse)
+	ret0, _ := ret[0].(*proto.PurchaseTicketResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -95,11 +291,26 @@ func (mr *MockTicketServiceMockRecorder) PurchaseTicket(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurchaseTicket", reflect.TypeOf((*MockTicketService)(nil).PurchaseTicket), arg0, arg1)
 }
 
+// QuoteFare mocks base method.
+func (m *MockTicketService) QuoteFare(arg0 context.Context, arg1 *proto.QuoteFareRequest) (*proto.QuoteFareResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QuoteFare", arg0, arg1)
+	ret0, _ := ret[0].(*proto.QuoteFareResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QuoteFare indicates an expected call of QuoteFare.
+func (mr *MockTicketServiceMockRecorder) QuoteFare(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QuoteFare", reflect.TypeOf((*MockTicketService)(nil).QuoteFare), arg0, arg1)
+}
+
 // RemoveUser mocks base method.
-func (m *MockTicketService) RemoveUser(arg0 context.Context, arg1 string) (proto.RemoveUserResponse, error) {
+func (m *MockTicketService) RemoveUser(arg0 context.Context, arg1 string) (*proto.RemoveUserResponse, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "RemoveUser", arg0, arg1)
-	ret0, _ := ret[0].(proto.RemoveUserResponse)
+	ret0, _ := ret[0].(*proto.RemoveUserResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -109,3 +320,17 @@ func (mr *MockTicketServiceMockRecorder) RemoveUser(arg0, arg1 interface{}) *gom
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveUser", reflect.TypeOf((*MockTicketService)(nil).RemoveUser), arg0, arg1)
 }
+
+// WatchAvailability mocks base method.
+func (m *MockTicketService) WatchAvailability(arg0 context.Context, arg1 *proto.WatchAvailabilityRequest, arg2 func(*proto.AvailabilityUpdate) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchAvailability", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WatchAvailability indicates an expected call of WatchAvailability.
+func (mr *MockTicketServiceMockRecorder) WatchAvailability(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchAvailability", reflect.TypeOf((*MockTicketService)(nil).WatchAvailability), arg0, arg1, arg2)
+}